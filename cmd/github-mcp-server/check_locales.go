@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// LocalesOutput is the output structure for the check-locales command.
+type LocalesOutput struct {
+	Keys    int                         `json:"keys"`
+	Locales []translations.BundleReport `json:"locales"`
+}
+
+var checkLocalesCmd = &cobra.Command{
+	Use:   "check-locales",
+	Short: "Report missing and stale keys of the locale bundles",
+	Long: `Report the missing and stale keys of the locale bundles.
+
+This command collects the translation keys of all tools, resources and prompts,
+and compares them with the embedded locale bundles and those in the directory
+set with --locales-dir. For each locale it lists the keys that are not
+translated and the keys that the server no longer uses. Set --locale to check
+a single locale.
+
+The output format can be controlled with the --output flag:
+  - text (default): Human-readable text output
+  - json: JSON output for programmatic use
+
+Examples:
+  # Check all locale bundles
+  github-mcp-server check-locales --locales-dir=./locales
+
+  # Check the German bundle
+  github-mcp-server check-locales --locales-dir=./locales --locale=de`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runCheckLocales()
+	},
+}
+
+func init() {
+	checkLocalesCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	_ = viper.BindPFlag("check-locales-output", checkLocalesCmd.Flags().Lookup("output"))
+
+	rootCmd.AddCommand(checkLocalesCmd)
+}
+
+func runCheckLocales() error {
+	bundles, err := translations.LoadBundles(viper.GetString("locales-dir"))
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]string)
+	t := translations.RecordingTranslationHelper(keys)
+	github.AllTools(t)
+	github.AllResources(t)
+	github.AllPrompts(t)
+
+	output := LocalesOutput{Keys: len(keys), Locales: []translations.BundleReport{}}
+	locale := translations.NormalizeLocale(viper.GetString("locale"))
+	for _, report := range translations.CheckBundles(bundles, keys) {
+		if locale == "" || report.Locale == locale {
+			output.Locales = append(output.Locales, report)
+		}
+	}
+	if locale != "" && len(output.Locales) == 0 {
+		return fmt.Errorf("no locale bundle for %s", locale)
+	}
+
+	if viper.GetString("check-locales-output") == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+	outputLocalesText(output)
+	return nil
+}
+
+func outputLocalesText(output LocalesOutput) {
+	fmt.Printf("Locale Bundle Check\n")
+	fmt.Printf("===================\n\n")
+	fmt.Printf("Keys in use: %d\n\n", output.Keys)
+
+	if len(output.Locales) == 0 {
+		fmt.Println("No locale bundles found.")
+		return
+	}
+
+	for _, report := range output.Locales {
+		fmt.Printf("## %s\n\n", report.Locale)
+		fmt.Printf("  Translated: %d/%d\n", report.Translated, output.Keys)
+		if len(report.Missing) > 0 {
+			fmt.Printf("  Missing (%d):\n", len(report.Missing))
+			for _, key := range report.Missing {
+				fmt.Printf("    %s\n", key)
+			}
+		}
+		if len(report.Stale) > 0 {
+			fmt.Printf("  Stale (%d):\n", len(report.Stale))
+			for _, key := range report.Stale {
+				fmt.Printf("    %s\n", key)
+			}
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ToolsnapsOutput is the output structure for the check-toolsnaps command.
+type ToolsnapsOutput struct {
+	Breaking   int                `json:"breaking"`
+	Compatible int                `json:"compatible"`
+	Changes    []toolsnaps.Change `json:"changes"`
+}
+
+var checkToolsnapsCmd = &cobra.Command{
+	Use:   "check-toolsnaps",
+	Short: "Classify the changes between the tools and their schema snapshots",
+	Long: `Compare the schema of every tool with its committed snapshot and classify each change.
+
+This command compares every tool the server defines, including those behind
+feature flags, with its snapshot in the directory set with --dir. Each change
+is classified as breaking or compatible. Removing a property, requiring a new
+one, narrowing a type or enum, or removing a tool are breaking; adding an
+optional property or editing a description are compatible. The command fails
+if any change is breaking.
+
+The output format can be controlled with the --output flag:
+  - text (default): Human-readable text output
+  - json: JSON output for programmatic use
+
+Examples:
+  # Check the tools against the committed snapshots
+  github-mcp-server check-toolsnaps
+
+  # Check the snapshots of another checkout
+  github-mcp-server check-toolsnaps --dir=../main/pkg/github/__toolsnaps__`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runCheckToolsnaps()
+	},
+}
+
+func init() {
+	checkToolsnapsCmd.Flags().String("dir", "pkg/github/__toolsnaps__", "Directory of the tool schema snapshots")
+	checkToolsnapsCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	_ = viper.BindPFlag("check-toolsnaps-dir", checkToolsnapsCmd.Flags().Lookup("dir"))
+	_ = viper.BindPFlag("check-toolsnaps-output", checkToolsnapsCmd.Flags().Lookup("output"))
+
+	rootCmd.AddCommand(checkToolsnapsCmd)
+}
+
+func runCheckToolsnaps() error {
+	dir := viper.GetString("check-toolsnaps-dir")
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	tools := make(map[string][]any)
+	for _, tool := range github.AllTools(translations.NullTranslationHelper) {
+		tools[tool.Tool.Name] = append(tools[tool.Tool.Name], tool.Tool)
+	}
+
+	changes, err := toolsnaps.CompareDir(dir, tools)
+	if err != nil {
+		return err
+	}
+	breaking := len(toolsnaps.Breaking(changes))
+	output := ToolsnapsOutput{
+		Breaking:   breaking,
+		Compatible: len(changes) - breaking,
+		Changes:    changes,
+	}
+	if output.Changes == nil {
+		output.Changes = []toolsnaps.Change{}
+	}
+
+	if viper.GetString("check-toolsnaps-output") == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			return err
+		}
+	} else {
+		outputToolsnapsText(output)
+	}
+
+	if breaking > 0 {
+		return fmt.Errorf("found %d breaking tool schema changes", breaking)
+	}
+	return nil
+}
+
+func outputToolsnapsText(output ToolsnapsOutput) {
+	fmt.Printf("Tool Schema Compatibility\n")
+	fmt.Printf("=========================\n\n")
+
+	if len(output.Changes) == 0 {
+		fmt.Println("No changes.")
+		return
+	}
+
+	for _, severity := range []toolsnaps.Severity{toolsnaps.SeverityBreaking, toolsnaps.SeverityCompatible} {
+		var changes []toolsnaps.Change
+		for _, change := range output.Changes {
+			if change.Severity == severity {
+				changes = append(changes, change)
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		fmt.Printf("## %s (%d)\n\n", severity, len(changes))
+		for _, change := range changes {
+			fmt.Printf("  %s\n", change)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("Summary: %d breaking, %d compatible\n", output.Breaking, output.Compatible)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose setup problems",
+	Long: `Diagnose problems with the token, the GitHub host and the enabled tools.
+
+This command resolves the API endpoints of the host set with --gh-host, checks
+that the REST, GraphQL and raw content endpoints can be reached with the token,
+and determines the token's scopes (classic PATs and OAuth tokens) or probes its
+permissions (fine-grained PATs and GitHub App tokens). It then builds the tools
+the stdio command would offer with the same flags, and reports for each tool
+whether it will work, will be hidden, or will fail with a 403.
+
+The command exits with a non-zero status if any check fails.
+
+The output format can be controlled with the --output flag:
+  - text (default): Human-readable text output
+  - json: JSON output for programmatic use
+
+Examples:
+  # Diagnose the default setup
+  github-mcp-server doctor
+
+  # Diagnose a GitHub Enterprise Server setup with specific toolsets
+  github-mcp-server doctor --gh-host=https://github.example.com --toolsets=repos,issues
+
+  # Output as JSON
+  github-mcp-server doctor --output=json`,
+	// Failed checks are reported in the output, the usage would only hide them
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runDoctor()
+	},
+}
+
+func init() {
+	doctorCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	_ = viper.BindPFlag("doctor-output", doctorCmd.Flags().Lookup("output"))
+
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor() error {
+	cfg, err := inventoryConfigFromFlags()
+	if err != nil {
+		return err
+	}
+	report := ghmcp.RunDoctor(context.Background(), ghmcp.DoctorConfig{
+		InventoryConfig: cfg,
+		Host:            viper.GetString("host"),
+		Token:           viper.GetString("personal_access_token"),
+	})
+
+	switch viper.GetString("doctor-output") {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	default:
+		outputDoctorText(report)
+	}
+
+	if report.HasErrors() {
+		return errors.New("doctor found problems with the setup")
+	}
+	return nil
+}
+
+var checkSymbols = map[ghmcp.CheckStatus]string{
+	ghmcp.CheckOK:      "✓",
+	ghmcp.CheckWarning: "!",
+	ghmcp.CheckError:   "✗",
+	ghmcp.CheckSkipped: "-",
+}
+
+var toolStatusDescriptions = map[ghmcp.ToolStatus]string{
+	ghmcp.ToolWillWork:     "will work",
+	ghmcp.ToolWillBeHidden: "will be hidden",
+	ghmcp.ToolWill403:      "will 403",
+	ghmcp.ToolUnknown:      "unknown",
+}
+
+func outputDoctorText(report *ghmcp.DoctorReport) {
+	fmt.Printf("GitHub MCP Server Doctor\n")
+	fmt.Printf("========================\n\n")
+
+	for _, check := range report.Checks {
+		fmt.Printf("  %s %s: %s\n", checkSymbols[check.Status], check.Name, check.Message)
+	}
+	fmt.Println()
+
+	if len(report.Tools) == 0 {
+		return
+	}
+
+	counts := make(map[ghmcp.ToolStatus]int)
+	toolset := ""
+	for _, tool := range report.Tools {
+		counts[tool.Status]++
+		if tool.Toolset != toolset {
+			if toolset != "" {
+				fmt.Println()
+			}
+			toolset = tool.Toolset
+			fmt.Printf("## %s\n\n", formatToolsetName(toolset))
+		}
+		line := fmt.Sprintf("  %s %s: %s", toolStatusSymbol(tool.Status), tool.Name, toolStatusDescriptions[tool.Status])
+		if tool.Reason != "" {
+			line += " (" + tool.Reason + ")"
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+
+	var summary []string
+	for _, status := range []ghmcp.ToolStatus{ghmcp.ToolWillWork, ghmcp.ToolWillBeHidden, ghmcp.ToolWill403, ghmcp.ToolUnknown} {
+		if counts[status] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[status], toolStatusDescriptions[status]))
+		}
+	}
+	fmt.Printf("Total: %d tools, %s\n", len(report.Tools), strings.Join(summary, ", "))
+	if len(report.MissingScopes) > 0 {
+		fmt.Printf("Missing scopes: %s\n", strings.Join(report.MissingScopes, ", "))
+	}
+}
+
+func toolStatusSymbol(status ghmcp.ToolStatus) string {
+	switch status {
+	case ghmcp.ToolWillWork:
+		return checkSymbols[ghmcp.CheckOK]
+	case ghmcp.ToolWill403:
+		return checkSymbols[ghmcp.CheckError]
+	default:
+		return checkSymbols[ghmcp.CheckSkipped]
+	}
+}
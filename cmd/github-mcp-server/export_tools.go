@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exportToolsCmd = &cobra.Command{
+	Use:   "export-tools",
+	Short: "Export the tool catalog as JSON",
+	Long: `Export the catalog of enabled tools in a machine-readable format.
+
+This command builds the tools the stdio command would offer with the same
+flags (--toolsets, --tools, --features, --read-only, --repo and
+--custom-toolsets) and writes them to stdout as JSON, translated to the
+locale set with --locale. This is useful for pre-registering the tools in
+gateways that don't speak MCP.
+
+The format can be controlled with the --format flag:
+  - mcp (default): The result of an MCP tools/list request
+  - openai: OpenAI function-calling tools
+  - anthropic: Anthropic tool-use tools
+  - jsonschema: A JSON Schema document with each tool's input schema in $defs
+
+Every entry includes the tool's toolset, required and accepted OAuth scopes,
+and annotations: under _meta.github for mcp, under metadata for openai and
+anthropic, and under x-github for jsonschema. Remove the metadata field before
+passing openai or anthropic tools to the API.
+
+Examples:
+  # Export the default toolsets as an MCP tools/list result
+  github-mcp-server export-tools > tools.json
+
+  # Export all read-only tools as OpenAI functions
+  github-mcp-server export-tools --toolsets=all --read-only --format=openai`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runExportTools()
+	},
+}
+
+func init() {
+	exportToolsCmd.Flags().StringP("format", "f", string(inventory.ExportFormatMCP), "Export format: mcp, openai, anthropic, or jsonschema")
+	_ = viper.BindPFlag("export-tools-format", exportToolsCmd.Flags().Lookup("format"))
+
+	rootCmd.AddCommand(exportToolsCmd)
+}
+
+func runExportTools() error {
+	cfg, err := inventoryConfigFromFlags()
+	if err != nil {
+		return err
+	}
+	inv, err := ghmcp.BuildInventory(cfg)
+	if err != nil {
+		return err
+	}
+
+	format := inventory.ExportFormat(viper.GetString("export-tools-format"))
+	exported, err := inventory.ExportTools(inv.AvailableTools(context.Background()), format)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(exported)
+}
@@ -51,8 +51,15 @@ func generateReadmeDocs(readmePath string) error {
 	t, _ := translations.TranslationHelper()
 
 	// (not available to regular users) while including tools with FeatureFlagDisable.
-	// Build() can only fail if WithTools specifies invalid tools - not used here
-	r, _ := github.NewInventory(t).WithToolsets([]string{"all"}).Build()
+	// Custom toolsets set with --custom-toolsets are documented along with the built-in ones.
+	customToolsets, err := loadCustomToolsets()
+	if err != nil {
+		return err
+	}
+	r, err := github.NewInventory(t).WithToolsets([]string{"all"}).WithCustomToolsets(customToolsets).Build()
+	if err != nil {
+		return fmt.Errorf("failed to build inventory: %w", err)
+	}
 
 	// Generate toolsets documentation
 	toolsetsDoc := generateToolsetsDoc(r)
@@ -196,6 +203,17 @@ func generateToolsDoc(r *inventory.Inventory) string {
 	// Emit the last section
 	writeSection()
 
+	// Custom toolsets get a section of their own, repeating the docs of their tools
+	for _, ts := range r.CustomToolsets() {
+		currentToolsetID = ts.ID
+		currentToolsetIcon = ts.Icon
+		for _, tool := range r.ToolsForToolset(ts.ID) {
+			writeToolDoc(&toolBuf, tool)
+			toolBuf.WriteString("\n\n")
+		}
+		writeSection()
+	}
+
 	return buf.String()
 }
 
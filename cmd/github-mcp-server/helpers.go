@@ -1,6 +1,15 @@
 package main
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/spf13/viper"
+)
 
 // formatToolsetName converts a toolset ID to a human-readable name.
 // Used by both generate_docs.go and list_scopes.go for consistent formatting.
@@ -27,3 +36,85 @@ func formatToolsetName(name string) string {
 		return strings.Join(parts, " ")
 	}
 }
+
+// loadCustomToolsets loads the custom toolsets file set with --custom-toolsets, if any.
+// Used by both the stdio server and generate_docs.go.
+func loadCustomToolsets() ([]inventory.CustomToolset, error) {
+	path := viper.GetString("custom-toolsets")
+	if path == "" {
+		return nil, nil
+	}
+	return github.LoadCustomToolsets(path)
+}
+
+// unmarshalSliceFlag reads a comma-separated list flag, returning nil if it is not set.
+// viper.GetStringSlice doesn't split comma-separated environment variables, and
+// viper.UnmarshalKey returns an empty slice for unset flags, which would mean "none".
+func unmarshalSliceFlag(key string) ([]string, error) {
+	if !viper.IsSet(key) {
+		return nil, nil
+	}
+	var values []string
+	if err := viper.UnmarshalKey(key, &values); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", key, err)
+	}
+	return values, nil
+}
+
+// localeTranslationHelper returns a translation helper for the locale set with --locale, using
+// the embedded bundles and those in the directory set with --locales-dir.
+func localeTranslationHelper() (translations.TranslationHelperFunc, error) {
+	bundles, err := translations.LoadBundles(viper.GetString("locales-dir"))
+	if err != nil {
+		return nil, err
+	}
+	t, _ := translations.LocaleTranslationHelper(bundles, viper.GetString("locale"))
+	return t, nil
+}
+
+// inventoryConfigFromFlags returns the configuration of the stdio server's inventory from the
+// flags, so that commands inspecting the inventory see the tools the server would offer.
+// Used by doctor.go, export_tools.go and list_scopes.go.
+func inventoryConfigFromFlags() (ghmcp.InventoryConfig, error) {
+	enabledToolsets, err := unmarshalSliceFlag("toolsets")
+	if err != nil {
+		return ghmcp.InventoryConfig{}, err
+	}
+	enabledTools, err := unmarshalSliceFlag("tools")
+	if err != nil {
+		return ghmcp.InventoryConfig{}, err
+	}
+	enabledFeatures, err := unmarshalSliceFlag("features")
+	if err != nil {
+		return ghmcp.InventoryConfig{}, err
+	}
+	customToolsets, err := loadCustomToolsets()
+	if err != nil {
+		return ghmcp.InventoryConfig{}, err
+	}
+
+	var repository *github.RepositoryScope
+	if viper.GetString("repo") != "" {
+		scope, err := github.ParseRepositoryScope(viper.GetString("repo"))
+		if err != nil {
+			return ghmcp.InventoryConfig{}, err
+		}
+		repository = &scope
+	}
+
+	t, err := localeTranslationHelper()
+	if err != nil {
+		return ghmcp.InventoryConfig{}, err
+	}
+	return ghmcp.InventoryConfig{
+		EnabledToolsets:   enabledToolsets,
+		EnabledTools:      enabledTools,
+		EnabledFeatures:   enabledFeatures,
+		CustomToolsets:    customToolsets,
+		Repository:        repository,
+		DynamicToolsets:   viper.GetBool("dynamic_toolsets"),
+		ContentWindowSize: viper.GetInt("content-window-size"),
+		ReadOnly:          viper.GetBool("read-only"),
+		Translator:        t,
+	}, nil
+}
@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/github/github-mcp-server/pkg/github"
 	"github.com/github/github-mcp-server/pkg/inventory"
-	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/scopes"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -34,6 +36,34 @@ type ScopesOutput struct {
 	ReadOnly        bool                `json:"read_only"`
 }
 
+// ToolsetScopeCheck contains the result of checking the tools of a toolset against the token's scopes.
+type ToolsetScopeCheck struct {
+	Toolset string `json:"toolset"`
+	// Usable are the tools the token can use.
+	Usable []string `json:"usable"`
+	// PublicReposOnly are the usable read-only tools that only work on public repositories
+	// because the token lacks the repo scope.
+	PublicReposOnly []string `json:"public_repos_only,omitempty"`
+	// Hidden are the tools hidden from the token by scope filtering.
+	Hidden []HiddenToolInfo `json:"hidden"`
+}
+
+// HiddenToolInfo describes a tool hidden by scope filtering.
+type HiddenToolInfo struct {
+	Name string `json:"name"`
+	// UnlockWith are the scopes, any one of which would make the tool visible.
+	UnlockWith []string `json:"unlock_with"`
+}
+
+// ScopeCheckOutput is the output structure for the list-scopes --check command.
+type ScopeCheckOutput struct {
+	TokenScopes   []string            `json:"token_scopes"`
+	GrantedScopes []string            `json:"granted_scopes"`
+	Toolsets      []ToolsetScopeCheck `json:"toolsets"`
+	// MissingScopes is a minimal set of extra scopes that would unlock every hidden tool.
+	MissingScopes []string `json:"missing_scopes"`
+}
+
 var listScopesCmd = &cobra.Command{
 	Use:   "list-scopes",
 	Short: "List required OAuth scopes for enabled tools",
@@ -43,10 +73,16 @@ This command creates an inventory based on the same flags as the stdio command
 and outputs the required OAuth scopes for each enabled tool. This is useful for
 determining what scopes a token needs to use specific tools.
 
+With --check, the command fetches the scopes of the token set with
+GITHUB_PERSONAL_ACCESS_TOKEN and reports per toolset which tools the token can
+use, which tools are hidden by scope filtering, and the minimal set of extra
+scopes that would unlock the hidden tools. This only works for classic personal
+access tokens and OAuth tokens; use the doctor command for fine-grained tokens.
+
 The output format can be controlled with the --output flag:
   - text (default): Human-readable text output
   - json: JSON output for programmatic use
-  - summary: Just the unique scopes needed
+  - summary: Just the unique scopes needed (with --check, the missing scopes)
 
 Examples:
   # List scopes for default toolsets
@@ -62,7 +98,12 @@ Examples:
   github-mcp-server list-scopes --output=json
 
   # Just show unique scopes needed
-  github-mcp-server list-scopes --output=summary`,
+  github-mcp-server list-scopes --output=summary
+
+  # Check which tools the token can use
+  GITHUB_PERSONAL_ACCESS_TOKEN=ghp_... github-mcp-server list-scopes --check`,
+	// Errors of the token check are not caused by the usage
+	SilenceUsage: true,
 	RunE: func(_ *cobra.Command, _ []string) error {
 		return runListScopes()
 	},
@@ -70,7 +111,9 @@ Examples:
 
 func init() {
 	listScopesCmd.Flags().StringP("output", "o", "text", "Output format: text, json, or summary")
+	listScopesCmd.Flags().Bool("check", false, "Check the enabled tools against the scopes of the configured token")
 	_ = viper.BindPFlag("list-scopes-output", listScopesCmd.Flags().Lookup("output"))
+	_ = viper.BindPFlag("list-scopes-check", listScopesCmd.Flags().Lookup("check"))
 
 	rootCmd.AddCommand(listScopesCmd)
 }
@@ -84,46 +127,22 @@ func formatScopeDisplay(scope string) string {
 }
 
 func runListScopes() error {
-	// Get toolsets configuration (same logic as stdio command)
-	var enabledToolsets []string
-	if viper.IsSet("toolsets") {
-		if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
-			return fmt.Errorf("failed to unmarshal toolsets: %w", err)
-		}
-	}
-	// else: enabledToolsets stays nil, meaning "use defaults"
-
-	// Get specific tools (similar to toolsets)
-	var enabledTools []string
-	if viper.IsSet("tools") {
-		if err := viper.UnmarshalKey("tools", &enabledTools); err != nil {
-			return fmt.Errorf("failed to unmarshal tools: %w", err)
-		}
+	cfg, err := inventoryConfigFromFlags()
+	if err != nil {
+		return err
 	}
-
-	readOnly := viper.GetBool("read-only")
+	readOnly := cfg.ReadOnly
 	outputFormat := viper.GetString("list-scopes-output")
 
-	// Create translation helper
-	t, _ := translations.TranslationHelper()
-
-	// Build inventory using the same logic as the stdio server
-	inventoryBuilder := github.NewInventory(t).
-		WithReadOnly(readOnly)
-
-	// Configure toolsets (same as stdio)
-	if enabledToolsets != nil {
-		inventoryBuilder = inventoryBuilder.WithToolsets(enabledToolsets)
-	}
-
-	// Configure specific tools
-	if len(enabledTools) > 0 {
-		inventoryBuilder = inventoryBuilder.WithTools(enabledTools)
+	// Build the inventory of the stdio server, so the tools listed and checked are the ones
+	// it offers and the doctor command diagnoses
+	inv, err := ghmcp.BuildInventory(cfg)
+	if err != nil {
+		return err
 	}
 
-	inv, err := inventoryBuilder.Build()
-	if err != nil {
-		return fmt.Errorf("failed to build inventory: %w", err)
+	if viper.GetBool("list-scopes-check") {
+		return runScopeCheck(inv, outputFormat)
 	}
 
 	// Collect all tools and their scopes
@@ -292,3 +311,120 @@ func outputText(output ScopesOutput) error {
 
 	return nil
 }
+
+func runScopeCheck(inv *inventory.Inventory, outputFormat string) error {
+	token := viper.GetString("personal_access_token")
+	if token == "" {
+		return fmt.Errorf("GITHUB_PERSONAL_ACCESS_TOKEN not set")
+	}
+	if scopes.IsFineGrainedToken(token) {
+		return fmt.Errorf("fine-grained tokens have permissions instead of scopes, use the doctor command to check them")
+	}
+
+	tokenScopes, err := ghmcp.FetchTokenScopesForHost(context.Background(), token, viper.GetString("host"))
+	if err != nil {
+		return fmt.Errorf("failed to fetch token scopes: %w", err)
+	}
+
+	output := checkToolScopes(inv.AvailableTools(context.Background()), tokenScopes)
+
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	case "summary":
+		outputScopeCheckSummary(output)
+	default:
+		outputScopeCheckText(output)
+	}
+	return nil
+}
+
+func checkToolScopes(tools []inventory.ServerTool, tokenScopes []string) ScopeCheckOutput {
+	granted := scopes.GrantedScopes(tokenScopes)
+	hasRepo := slices.Contains(granted, string(scopes.Repo))
+
+	var toolsets []ToolsetScopeCheck
+	var hiddenTools []inventory.ServerTool
+	for i := range tools {
+		tool := &tools[i]
+		toolset := string(tool.Toolset.ID)
+		if len(toolsets) == 0 || toolsets[len(toolsets)-1].Toolset != toolset {
+			toolsets = append(toolsets, ToolsetScopeCheck{Toolset: toolset, Usable: []string{}, Hidden: []HiddenToolInfo{}})
+		}
+		check := &toolsets[len(toolsets)-1]
+
+		unlockWith := github.ScopesToUnlock(tokenScopes, tool)
+		if len(unlockWith) > 0 {
+			check.Hidden = append(check.Hidden, HiddenToolInfo{Name: tool.Tool.Name, UnlockWith: unlockWith})
+			hiddenTools = append(hiddenTools, *tool)
+			continue
+		}
+		check.Usable = append(check.Usable, tool.Tool.Name)
+		if !hasRepo && tool.IsReadOnly() && slices.Contains(tool.RequiredScopes, string(scopes.Repo)) {
+			check.PublicReposOnly = append(check.PublicReposOnly, tool.Tool.Name)
+		}
+	}
+
+	missingScopes := github.MinimalScopesToUnlock(tokenScopes, hiddenTools)
+	if missingScopes == nil {
+		missingScopes = []string{}
+	}
+	if tokenScopes == nil {
+		tokenScopes = []string{}
+	}
+	return ScopeCheckOutput{
+		TokenScopes:   tokenScopes,
+		GrantedScopes: granted,
+		Toolsets:      toolsets,
+		MissingScopes: missingScopes,
+	}
+}
+
+func outputScopeCheckSummary(output ScopeCheckOutput) {
+	if len(output.MissingScopes) == 0 {
+		fmt.Println("The token can use all enabled tools.")
+		return
+	}
+
+	fmt.Println("Scopes to add to the token to use all enabled tools:")
+	fmt.Println()
+	for _, scope := range output.MissingScopes {
+		fmt.Printf("  %s\n", scope)
+	}
+}
+
+func outputScopeCheckText(output ScopeCheckOutput) {
+	fmt.Printf("OAuth Scope Check for Enabled Tools\n")
+	fmt.Printf("===================================\n\n")
+
+	tokenScopes := "(none)"
+	if len(output.TokenScopes) > 0 {
+		tokenScopes = strings.Join(output.TokenScopes, ", ")
+	}
+	fmt.Printf("Token Scopes: %s\n\n", tokenScopes)
+
+	usable, hidden := 0, 0
+	for _, toolset := range output.Toolsets {
+		fmt.Printf("## %s\n\n", formatToolsetName(toolset.Toolset))
+		for _, name := range toolset.Usable {
+			line := fmt.Sprintf("  ✓ %s", name)
+			if slices.Contains(toolset.PublicReposOnly, name) {
+				line += " (public repositories only)"
+			}
+			fmt.Println(line)
+		}
+		for _, tool := range toolset.Hidden {
+			fmt.Printf("  ✗ %s: hidden, add %s\n", tool.Name, strings.Join(tool.UnlockWith, " or "))
+		}
+		fmt.Println()
+		usable += len(toolset.Usable)
+		hidden += len(toolset.Hidden)
+	}
+
+	fmt.Printf("Total: %d usable, %d hidden\n", usable, hidden)
+	if len(output.MissingScopes) > 0 {
+		fmt.Printf("Add these scopes to use all enabled tools: %s\n", strings.Join(output.MissingScopes, ", "))
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readJSONFile reads a JSON value from a file.
+func readJSONFile(path string) (any, error) {
+	data, err := os.ReadFile(path) //nolint:gosec //mcpcurl reads the argument files it is given
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON in %s: %w", path, err)
+	}
+	return value, nil
+}
+
+// readArgumentsFile reads the arguments of a call from a file holding a JSON object.
+func readArgumentsFile(path string) (map[string]any, error) {
+	value, err := readJSONFile(path)
+	if err != nil {
+		return nil, err
+	}
+	arguments, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must hold a JSON object of arguments", path)
+	}
+	return arguments, nil
+}
+
+// parseJSONValue parses a JSON value, or reads it from a file if raw is @path.
+func parseJSONValue(raw string) (any, error) {
+	if path, ok := strings.CutPrefix(raw, "@"); ok {
+		return readJSONFile(path)
+	}
+	var value any
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return value, nil
+}
+
+// parseValue converts the text form of an argument to the type of its property. Objects and
+// arrays are given as JSON or read from a JSON file with @path, and arrays of strings can also
+// be given as comma-separated values.
+func parseValue(prop Property, raw string) (any, error) {
+	switch prop.Type {
+	case "string":
+		return raw, nil
+	case "integer":
+		return strconv.ParseInt(raw, 10, 64)
+	case "number":
+		return strconv.ParseFloat(raw, 64)
+	case "boolean":
+		return strconv.ParseBool(raw)
+	case "array":
+		if prop.Items != nil && prop.Items.Type == "string" && !strings.HasPrefix(raw, "[") && !strings.HasPrefix(raw, "@") {
+			return strings.Split(raw, ","), nil
+		}
+		return parseJSONValue(raw)
+	case "object":
+		return parseJSONValue(raw)
+	default:
+		// Untyped properties take any JSON value, falling back to a string
+		if value, err := parseJSONValue(raw); err == nil {
+			return value, nil
+		}
+		return raw, nil
+	}
+}
+
+// parseAssignments parses name=value words into the arguments of a tool, using the tool's
+// schema to convert the values. A word @path reads arguments from a JSON file, which later
+// assignments override.
+func parseAssignments(schema Property, words []string) (map[string]any, error) {
+	arguments := make(map[string]any)
+	for _, word := range words {
+		if path, ok := strings.CutPrefix(word, "@"); ok {
+			fileArguments, err := readArgumentsFile(path)
+			if err != nil {
+				return nil, err
+			}
+			for name, value := range fileArguments {
+				arguments[name] = value
+			}
+			continue
+		}
+
+		name, raw, ok := strings.Cut(word, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid argument %q, expected name=value or @file", word)
+		}
+		value, err := parseValue(schema.Properties[name], raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", name, err)
+		}
+		arguments[name] = value
+	}
+	return arguments, nil
+}
+
+// missingRequired returns the required properties of a schema that have no argument.
+func missingRequired(schema Property, arguments map[string]any) []string {
+	var missing []string
+	for _, name := range schema.Required {
+		if _, ok := arguments[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// splitWords splits a REPL line into words at spaces. Single and double quotes group words with
+// spaces, and a backslash escapes the next character outside single quotes.
+func splitWords(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		expected    []string
+		expectedErr string
+	}{
+		{name: "empty line", line: "", expected: nil},
+		{name: "spaces and tabs", line: "  get_me \t owner=octo  ", expected: []string{"get_me", "owner=octo"}},
+		{name: "double quotes group words", line: `create_issue title="a title"`, expected: []string{"create_issue", "title=a title"}},
+		{name: "single quotes keep JSON", line: `push_files files='[{"path": "a"}]'`, expected: []string{"push_files", `files=[{"path": "a"}]`}},
+		{name: "empty quotes are a word", line: `tool body=""`, expected: []string{"tool", "body="}},
+		{name: "adjacent quotes join", line: `tool body="a"' b'`, expected: []string{"tool", "body=a b"}},
+		{name: "backslash escapes a space", line: `tool path=a\ b`, expected: []string{"tool", "path=a b"}},
+		{name: "backslash escapes a quote in double quotes", line: `tool body="say \"hi\""`, expected: []string{"tool", `body=say "hi"`}},
+		{name: "backslash is literal in single quotes", line: `tool body='a\nb'`, expected: []string{"tool", `body=a\nb`}},
+		{name: "unterminated quote", line: `tool body="open`, expectedErr: "unterminated quote"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			words, err := splitWords(tc.line)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, words)
+		})
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	dir := t.TempDir()
+	labelsFile := filepath.Join(dir, "labels.json")
+	require.NoError(t, os.WriteFile(labelsFile, []byte(`["bug", "help wanted"]`), 0600))
+
+	stringArray := Property{Type: "array", Items: &Property{Type: "string"}}
+	tests := []struct {
+		name        string
+		prop        Property
+		raw         string
+		expected    any
+		expectedErr string
+	}{
+		{name: "string", prop: Property{Type: "string"}, raw: "[not json]", expected: "[not json]"},
+		{name: "integer", prop: Property{Type: "integer"}, raw: "42", expected: int64(42)},
+		{name: "invalid integer", prop: Property{Type: "integer"}, raw: "4.2", expectedErr: "invalid syntax"},
+		{name: "number", prop: Property{Type: "number"}, raw: "4.2", expected: 4.2},
+		{name: "boolean", prop: Property{Type: "boolean"}, raw: "true", expected: true},
+		{name: "comma-separated string array", prop: stringArray, raw: "bug,help wanted", expected: []string{"bug", "help wanted"}},
+		{name: "JSON string array", prop: stringArray, raw: `["bug,critical"]`, expected: []any{"bug,critical"}},
+		{name: "string array from a file", prop: stringArray, raw: "@" + labelsFile, expected: []any{"bug", "help wanted"}},
+		{name: "object array takes JSON only", prop: Property{Type: "array", Items: &Property{Type: "object"}}, raw: "a,b", expectedErr: "invalid JSON"},
+		{name: "object", prop: Property{Type: "object"}, raw: `{"a": 1}`, expected: map[string]any{"a": float64(1)}},
+		{name: "missing file", prop: Property{Type: "object"}, raw: "@" + filepath.Join(dir, "missing.json"), expectedErr: "failed to read"},
+		{name: "untyped JSON", prop: Property{}, raw: "[1]", expected: []any{float64(1)}},
+		{name: "untyped text falls back to a string", prop: Property{}, raw: "hello", expected: "hello"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := parseValue(tc.prop, tc.raw)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestParseAssignments(t *testing.T) {
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args.json")
+	require.NoError(t, os.WriteFile(argsFile, []byte(`{"owner": "octo", "repo": "hello", "per_page": 10}`), 0600))
+	arrayFile := filepath.Join(dir, "array.json")
+	require.NoError(t, os.WriteFile(arrayFile, []byte(`["not", "an", "object"]`), 0600))
+
+	schema := Property{
+		Type: "object",
+		Properties: map[string]Property{
+			"owner":    {Type: "string"},
+			"repo":     {Type: "string"},
+			"per_page": {Type: "integer"},
+			"labels":   {Type: "array", Items: &Property{Type: "string"}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		words       []string
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			name:     "typed assignments",
+			words:    []string{"owner=octo", "per_page=5", "labels=bug,docs"},
+			expected: map[string]any{"owner": "octo", "per_page": int64(5), "labels": []string{"bug", "docs"}},
+		},
+		{
+			name:     "values may contain equals signs",
+			words:    []string{"owner=a=b"},
+			expected: map[string]any{"owner": "a=b"},
+		},
+		{
+			name:     "later assignments override the file",
+			words:    []string{"@" + argsFile, "repo=other"},
+			expected: map[string]any{"owner": "octo", "repo": "other", "per_page": float64(10)},
+		},
+		{
+			name:     "the file overrides earlier assignments",
+			words:    []string{"repo=other", "@" + argsFile},
+			expected: map[string]any{"owner": "octo", "repo": "hello", "per_page": float64(10)},
+		},
+		{
+			name:        "word without equals sign",
+			words:       []string{"owner"},
+			expectedErr: `invalid argument "owner", expected name=value or @file`,
+		},
+		{
+			name:        "invalid typed value",
+			words:       []string{"per_page=many"},
+			expectedErr: "invalid value for per_page",
+		},
+		{
+			name:        "file without an object",
+			words:       []string{"@" + arrayFile},
+			expectedErr: "must hold a JSON object of arguments",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			arguments, err := parseAssignments(schema, tc.words)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, arguments)
+		})
+	}
+}
+
+func TestMissingRequired(t *testing.T) {
+	schema := Property{Required: []string{"owner", "repo"}}
+	assert.Equal(t, []string{"repo"}, missingRequired(schema, map[string]any{"owner": "octo"}))
+	assert.Empty(t, missingRequired(schema, map[string]any{"owner": "octo", "repo": "hello"}))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// connectionOptions configures how mcpcurl connects to the MCP server.
+type connectionOptions struct {
+	// stdioServerCmd is the shell command to start the server with, connected via stdio
+	stdioServerCmd string
+	// url is the endpoint of a server using the streamable HTTP transport
+	url string
+	// headers are sent with every HTTP request, in the "Name: value" form
+	headers []string
+}
+
+// headerTransport adds headers, like an Authorization header, to every request.
+type headerTransport struct {
+	headers   http.Header
+	transport http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// connect starts or connects to the MCP server and initializes a session with it.
+func connect(ctx context.Context, opts connectionOptions) (*mcp.ClientSession, error) {
+	var transport mcp.Transport
+	switch {
+	case opts.stdioServerCmd != "" && opts.url != "":
+		return nil, fmt.Errorf("--stdio-server-cmd and --url are mutually exclusive")
+	case opts.stdioServerCmd != "":
+		// Split the command string into command and arguments
+		cmdParts := strings.Fields(opts.stdioServerCmd)
+		if len(cmdParts) == 0 {
+			return nil, fmt.Errorf("empty command")
+		}
+		cmd := exec.Command(cmdParts[0], cmdParts[1:]...) //nolint:gosec //mcpcurl is a test command that needs to execute arbitrary shell commands
+		transport = &mcp.CommandTransport{Command: cmd}
+	case opts.url != "":
+		headers := http.Header{}
+		for _, header := range opts.headers {
+			name, value, ok := strings.Cut(header, ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid header %q, expected the \"Name: value\" form", header)
+			}
+			headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+		transport = &mcp.StreamableClientTransport{
+			Endpoint:   opts.url,
+			HTTPClient: &http.Client{Transport: &headerTransport{headers: headers, transport: http.DefaultTransport}},
+		}
+	default:
+		return nil, fmt.Errorf("--stdio-server-cmd or --url is required")
+	}
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "mcpcurl", Version: "0.1.0"}, nil)
+	session, err := client.Connect(ctx, transport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MCP server: %w", err)
+	}
+	return session, nil
+}
+
+// listTools returns all tools of the server, with their input schemas decoded.
+func listTools(ctx context.Context, session *mcp.ClientSession) ([]Tool, error) {
+	var tools []Tool
+	for tool, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tools: %w", err)
+		}
+		data, err := json.Marshal(tool)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal tool %s: %w", tool.Name, err)
+		}
+		var t Tool
+		if err := json.Unmarshal(data, &t); err != nil {
+			return nil, fmt.Errorf("failed to parse schema of tool %s: %w", tool.Name, err)
+		}
+		tools = append(tools, t)
+	}
+	return tools, nil
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	resourcesCmd = &cobra.Command{
+		Use:   "resources",
+		Short: "List and read resources",
+	}
+
+	resourcesListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List resources and resource templates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return listResources(cmd.Context(), cmd.OutOrStdout(), prettyPrint(cmd))
+		},
+	}
+
+	resourcesReadCmd = &cobra.Command{
+		Use:   "read <uri>",
+		Short: "Read a resource",
+		Long:  "Reads a resource with the resources/read method. The URI can be that of a listed resource or fill in a resource template.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return readResource(cmd.Context(), cmd.OutOrStdout(), args[0], prettyPrint(cmd))
+		},
+	}
+
+	promptsCmd = &cobra.Command{
+		Use:   "prompts",
+		Short: "List and get prompts",
+	}
+
+	promptsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List prompts and their arguments",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return listPrompts(cmd.Context(), cmd.OutOrStdout(), prettyPrint(cmd))
+		},
+	}
+
+	promptsGetCmd = &cobra.Command{
+		Use:   "get <name>",
+		Short: "Get a prompt",
+		Long:  "Gets a prompt with the prompts/get method. Set its arguments with --arg name=value.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			assignments, _ := cmd.Flags().GetStringArray("arg")
+			arguments, err := parseStringAssignments(assignments)
+			if err != nil {
+				return err
+			}
+			return getPrompt(cmd.Context(), cmd.OutOrStdout(), args[0], arguments, prettyPrint(cmd))
+		},
+	}
+
+	completeCmd = &cobra.Command{
+		Use:   "complete",
+		Short: "Complete an argument of a prompt or resource template",
+		Long: `Requests completions with the completion/complete method.
+
+Examples:
+  # Complete the owner argument of a resource template
+  mcpcurl --stdio-server-cmd="github-mcp-server stdio" complete --resource "repo://{owner}/{repo}/contents{/path*}" --argument owner --value gith
+
+  # Complete the repo argument, given the owner
+  mcpcurl --stdio-server-cmd="github-mcp-server stdio" complete --resource "repo://{owner}/{repo}/contents{/path*}" --argument repo --value git --context owner=github`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			prompt, _ := cmd.Flags().GetString("prompt")
+			resource, _ := cmd.Flags().GetString("resource")
+			argument, _ := cmd.Flags().GetString("argument")
+			value, _ := cmd.Flags().GetString("value")
+			assignments, _ := cmd.Flags().GetStringArray("context")
+
+			ref := &mcp.CompleteReference{Type: "ref/prompt", Name: prompt}
+			if resource != "" {
+				ref = &mcp.CompleteReference{Type: "ref/resource", URI: resource}
+			}
+			contextArgs, err := parseStringAssignments(assignments)
+			if err != nil {
+				return err
+			}
+			return complete(cmd.Context(), cmd.OutOrStdout(), ref, argument, value, contextArgs, prettyPrint(cmd))
+		},
+	}
+)
+
+func init() {
+	resourcesCmd.AddCommand(resourcesListCmd, resourcesReadCmd)
+	rootCmd.AddCommand(resourcesCmd)
+
+	promptsGetCmd.Flags().StringArray("arg", nil, "Prompt argument as name=value (repeatable)")
+	promptsCmd.AddCommand(promptsListCmd, promptsGetCmd)
+	rootCmd.AddCommand(promptsCmd)
+
+	completeCmd.Flags().String("prompt", "", "Name of the prompt to complete an argument of")
+	completeCmd.Flags().String("resource", "", "URI template of the resource to complete an argument of")
+	completeCmd.Flags().String("argument", "", "Name of the argument to complete")
+	completeCmd.Flags().String("value", "", "Value typed so far")
+	completeCmd.Flags().StringArray("context", nil, "Value of an argument already given, as name=value (repeatable)")
+	completeCmd.MarkFlagsOneRequired("prompt", "resource")
+	completeCmd.MarkFlagsMutuallyExclusive("prompt", "resource")
+	_ = completeCmd.MarkFlagRequired("argument")
+	rootCmd.AddCommand(completeCmd)
+}
+
+// parseStringAssignments parses name=value words into string arguments.
+func parseStringAssignments(words []string) (map[string]string, error) {
+	arguments := make(map[string]string)
+	for _, word := range words {
+		name, value, ok := strings.Cut(word, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid argument %q, expected name=value", word)
+		}
+		arguments[name] = value
+	}
+	return arguments, nil
+}
+
+// listResources writes the resources and resource templates of the server.
+func listResources(ctx context.Context, w io.Writer, pretty bool) error {
+	var resources []*mcp.Resource
+	for resource, err := range session.Resources(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list resources: %w", err)
+		}
+		resources = append(resources, resource)
+	}
+	var templates []*mcp.ResourceTemplate
+	for template, err := range session.ResourceTemplates(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list resource templates: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if !pretty {
+		return printJSON(w, map[string]any{"resources": resources, "resourceTemplates": templates}, false)
+	}
+	for _, resource := range resources {
+		if _, err := fmt.Fprintf(w, "%s\t%s\n", resource.URI, resource.Description); err != nil {
+			return err
+		}
+	}
+	for _, template := range templates {
+		if _, err := fmt.Fprintf(w, "%s\t%s\n", template.URITemplate, template.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readResource reads a resource and writes its contents.
+func readResource(ctx context.Context, w io.Writer, uri string, pretty bool) error {
+	result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		return fmt.Errorf("failed to read resource: %w", err)
+	}
+	return printReadResourceResult(w, result, pretty)
+}
+
+// listPrompts writes the prompts of the server with their arguments.
+func listPrompts(ctx context.Context, w io.Writer, pretty bool) error {
+	var prompts []*mcp.Prompt
+	for prompt, err := range session.Prompts(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list prompts: %w", err)
+		}
+		prompts = append(prompts, prompt)
+	}
+
+	if !pretty {
+		return printJSON(w, map[string]any{"prompts": prompts}, false)
+	}
+	for _, prompt := range prompts {
+		if _, err := fmt.Fprintf(w, "%s\t%s\n", prompt.Name, prompt.Description); err != nil {
+			return err
+		}
+		for _, argument := range prompt.Arguments {
+			required := ""
+			if argument.Required {
+				required = " (required)"
+			}
+			if _, err := fmt.Fprintf(w, "  %s%s\t%s\n", argument.Name, required, argument.Description); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getPrompt gets a prompt and writes its messages.
+func getPrompt(ctx context.Context, w io.Writer, name string, arguments map[string]string, pretty bool) error {
+	result, err := session.GetPrompt(ctx, &mcp.GetPromptParams{Name: name, Arguments: arguments})
+	if err != nil {
+		return fmt.Errorf("failed to get prompt: %w", err)
+	}
+	return printGetPromptResult(w, result, pretty)
+}
+
+// complete requests the completions of an argument and writes them, one per line.
+func complete(ctx context.Context, w io.Writer, ref *mcp.CompleteReference, argument, value string, contextArgs map[string]string, pretty bool) error {
+	params := &mcp.CompleteParams{
+		Ref:      ref,
+		Argument: mcp.CompleteParamsArgument{Name: argument, Value: value},
+	}
+	if len(contextArgs) > 0 {
+		params.Context = &mcp.CompleteContext{Arguments: contextArgs}
+	}
+	result, err := session.Complete(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to complete: %w", err)
+	}
+
+	if !pretty {
+		return printJSON(w, result, false)
+	}
+	for _, completion := range result.Completion.Values {
+		if _, err := fmt.Fprintln(w, completion); err != nil {
+			return err
+		}
+	}
+	if result.Completion.HasMore {
+		_, err := fmt.Fprintln(w, "...")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// printJSON writes a value as JSON, indented if pretty is set.
+func printJSON(w io.Writer, value any, pretty bool) error {
+	var data []byte
+	var err error
+	if pretty {
+		data, err = json.MarshalIndent(value, "", "  ")
+	} else {
+		data, err = json.Marshal(value)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// printText writes text, indenting it if it is JSON.
+func printText(w io.Writer, text string) error {
+	var value any
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		_, err := fmt.Fprintln(w, text)
+		return err
+	}
+	return printJSON(w, value, true)
+}
+
+// sameJSON reports whether text is the JSON encoding of value, which is how tools return their
+// structured content as text for clients that don't read structuredContent.
+func sameJSON(text string, value any) bool {
+	var textValue, normalized any
+	if err := json.Unmarshal([]byte(text), &textValue); err != nil {
+		return false
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(textValue, normalized)
+}
+
+// printContent writes a content item of a tool result or prompt message.
+func printContent(w io.Writer, content mcp.Content) error {
+	switch c := content.(type) {
+	case *mcp.TextContent:
+		return printText(w, c.Text)
+	case *mcp.ImageContent:
+		_, err := fmt.Fprintf(w, "[image %s, %d bytes]\n", c.MIMEType, len(c.Data))
+		return err
+	case *mcp.AudioContent:
+		_, err := fmt.Fprintf(w, "[audio %s, %d bytes]\n", c.MIMEType, len(c.Data))
+		return err
+	case *mcp.ResourceLink:
+		_, err := fmt.Fprintf(w, "[resource link %s]\n", c.URI)
+		return err
+	case *mcp.EmbeddedResource:
+		return printResourceContents(w, c.Resource)
+	default:
+		return printJSON(w, content, true)
+	}
+}
+
+// printResourceContents writes the text of a resource, or a summary of its binary content.
+func printResourceContents(w io.Writer, contents *mcp.ResourceContents) error {
+	if contents == nil {
+		return nil
+	}
+	if contents.Blob != nil {
+		_, err := fmt.Fprintf(w, "[resource %s, %s, %d bytes]\n", contents.URI, contents.MIMEType, len(contents.Blob))
+		return err
+	}
+	return printText(w, contents.Text)
+}
+
+// printCallToolResult writes the result of a tool call. Unless pretty is set, the result is
+// written as raw JSON. Otherwise the content is written with JSON text indented, followed by the
+// structured content, and text that only repeats the structured content is left out.
+func printCallToolResult(w io.Writer, result *mcp.CallToolResult, pretty bool) error {
+	if !pretty {
+		return printJSON(w, result, false)
+	}
+
+	if result.IsError {
+		if _, err := fmt.Fprintln(w, "The tool returned an error:"); err != nil {
+			return err
+		}
+	}
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok && result.StructuredContent != nil && sameJSON(text.Text, result.StructuredContent) {
+			continue
+		}
+		if err := printContent(w, content); err != nil {
+			return err
+		}
+	}
+	if result.StructuredContent != nil {
+		if _, err := fmt.Fprintln(w, "structuredContent:"); err != nil {
+			return err
+		}
+		return printJSON(w, result.StructuredContent, true)
+	}
+	return nil
+}
+
+// printReadResourceResult writes the contents of a resource.
+func printReadResourceResult(w io.Writer, result *mcp.ReadResourceResult, pretty bool) error {
+	if !pretty {
+		return printJSON(w, result, false)
+	}
+	for _, contents := range result.Contents {
+		if err := printResourceContents(w, contents); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printGetPromptResult writes the messages of a prompt, each after its role.
+func printGetPromptResult(w io.Writer, result *mcp.GetPromptResult, pretty bool) error {
+	if !pretty {
+		return printJSON(w, result, false)
+	}
+	for _, message := range result.Messages {
+		if _, err := fmt.Fprintf(w, "%s:\n", message.Role); err != nil {
+			return err
+		}
+		if err := printContent(w, message.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const replHelp = `Commands:
+  <tool> [name=value ...] [@args.json]   Call a tool. Objects and arrays are given as JSON in
+                                         single quotes, like files='[{"path": "a"}]', or read
+                                         from a JSON file with name=@file.json
+  tools                                  List the tools
+  resources                              List the resources and resource templates
+  read <uri>                             Read a resource
+  prompts                                List the prompts
+  prompt <name> [name=value ...]         Get a prompt
+  complete prompt|resource <name|uri> <argument>=<value> [name=value ...]
+                                         Complete an argument of a prompt or resource template
+  help                                   Show this help
+  exit                                   Leave the REPL
+
+Press Tab to complete commands, tool names, arguments and enum values.`
+
+// replCommands are the commands of the REPL, besides tool names.
+var replCommands = []string{"tools", "resources", "read", "prompts", "prompt", "complete", "help", "exit", "quit"}
+
+var replCmd = &cobra.Command{
+	Use:   "repl",
+	Short: "Start an interactive session with the MCP server",
+	Long: `Starts an interactive session with the MCP server, to call tools, read resources,
+get prompts and request completions in a single session.
+
+When stdin is a terminal, Tab completes commands, tool names, argument names
+and enum values from the tool schemas. Otherwise commands are read line by
+line, so a session can be scripted.
+
+` + replHelp,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runREPL(cmd.Context(), prettyPrint(cmd))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(replCmd)
+}
+
+// repl is an interactive session with the MCP server.
+type repl struct {
+	ctx    context.Context
+	out    io.Writer
+	pretty bool
+	tools  map[string]Tool
+
+	// prompts and resources are listed when first completed
+	prompts   []*mcp.Prompt
+	resources []string
+}
+
+func runREPL(ctx context.Context, pretty bool) error {
+	r := &repl{ctx: ctx, pretty: pretty, tools: make(map[string]Tool)}
+	for _, tool := range serverTools {
+		r.tools[tool.Name] = tool
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		r.out = os.Stdout
+		scanner := bufio.NewScanner(os.Stdin)
+		// Lines can hold the JSON arguments of tools like push_files
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			if r.execute(scanner.Text()) {
+				return nil
+			}
+		}
+		return scanner.Err()
+	}
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to set up terminal: %w", err)
+	}
+	defer func() { _ = term.Restore(fd, oldState) }()
+
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, "mcp> ")
+	terminal.AutoCompleteCallback = r.autoComplete
+	r.out = terminal
+	_, _ = fmt.Fprintln(r.out, "Type help for the commands, Tab to complete, exit or Ctrl-D to leave.")
+	for {
+		line, err := terminal.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if r.execute(line) {
+			return nil
+		}
+	}
+}
+
+// execute runs a line of input and reports whether the session ends. Errors are written to the
+// output, so the session continues after them.
+func (r *repl) execute(line string) bool {
+	words, err := splitWords(line)
+	if err != nil {
+		r.printError(err)
+		return false
+	}
+	if len(words) == 0 {
+		return false
+	}
+
+	command, args := words[0], words[1:]
+	switch command {
+	case "exit", "quit":
+		return true
+	case "help":
+		_, _ = fmt.Fprintln(r.out, replHelp)
+	case "tools":
+		r.printTools()
+	case "resources":
+		r.printError(listResources(r.ctx, r.out, r.pretty))
+	case "read":
+		if len(args) != 1 {
+			r.printError(fmt.Errorf("usage: read <uri>"))
+			return false
+		}
+		r.printError(readResource(r.ctx, r.out, args[0], r.pretty))
+	case "prompts":
+		r.printError(listPrompts(r.ctx, r.out, r.pretty))
+	case "prompt":
+		if len(args) == 0 {
+			r.printError(fmt.Errorf("usage: prompt <name> [name=value ...]"))
+			return false
+		}
+		arguments, err := parseStringAssignments(args[1:])
+		if err != nil {
+			r.printError(err)
+			return false
+		}
+		r.printError(getPrompt(r.ctx, r.out, args[0], arguments, r.pretty))
+	case "complete":
+		r.printError(r.complete(args))
+	default:
+		r.printError(r.callTool(command, args))
+	}
+	return false
+}
+
+func (r *repl) printError(err error) {
+	if err != nil {
+		_, _ = fmt.Fprintf(r.out, "Error: %v\n", err)
+	}
+}
+
+func (r *repl) printTools() {
+	for _, name := range r.toolNames() {
+		description, _, _ := strings.Cut(r.tools[name].Description, "\n")
+		_, _ = fmt.Fprintf(r.out, "%s\t%s\n", name, description)
+	}
+}
+
+func (r *repl) callTool(name string, args []string) error {
+	tool, ok := r.tools[name]
+	if !ok {
+		return fmt.Errorf("unknown command or tool %q, type help for the commands", name)
+	}
+	arguments, err := parseAssignments(tool.InputSchema, args)
+	if err != nil {
+		return err
+	}
+	if missing := missingRequired(tool.InputSchema, arguments); len(missing) > 0 {
+		return fmt.Errorf("missing required arguments: %s", strings.Join(missing, ", "))
+	}
+
+	result, err := session.CallTool(r.ctx, &mcp.CallToolParams{Name: name, Arguments: arguments})
+	if err != nil {
+		return fmt.Errorf("error calling tool: %w", err)
+	}
+	return printCallToolResult(r.out, result, r.pretty)
+}
+
+// complete runs complete prompt|resource <name|uri> <argument>=<value> [name=value ...].
+func (r *repl) complete(args []string) error {
+	const usage = "usage: complete prompt|resource <name|uri> <argument>=<value> [name=value ...]"
+	if len(args) < 3 {
+		return errors.New(usage)
+	}
+
+	var ref *mcp.CompleteReference
+	switch args[0] {
+	case "prompt":
+		ref = &mcp.CompleteReference{Type: "ref/prompt", Name: args[1]}
+	case "resource":
+		ref = &mcp.CompleteReference{Type: "ref/resource", URI: args[1]}
+	default:
+		return errors.New(usage)
+	}
+	argument, value, ok := strings.Cut(args[2], "=")
+	if !ok {
+		return errors.New(usage)
+	}
+	contextArgs, err := parseStringAssignments(args[3:])
+	if err != nil {
+		return err
+	}
+	return complete(r.ctx, r.out, ref, argument, value, contextArgs, r.pretty)
+}
+
+func (r *repl) toolNames() []string {
+	names := make([]string, 0, len(r.tools))
+	for name := range r.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// listPrompts returns the prompts of the server, listed once.
+func (r *repl) listPrompts() []*mcp.Prompt {
+	if r.prompts == nil {
+		r.prompts = []*mcp.Prompt{}
+		for prompt, err := range session.Prompts(r.ctx, nil) {
+			if err != nil {
+				break
+			}
+			r.prompts = append(r.prompts, prompt)
+		}
+	}
+	return r.prompts
+}
+
+// listResources returns the URIs of the resources and the resource templates, listed once.
+func (r *repl) listResources() []string {
+	if r.resources == nil {
+		r.resources = []string{}
+		for resource, err := range session.Resources(r.ctx, nil) {
+			if err != nil {
+				break
+			}
+			r.resources = append(r.resources, resource.URI)
+		}
+		for template, err := range session.ResourceTemplates(r.ctx, nil) {
+			if err != nil {
+				break
+			}
+			r.resources = append(r.resources, template.URITemplate)
+		}
+	}
+	return r.resources
+}
+
+// candidates returns the completions of word, which follows words on the line.
+func (r *repl) candidates(words []string, word string) []string {
+	if len(words) == 0 {
+		return append(slices.Clone(replCommands), r.toolNames()...)
+	}
+
+	switch command := words[0]; {
+	case command == "read" && len(words) == 1:
+		return r.listResources()
+	case command == "prompt" && len(words) == 1:
+		var names []string
+		for _, prompt := range r.listPrompts() {
+			names = append(names, prompt.Name)
+		}
+		return names
+	case command == "prompt":
+		for _, prompt := range r.listPrompts() {
+			if prompt.Name != words[1] {
+				continue
+			}
+			var names []string
+			for _, argument := range prompt.Arguments {
+				names = append(names, argument.Name+"=")
+			}
+			return unassigned(names, words)
+		}
+		return nil
+	case command == "complete" && len(words) == 1:
+		return []string{"prompt", "resource"}
+	case command == "complete" && len(words) == 2 && words[1] == "prompt":
+		return r.candidates([]string{"prompt"}, word)
+	case command == "complete" && len(words) == 2 && words[1] == "resource":
+		return r.listResources()
+	}
+
+	tool, ok := r.tools[words[0]]
+	if !ok {
+		return nil
+	}
+	if name, _, ok := strings.Cut(word, "="); ok {
+		prop := tool.InputSchema.Properties[name]
+		values := prop.enumValues()
+		if prop.Type == "boolean" {
+			values = []string{"true", "false"}
+		}
+		var assignments []string
+		for _, value := range values {
+			assignments = append(assignments, name+"="+value)
+		}
+		return assignments
+	}
+	var names []string
+	for name := range tool.InputSchema.Properties {
+		names = append(names, name+"=")
+	}
+	sort.Strings(names)
+	return unassigned(names, words)
+}
+
+// unassigned returns the name= candidates that aren't assigned yet in words.
+func unassigned(names []string, words []string) []string {
+	return slices.DeleteFunc(names, func(name string) bool {
+		return slices.ContainsFunc(words, func(word string) bool { return strings.HasPrefix(word, name) })
+	})
+}
+
+// autoComplete completes the word before the cursor on Tab. A single match is completed, and
+// several matches are completed to their common prefix, or listed if that adds nothing.
+func (r *repl) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	head, tail := line[:pos], line[pos:]
+	start := strings.LastIndexByte(head, ' ') + 1
+	word := head[start:]
+	var matches []string
+	for _, candidate := range r.candidates(strings.Fields(head[:start]), word) {
+		if strings.HasPrefix(candidate, word) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 0 {
+		return "", 0, false
+	}
+
+	completion := matches[0]
+	for _, match := range matches[1:] {
+		for !strings.HasPrefix(match, completion) {
+			completion = completion[:len(completion)-1]
+		}
+	}
+	switch {
+	case len(matches) == 1 && !strings.HasSuffix(completion, "="):
+		completion += " "
+	case len(matches) > 1 && completion == word:
+		_, _ = fmt.Fprintln(r.out, strings.Join(matches, "  "))
+		return "", 0, false
+	}
+
+	head = head[:start] + completion
+	return head + tail, len(head), true
+}
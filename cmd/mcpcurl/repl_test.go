@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestREPL(out *bytes.Buffer) *repl {
+	return &repl{
+		ctx: context.Background(),
+		out: out,
+		tools: map[string]Tool{
+			"list_issues": {
+				Name: "list_issues",
+				InputSchema: Property{
+					Type: "object",
+					Properties: map[string]Property{
+						"owner": {Type: "string"},
+						"repo":  {Type: "string"},
+						"state": {Type: "string", Enum: []any{"OPEN", "CLOSED"}},
+						"draft": {Type: "boolean"},
+					},
+				},
+			},
+			"list_commits": {Name: "list_commits", InputSchema: Property{Type: "object"}},
+		},
+	}
+}
+
+func TestREPLCandidates(t *testing.T) {
+	r := newTestREPL(&bytes.Buffer{})
+
+	tests := []struct {
+		name     string
+		words    []string
+		word     string
+		expected []string
+	}{
+		{name: "argument names", words: []string{"list_issues"}, expected: []string{"draft=", "owner=", "repo=", "state="}},
+		{name: "assigned arguments are skipped", words: []string{"list_issues", "owner=octo"}, expected: []string{"draft=", "repo=", "state="}},
+		{name: "enum values", words: []string{"list_issues"}, word: "state=", expected: []string{"state=OPEN", "state=CLOSED"}},
+		{name: "boolean values", words: []string{"list_issues"}, word: "draft=", expected: []string{"draft=true", "draft=false"}},
+		{name: "unknown tool", words: []string{"nope"}, expected: nil},
+		{name: "complete kinds", words: []string{"complete"}, expected: []string{"prompt", "resource"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, r.candidates(tc.words, tc.word))
+		})
+	}
+
+	assert.Subset(t, r.candidates(nil, ""), []string{"help", "exit", "list_issues", "list_commits"}, "commands and tools are completed first")
+}
+
+func TestREPLAutoComplete(t *testing.T) {
+	tests := []struct {
+		name           string
+		line           string
+		expectedLine   string
+		expectedOutput string
+		expectedOK     bool
+	}{
+		{name: "single match gets a space", line: "he", expectedLine: "help ", expectedOK: true},
+		{name: "argument names keep the cursor after equals", line: "list_issues ow", expectedLine: "list_issues owner=", expectedOK: true},
+		{name: "common prefix of several matches", line: "list_", expectedLine: "list_", expectedOutput: "list_commits  list_issues\n"},
+		{name: "enum value", line: "list_issues state=C", expectedLine: "list_issues state=CLOSED ", expectedOK: true},
+		{name: "no match", line: "list_issues zzz", expectedLine: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			r := newTestREPL(out)
+			line, pos, ok := r.autoComplete(tc.line, len(tc.line), '\t')
+			assert.Equal(t, tc.expectedOK, ok)
+			if ok {
+				assert.Equal(t, tc.expectedLine, line)
+				assert.Equal(t, len(tc.expectedLine), pos)
+			}
+			assert.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+
+	_, _, ok := newTestREPL(&bytes.Buffer{}).autoComplete("he", 2, 'x')
+	assert.False(t, ok, "only Tab completes")
+}
+
+func TestREPLExecute(t *testing.T) {
+	tests := []struct {
+		name           string
+		line           string
+		expectedExit   bool
+		expectedOutput string
+	}{
+		{name: "empty line", line: "   "},
+		{name: "exit", line: "exit", expectedExit: true},
+		{name: "quit", line: "quit", expectedExit: true},
+		{name: "unterminated quote", line: `list_issues owner="octo`, expectedOutput: "Error: unterminated quote\n"},
+		{name: "read without uri", line: "read", expectedOutput: "Error: usage: read <uri>\n"},
+		{name: "prompt without name", line: "prompt", expectedOutput: "Error: usage: prompt <name> [name=value ...]\n"},
+		{name: "prompt with invalid argument", line: "prompt triage owner", expectedOutput: "Error: invalid argument \"owner\", expected name=value\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			assert.Equal(t, tc.expectedExit, newTestREPL(out).execute(tc.line))
+			assert.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+}
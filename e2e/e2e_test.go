@@ -9,13 +9,17 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/github/github-mcp-server/internal/githubfake"
+	"github.com/github/github-mcp-server/internal/httprecord"
 	"github.com/github/github-mcp-server/pkg/github"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v79/github"
@@ -36,15 +40,38 @@ var (
 
 	// Rate limit management
 	rateLimitMu sync.Mutex
+
+	// Recorders of the API traffic of each test, by test name
+	recorders sync.Map
 )
 
 // minRateLimitRemaining is the minimum number of API requests we want to have
 // remaining before we start waiting for the rate limit to reset.
 const minRateLimitRemaining = 50
 
+// getFixturesMode returns whether the tests record their API traffic into fixtures or replay it
+// from them, or the empty string to talk to the GitHub API directly.
+func getFixturesMode() httprecord.Mode {
+	return httprecord.Mode(os.Getenv("GITHUB_MCP_SERVER_E2E_FIXTURES"))
+}
+
+// fakeLogin is the authenticated user of the fake GitHub API.
+const fakeLogin = "github-mcp-server-e2e"
+
+// usesFakeGitHub returns whether the tests record their fixtures against an in-process fake of
+// the GitHub API instead of the live one, which needs neither a token nor network access.
+func usesFakeGitHub() bool {
+	return getFixturesMode() == httprecord.ModeRecord && os.Getenv("GITHUB_MCP_SERVER_E2E_FAKE") == "true"
+}
+
 // getE2EToken ensures the environment variable is checked only once and returns the token
 func getE2EToken(t *testing.T) string {
 	getTokenOnce.Do(func() {
+		if getFixturesMode() == httprecord.ModeReplay || usesFakeGitHub() {
+			// Replayed requests and the fake are not authenticated
+			token = httprecord.Redacted
+			return
+		}
 		token = os.Getenv("GITHUB_MCP_SERVER_E2E_TOKEN")
 		if token == "" {
 			t.Fatalf("GITHUB_MCP_SERVER_E2E_TOKEN environment variable is not set")
@@ -61,12 +88,65 @@ func getE2EHost() string {
 	return host
 }
 
+// getRecorder returns the recorder of the test's API traffic, or nil when the tests talk to the
+// GitHub API directly. The recorder is shared by the in-process server and the test's REST client,
+// and saves the fixture, or checks that the traffic matched it, when the test finishes.
+func getRecorder(t *testing.T) *httprecord.Recorder {
+	mode := getFixturesMode()
+	if mode == "" {
+		return nil
+	}
+	if recorder, ok := recorders.Load(t.Name()); ok {
+		return recorder.(*httprecord.Recorder)
+	}
+
+	opts := httprecord.Options{
+		Mode:    mode,
+		Secrets: []string{getE2EToken(t)},
+	}
+	var fake *githubfake.Server
+	if usesFakeGitHub() {
+		fake = githubfake.New(fakeLogin)
+		opts.Transport = fake.Transport()
+	}
+
+	path := filepath.Join("testdata", "fixtures", strings.ReplaceAll(t.Name(), "/", "_")+".json")
+	recorder, err := httprecord.New(path, opts)
+	require.NoError(t, err, "expected to create recorder, record the fixture with GITHUB_MCP_SERVER_E2E_FIXTURES=record")
+	recorders.Store(t.Name(), recorder)
+
+	// Registered first, so this runs after the other cleanups, which make requests too
+	t.Cleanup(func() {
+		recorders.Delete(t.Name())
+		require.NoError(t, recorder.Save(), "expected to save fixture")
+		require.NoError(t, recorder.Err(), "expected requests to match the fixture")
+	})
+	if fake != nil {
+		t.Cleanup(fake.Close)
+	}
+	return recorder
+}
+
+// getUniqueSuffix returns a suffix for the names of resources the test creates, which is recorded
+// in the test's fixture to replay the same names.
+func getUniqueSuffix(t *testing.T) string {
+	generate := func() string { return strconv.FormatInt(time.Now().UnixMilli(), 10) }
+	if recorder := getRecorder(t); recorder != nil {
+		return recorder.Value("uniqueSuffix", generate)
+	}
+	return generate()
+}
+
 func getRESTClient(t *testing.T) *gogithub.Client {
 	// Get token and ensure Docker image is built
 	token := getE2EToken(t)
 
-	// Create a new GitHub client with the token
-	ghClient := gogithub.NewClient(nil).WithAuthToken(token)
+	// Create a new GitHub client with the token, recording or replaying its traffic if enabled
+	httpClient := &http.Client{}
+	if recorder := getRecorder(t); recorder != nil {
+		httpClient.Transport = recorder
+	}
+	ghClient := gogithub.NewClient(httpClient).WithAuthToken(token)
 
 	if host := getE2EHost(); host != "" && host != "https://github.com" {
 		var err error
@@ -82,6 +162,11 @@ func getRESTClient(t *testing.T) *gogithub.Client {
 // waitForRateLimit checks the current rate limit and waits if necessary.
 // It ensures we have at least minRateLimitRemaining requests available before proceeding.
 func waitForRateLimit(t *testing.T) {
+	// Replayed requests and the fake don't count against the rate limit
+	if getFixturesMode() == httprecord.ModeReplay || usesFakeGitHub() {
+		return
+	}
+
 	rateLimitMu.Lock()
 	defer rateLimitMu.Unlock()
 
@@ -164,8 +249,9 @@ func setupMCPClient(t *testing.T, options ...clientOption) *mcp.ClientSession {
 
 	// By default, we run the tests including the Docker image, but with DEBUG
 	// enabled, we run the server in-process, allowing for easier debugging.
+	// Recording and replaying fixtures needs the server in-process too.
 	var session *mcp.ClientSession
-	if os.Getenv("GITHUB_MCP_SERVER_E2E_DEBUG") == "" {
+	if os.Getenv("GITHUB_MCP_SERVER_E2E_DEBUG") == "" && getFixturesMode() == "" {
 		ensureDockerImageBuilt(t)
 
 		// Prepare Docker arguments
@@ -221,12 +307,16 @@ func setupMCPClient(t *testing.T, options ...clientOption) *mcp.ClientSession {
 			enabledToolsets = github.GetDefaultToolsetIDs()
 		}
 
-		ghServer, err := ghmcp.NewMCPServer(ghmcp.MCPServerConfig{
+		cfg := ghmcp.MCPServerConfig{
 			Token:           token,
 			EnabledToolsets: enabledToolsets,
 			Host:            getE2EHost(),
 			Translator:      translations.NullTranslationHelper,
-		})
+		}
+		if recorder := getRecorder(t); recorder != nil {
+			cfg.Transport = recorder
+		}
+		ghServer, err := ghmcp.NewMCPServer(cfg)
 		require.NoError(t, err, "expected to construct MCP server successfully")
 
 		t.Log("Starting In Process MCP client...")
@@ -336,7 +426,7 @@ func TestTags(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%s", t.Name(), getUniqueSuffix(t))
 
 	t.Logf("Creating repository %s/%s...", currentOwner, repoName)
 	_, err = mcpClient.CallTool(ctx, &mcp.CallToolParams{
@@ -468,7 +558,7 @@ func TestFileDeletion(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%s", t.Name(), getUniqueSuffix(t))
 	t.Logf("Creating repository %s/%s...", currentOwner, repoName)
 	_, err = mcpClient.CallTool(ctx, &mcp.CallToolParams{
 		Name: "create_repository",
@@ -655,7 +745,7 @@ func TestDirectoryDeletion(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%s", t.Name(), getUniqueSuffix(t))
 	t.Logf("Creating repository %s/%s...", currentOwner, repoName)
 	_, err = mcpClient.CallTool(ctx, &mcp.CallToolParams{
 		Name: "create_repository",
@@ -871,7 +961,7 @@ func TestRequestCopilotReview(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%s", t.Name(), getUniqueSuffix(t))
 
 	t.Logf("Creating repository %s/%s...", currentOwner, repoName)
 	_, err = mcpClient.CallTool(ctx, &mcp.CallToolParams{
@@ -888,7 +978,7 @@ func TestRequestCopilotReview(t *testing.T) {
 	// Cleanup the repository after the test
 	t.Cleanup(func() {
 		// MCP Server doesn't support deletions, but we can use the GitHub Client
-		ghClient := gogithub.NewClient(nil).WithAuthToken(getE2EToken(t))
+		ghClient := getRESTClient(t)
 		t.Logf("Deleting repository %s/%s...", currentOwner, repoName)
 		_, err := ghClient.Repositories.Delete(context.Background(), currentOwner, repoName)
 		require.NoError(t, err, "expected to delete repository successfully")
@@ -983,7 +1073,7 @@ func TestRequestCopilotReview(t *testing.T) {
 
 	// Finally, get requested reviews and see copilot is in there
 	// MCP Server doesn't support requesting reviews yet, but we can use the GitHub Client
-	ghClient := gogithub.NewClient(nil).WithAuthToken(getE2EToken(t))
+	ghClient := getRESTClient(t)
 	t.Logf("Getting reviews for pull request in %s/%s...", currentOwner, repoName)
 	reviewRequests, _, err := ghClient.PullRequests.ListReviewers(context.Background(), currentOwner, repoName, 1, nil)
 	require.NoError(t, err, "expected to get review requests successfully")
@@ -1031,7 +1121,7 @@ func TestAssignCopilotToIssue(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%s", t.Name(), getUniqueSuffix(t))
 
 	t.Logf("Creating repository %s/%s...", currentOwner, repoName)
 	_, err = mcpClient.CallTool(ctx, &mcp.CallToolParams{
@@ -1077,7 +1167,7 @@ func TestAssignCopilotToIssue(t *testing.T) {
 		Arguments: map[string]any{
 			"owner":       currentOwner,
 			"repo":        repoName,
-			"issueNumber": 1,
+			"issue_number": 1,
 		},
 	})
 	require.NoError(t, err, "expected to call 'assign_copilot_to_issue' tool successfully")
@@ -1092,7 +1182,12 @@ func TestAssignCopilotToIssue(t *testing.T) {
 
 	require.False(t, resp.IsError, fmt.Sprintf("expected result not to be an error: %+v", resp))
 
-	require.Equal(t, "successfully assigned copilot to issue", textContent.Text)
+	var assignment struct {
+		Message string `json:"message"`
+	}
+	err = json.Unmarshal([]byte(textContent.Text), &assignment)
+	require.NoError(t, err, "expected to unmarshal text content successfully")
+	require.True(t, strings.HasPrefix(assignment.Message, "successfully assigned copilot to issue"), "expected copilot to be assigned, got %q", assignment.Message)
 
 	// Check that copilot is assigned to the issue
 	// MCP Server doesn't support getting assignees yet
@@ -1133,7 +1228,7 @@ func TestPullRequestAtomicCreateAndSubmit(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%s", t.Name(), getUniqueSuffix(t))
 
 	t.Logf("Creating repository %s/%s...", currentOwner, repoName)
 	_, err = mcpClient.CallTool(ctx, &mcp.CallToolParams{
@@ -1294,7 +1389,7 @@ func TestPullRequestReviewCommentSubmit(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%s", t.Name(), getUniqueSuffix(t))
 
 	t.Logf("Creating repository %s/%s...", currentOwner, repoName)
 	_, err = mcpClient.CallTool(ctx, &mcp.CallToolParams{
@@ -1552,7 +1647,7 @@ func TestPullRequestReviewDeletion(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%s", t.Name(), getUniqueSuffix(t))
 
 	t.Logf("Creating repository %s/%s...", currentOwner, repoName)
 	_, err = mcpClient.CallTool(ctx, &mcp.CallToolParams{
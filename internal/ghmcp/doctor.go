@@ -0,0 +1,427 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+)
+
+// DoctorConfig configures the setup diagnosis run by the doctor command. The tool settings are
+// the same as those of the stdio server, so that the diagnosis covers the tools it would offer.
+type DoctorConfig struct {
+	InventoryConfig
+
+	// GitHub Host to target for API requests (e.g. github.com or github.enterprise.com)
+	Host string
+
+	// GitHub Token to authenticate with the GitHub API
+	Token string
+}
+
+// CheckStatus is the outcome of a doctor check.
+type CheckStatus string
+
+const (
+	CheckOK      CheckStatus = "ok"
+	CheckWarning CheckStatus = "warning"
+	CheckError   CheckStatus = "error"
+	CheckSkipped CheckStatus = "skipped"
+)
+
+// ToolStatus is what happens to a tool with the diagnosed token.
+type ToolStatus string
+
+const (
+	// ToolWillWork means the tool is offered and the token can use it.
+	ToolWillWork ToolStatus = "will_work"
+	// ToolWillBeHidden means the tool is hidden by scope or permission filtering.
+	ToolWillBeHidden ToolStatus = "will_be_hidden"
+	// ToolWill403 means the tool is offered but the API will reject the token.
+	ToolWill403 ToolStatus = "will_403"
+	// ToolUnknown means the token's scopes or permissions could not be determined.
+	ToolUnknown ToolStatus = "unknown"
+)
+
+// DoctorCheck is the result of a single check.
+type DoctorCheck struct {
+	Name    string      `json:"name"`
+	Status  CheckStatus `json:"status"`
+	Message string      `json:"message"`
+}
+
+// DoctorHost describes the API endpoints resolved from the configured host.
+type DoctorHost struct {
+	Kind    string `json:"kind"`
+	REST    string `json:"rest_url"`
+	GraphQL string `json:"graphql_url"`
+	Upload  string `json:"upload_url"`
+	Raw     string `json:"raw_url"`
+	// SubdomainIsolation is only set for GitHub Enterprise Server.
+	SubdomainIsolation *bool `json:"subdomain_isolation,omitempty"`
+}
+
+// DoctorToken describes the diagnosed token.
+type DoctorToken struct {
+	Type string `json:"type"`
+	// Scopes are the OAuth scopes of classic PATs and OAuth tokens.
+	Scopes []string `json:"scopes,omitempty"`
+	// DeniedPermissions are the probed permissions that fine-grained tokens lack.
+	DeniedPermissions []string `json:"denied_permissions,omitempty"`
+}
+
+// DoctorTool is the diagnosis of a single tool.
+type DoctorTool struct {
+	Name           string     `json:"name"`
+	Toolset        string     `json:"toolset"`
+	ReadOnly       bool       `json:"read_only"`
+	RequiredScopes []string   `json:"required_scopes"`
+	Status         ToolStatus `json:"status"`
+	Reason         string     `json:"reason,omitempty"`
+}
+
+// DoctorReport is the full diagnosis.
+type DoctorReport struct {
+	Host   DoctorHost    `json:"host"`
+	Token  DoctorToken   `json:"token"`
+	Checks []DoctorCheck `json:"checks"`
+	// MissingScopes is the smallest set of scopes to add to the token so that the tools that will
+	// be hidden or will 403 work.
+	MissingScopes []string     `json:"missing_scopes,omitempty"`
+	Tools         []DoctorTool `json:"tools,omitempty"`
+}
+
+// HasErrors reports whether any check failed.
+func (r *DoctorReport) HasErrors() bool {
+	return slices.ContainsFunc(r.Checks, func(c DoctorCheck) bool { return c.Status == CheckError })
+}
+
+func (r *DoctorReport) addCheck(name string, status CheckStatus, format string, args ...any) {
+	r.Checks = append(r.Checks, DoctorCheck{Name: name, Status: status, Message: fmt.Sprintf(format, args...)})
+}
+
+// tokenType names the kind of token from its prefix.
+func tokenType(token string) string {
+	switch {
+	case token == "":
+		return "none"
+	case strings.HasPrefix(token, "ghp_"):
+		return "classic personal access token"
+	case strings.HasPrefix(token, "github_pat_"):
+		return "fine-grained personal access token"
+	case strings.HasPrefix(token, "ghs_"):
+		return "GitHub App installation token"
+	case strings.HasPrefix(token, "gho_"):
+		return "OAuth app token"
+	case strings.HasPrefix(token, "ghu_"):
+		return "GitHub App user-to-server token"
+	default:
+		return "unknown"
+	}
+}
+
+// hostKind names the kind of GitHub deployment, following the rules of parseAPIHost.
+func hostKind(host string) string {
+	u, err := url.Parse(host)
+	switch {
+	case host == "", err == nil && strings.HasSuffix(u.Hostname(), "github.com"):
+		return "github.com"
+	case err == nil && strings.HasSuffix(u.Hostname(), "ghe.com"):
+		return "GHE.com"
+	default:
+		return "GitHub Enterprise Server"
+	}
+}
+
+// doctorHTTPClient is used for all doctor requests.
+var doctorHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// RunDoctor diagnoses the setup: it resolves the API endpoints of the host, checks that they can
+// be reached with the token, determines the token's scopes or permissions, and predicts for each
+// tool that the server would offer whether it will work, be hidden, or fail with a 403.
+func RunDoctor(ctx context.Context, cfg DoctorConfig) *DoctorReport {
+	report := &DoctorReport{Token: DoctorToken{Type: tokenType(cfg.Token)}}
+
+	apiHost, err := parseAPIHost(cfg.Host)
+	if err != nil {
+		report.addCheck("host", CheckError, "%v", err)
+		return report
+	}
+	report.Host = DoctorHost{
+		Kind:    hostKind(cfg.Host),
+		REST:    apiHost.baseRESTURL.String(),
+		GraphQL: apiHost.graphqlURL.String(),
+		Upload:  apiHost.uploadURL.String(),
+		Raw:     apiHost.rawURL.String(),
+	}
+	report.addCheck("host", CheckOK, "%s, REST API at %s", report.Host.Kind, report.Host.REST)
+
+	if report.Host.Kind == "GitHub Enterprise Server" {
+		u, _ := url.Parse(cfg.Host)
+		isolated := checkSubdomainIsolation(u.Scheme, u.Hostname())
+		report.Host.SubdomainIsolation = &isolated
+		if isolated {
+			report.addCheck("subdomain_isolation", CheckOK, "raw.%s/_ping answered, using subdomains for raw and upload URLs", u.Hostname())
+		} else {
+			report.addCheck("subdomain_isolation", CheckOK, "raw.%s/_ping did not answer, using paths for raw and upload URLs", u.Hostname())
+		}
+	}
+
+	if cfg.Token == "" {
+		report.addCheck("token", CheckError, "no token set, set GITHUB_PERSONAL_ACCESS_TOKEN")
+	} else {
+		report.addCheck("token", CheckOK, "%s", report.Token.Type)
+	}
+
+	restOK := checkREST(ctx, report, apiHost, cfg.Token)
+	checkGraphQL(ctx, report, apiHost, cfg.Token)
+	checkRaw(ctx, report, apiHost)
+
+	inv, err := BuildInventory(cfg.InventoryConfig)
+	if err != nil {
+		report.addCheck("tools", CheckError, "%v", err)
+		return report
+	}
+	tools := inv.AvailableTools(ctx)
+
+	var tokenScopes []string
+	var tokenPermissions scopes.TokenPermissions
+	switch {
+	case !restOK:
+		report.addCheck("scopes", CheckSkipped, "the REST API could not be used with the token")
+	case scopes.IsFineGrainedToken(cfg.Token):
+		tokenPermissions = probeDoctorPermissions(ctx, report, apiHost, cfg)
+	case strings.HasPrefix(cfg.Token, "ghp_") || strings.HasPrefix(cfg.Token, "gho_") || strings.HasPrefix(cfg.Token, "ghu_"):
+		tokenScopes = fetchDoctorScopes(ctx, report, apiHost, cfg.Token)
+	default:
+		report.addCheck("scopes", CheckSkipped, "scopes cannot be determined for this token type")
+	}
+
+	var lacking []inventory.ServerTool
+	for i := range tools {
+		tool := diagnoseTool(ctx, &tools[i], cfg.Token, tokenScopes, tokenPermissions)
+		report.Tools = append(report.Tools, tool)
+		if tokenScopes != nil && (tool.Status == ToolWillBeHidden || tool.Status == ToolWill403) {
+			lacking = append(lacking, tools[i])
+		}
+	}
+	report.MissingScopes = github.MinimalScopesToUnlock(tokenScopes, lacking)
+	sort.Slice(report.Tools, func(i, j int) bool {
+		if report.Tools[i].Toolset != report.Tools[j].Toolset {
+			return report.Tools[i].Toolset < report.Tools[j].Toolset
+		}
+		return report.Tools[i].Name < report.Tools[j].Name
+	})
+
+	if len(report.MissingScopes) > 0 {
+		report.addCheck("tools", CheckWarning, "the token lacks scopes needed by enabled tools: %s", strings.Join(report.MissingScopes, ", "))
+	} else if slices.ContainsFunc(report.Tools, func(t DoctorTool) bool { return t.Status == ToolWillBeHidden || t.Status == ToolWill403 }) {
+		report.addCheck("tools", CheckWarning, "the token lacks permissions needed by enabled tools: %s", strings.Join(report.Token.DeniedPermissions, ", "))
+	} else if slices.ContainsFunc(report.Tools, func(t DoctorTool) bool { return t.Status == ToolUnknown }) {
+		report.addCheck("tools", CheckSkipped, "%d tools enabled, which of them the token can use is unknown", len(report.Tools))
+	} else {
+		report.addCheck("tools", CheckOK, "%d tools enabled", len(report.Tools))
+	}
+	return report
+}
+
+// doctorRequest sends a request with the token, if any, and returns the response status.
+func doctorRequest(ctx context.Context, method, endpoint, token string, body []byte, v any) (int, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, 0, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "github-mcp-server/doctor")
+
+	start := time.Now()
+	resp, err := doctorHTTPClient.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	if v != nil && resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return resp.StatusCode, elapsed, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return resp.StatusCode, elapsed, nil
+}
+
+// checkREST calls the REST API root and reports whether the token was accepted.
+func checkREST(ctx context.Context, report *DoctorReport, apiHost apiHost, token string) bool {
+	status, elapsed, err := doctorRequest(ctx, http.MethodGet, apiHost.baseRESTURL.String(), token, nil, nil)
+	switch {
+	case err != nil:
+		report.addCheck("rest", CheckError, "cannot reach %s: %v", apiHost.baseRESTURL, err)
+		return false
+	case status == http.StatusUnauthorized:
+		report.addCheck("rest", CheckError, "the token is invalid or expired (401 from %s)", apiHost.baseRESTURL)
+		return false
+	case status != http.StatusOK:
+		report.addCheck("rest", CheckError, "unexpected status %d from %s", status, apiHost.baseRESTURL)
+		return false
+	default:
+		report.addCheck("rest", CheckOK, "%s answered in %s", apiHost.baseRESTURL, elapsed)
+		return token != ""
+	}
+}
+
+// checkGraphQL runs a query that any token can run.
+func checkGraphQL(ctx context.Context, report *DoctorReport, apiHost apiHost, token string) {
+	if token == "" {
+		report.addCheck("graphql", CheckSkipped, "the GraphQL API requires a token")
+		return
+	}
+
+	var result struct {
+		Data struct {
+			RateLimit struct {
+				Remaining int `json:"remaining"`
+			} `json:"rateLimit"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	body := []byte(`{"query":"query { rateLimit { remaining } }"}`)
+	status, elapsed, err := doctorRequest(ctx, http.MethodPost, apiHost.graphqlURL.String(), token, body, &result)
+	switch {
+	case err != nil:
+		report.addCheck("graphql", CheckError, "cannot reach %s: %v", apiHost.graphqlURL, err)
+	case status == http.StatusUnauthorized:
+		report.addCheck("graphql", CheckError, "the token is invalid or expired (401 from %s)", apiHost.graphqlURL)
+	case status != http.StatusOK:
+		report.addCheck("graphql", CheckError, "unexpected status %d from %s", status, apiHost.graphqlURL)
+	case len(result.Errors) > 0:
+		report.addCheck("graphql", CheckError, "query failed: %s", result.Errors[0].Message)
+	default:
+		report.addCheck("graphql", CheckOK, "%s answered in %s, %d points of rate limit remaining", apiHost.graphqlURL, elapsed, result.Data.RateLimit.Remaining)
+	}
+}
+
+// checkRaw checks that the raw content host can be reached. Any HTTP answer will do, since the
+// root of the raw host is not a file.
+func checkRaw(ctx context.Context, report *DoctorReport, apiHost apiHost) {
+	_, elapsed, err := doctorRequest(ctx, http.MethodHead, apiHost.rawURL.String(), "", nil, nil)
+	if err == nil {
+		report.addCheck("raw", CheckOK, "%s answered in %s", apiHost.rawURL, elapsed)
+		return
+	}
+	if report.Host.SubdomainIsolation != nil {
+		report.addCheck("raw", CheckError, "cannot reach %s: %v, subdomain isolation may be misdetected", apiHost.rawURL, err)
+		return
+	}
+	report.addCheck("raw", CheckError, "cannot reach %s: %v", apiHost.rawURL, err)
+}
+
+func fetchDoctorScopes(ctx context.Context, report *DoctorReport, apiHost apiHost, token string) []string {
+	fetcher := scopes.NewFetcher(scopes.FetcherOptions{APIHost: apiHost.baseRESTURL.String()})
+	tokenScopes, err := fetcher.FetchTokenScopes(ctx, token)
+	if err != nil {
+		report.addCheck("scopes", CheckError, "failed to fetch token scopes: %v", err)
+		return nil
+	}
+	report.Token.Scopes = tokenScopes
+	if len(tokenScopes) == 0 {
+		report.addCheck("scopes", CheckOK, "the token has no scopes")
+	} else {
+		report.addCheck("scopes", CheckOK, "%s", strings.Join(tokenScopes, ", "))
+	}
+	return tokenScopes
+}
+
+func probeDoctorPermissions(ctx context.Context, report *DoctorReport, apiHost apiHost, cfg DoctorConfig) scopes.TokenPermissions {
+	permissions, err := probeTokenPermissionsForHost(ctx, cfg.Token, cfg.Host, cfg.Repository)
+	if err != nil {
+		report.addCheck("permissions", CheckError, "failed to probe token permissions on %s: %v", apiHost.baseRESTURL, err)
+		return nil
+	}
+	report.Token.DeniedPermissions = permissions.Denied()
+	if len(report.Token.DeniedPermissions) == 0 {
+		report.addCheck("permissions", CheckOK, "no probed permission is denied")
+	} else {
+		report.addCheck("permissions", CheckOK, "denied: %s", strings.Join(report.Token.DeniedPermissions, ", "))
+	}
+	return permissions
+}
+
+// diagnoseTool predicts what happens to a tool with the token. Only classic PATs and fine-grained
+// tokens are filtered by the stdio server; other tokens are offered every tool, which the API
+// rejects if the token lacks the scopes.
+func diagnoseTool(ctx context.Context, serverTool *inventory.ServerTool, token string, tokenScopes []string, tokenPermissions scopes.TokenPermissions) DoctorTool {
+	tool := DoctorTool{
+		Name:           serverTool.Tool.Name,
+		Toolset:        string(serverTool.Toolset.ID),
+		ReadOnly:       serverTool.IsReadOnly(),
+		RequiredScopes: serverTool.RequiredScopes,
+		Status:         ToolWillWork,
+	}
+	if len(serverTool.RequiredScopes) == 0 {
+		return tool
+	}
+
+	switch {
+	case tokenPermissions != nil:
+		var denied []string
+		for _, permission := range github.ToolPermissions(serverTool) {
+			if tokenPermissions.IsDenied(permission) {
+				denied = append(denied, string(permission))
+			}
+		}
+		if len(denied) == 0 {
+			return tool
+		}
+		visible, _ := github.CreateToolPermissionFilter(tokenPermissions)(ctx, serverTool)
+		if !visible {
+			tool.Status = ToolWillBeHidden
+			tool.Reason = "the token is denied the " + strings.Join(denied, ", ") + " permission"
+		} else {
+			tool.Reason = "public repositories only, the token is denied the " + strings.Join(denied, ", ") + " permission"
+		}
+
+	case tokenScopes != nil:
+		if scopes.HasRequiredScopes(tokenScopes, serverTool.AcceptedScopes) {
+			return tool
+		}
+		unlock := github.ScopesToUnlock(tokenScopes, serverTool)
+		switch {
+		case len(unlock) == 0:
+			// The scope filter keeps read-only repository tools, which work on public repositories
+			tool.Reason = "public repositories only, the token lacks the " + strings.Join(serverTool.RequiredScopes, ", ") + " scope"
+		case !strings.HasPrefix(token, "ghp_"):
+			tool.Status = ToolWill403
+			tool.Reason = "the token lacks " + lackedScopes(unlock)
+		default:
+			tool.Status = ToolWillBeHidden
+			tool.Reason = "the token lacks " + lackedScopes(unlock)
+		}
+
+	default:
+		tool.Status = ToolUnknown
+	}
+	return tool
+}
+
+// lackedScopes describes the scopes that would unlock a tool, any one of which is enough.
+func lackedScopes(unlock []string) string {
+	if len(unlock) == 1 {
+		return "the " + unlock[0] + " scope"
+	}
+	return "one of the " + strings.Join(unlock, ", ") + " scopes"
+}
@@ -0,0 +1,215 @@
+package ghmcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenType(t *testing.T) {
+	assert.Equal(t, "none", tokenType(""))
+	assert.Equal(t, "classic personal access token", tokenType("ghp_abc"))
+	assert.Equal(t, "fine-grained personal access token", tokenType("github_pat_abc"))
+	assert.Equal(t, "GitHub App installation token", tokenType("ghs_abc"))
+	assert.Equal(t, "OAuth app token", tokenType("gho_abc"))
+	assert.Equal(t, "unknown", tokenType("abc"))
+}
+
+func TestHostKind(t *testing.T) {
+	assert.Equal(t, "github.com", hostKind(""))
+	assert.Equal(t, "github.com", hostKind("https://github.com"))
+	assert.Equal(t, "GHE.com", hostKind("https://octocorp.ghe.com"))
+	assert.Equal(t, "GitHub Enterprise Server", hostKind("https://github.example.com"))
+}
+
+func findDoctorTool(t *testing.T, tools []inventory.ServerTool, name string) *inventory.ServerTool {
+	t.Helper()
+	for i := range tools {
+		if tools[i].Tool.Name == name {
+			return &tools[i]
+		}
+	}
+	t.Fatalf("tool %s not found", name)
+	return nil
+}
+
+func TestDiagnoseTool(t *testing.T) {
+	inv, err := BuildInventory(InventoryConfig{
+		EnabledToolsets: []string{"all"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+	tools := inv.AvailableTools(context.Background())
+
+	tests := []struct {
+		name        string
+		tool        string
+		token       string
+		scopes      []string
+		permissions scopes.TokenPermissions
+		status      ToolStatus
+		reason      string
+	}{
+		{
+			name:   "tool without scopes",
+			tool:   "get_me",
+			token:  "ghp_test",
+			scopes: []string{},
+			status: ToolWillWork,
+		},
+		{
+			name:   "classic PAT with scope",
+			tool:   "create_gist",
+			token:  "ghp_test",
+			scopes: []string{"gist"},
+			status: ToolWillWork,
+		},
+		{
+			name:   "classic PAT without scope hides tool",
+			tool:   "create_gist",
+			token:  "ghp_test",
+			scopes: []string{"repo"},
+			status: ToolWillBeHidden,
+			reason: "the token lacks the gist scope",
+		},
+		{
+			name:   "classic PAT without repo scope reads public repositories",
+			tool:   "list_issues",
+			token:  "ghp_test",
+			scopes: []string{},
+			status: ToolWillWork,
+			reason: "public repositories only, the token lacks the repo scope",
+		},
+		{
+			name:   "OAuth token without scope is not filtered",
+			tool:   "create_gist",
+			token:  "gho_test",
+			scopes: []string{"repo"},
+			status: ToolWill403,
+			reason: "the token lacks the gist scope",
+		},
+		{
+			name:   "any of the accepted scopes unlocks the tool",
+			tool:   "get_teams",
+			token:  "ghp_test",
+			scopes: []string{"repo"},
+			status: ToolWillBeHidden,
+			reason: "the token lacks one of the admin:org, read:org, write:org scopes",
+		},
+		{
+			name:   "OAuth token without repo scope reads public repositories",
+			tool:   "list_issues",
+			token:  "gho_test",
+			scopes: []string{},
+			status: ToolWillWork,
+			reason: "public repositories only, the token lacks the repo scope",
+		},
+		{
+			name:        "fine-grained token with denied permission hides tool",
+			tool:        "list_notifications",
+			token:       "github_pat_test",
+			permissions: scopes.TokenPermissions{scopes.PermissionNotifications: false},
+			status:      ToolWillBeHidden,
+			reason:      "the token is denied the notifications permission",
+		},
+		{
+			name:        "fine-grained token with unknown permission",
+			tool:        "issue_write",
+			token:       "github_pat_test",
+			permissions: scopes.TokenPermissions{scopes.PermissionNotifications: false},
+			status:      ToolWillWork,
+		},
+		{
+			name:   "unknown scopes",
+			tool:   "create_gist",
+			token:  "ghp_test",
+			status: ToolUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := diagnoseTool(context.Background(), findDoctorTool(t, tools, tc.tool), tc.token, tc.scopes, tc.permissions)
+			assert.Equal(t, tc.status, tool.Status)
+			assert.Equal(t, tc.reason, tool.Reason)
+		})
+	}
+}
+
+func testAPIHost(t *testing.T, serverURL string) apiHost {
+	t.Helper()
+	restURL, err := url.Parse(serverURL + "/")
+	require.NoError(t, err)
+	gqlURL, err := url.Parse(serverURL + "/graphql")
+	require.NoError(t, err)
+	rawURL, err := url.Parse(serverURL + "/raw/")
+	require.NoError(t, err)
+	return apiHost{baseRESTURL: restURL, graphqlURL: gqlURL, uploadURL: restURL, rawURL: rawURL}
+}
+
+func TestDoctorEndpointChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer expired" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/graphql" {
+			_, _ = w.Write([]byte(`{"data":{"rateLimit":{"remaining":4999}}}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	host := testAPIHost(t, server.URL)
+
+	t.Run("valid token", func(t *testing.T) {
+		report := &DoctorReport{}
+		assert.True(t, checkREST(context.Background(), report, host, "ghp_test"))
+		checkGraphQL(context.Background(), report, host, "ghp_test")
+		checkRaw(context.Background(), report, host)
+
+		require.Len(t, report.Checks, 3)
+		for _, check := range report.Checks {
+			assert.Equal(t, CheckOK, check.Status, check.Name)
+		}
+		assert.Contains(t, report.Checks[1].Message, "4999 points of rate limit remaining")
+		assert.False(t, report.HasErrors())
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		report := &DoctorReport{}
+		assert.False(t, checkREST(context.Background(), report, host, "expired"))
+		checkGraphQL(context.Background(), report, host, "expired")
+
+		require.Len(t, report.Checks, 2)
+		assert.Equal(t, CheckError, report.Checks[0].Status)
+		assert.Contains(t, report.Checks[0].Message, "the token is invalid or expired")
+		assert.Equal(t, CheckError, report.Checks[1].Status)
+		assert.True(t, report.HasErrors())
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		report := &DoctorReport{}
+		assert.False(t, checkREST(context.Background(), report, host, ""))
+		checkGraphQL(context.Background(), report, host, "")
+
+		assert.Equal(t, CheckOK, report.Checks[0].Status)
+		assert.Equal(t, CheckSkipped, report.Checks[1].Status)
+	})
+}
+
+func TestRunDoctor_InvalidHost(t *testing.T) {
+	report := RunDoctor(context.Background(), DoctorConfig{Host: "github.example.com", Token: "ghp_test"})
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, "host", report.Checks[0].Name)
+	assert.Equal(t, CheckError, report.Checks[0].Status)
+	assert.True(t, report.HasErrors())
+}
@@ -0,0 +1,180 @@
+package ghmcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubfake"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// connectToFake starts a server with toolsets, backed by a fake GitHub, and connects a client to
+// it.
+func connectToFake(t *testing.T, fake *githubfake.Server, toolsets ...string) *mcp.ClientSession {
+	t.Helper()
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:           "test",
+		Token:             "test-token",
+		EnabledToolsets:   toolsets,
+		Translator:        translations.NullTranslationHelper,
+		ContentWindowSize: 5000,
+		Transport:         fake.Transport(),
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := ghServer.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.1"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+// callTool calls a tool, requires it to succeed, and returns its text result.
+func callTool(t *testing.T, session *mcp.ClientSession, name string, args map[string]any) string {
+	t.Helper()
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Content)
+	text, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected text content")
+	require.False(t, result.IsError, "%s failed: %s", name, text.Text)
+	return text.Text
+}
+
+func TestFlow_BranchPushPullRequestMerge(t *testing.T) {
+	t.Parallel()
+
+	fake := githubfake.New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{
+		"README.md":   "# Hello\n",
+		"src/main.go": "package main\n",
+	})
+	session := connectToFake(t, fake, "repos", "pull_requests")
+	repo := map[string]any{"owner": "octocat", "repo": "hello-world"}
+	with := func(args map[string]any) map[string]any {
+		for k, v := range repo {
+			args[k] = v
+		}
+		return args
+	}
+
+	callTool(t, session, "create_branch", with(map[string]any{"branch": "feature"}))
+	callTool(t, session, "push_files", with(map[string]any{
+		"branch":  "feature",
+		"message": "Add feature",
+		"files": []any{
+			map[string]any{"path": "src/main.go", "content": "package main\n\nfunc main() {}\n"},
+			map[string]any{"path": "docs/feature.md", "content": "# Feature\n"},
+		},
+	}))
+
+	callTool(t, session, "create_or_update_file", with(map[string]any{
+		"branch":  "feature",
+		"message": "Document feature",
+		"path":    "docs/usage.md",
+		"content": "Run it.\n",
+	}))
+
+	_, ok := fake.File("octocat", "hello-world", "main", "docs/feature.md")
+	require.False(t, ok, "the push should only change the feature branch")
+
+	text := callTool(t, session, "create_pull_request", with(map[string]any{
+		"title": "Add feature",
+		"head":  "feature",
+		"base":  "main",
+	}))
+	assert.Contains(t, text, "/pull/1")
+
+	callTool(t, session, "merge_pull_request", with(map[string]any{
+		"pullNumber":   1,
+		"merge_method": "squash",
+	}))
+
+	content, ok := fake.File("octocat", "hello-world", "main", "src/main.go")
+	require.True(t, ok)
+	assert.Equal(t, "package main\n\nfunc main() {}\n", content)
+	content, ok = fake.File("octocat", "hello-world", "main", "docs/usage.md")
+	require.True(t, ok)
+	assert.Equal(t, "Run it.\n", content)
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "get_file_contents",
+		Arguments: with(map[string]any{"path": "docs/feature.md", "ref": "main"}),
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.Len(t, result.Content, 2)
+	resource, ok := result.Content[1].(*mcp.EmbeddedResource)
+	require.True(t, ok, "expected the file as an embedded resource")
+	assert.Equal(t, "# Feature\n", resource.Resource.Text)
+}
+
+func TestFlow_IssueLifecycle(t *testing.T) {
+	t.Parallel()
+
+	fake := githubfake.New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{"README.md": "# Hello\n"})
+	session := connectToFake(t, fake, "issues", "labels")
+	repo := map[string]any{"owner": "octocat", "repo": "hello-world"}
+	with := func(args map[string]any) map[string]any {
+		for k, v := range repo {
+			args[k] = v
+		}
+		return args
+	}
+
+	callTool(t, session, "label_write", with(map[string]any{"method": "create", "name": "bug", "color": "d73a4a"}))
+	callTool(t, session, "issue_write", with(map[string]any{
+		"method": "create",
+		"title":  "Crash on start",
+		"labels": []any{"bug"},
+	}))
+	callTool(t, session, "add_issue_comment", with(map[string]any{"issue_number": 1, "body": "Cannot reproduce"}))
+	callTool(t, session, "issue_write", with(map[string]any{
+		"method":       "update",
+		"issue_number": 1,
+		"state":        "closed",
+		"state_reason": "not_planned",
+	}))
+
+	text := callTool(t, session, "issue_read", with(map[string]any{"method": "get", "issue_number": 1}))
+	assert.Contains(t, text, `"state":"closed"`)
+	assert.Contains(t, text, `"state_reason":"not_planned"`)
+
+	text = callTool(t, session, "issue_read", with(map[string]any{"method": "get_comments", "issue_number": 1}))
+	assert.Contains(t, text, "Cannot reproduce")
+
+	text = callTool(t, session, "list_issues", with(map[string]any{"state": "CLOSED"}))
+	assert.Contains(t, text, "Crash on start")
+	text = callTool(t, session, "list_issues", with(map[string]any{"state": "OPEN"}))
+	assert.NotContains(t, text, "Crash on start")
+}
+
+func TestFlow_WorkflowRuns(t *testing.T) {
+	t.Parallel()
+
+	fake := githubfake.New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{".github/workflows/ci.yml": "name: CI\non: push\n"})
+	fake.AddWorkflowRun("octocat", "hello-world", githubfake.WorkflowRun{Workflow: ".github/workflows/ci.yml", Conclusion: "failure"})
+	session := connectToFake(t, fake, "actions")
+
+	text := callTool(t, session, "actions_list", map[string]any{
+		"method": "list_workflow_runs",
+		"owner":  "octocat",
+		"repo":   "hello-world",
+	})
+	assert.Contains(t, text, `"conclusion":"failure"`)
+}
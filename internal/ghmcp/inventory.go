@@ -0,0 +1,88 @@
+package ghmcp
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// InventoryConfig configures the tools offered by the server, for commands that inspect
+// them without running it. The settings are the same as those of the stdio server.
+type InventoryConfig struct {
+	// EnabledToolsets is a list of toolsets to enable
+	EnabledToolsets []string
+
+	// EnabledTools is a list of specific tools to enable (additive to toolsets)
+	EnabledTools []string
+
+	// EnabledFeatures is a list of feature flags that are enabled
+	EnabledFeatures []string
+
+	// CustomToolsets are toolsets composed of existing tools, defined by configuration.
+	CustomToolsets []inventory.CustomToolset
+
+	// Repository restricts the server to a single repository when non-nil.
+	Repository *github.RepositoryScope
+
+	// DynamicToolsets starts without toolsets unless they are set explicitly
+	DynamicToolsets bool
+
+	// ContentWindowSize is the size results are truncated to. When positive, read_more is
+	// offered whichever toolsets are enabled.
+	ContentWindowSize int
+
+	// ReadOnly indicates if we should only offer read-only tools
+	ReadOnly bool
+
+	// Translator provides translated text for the server tooling
+	Translator translations.TranslationHelperFunc
+}
+
+// BuildInventory builds the inventory of the stdio server, without token filtering.
+func BuildInventory(cfg InventoryConfig) (*inventory.Inventory, error) {
+	inv, err := newInventoryBuilder(cfg).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build inventory: %w", err)
+	}
+	return inv, nil
+}
+
+// newInventoryBuilder returns the builder of the stdio server's inventory, which the server adds
+// token filtering and instructions to.
+func newInventoryBuilder(cfg InventoryConfig) *inventory.Builder {
+	t := cfg.Translator
+	if t == nil {
+		t = translations.NullTranslationHelper
+	}
+	enabledToolsets := resolveEnabledToolsets(MCPServerConfig{
+		EnabledToolsets: cfg.EnabledToolsets,
+		EnabledTools:    cfg.EnabledTools,
+		DynamicToolsets: cfg.DynamicToolsets,
+	})
+
+	// Results larger than the content window are truncated with a pointer to read_more, so it is
+	// offered whichever toolsets are enabled
+	enabledTools := cfg.EnabledTools
+	if cfg.ContentWindowSize > 0 {
+		enabledTools = append(slices.Clone(enabledTools), "read_more")
+	}
+
+	builder := github.NewInventory(t).
+		WithDeprecatedAliases(github.DeprecatedToolAliases).
+		WithReadOnly(cfg.ReadOnly).
+		WithToolsets(enabledToolsets).
+		WithTools(enabledTools).
+		WithCustomToolsets(cfg.CustomToolsets).
+		WithFeatureChecker(createFeatureChecker(cfg.EnabledFeatures))
+
+	// Restrict tools to a single repository
+	if cfg.Repository != nil {
+		builder = builder.
+			WithToolTransform(github.ScopeToolToRepository(*cfg.Repository)).
+			WithResourceTemplateTransform(github.ScopeResourceToRepository(*cfg.Repository))
+	}
+	return builder
+}
@@ -0,0 +1,198 @@
+package ghmcp
+
+import (
+	"context"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// LocaleMetaKey is the key of the initialize request's _meta that clients set to their locale,
+// as a BCP 47 tag like de-DE, to get tool and prompt listings in their language.
+const LocaleMetaKey = "locale"
+
+// localizer translates tool and prompt listings to the locale the client sent on initialize.
+// Tools are registered once, in the server's locale, so the listings are translated by matching
+// the registered text against the text the same tool has with the server's translations.
+type localizer struct {
+	bundles *translations.Bundles
+	english translations.TranslationHelperFunc
+
+	mu sync.Mutex
+	// texts holds, by locale, the localized text by name and server text (see textKey)
+	texts map[string]map[string]string
+}
+
+func newLocalizer(bundles *translations.Bundles, english translations.TranslationHelperFunc) *localizer {
+	return &localizer{
+		bundles: bundles,
+		english: english,
+		texts:   make(map[string]map[string]string),
+	}
+}
+
+func textKey(name, text string) string {
+	return name + "\x00" + text
+}
+
+// propertyName names a property of a tool's input schema in textKey.
+func propertyName(tool, property string) string {
+	return tool + "." + property
+}
+
+// inputProperties returns the properties of a tool's input schema, if it is a *jsonschema.Schema.
+func inputProperties(tool *mcp.Tool) map[string]*jsonschema.Schema {
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	if !ok || schema == nil {
+		return nil
+	}
+	return schema.Properties
+}
+
+// localizedTexts returns the localized text of the tools and prompts, computed once per locale.
+func (l *localizer) localizedTexts(locale string) map[string]string {
+	locale = translations.NormalizeLocale(locale)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if texts, ok := l.texts[locale]; ok {
+		return texts
+	}
+
+	texts := make(map[string]string)
+	add := func(name, text, localized string) {
+		if text != "" && localized != text {
+			texts[textKey(name, text)] = localized
+		}
+	}
+
+	localeT, _ := translations.LocaleTranslationHelper(l.bundles, locale)
+	englishTools, localizedTools := github.AllTools(l.english), github.AllTools(localeT)
+	for i := range englishTools {
+		english, localized := englishTools[i].Tool, localizedTools[i].Tool
+		add(english.Name, english.Description, localized.Description)
+		add(english.Name, english.Title, localized.Title)
+		if english.Annotations != nil && localized.Annotations != nil {
+			add(english.Name, english.Annotations.Title, localized.Annotations.Title)
+		}
+		localizedProperties := inputProperties(&localized)
+		for property, schema := range inputProperties(&english) {
+			if localizedSchema := localizedProperties[property]; localizedSchema != nil {
+				add(propertyName(english.Name, property), schema.Description, localizedSchema.Description)
+			}
+		}
+	}
+	englishPrompts, localizedPrompts := github.AllPrompts(l.english), github.AllPrompts(localeT)
+	for i := range englishPrompts {
+		english, localized := englishPrompts[i].Prompt, localizedPrompts[i].Prompt
+		add(english.Name, english.Description, localized.Description)
+		add(english.Name, english.Title, localized.Title)
+	}
+
+	l.texts[locale] = texts
+	return texts
+}
+
+// localize returns a copy of a tools/list or prompts/list result with the text translated to the
+// locale, including the descriptions of the tools' input properties. Text without a translation,
+// like that of tools added at runtime, is left as is.
+func (l *localizer) localize(result mcp.Result, locale string) mcp.Result {
+	texts := l.localizedTexts(locale)
+	if len(texts) == 0 {
+		return result
+	}
+	translate := func(name, text string) string {
+		if localized, ok := texts[textKey(name, text)]; ok {
+			return localized
+		}
+		return text
+	}
+
+	switch r := result.(type) {
+	case *mcp.ListToolsResult:
+		localized := *r
+		localized.Tools = make([]*mcp.Tool, len(r.Tools))
+		for i, tool := range r.Tools {
+			// The listed tools are the server's, copy them before changing them
+			t := *tool
+			t.Description = translate(t.Name, t.Description)
+			t.Title = translate(t.Name, t.Title)
+			if t.Annotations != nil {
+				annotations := *t.Annotations
+				annotations.Title = translate(t.Name, annotations.Title)
+				t.Annotations = &annotations
+			}
+			t.InputSchema = localizeProperties(t.Name, t.InputSchema, translate)
+			localized.Tools[i] = &t
+		}
+		return &localized
+	case *mcp.ListPromptsResult:
+		localized := *r
+		localized.Prompts = make([]*mcp.Prompt, len(r.Prompts))
+		for i, prompt := range r.Prompts {
+			p := *prompt
+			p.Description = translate(p.Name, p.Description)
+			p.Title = translate(p.Name, p.Title)
+			localized.Prompts[i] = &p
+		}
+		return &localized
+	default:
+		return result
+	}
+}
+
+// localizeProperties returns the input schema of a tool with the descriptions of its properties
+// translated. The schema is copied if any description changes, since it is the server's.
+func localizeProperties(tool string, inputSchema any, translate func(name, text string) string) any {
+	schema, ok := inputSchema.(*jsonschema.Schema)
+	if !ok || schema == nil {
+		return inputSchema
+	}
+	var localized *jsonschema.Schema
+	for property, propertySchema := range schema.Properties {
+		description := translate(propertyName(tool, property), propertySchema.Description)
+		if description == propertySchema.Description {
+			continue
+		}
+		if localized == nil {
+			localized = schema.CloneSchemas()
+		}
+		localized.Properties[property].Description = description
+	}
+	if localized == nil {
+		return inputSchema
+	}
+	return localized
+}
+
+// middleware translates tool and prompt listings to the locale the client sent on initialize,
+// if there is a bundle for it.
+func (l *localizer) middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := next(ctx, method, req)
+		if err != nil || (method != "tools/list" && method != "prompts/list") {
+			return result, err
+		}
+		session, ok := req.GetSession().(*mcp.ServerSession)
+		if !ok {
+			return result, nil
+		}
+		locale := clientLocale(session.InitializeParams())
+		if l.bundles.Lookup(locale) == nil {
+			return result, nil
+		}
+		return l.localize(result, locale), nil
+	}
+}
+
+// clientLocale returns the locale the client sent in the _meta of its initialize request.
+func clientLocale(params *mcp.InitializeParams) string {
+	if params == nil {
+		return ""
+	}
+	locale, _ := params.Meta[LocaleMetaKey].(string)
+	return locale
+}
@@ -0,0 +1,79 @@
+package ghmcp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedBundles(t *testing.T) {
+	bundles, err := translations.LoadBundles("")
+	require.NoError(t, err)
+	assert.Contains(t, bundles.Locales(), "de")
+	assert.Equal(t, "Mein Benutzerprofil abrufen", bundles.Lookup("de-DE")["TOOL_GET_ME_USER_TITLE"])
+
+	keys := make(map[string]string)
+	recorder := translations.RecordingTranslationHelper(keys)
+	github.AllTools(recorder)
+	github.AllResources(recorder)
+	github.AllPrompts(recorder)
+	for _, report := range translations.CheckBundles(bundles, keys) {
+		assert.Empty(t, report.Stale, "the %s bundle has keys that are not in use", report.Locale)
+	}
+}
+
+func TestLocalizer(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "de.json"), []byte(`{
+		"TOOL_GET_ME_DESCRIPTION": "Details des angemeldeten Benutzers",
+		"TOOL_GET_ME_USER_TITLE": "Mein Profil",
+		"TOOL_GET_TEAMS_USER_DESCRIPTION": "Benutzername"
+	}`), 0600))
+	bundles, err := translations.LoadBundles(dir)
+	require.NoError(t, err)
+	// Translation helpers cache text by key, which matters for keys shared by tools with
+	// different defaults, so the English text must come from one like the server's
+	english, _ := translations.TranslationHelper()
+	l := newLocalizer(bundles, english)
+
+	getMe := &mcp.Tool{
+		Name:        "get_me",
+		Description: "Get details of the authenticated GitHub user. Use this when a request is about the user's own profile for GitHub. Or when information is missing to build other tool calls.",
+		Annotations: &mcp.ToolAnnotations{Title: "Get my user profile", ReadOnlyHint: true},
+	}
+	getTeamsSchema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"user": {Type: "string", Description: "Username to get teams for. If not provided, uses the authenticated user."},
+		},
+	}
+	getTeams := &mcp.Tool{Name: "get_teams", InputSchema: getTeamsSchema}
+	dynamic := &mcp.Tool{Name: "enable_toolset", Description: "Enable a toolset"}
+	result := &mcp.ListToolsResult{Tools: []*mcp.Tool{getMe, getTeams, dynamic}}
+
+	localized := l.localize(result, "de-DE").(*mcp.ListToolsResult)
+	require.Len(t, localized.Tools, 3)
+	assert.Equal(t, "Details des angemeldeten Benutzers", localized.Tools[0].Description)
+	assert.Equal(t, "Mein Profil", localized.Tools[0].Annotations.Title)
+	assert.True(t, localized.Tools[0].Annotations.ReadOnlyHint)
+	assert.Equal(t, "Benutzername", localized.Tools[1].InputSchema.(*jsonschema.Schema).Properties["user"].Description)
+	assert.Equal(t, "Enable a toolset", localized.Tools[2].Description, "text without translation is left as is")
+
+	assert.Equal(t, "Get my user profile", getMe.Annotations.Title, "the server's tools are not modified")
+	assert.Equal(t, "Username to get teams for. If not provided, uses the authenticated user.", getTeamsSchema.Properties["user"].Description,
+		"the server's input schemas are not modified")
+	assert.Same(t, result, l.localize(result, "fr"), "results for locales without translations are returned as is")
+}
+
+func TestClientLocale(t *testing.T) {
+	assert.Equal(t, "", clientLocale(nil))
+	assert.Equal(t, "", clientLocale(&mcp.InitializeParams{}))
+	assert.Equal(t, "de-DE", clientLocale(&mcp.InitializeParams{Meta: mcp.Meta{LocaleMetaKey: "de-DE"}}))
+}
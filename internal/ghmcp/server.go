@@ -18,6 +18,7 @@ import (
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/lockdown"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/outputformat"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/scopes"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -48,6 +49,14 @@ type MCPServerConfig struct {
 	// Items with FeatureFlagEnable matching an entry in this list will be available
 	EnabledFeatures []string
 
+	// CustomToolsets are toolsets composed of existing tools, defined by configuration.
+	// They can be enabled like built-in toolsets.
+	CustomToolsets []inventory.CustomToolset
+
+	// Repository restricts the server to a single repository when non-nil. The owner and repo
+	// parameters are removed from tool schemas and set at call time.
+	Repository *github.RepositoryScope
+
 	// Whether to enable dynamic toolsets
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#dynamic-tool-discovery
 	DynamicToolsets bool
@@ -58,9 +67,19 @@ type MCPServerConfig struct {
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
 
+	// Locale is the locale the Translator translates to, set with --locale. When empty, tool and
+	// prompt listings are translated to the locale the client sends on initialize, if any.
+	Locale string
+
+	// LocaleBundles are the locale bundles to translate listings to the client's locale with
+	LocaleBundles *translations.Bundles
+
 	// Content window size
 	ContentWindowSize int
 
+	// OutputFormat is the default text format of tool results
+	OutputFormat outputformat.Format
+
 	// LockdownMode indicates if we should enable lockdown mode
 	LockdownMode bool
 
@@ -76,6 +95,14 @@ type MCPServerConfig struct {
 	// When non-nil, tools requiring scopes not in this list will be hidden.
 	// This is used for PAT scope filtering where we can't issue scope challenges.
 	TokenScopes []string
+
+	// TokenPermissions contains the probed permissions of a fine-grained PAT or GitHub App
+	// installation token. When non-nil, tools needing a permission that is denied will be hidden.
+	TokenPermissions scopes.TokenPermissions
+
+	// Transport is the HTTP transport of the REST, GraphQL and raw content clients. If nil,
+	// http.DefaultTransport is used. Tests set it to record or replay the API traffic.
+	Transport http.RoundTripper
 }
 
 // githubClients holds all the GitHub API clients created for a server instance.
@@ -89,8 +116,13 @@ type githubClients struct {
 
 // createGitHubClients creates all the GitHub API clients needed by the server.
 func createGitHubClients(cfg MCPServerConfig, apiHost apiHost) (*githubClients, error) {
+	transport := cfg.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
 	// Construct REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
+	restClient := gogithub.NewClient(&http.Client{Transport: transport}).WithAuthToken(cfg.Token)
 	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
 	restClient.BaseURL = apiHost.baseRESTURL
 	restClient.UploadURL = apiHost.uploadURL
@@ -100,7 +132,7 @@ func createGitHubClients(cfg MCPServerConfig, apiHost apiHost) (*githubClients,
 	gqlHTTPClient := &http.Client{
 		Transport: &bearerAuthTransport{
 			transport: &github.GraphQLFeaturesTransport{
-				Transport: http.DefaultTransport,
+				Transport: transport,
 			},
 			token: cfg.Token,
 		},
@@ -169,44 +201,71 @@ func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
 		return nil, fmt.Errorf("failed to create GitHub clients: %w", err)
 	}
 
-	enabledToolsets := resolveEnabledToolsets(cfg)
-
-	// Create feature checker
-	featureChecker := createFeatureChecker(cfg.EnabledFeatures)
-
-	// Build and register the tool/resource/prompt inventory
-	inventoryBuilder := github.NewInventory(cfg.Translator).
-		WithDeprecatedAliases(github.DeprecatedToolAliases).
-		WithReadOnly(cfg.ReadOnly).
-		WithToolsets(enabledToolsets).
-		WithTools(cfg.EnabledTools).
-		WithFeatureChecker(featureChecker).
-		WithServerInstructions()
+	// Build the tool/resource/prompt inventory like the doctor and export-tools commands do,
+	// with the token filters and server instructions on top
+	inventoryBuilder := newInventoryBuilder(InventoryConfig{
+		EnabledToolsets:   cfg.EnabledToolsets,
+		EnabledTools:      cfg.EnabledTools,
+		EnabledFeatures:   cfg.EnabledFeatures,
+		CustomToolsets:    cfg.CustomToolsets,
+		Repository:        cfg.Repository,
+		DynamicToolsets:   cfg.DynamicToolsets,
+		ContentWindowSize: cfg.ContentWindowSize,
+		ReadOnly:          cfg.ReadOnly,
+		Translator:        cfg.Translator,
+	}).WithServerInstructions()
 
 	// Apply token scope filtering if scopes are known (for PAT filtering)
 	if cfg.TokenScopes != nil {
 		inventoryBuilder = inventoryBuilder.WithFilter(github.CreateToolScopeFilter(cfg.TokenScopes))
 	}
 
+	// Apply token permission filtering if permissions were probed (for fine-grained tokens)
+	if cfg.TokenPermissions != nil {
+		inventoryBuilder = inventoryBuilder.WithFilter(github.CreateToolPermissionFilter(cfg.TokenPermissions))
+	}
+
 	inventory, err := inventoryBuilder.Build()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build inventory: %w", err)
 	}
 
+	// Find the repository the server was started in, which get_current_repository falls back to
+	// when the client's roots are not in a checkout
+	workingDir, err := os.Getwd()
+	if err != nil {
+		workingDir = ""
+	}
+	repositoryDetector := github.NewRepositoryDetector(cfg.Host, workingDir)
+
+	instructions := inventory.Instructions()
+	if cfg.Repository != nil && instructions != "" {
+		instructions += " " + cfg.Repository.Instructions()
+	} else if instructions != "" && inventory.IsToolEnabled(context.Background(), "get_current_repository") {
+		if repo, err := repositoryDetector.FromWorkingDirectory(); err == nil && repo != nil {
+			instructions += " " + repo.Instructions()
+		}
+	}
+
+	completionHandler := github.CompletionsHandler(func(_ context.Context) (*gogithub.Client, error) {
+		return clients.rest, nil
+	})
+	if cfg.Repository != nil {
+		completionHandler = github.ScopeCompletionsToRepository(*cfg.Repository, completionHandler)
+	}
+
 	// Create the MCP server
 	serverOpts := &mcp.ServerOptions{
-		Instructions: inventory.Instructions(),
-		Logger:       cfg.Logger,
-		CompletionHandler: github.CompletionsHandler(func(_ context.Context) (*gogithub.Client, error) {
-			return clients.rest, nil
-		}),
+		Instructions:      instructions,
+		Logger:            cfg.Logger,
+		CompletionHandler: completionHandler,
 	}
 
 	// In dynamic mode, explicitly advertise capabilities since tools/resources/prompts
-	// may be enabled at runtime even if none are registered initially.
+	// may be enabled or disabled at runtime even if none are registered initially.
 	if cfg.DynamicToolsets {
 		serverOpts.Capabilities = &mcp.ServerCapabilities{
-			Tools:     &mcp.ToolCapabilities{},
+			Tools:     &mcp.ToolCapabilities{ListChanged: true},
 			Resources: &mcp.ResourceCapabilities{},
 			Prompts:   &mcp.PromptCapabilities{},
 		}
@@ -218,7 +277,13 @@ func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
 	ghServer.AddReceivingMiddleware(addGitHubAPIErrorToContext)
 	ghServer.AddReceivingMiddleware(addUserAgentsMiddleware(cfg, clients.rest, clients.gqlHTTP))
 
+	// Translate listings to the client's locale unless the locale was set explicitly
+	if cfg.Locale == "" && len(cfg.LocaleBundles.Locales()) > 0 {
+		ghServer.AddReceivingMiddleware(newLocalizer(cfg.LocaleBundles, cfg.Translator).middleware)
+	}
+
 	// Create dependencies for tool handlers
+	featureChecker := createFeatureChecker(cfg.EnabledFeatures)
 	deps := github.NewBaseDeps(
 		clients.rest,
 		clients.gql,
@@ -230,13 +295,16 @@ func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
 			InsidersMode: cfg.InsidersMode,
 		},
 		cfg.ContentWindowSize,
+		cfg.OutputFormat,
 		featureChecker,
 	)
 
-	// Inject dependencies into context for all tool handlers
+	// Inject dependencies and the repository detector into context for all tool handlers
 	ghServer.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
 		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
-			return next(github.ContextWithDeps(ctx, deps), method, req)
+			ctx = github.ContextWithDeps(ctx, deps)
+			ctx = github.ContextWithRepositoryDetector(ctx, repositoryDetector)
+			return next(ctx, method, req)
 		}
 	})
 
@@ -308,6 +376,14 @@ type StdioServerConfig struct {
 	// Items with FeatureFlagEnable matching an entry in this list will be available
 	EnabledFeatures []string
 
+	// CustomToolsets are toolsets composed of existing tools, defined by configuration.
+	// They can be enabled like built-in toolsets.
+	CustomToolsets []inventory.CustomToolset
+
+	// Repository restricts the server to a single repository when non-nil. The owner and repo
+	// parameters are removed from tool schemas and set at call time.
+	Repository *github.RepositoryScope
+
 	// Whether to enable dynamic toolsets
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#dynamic-tool-discovery
 	DynamicToolsets bool
@@ -319,6 +395,13 @@ type StdioServerConfig struct {
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
 
+	// Locale selects the locale bundle to translate the server tooling with, falling back to
+	// English. When empty, the locale the client sends on initialize is used for listings.
+	Locale string
+
+	// LocalesDir is a directory of locale bundles, in addition to the embedded ones
+	LocalesDir string
+
 	// EnableCommandLogging indicates if we should log commands
 	EnableCommandLogging bool
 
@@ -328,6 +411,9 @@ type StdioServerConfig struct {
 	// Content window size
 	ContentWindowSize int
 
+	// OutputFormat is the default text format of tool results
+	OutputFormat outputformat.Format
+
 	// LockdownMode indicates if we should enable lockdown mode
 	LockdownMode bool
 
@@ -344,7 +430,11 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	t, dumpTranslations := translations.TranslationHelper()
+	bundles, err := translations.LoadBundles(cfg.LocalesDir)
+	if err != nil {
+		return err
+	}
+	t, dumpTranslations := translations.LocaleTranslationHelper(bundles, cfg.Locale)
 
 	var slogHandler slog.Handler
 	var logOutput io.Writer
@@ -361,20 +451,34 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	}
 	logger := slog.New(slogHandler)
 	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly, "lockdownEnabled", cfg.LockdownMode)
+	if cfg.Repository != nil {
+		logger.Info("restricting server to a single repository", "repository", cfg.Repository.String())
+	}
 
 	// Fetch token scopes for scope-based tool filtering (PAT tokens only)
 	// Only classic PATs (ghp_ prefix) return OAuth scopes via X-OAuth-Scopes header.
-	// Fine-grained PATs and other token types don't support this, so we skip filtering.
+	// Fine-grained PATs and GitHub App installation tokens have permissions instead,
+	// which are probed since they can't be listed.
 	var tokenScopes []string
-	if strings.HasPrefix(cfg.Token, "ghp_") {
-		fetchedScopes, err := fetchTokenScopesForHost(ctx, cfg.Token, cfg.Host)
+	var tokenPermissions scopes.TokenPermissions
+	switch {
+	case strings.HasPrefix(cfg.Token, "ghp_"):
+		fetchedScopes, err := FetchTokenScopesForHost(ctx, cfg.Token, cfg.Host)
 		if err != nil {
 			logger.Warn("failed to fetch token scopes, continuing without scope filtering", "error", err)
 		} else {
 			tokenScopes = fetchedScopes
 			logger.Info("token scopes fetched for filtering", "scopes", tokenScopes)
 		}
-	} else {
+	case scopes.IsFineGrainedToken(cfg.Token):
+		probedPermissions, err := probeTokenPermissionsForHost(ctx, cfg.Token, cfg.Host, cfg.Repository)
+		if err != nil {
+			logger.Warn("failed to probe token permissions, continuing without permission filtering", "error", err)
+		} else {
+			tokenPermissions = probedPermissions
+			logger.Info("token permissions probed for filtering", "denied", tokenPermissions.Denied())
+		}
+	default:
 		logger.Debug("skipping scope filtering for non-PAT token")
 	}
 
@@ -385,15 +489,21 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		EnabledToolsets:   cfg.EnabledToolsets,
 		EnabledTools:      cfg.EnabledTools,
 		EnabledFeatures:   cfg.EnabledFeatures,
+		CustomToolsets:    cfg.CustomToolsets,
+		Repository:        cfg.Repository,
 		DynamicToolsets:   cfg.DynamicToolsets,
 		ReadOnly:          cfg.ReadOnly,
 		Translator:        t,
+		Locale:            cfg.Locale,
+		LocaleBundles:     bundles,
 		ContentWindowSize: cfg.ContentWindowSize,
+		OutputFormat:      cfg.OutputFormat,
 		LockdownMode:      cfg.LockdownMode,
 		InsidersMode:      cfg.InsidersMode,
 		Logger:            logger,
 		RepoAccessTTL:     cfg.RepoAccessCacheTTL,
 		TokenScopes:       tokenScopes,
+		TokenPermissions:  tokenPermissions,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
@@ -679,9 +789,9 @@ func addUserAgentsMiddleware(cfg MCPServerConfig, restClient *gogithub.Client, g
 	}
 }
 
-// fetchTokenScopesForHost fetches the OAuth scopes for a token from the GitHub API.
+// FetchTokenScopesForHost fetches the OAuth scopes for a token from the GitHub API.
 // It constructs the appropriate API host URL based on the configured host.
-func fetchTokenScopesForHost(ctx context.Context, token, host string) ([]string, error) {
+func FetchTokenScopesForHost(ctx context.Context, token, host string) ([]string, error) {
 	apiHost, err := parseAPIHost(host)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse API host: %w", err)
@@ -693,3 +803,21 @@ func fetchTokenScopesForHost(ctx context.Context, token, host string) ([]string,
 
 	return fetcher.FetchTokenScopes(ctx, token)
 }
+
+// probeTokenPermissionsForHost probes the permissions of a fine-grained token on the GitHub API.
+// Repository permissions are probed on the repository the server is restricted to, if any.
+func probeTokenPermissionsForHost(ctx context.Context, token, host string, repository *github.RepositoryScope) (scopes.TokenPermissions, error) {
+	apiHost, err := parseAPIHost(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API host: %w", err)
+	}
+
+	opts := scopes.PermissionProberOptions{
+		APIHost: apiHost.baseRESTURL.String(),
+	}
+	if repository != nil {
+		opts.Repository = repository.String()
+	}
+
+	return scopes.NewPermissionProber(opts).ProbePermissions(ctx, token)
+}
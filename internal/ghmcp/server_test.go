@@ -1,9 +1,13 @@
 package ghmcp
 
 import (
+	"context"
+	"slices"
 	"testing"
 
+	"github.com/github/github-mcp-server/pkg/github"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -42,6 +46,98 @@ func TestNewMCPServer_CreatesSuccessfully(t *testing.T) {
 	// is already tested in pkg/github/*_test.go.
 }
 
+// TestNewMCPServer_OffersReadMore verifies that read_more is offered whenever results can be
+// truncated, since truncated results point to it.
+func TestNewMCPServer_OffersReadMore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		toolsets          []string
+		tools             []string
+		contentWindowSize int
+		expected          bool
+	}{
+		{name: "toolset without read_more", toolsets: []string{"repos"}, contentWindowSize: 5000, expected: true},
+		{name: "individual tools", tools: []string{"get_me"}, contentWindowSize: 5000, expected: true},
+		{name: "no truncation", toolsets: []string{"repos"}, expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ghServer, err := NewMCPServer(MCPServerConfig{
+				Version:           "test",
+				Token:             "test-token",
+				EnabledToolsets:   tc.toolsets,
+				EnabledTools:      tc.tools,
+				Translator:        translations.NullTranslationHelper,
+				ContentWindowSize: tc.contentWindowSize,
+			})
+			require.NoError(t, err)
+
+			names := listServerTools(t, ghServer)
+			assert.Equal(t, tc.expected, slices.Contains(names, "read_more"), "tools: %v", names)
+		})
+	}
+}
+
+// TestNewMCPServer_MatchesBuildInventory verifies that the server offers the tools that the
+// doctor and export-tools commands inspect with BuildInventory.
+func TestNewMCPServer_MatchesBuildInventory(t *testing.T) {
+	t.Parallel()
+
+	scope := github.RepositoryScope{Owner: "octo-org", Repo: "hello-world"}
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:           "test",
+		Token:             "test-token",
+		EnabledToolsets:   []string{"repos", "issues"},
+		EnabledTools:      []string{"get_me"},
+		Repository:        &scope,
+		ReadOnly:          true,
+		Translator:        translations.NullTranslationHelper,
+		ContentWindowSize: 5000,
+	})
+	require.NoError(t, err)
+
+	inv, err := BuildInventory(InventoryConfig{
+		EnabledToolsets:   []string{"repos", "issues"},
+		EnabledTools:      []string{"get_me"},
+		Repository:        &scope,
+		ReadOnly:          true,
+		Translator:        translations.NullTranslationHelper,
+		ContentWindowSize: 5000,
+	})
+	require.NoError(t, err)
+	var expected []string
+	for _, tool := range inv.AvailableTools(context.Background()) {
+		expected = append(expected, tool.Tool.Name)
+	}
+
+	assert.ElementsMatch(t, expected, listServerTools(t, ghServer))
+	assert.Contains(t, expected, "read_more")
+}
+
+// listServerTools connects a client to the server and returns the names of the tools it lists.
+func listServerTools(t *testing.T, ghServer *mcp.Server) []string {
+	t.Helper()
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := ghServer.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = serverSession.Close() }()
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.1"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = session.Close() }()
+
+	result, err := session.ListTools(ctx, nil)
+	require.NoError(t, err)
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
 // TestResolveEnabledToolsets verifies the toolset resolution logic.
 func TestResolveEnabledToolsets(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,252 @@
+package githubfake
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v79/github"
+)
+
+// The workflows of a repository are the workflow files on its default branch. Their runs are
+// added with Server.AddWorkflowRun or by dispatching a workflow, and never run: they stay in the
+// status they were added or last set to.
+
+// workflowID returns the ID of the workflow at a path, assigning one on first use.
+func (r *repository) workflowID(workflowPath string) int64 {
+	id, ok := r.workflows[workflowPath]
+	if !ok {
+		id, _ = r.server.newID("W", workflowPath)
+		r.workflows[workflowPath] = id
+	}
+	return id
+}
+
+func (r *repository) workflowList() []*github.Workflow {
+	workflows := []*github.Workflow{}
+	sha, ok := r.resolve("")
+	if !ok {
+		return workflows
+	}
+	entry, ok := r.lookup(r.commits[sha].tree, ".github/workflows")
+	if !ok || entry.typ != "tree" {
+		return workflows
+	}
+	for _, e := range r.trees[entry.sha] {
+		if e.typ != "blob" || (path.Ext(e.name) != ".yml" && path.Ext(e.name) != ".yaml") {
+			continue
+		}
+		workflowPath := ".github/workflows/" + e.name
+		id := r.workflowID(workflowPath)
+		workflows = append(workflows, &github.Workflow{
+			ID:        github.Ptr(id),
+			NodeID:    github.Ptr("W_" + strconv.FormatInt(id, 10)),
+			Name:      github.Ptr(workflowName(string(r.blobs[e.sha]), workflowPath)),
+			Path:      github.Ptr(workflowPath),
+			State:     github.Ptr("active"),
+			CreatedAt: &r.createdAt,
+			UpdatedAt: &r.createdAt,
+			URL:       github.Ptr(r.apiURL("/actions/workflows/%d", id)),
+			HTMLURL:   github.Ptr(r.htmlURL("/blob/%s/%s", r.defaultBranch, workflowPath)),
+			BadgeURL:  github.Ptr(r.htmlURL("/workflows/%s/badge.svg", strings.TrimSuffix(e.name, path.Ext(e.name)))),
+		})
+	}
+	return workflows
+}
+
+// workflowName returns the top-level name of a workflow file, or its path if it has none.
+func workflowName(content, workflowPath string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if name, ok := strings.CutPrefix(line, "name:"); ok {
+			return strings.Trim(strings.TrimSpace(name), `"'`)
+		}
+	}
+	return workflowPath
+}
+
+// workflow returns the workflow with an ID or file name.
+func (r *repository) workflow(idOrFile string) *github.Workflow {
+	for _, workflow := range r.workflowList() {
+		if strconv.FormatInt(workflow.GetID(), 10) == idOrFile || path.Base(workflow.GetPath()) == idOrFile {
+			return workflow
+		}
+	}
+	return nil
+}
+
+func (r *repository) addWorkflowRun(run WorkflowRun) *github.WorkflowRun {
+	if run.Branch == "" {
+		run.Branch = r.defaultBranch
+	}
+	headSHA := r.refs["refs/heads/"+run.Branch]
+	var title string
+	if c := r.commits[headSHA]; c != nil {
+		title, _, _ = strings.Cut(c.message, "\n")
+	}
+	name := run.Workflow
+	if workflow := r.workflow(path.Base(run.Workflow)); workflow != nil {
+		name = workflow.GetName()
+	}
+
+	workflowID := r.workflowID(run.Workflow)
+	number := 1
+	for _, existing := range r.runs {
+		if existing.GetWorkflowID() == workflowID {
+			number++
+		}
+	}
+
+	now := &github.Timestamp{Time: r.server.now()}
+	result := &github.WorkflowRun{
+		Name:         github.Ptr(name),
+		DisplayTitle: github.Ptr(title),
+		HeadBranch:   github.Ptr(run.Branch),
+		HeadSHA:      github.Ptr(headSHA),
+		Path:         github.Ptr(run.Workflow),
+		RunNumber:    github.Ptr(number),
+		RunAttempt:   github.Ptr(1),
+		Event:        github.Ptr(run.Event),
+		Status:       github.Ptr(run.Status),
+		WorkflowID:   github.Ptr(workflowID),
+		Actor:        r.server.user(r.server.login),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		RunStartedAt: now,
+	}
+	if run.Conclusion != "" {
+		result.Conclusion = github.Ptr(run.Conclusion)
+	}
+	id, nodeID := r.server.newID("WFR", result)
+	result.ID = github.Ptr(id)
+	result.NodeID = github.Ptr(nodeID)
+	result.HTMLURL = github.Ptr(r.htmlURL("/actions/runs/%d", id))
+	result.URL = github.Ptr(r.apiURL("/actions/runs/%d", id))
+	r.runs = append(r.runs, result)
+	return result
+}
+
+// pathWorkflowRun returns the run with the id path value, writing 404 Not Found if there is none.
+func (r *repository) pathWorkflowRun(w http.ResponseWriter, req *http.Request) *github.WorkflowRun {
+	for _, run := range r.runs {
+		if strconv.FormatInt(run.GetID(), 10) == req.PathValue("id") {
+			return run
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil
+}
+
+func (s *Server) listWorkflows(w http.ResponseWriter, r *http.Request, repo *repository) {
+	workflows := repo.workflowList()
+	writeJSON(w, http.StatusOK, &github.Workflows{
+		TotalCount: github.Ptr(len(workflows)),
+		Workflows:  paginate(w, r, workflows),
+	})
+}
+
+func (s *Server) getWorkflow(w http.ResponseWriter, r *http.Request, repo *repository) {
+	workflow := repo.workflow(r.PathValue("workflow"))
+	if workflow == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, workflow)
+}
+
+func (s *Server) listWorkflowRuns(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var workflowID int64
+	if idOrFile := r.PathValue("workflow"); idOrFile != "" {
+		workflow := repo.workflow(idOrFile)
+		if workflow == nil {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		workflowID = workflow.GetID()
+	}
+
+	query := r.URL.Query()
+	runs := []*github.WorkflowRun{}
+	for n := len(repo.runs) - 1; n >= 0; n-- {
+		run := repo.runs[n]
+		status := query.Get("status")
+		switch {
+		case workflowID != 0 && run.GetWorkflowID() != workflowID:
+			continue
+		case query.Get("branch") != "" && run.GetHeadBranch() != query.Get("branch"):
+			continue
+		case query.Get("event") != "" && run.GetEvent() != query.Get("event"):
+			continue
+		case query.Get("actor") != "" && run.GetActor().GetLogin() != query.Get("actor"):
+			continue
+		case status != "" && run.GetStatus() != status && run.GetConclusion() != status:
+			continue
+		}
+		runs = append(runs, run)
+	}
+	writeJSON(w, http.StatusOK, &github.WorkflowRuns{
+		TotalCount:   github.Ptr(len(runs)),
+		WorkflowRuns: paginate(w, r, runs),
+	})
+}
+
+func (s *Server) dispatchWorkflow(w http.ResponseWriter, r *http.Request, repo *repository) {
+	workflow := repo.workflow(r.PathValue("workflow"))
+	if workflow == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body github.CreateWorkflowDispatchEventRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if _, ok := repo.refs["refs/heads/"+body.Ref]; !ok {
+		writeError(w, http.StatusUnprocessableEntity, "No ref found for: "+body.Ref)
+		return
+	}
+
+	repo.addWorkflowRun(WorkflowRun{
+		Workflow: workflow.GetPath(),
+		Branch:   body.Ref,
+		Event:    "workflow_dispatch",
+		Status:   "queued",
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getWorkflowRun(w http.ResponseWriter, r *http.Request, repo *repository) {
+	if run := repo.pathWorkflowRun(w, r); run != nil {
+		writeJSON(w, http.StatusOK, run)
+	}
+}
+
+func (s *Server) rerunWorkflowRun(w http.ResponseWriter, r *http.Request, repo *repository) {
+	run := repo.pathWorkflowRun(w, r)
+	if run == nil {
+		return
+	}
+	if run.GetStatus() != "completed" {
+		writeError(w, http.StatusForbidden, "This workflow is already running")
+		return
+	}
+	run.Status = github.Ptr("queued")
+	run.Conclusion = nil
+	run.RunAttempt = github.Ptr(run.GetRunAttempt() + 1)
+	run.UpdatedAt = &github.Timestamp{Time: s.now()}
+	writeJSON(w, http.StatusCreated, struct{}{})
+}
+
+func (s *Server) cancelWorkflowRun(w http.ResponseWriter, r *http.Request, repo *repository) {
+	run := repo.pathWorkflowRun(w, r)
+	if run == nil {
+		return
+	}
+	if run.GetStatus() == "completed" {
+		writeError(w, http.StatusConflict, "Cannot cancel a workflow run that is completed.")
+		return
+	}
+	run.Status = github.Ptr("completed")
+	run.Conclusion = github.Ptr("cancelled")
+	run.UpdatedAt = &github.Timestamp{Time: s.now()}
+	writeJSON(w, http.StatusAccepted, struct{}{})
+}
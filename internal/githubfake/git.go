@@ -0,0 +1,386 @@
+package githubfake
+
+import (
+	"bytes"
+	"crypto/sha1" // #nosec G505 -- git object IDs are SHA-1 hashes
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// A repository stores git objects like git does, so the SHAs of blobs, trees and commits are
+// those git would compute for the same content.
+
+type treeEntry struct {
+	name string
+	mode string
+	typ  string
+	sha  string
+}
+
+type signature struct {
+	name  string
+	email string
+	date  time.Time
+}
+
+type commit struct {
+	sha       string
+	tree      string
+	parents   []string
+	message   string
+	author    signature
+	committer signature
+}
+
+// tag is an annotated tag, pointing to a commit.
+type tag struct {
+	sha     string
+	name    string
+	message string
+	object  string
+	tagger  signature
+}
+
+// file is a blob in a flattened tree.
+type file struct {
+	mode string
+	sha  string
+}
+
+// change is a file changed between two trees.
+type change struct {
+	path   string
+	status string
+	before file
+	after  file
+}
+
+func hashObject(kind string, data []byte) string {
+	h := sha1.New() // #nosec G401 -- git object IDs are SHA-1 hashes
+	_, _ = fmt.Fprintf(h, "%s %d\x00", kind, len(data))
+	_, _ = h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (r *repository) writeBlob(content []byte) string {
+	sha := hashObject("blob", content)
+	r.blobs[sha] = content
+	return sha
+}
+
+func (r *repository) writeTree(entries []treeEntry) string {
+	// git sorts tree entries by name, comparing the names of trees as if they ended with a slash
+	sortName := func(e treeEntry) string {
+		if e.typ == "tree" {
+			return e.name + "/"
+		}
+		return e.name
+	}
+	entries = append([]treeEntry(nil), entries...)
+	sort.Slice(entries, func(i, j int) bool { return sortName(entries[i]) < sortName(entries[j]) })
+
+	var data bytes.Buffer
+	for _, e := range entries {
+		raw, _ := hex.DecodeString(e.sha)
+		_, _ = fmt.Fprintf(&data, "%s %s\x00", strings.TrimPrefix(e.mode, "0"), e.name)
+		data.Write(raw)
+	}
+	sha := hashObject("tree", data.Bytes())
+	r.trees[sha] = entries
+	return sha
+}
+
+func (r *repository) writeCommit(c *commit) string {
+	var data strings.Builder
+	_, _ = fmt.Fprintf(&data, "tree %s\n", c.tree)
+	for _, parent := range c.parents {
+		_, _ = fmt.Fprintf(&data, "parent %s\n", parent)
+	}
+	_, _ = fmt.Fprintf(&data, "author %s <%s> %d +0000\n", c.author.name, c.author.email, c.author.date.Unix())
+	_, _ = fmt.Fprintf(&data, "committer %s <%s> %d +0000\n\n", c.committer.name, c.committer.email, c.committer.date.Unix())
+	data.WriteString(c.message)
+	c.sha = hashObject("commit", []byte(data.String()))
+	r.commits[c.sha] = c
+	return c.sha
+}
+
+func (r *repository) writeTag(t *tag) string {
+	var data strings.Builder
+	_, _ = fmt.Fprintf(&data, "object %s\ntype commit\ntag %s\n", t.object, t.name)
+	_, _ = fmt.Fprintf(&data, "tagger %s <%s> %d +0000\n\n", t.tagger.name, t.tagger.email, t.tagger.date.Unix())
+	data.WriteString(t.message)
+	t.sha = hashObject("tag", []byte(data.String()))
+	r.tags[t.sha] = t
+	return t.sha
+}
+
+// newCommit writes a commit of tree by the authenticated user.
+func (r *repository) newCommit(tree, message string, parents ...string) *commit {
+	sig := signature{name: r.server.login, email: r.server.login + "@users.noreply.github.com", date: r.server.now()}
+	c := &commit{tree: tree, parents: parents, message: message, author: sig, committer: sig}
+	r.writeCommit(c)
+	return c
+}
+
+// flatten returns the files of a tree by path.
+func (r *repository) flatten(tree string) map[string]file {
+	files := make(map[string]file)
+	var walk func(sha, prefix string)
+	walk = func(sha, prefix string) {
+		for _, e := range r.trees[sha] {
+			if e.typ == "tree" {
+				walk(e.sha, prefix+e.name+"/")
+				continue
+			}
+			files[prefix+e.name] = file{mode: e.mode, sha: e.sha}
+		}
+	}
+	walk(tree, "")
+	return files
+}
+
+// buildTree writes the trees of files by path, and returns the SHA of the root tree.
+func (r *repository) buildTree(files map[string]file) string {
+	dirs := make(map[string]map[string]file)
+	var entries []treeEntry
+	for path, f := range files {
+		if dir, rest, ok := strings.Cut(path, "/"); ok {
+			if dirs[dir] == nil {
+				dirs[dir] = make(map[string]file)
+			}
+			dirs[dir][rest] = f
+			continue
+		}
+		entries = append(entries, treeEntry{name: path, mode: f.mode, typ: "blob", sha: f.sha})
+	}
+	for dir, dirFiles := range dirs {
+		entries = append(entries, treeEntry{name: dir, mode: "040000", typ: "tree", sha: r.buildTree(dirFiles)})
+	}
+	return r.writeTree(entries)
+}
+
+// lookup returns the tree entry at path in a tree. The root of the tree is at the empty path.
+func (r *repository) lookup(tree, path string) (treeEntry, bool) {
+	entry := treeEntry{mode: "040000", typ: "tree", sha: tree}
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return entry, true
+	}
+	for _, name := range strings.Split(path, "/") {
+		if entry.typ != "tree" {
+			return treeEntry{}, false
+		}
+		found := false
+		for _, e := range r.trees[entry.sha] {
+			if e.name == name {
+				entry, found = e, true
+				break
+			}
+		}
+		if !found {
+			return treeEntry{}, false
+		}
+	}
+	return entry, true
+}
+
+// resolve returns the SHA of the commit a commit SHA, branch, tag or fully qualified ref points
+// to. The empty ref and HEAD resolve to the default branch.
+func (r *repository) resolve(ref string) (string, bool) {
+	if ref == "" || ref == "HEAD" {
+		ref = "refs/heads/" + r.defaultBranch
+	}
+	if _, ok := r.commits[ref]; ok {
+		return ref, true
+	}
+	for _, candidate := range []string{ref, "refs/" + ref, "refs/heads/" + ref, "refs/tags/" + ref} {
+		if sha, ok := r.refs[candidate]; ok {
+			// Annotated tags are peeled to their commit
+			if t, ok := r.tags[sha]; ok {
+				return t.object, true
+			}
+			return sha, true
+		}
+	}
+	return "", false
+}
+
+// resolveTree returns the SHA of the tree a tree SHA or anything resolve accepts points to.
+func (r *repository) resolveTree(treeish string) (string, bool) {
+	if _, ok := r.trees[treeish]; ok {
+		return treeish, true
+	}
+	sha, ok := r.resolve(treeish)
+	if !ok {
+		return "", false
+	}
+	return r.commits[sha].tree, true
+}
+
+// commitFiles commits files, by path, on top of a branch, creating the branch if it doesn't
+// exist. A nil content deletes the file.
+func (r *repository) commitFiles(branch, message string, contents map[string]*string) *commit {
+	files := map[string]file{}
+	var parents []string
+	if sha, ok := r.refs["refs/heads/"+branch]; ok {
+		files = r.flatten(r.commits[sha].tree)
+		parents = []string{sha}
+	}
+	for path, content := range contents {
+		if content == nil {
+			delete(files, path)
+			continue
+		}
+		files[path] = file{mode: "100644", sha: r.writeBlob([]byte(*content))}
+	}
+	c := r.newCommit(r.buildTree(files), message, parents...)
+	r.refs["refs/heads/"+branch] = c.sha
+	return c
+}
+
+// ancestors returns the commits reachable from sha, including itself, newest first.
+func (r *repository) ancestors(sha string) []*commit {
+	seen := map[string]bool{}
+	var commits []*commit
+	queue := []string{sha}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		c, ok := r.commits[current]
+		if !ok || seen[current] {
+			continue
+		}
+		seen[current] = true
+		commits = append(commits, c)
+		queue = append(queue, c.parents...)
+	}
+	sort.SliceStable(commits, func(i, j int) bool { return commits[i].committer.date.After(commits[j].committer.date) })
+	return commits
+}
+
+// isAncestor reports whether ancestor is reachable from sha.
+func (r *repository) isAncestor(ancestor, sha string) bool {
+	for _, c := range r.ancestors(sha) {
+		if c.sha == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeBase returns the newest common ancestor of two commits.
+func (r *repository) mergeBase(a, b string) (string, bool) {
+	for _, c := range r.ancestors(b) {
+		if r.isAncestor(c.sha, a) {
+			return c.sha, true
+		}
+	}
+	return "", false
+}
+
+// diffTrees returns the files changed from one tree to another, by path.
+func (r *repository) diffTrees(from, to string) []change {
+	before, after := r.flatten(from), r.flatten(to)
+	var changes []change
+	for path, f := range after {
+		old, ok := before[path]
+		switch {
+		case !ok:
+			changes = append(changes, change{path: path, status: "added", after: f})
+		case old != f:
+			changes = append(changes, change{path: path, status: "modified", before: old, after: f})
+		}
+	}
+	for path, f := range before {
+		if _, ok := after[path]; !ok {
+			changes = append(changes, change{path: path, status: "removed", before: f})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].path < changes[j].path })
+	return changes
+}
+
+// mergeTrees merges the changes from base to theirs into ours, and reports whether they merge
+// without conflicts.
+func (r *repository) mergeTrees(base, ours, theirs string) (string, bool) {
+	baseFiles, ourFiles := r.flatten(base), r.flatten(ours)
+	for _, c := range r.diffTrees(base, theirs) {
+		ourFile := ourFiles[c.path]
+		if ourFile != baseFiles[c.path] && ourFile != c.after {
+			return "", false
+		}
+		if c.status == "removed" {
+			delete(ourFiles, c.path)
+			continue
+		}
+		ourFiles[c.path] = c.after
+	}
+	return r.buildTree(ourFiles), true
+}
+
+// patch returns the unified diff hunks of a changed file, and the numbers of added and deleted
+// lines.
+func (r *repository) patch(c change) (string, int, int) {
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:       splitLines(string(r.blobs[c.before.sha])),
+		B:       splitLines(string(r.blobs[c.after.sha])),
+		Context: 3,
+	})
+	var additions, deletions int
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return diff, additions, deletions
+}
+
+// diff returns the changes between two trees in the format of git diff.
+func (r *repository) diff(from, to string) string {
+	var out strings.Builder
+	for _, c := range r.diffTrees(from, to) {
+		_, _ = fmt.Fprintf(&out, "diff --git a/%s b/%s\n", c.path, c.path)
+		oldName, newName := "a/"+c.path, "b/"+c.path
+		switch c.status {
+		case "added":
+			_, _ = fmt.Fprintf(&out, "new file mode %s\n", c.after.mode)
+			oldName = "/dev/null"
+		case "removed":
+			_, _ = fmt.Fprintf(&out, "deleted file mode %s\n", c.before.mode)
+			newName = "/dev/null"
+		}
+		_, _ = fmt.Fprintf(&out, "index %s..%s\n", shortSHA(c.before.sha), shortSHA(c.after.sha))
+		_, _ = fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+		patch, _, _ := r.patch(c)
+		out.WriteString(patch)
+	}
+	return out.String()
+}
+
+func shortSHA(sha string) string {
+	if sha == "" {
+		return "0000000"
+	}
+	return sha[:7]
+}
+
+// splitLines splits text into lines that all end with a newline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
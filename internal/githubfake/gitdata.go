@@ -0,0 +1,326 @@
+package githubfake
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v79/github"
+)
+
+func (r *repository) reference(ref string) *github.Reference {
+	sha := r.refs[ref]
+	typ := "commit"
+	if _, ok := r.tags[sha]; ok {
+		typ = "tag"
+	}
+	return &github.Reference{
+		Ref:    github.Ptr(ref),
+		NodeID: github.Ptr("REF_" + ref),
+		URL:    github.Ptr(r.apiURL("/git/%s", ref)),
+		Object: &github.GitObject{
+			Type: github.Ptr(typ),
+			SHA:  github.Ptr(sha),
+			URL:  github.Ptr(r.apiURL("/git/%ss/%s", typ, sha)),
+		},
+	}
+}
+
+func (r *repository) gitTag(t *tag) *github.Tag {
+	return &github.Tag{
+		Tag:     github.Ptr(t.name),
+		SHA:     github.Ptr(t.sha),
+		NodeID:  github.Ptr("TAG_" + t.sha),
+		Message: github.Ptr(t.message),
+		URL:     github.Ptr(r.apiURL("/git/tags/%s", t.sha)),
+		Tagger: &github.CommitAuthor{
+			Name:  github.Ptr(t.tagger.name),
+			Email: github.Ptr(t.tagger.email),
+			Date:  &github.Timestamp{Time: t.tagger.date},
+		},
+		Object: &github.GitObject{
+			Type: github.Ptr("commit"),
+			SHA:  github.Ptr(t.object),
+			URL:  github.Ptr(r.apiURL("/git/commits/%s", t.object)),
+		},
+		Verification: &github.SignatureVerification{
+			Verified: github.Ptr(false),
+			Reason:   github.Ptr("unsigned"),
+		},
+	}
+}
+
+func (r *repository) gitCommit(c *commit) *github.Commit {
+	author := func(sig signature) *github.CommitAuthor {
+		return &github.CommitAuthor{
+			Name:  github.Ptr(sig.name),
+			Email: github.Ptr(sig.email),
+			Date:  &github.Timestamp{Time: sig.date},
+		}
+	}
+	result := &github.Commit{
+		SHA:       github.Ptr(c.sha),
+		NodeID:    github.Ptr("C_" + c.sha),
+		Message:   github.Ptr(c.message),
+		Author:    author(c.author),
+		Committer: author(c.committer),
+		Tree:      &github.Tree{SHA: github.Ptr(c.tree)},
+		HTMLURL:   github.Ptr(r.htmlURL("/commit/%s", c.sha)),
+		URL:       github.Ptr(r.apiURL("/git/commits/%s", c.sha)),
+		Verification: &github.SignatureVerification{
+			Verified: github.Ptr(false),
+			Reason:   github.Ptr("unsigned"),
+		},
+	}
+	for _, parent := range c.parents {
+		result.Parents = append(result.Parents, &github.Commit{SHA: github.Ptr(parent), URL: github.Ptr(r.apiURL("/git/commits/%s", parent))})
+	}
+	return result
+}
+
+func (s *Server) getRef(w http.ResponseWriter, r *http.Request, repo *repository) {
+	if repo.isEmpty(w) {
+		return
+	}
+	ref := "refs/" + r.PathValue("ref")
+	if _, ok := repo.refs[ref]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, repo.reference(ref))
+}
+
+func (s *Server) createRef(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var body github.CreateRef
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if !strings.HasPrefix(body.Ref, "refs/") || strings.Count(body.Ref, "/") < 2 {
+		writeError(w, http.StatusUnprocessableEntity, "Reference name must start with 'refs/' and have at least two slashes.")
+		return
+	}
+	_, isCommit := repo.commits[body.SHA]
+	_, isTag := repo.tags[body.SHA]
+	if !isCommit && !isTag {
+		writeError(w, http.StatusUnprocessableEntity, "Object does not exist")
+		return
+	}
+	if _, ok := repo.refs[body.Ref]; ok {
+		writeError(w, http.StatusUnprocessableEntity, "Reference already exists")
+		return
+	}
+	repo.refs[body.Ref] = body.SHA
+	writeJSON(w, http.StatusCreated, repo.reference(body.Ref))
+}
+
+func (s *Server) updateRef(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var body github.UpdateRef
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	ref := "refs/" + r.PathValue("ref")
+	current, ok := repo.refs[ref]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Reference does not exist")
+		return
+	}
+	if _, ok := repo.commits[body.SHA]; !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Object does not exist")
+		return
+	}
+	if !body.GetForce() && !repo.isAncestor(current, body.SHA) {
+		writeError(w, http.StatusUnprocessableEntity, "Update is not a fast forward")
+		return
+	}
+	repo.refs[ref] = body.SHA
+	writeJSON(w, http.StatusOK, repo.reference(ref))
+}
+
+func (s *Server) deleteRef(w http.ResponseWriter, r *http.Request, repo *repository) {
+	ref := "refs/" + r.PathValue("ref")
+	if _, ok := repo.refs[ref]; !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Reference does not exist")
+		return
+	}
+	delete(repo.refs, ref)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getGitCommit(w http.ResponseWriter, r *http.Request, repo *repository) {
+	c, ok := repo.commits[r.PathValue("sha")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, repo.gitCommit(c))
+}
+
+func (s *Server) createGitCommit(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var body struct {
+		Message string   `json:"message"`
+		Tree    string   `json:"tree"`
+		Parents []string `json:"parents"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if _, ok := repo.trees[body.Tree]; !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Tree SHA does not exist")
+		return
+	}
+	for _, parent := range body.Parents {
+		if _, ok := repo.commits[parent]; !ok {
+			writeError(w, http.StatusUnprocessableEntity, "Parent SHA does not exist or is not a commit object")
+			return
+		}
+	}
+	writeJSON(w, http.StatusCreated, repo.gitCommit(repo.newCommit(body.Tree, body.Message, body.Parents...)))
+}
+
+func (s *Server) getTag(w http.ResponseWriter, r *http.Request, repo *repository) {
+	t, ok := repo.tags[r.PathValue("sha")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, repo.gitTag(t))
+}
+
+// createTag creates an annotated tag object. Like GitHub, it doesn't create the tag's reference.
+func (s *Server) createTag(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var body github.CreateTag
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Type != "commit" {
+		writeError(w, http.StatusUnprocessableEntity, "Only tags of commits are supported")
+		return
+	}
+	if _, ok := repo.commits[body.Object]; !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Object does not exist")
+		return
+	}
+	t := &tag{
+		name:    body.Tag,
+		message: body.Message,
+		object:  body.Object,
+		tagger:  signature{name: s.login, email: s.login + "@users.noreply.github.com", date: s.now()},
+	}
+	repo.writeTag(t)
+	writeJSON(w, http.StatusCreated, repo.gitTag(t))
+}
+
+func (s *Server) getTree(w http.ResponseWriter, r *http.Request, repo *repository) {
+	tree, ok := repo.resolveTree(r.PathValue("sha"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	entries := []*github.TreeEntry{}
+	var walk func(sha, prefix string)
+	walk = func(sha, prefix string) {
+		for _, e := range repo.trees[sha] {
+			entry := &github.TreeEntry{
+				SHA:  github.Ptr(e.sha),
+				Path: github.Ptr(prefix + e.name),
+				Mode: github.Ptr(e.mode),
+				Type: github.Ptr(e.typ),
+				URL:  github.Ptr(repo.apiURL("/git/%ss/%s", e.typ, e.sha)),
+			}
+			if e.typ == "blob" {
+				entry.Size = github.Ptr(len(repo.blobs[e.sha]))
+			}
+			entries = append(entries, entry)
+			if e.typ == "tree" && r.URL.Query().Get("recursive") != "" {
+				walk(e.sha, prefix+e.name+"/")
+			}
+		}
+	}
+	walk(tree, "")
+	writeJSON(w, http.StatusOK, &github.Tree{SHA: github.Ptr(tree), Entries: entries, Truncated: github.Ptr(false)})
+}
+
+func (s *Server) createTree(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var body struct {
+		BaseTree string `json:"base_tree"`
+		Tree     []struct {
+			Path    string  `json:"path"`
+			Mode    string  `json:"mode"`
+			Type    string  `json:"type"`
+			SHA     *string `json:"sha"`
+			Content *string `json:"content"`
+		} `json:"tree"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	files := map[string]file{}
+	if body.BaseTree != "" {
+		if _, ok := repo.trees[body.BaseTree]; !ok {
+			writeError(w, http.StatusUnprocessableEntity, "base_tree is not a valid tree oid")
+			return
+		}
+		files = repo.flatten(body.BaseTree)
+	}
+	for _, entry := range body.Tree {
+		mode := entry.Mode
+		if mode == "" {
+			mode = "100644"
+		}
+		switch {
+		case entry.Content != nil:
+			files[entry.Path] = file{mode: mode, sha: repo.writeBlob([]byte(*entry.Content))}
+		case entry.SHA == nil:
+			delete(files, entry.Path)
+		default:
+			if _, ok := repo.blobs[*entry.SHA]; !ok {
+				writeError(w, http.StatusUnprocessableEntity, "tree.sha "+*entry.SHA+" is not a valid blob")
+				return
+			}
+			files[entry.Path] = file{mode: mode, sha: *entry.SHA}
+		}
+	}
+
+	tree := repo.buildTree(files)
+	entries := []*github.TreeEntry{}
+	for _, e := range repo.trees[tree] {
+		entries = append(entries, &github.TreeEntry{SHA: github.Ptr(e.sha), Path: github.Ptr(e.name), Mode: github.Ptr(e.mode), Type: github.Ptr(e.typ)})
+	}
+	writeJSON(w, http.StatusCreated, &github.Tree{SHA: github.Ptr(tree), Entries: entries, Truncated: github.Ptr(false)})
+}
+
+func (s *Server) getBlob(w http.ResponseWriter, r *http.Request, repo *repository) {
+	sha := r.PathValue("sha")
+	content, ok := repo.blobs[sha]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, &github.Blob{
+		SHA:      github.Ptr(sha),
+		Size:     github.Ptr(len(content)),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString(content)),
+		Encoding: github.Ptr("base64"),
+		URL:      github.Ptr(repo.apiURL("/git/blobs/%s", sha)),
+	})
+}
+
+func (s *Server) createBlob(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var body github.Blob
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	content := []byte(body.GetContent())
+	if body.GetEncoding() == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(body.GetContent())
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, "content is not valid Base64")
+			return
+		}
+		content = decoded
+	}
+	sha := repo.writeBlob(content)
+	writeJSON(w, http.StatusCreated, &github.Blob{SHA: github.Ptr(sha), URL: github.Ptr(repo.apiURL("/git/blobs/%s", sha))})
+}
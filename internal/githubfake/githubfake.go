@@ -0,0 +1,345 @@
+// Package githubfake provides a stateful, in-process fake of the subset of the GitHub REST and
+// GraphQL APIs used by the server's tools, for integration tests of multi-step flows.
+//
+// The fake keeps repositories, git objects and tags, issues, pull requests and their reviews,
+// labels, comments and workflow runs in memory, so a branch created by one tool call can be pushed to, opened as a pull request
+// and merged by the next ones. Point the server's clients at it with Transport, which routes
+// requests for api.github.com and raw.githubusercontent.com to the fake:
+//
+//	fake := githubfake.New("octocat")
+//	defer fake.Close()
+//	fake.CreateRepository("octocat", "hello-world", map[string]string{"README.md": "# Hello"})
+//
+//	server, err := ghmcp.NewMCPServer(ghmcp.MCPServerConfig{Token: "token", Transport: fake.Transport(), ...})
+//
+// Only the endpoints, fields and GraphQL selections the tools use are implemented. Requests
+// outside that subset fail with 404 Not Found, or a GraphQL error naming the unknown field, so a
+// test that starts using a new endpoint fails loudly instead of passing against empty data.
+package githubfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v79/github"
+)
+
+// epoch is the time of the first event in the fake. Its clock is deterministic: every event,
+// such as a commit or a new issue, happens one second after the previous one.
+var epoch = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Server is a fake GitHub API server backed by in-memory state. Its methods and the requests it
+// serves are safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	login  string
+	repos  map[string]*repository
+	nodes  map[string]any
+	nextID int64
+	ticks  int
+}
+
+// New starts a fake GitHub server, on which login is the authenticated user. Close it when done.
+func New(login string) *Server {
+	s := &Server{
+		login: login,
+		repos: make(map[string]*repository),
+		nodes: make(map[string]any),
+	}
+	s.Server = httptest.NewServer(s.handler())
+	return s
+}
+
+// Transport returns an HTTP transport that sends all requests to the fake, whatever their host.
+// The original host is kept in the Host header, which tells raw content requests apart.
+func (s *Server) Transport() http.RoundTripper {
+	target, _ := url.Parse(s.URL)
+	return &transport{target: target, base: s.Client().Transport}
+}
+
+type transport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Host = req.URL.Host
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return t.base.RoundTrip(req)
+}
+
+// CreateRepository adds a repository with a first commit of files on its main branch. Without
+// files, the repository is empty, like a repository created without a README.
+func (s *Server) CreateRepository(owner, name string, files map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.newRepository(owner, name)
+	if len(files) > 0 {
+		contents := make(map[string]*string, len(files))
+		for path, content := range files {
+			contents[path] = &content
+		}
+		repo.commitFiles(repo.defaultBranch, "Initial commit", contents)
+	}
+}
+
+// File returns the content of the file at path on ref, a branch, tag or commit SHA, and whether
+// it exists.
+func (s *Server) File(owner, repo, ref, path string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.repos[repoKey(owner, repo)]
+	if r == nil {
+		return "", false
+	}
+	sha, ok := r.resolve(ref)
+	if !ok {
+		return "", false
+	}
+	entry, ok := r.lookup(r.commits[sha].tree, path)
+	if !ok || entry.typ != "blob" {
+		return "", false
+	}
+	return string(r.blobs[entry.sha]), true
+}
+
+// WorkflowRun describes a workflow run added with AddWorkflowRun.
+type WorkflowRun struct {
+	// Workflow is the path of the workflow file, like .github/workflows/ci.yml
+	Workflow string
+	// Branch is the branch the run ran on, the default branch if empty
+	Branch string
+	// Event triggered the run, push if empty
+	Event string
+	// Status of the run, completed if empty
+	Status string
+	// Conclusion of a completed run, like success or failure
+	Conclusion string
+}
+
+// AddWorkflowRun adds a run of a workflow of a repository and returns its ID.
+func (s *Server) AddWorkflowRun(owner, repo string, run WorkflowRun) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.repos[repoKey(owner, repo)]
+	if r == nil {
+		panic(fmt.Sprintf("githubfake: repository %s/%s does not exist", owner, repo))
+	}
+	if run.Event == "" {
+		run.Event = "push"
+	}
+	if run.Status == "" {
+		run.Status = "completed"
+	}
+	return r.addWorkflowRun(run).GetID()
+}
+
+// now returns the time of the next event.
+func (s *Server) now() time.Time {
+	s.ticks++
+	return epoch.Add(time.Duration(s.ticks) * time.Second)
+}
+
+// newID returns the next database ID, and registers node under a node ID made of prefix and
+// that ID, which GraphQL mutations look it up by.
+func (s *Server) newID(prefix string, node any) (int64, string) {
+	s.nextID++
+	nodeID := fmt.Sprintf("%s_%d", prefix, s.nextID)
+	s.nodes[nodeID] = node
+	return s.nextID, nodeID
+}
+
+func (s *Server) user(login string) *github.User {
+	typ := "User"
+	if login == copilotLogin {
+		typ = "Bot"
+	}
+	return &github.User{
+		Login:   github.Ptr(login),
+		Type:    github.Ptr(typ),
+		HTMLURL: github.Ptr("https://github.com/" + login),
+		URL:     github.Ptr("https://api.github.com/users/" + login),
+	}
+}
+
+func repoKey(owner, name string) string {
+	return strings.ToLower(owner + "/" + name)
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		writeError(w, http.StatusNotFound, "Not Found")
+	})
+	mux.HandleFunc("GET /user", s.getAuthenticatedUser)
+	mux.HandleFunc("POST /user/repos", s.createRepository)
+	mux.HandleFunc("POST /orgs/{org}/repos", s.createRepository)
+	mux.HandleFunc("POST /graphql", s.serveGraphQL)
+
+	routes := map[string]func(http.ResponseWriter, *http.Request, *repository){
+		"GET /repos/{owner}/{repo}":                           s.getRepository,
+		"DELETE /repos/{owner}/{repo}":                        s.deleteRepository,
+		"GET /repos/{owner}/{repo}/tags":                      s.listTags,
+		"GET /repos/{owner}/{repo}/branches":                  s.listBranches,
+		"GET /repos/{owner}/{repo}/commits":                   s.listCommits,
+		"GET /repos/{owner}/{repo}/commits/{ref...}":          s.getCommit,
+		"GET /repos/{owner}/{repo}/commits/{ref}/status":      s.getCombinedStatus,
+		"GET /repos/{owner}/{repo}/contents/{path...}":        s.getContents,
+		"PUT /repos/{owner}/{repo}/contents/{path...}":        s.putContents,
+		"DELETE /repos/{owner}/{repo}/contents/{path...}":     s.deleteContents,
+		"GET /repos/{owner}/{repo}/git/ref/{ref...}":          s.getRef,
+		"POST /repos/{owner}/{repo}/git/refs":                 s.createRef,
+		"PATCH /repos/{owner}/{repo}/git/refs/{ref...}":       s.updateRef,
+		"DELETE /repos/{owner}/{repo}/git/refs/{ref...}":      s.deleteRef,
+		"GET /repos/{owner}/{repo}/git/commits/{sha}":         s.getGitCommit,
+		"POST /repos/{owner}/{repo}/git/commits":              s.createGitCommit,
+		"GET /repos/{owner}/{repo}/git/trees/{sha...}":        s.getTree,
+		"POST /repos/{owner}/{repo}/git/trees":                s.createTree,
+		"GET /repos/{owner}/{repo}/git/tags/{sha}":            s.getTag,
+		"POST /repos/{owner}/{repo}/git/tags":                 s.createTag,
+		"GET /repos/{owner}/{repo}/git/blobs/{sha}":           s.getBlob,
+		"POST /repos/{owner}/{repo}/git/blobs":                s.createBlob,
+		"POST /repos/{owner}/{repo}/issues":                   s.createIssue,
+		"GET /repos/{owner}/{repo}/issues/{number}":           s.getIssue,
+		"PATCH /repos/{owner}/{repo}/issues/{number}":         s.updateIssue,
+		"GET /repos/{owner}/{repo}/issues/{number}/comments":  s.listComments,
+		"POST /repos/{owner}/{repo}/issues/{number}/comments": s.createComment,
+		"GET /repos/{owner}/{repo}/pulls":                     s.listPullRequests,
+		"POST /repos/{owner}/{repo}/pulls":                    s.createPullRequest,
+		"GET /repos/{owner}/{repo}/pulls/{number}":            s.getPullRequest,
+		"PATCH /repos/{owner}/{repo}/pulls/{number}":          s.updatePullRequest,
+		"GET /repos/{owner}/{repo}/pulls/{number}/files":      s.listPullRequestFiles,
+		"PUT /repos/{owner}/{repo}/pulls/{number}/merge":      s.mergePullRequest,
+
+		"GET /repos/{owner}/{repo}/pulls/{number}/reviews":               s.listReviews,
+		"GET /repos/{owner}/{repo}/pulls/{number}/reviews/{id}/comments": s.listReviewComments,
+		"GET /repos/{owner}/{repo}/pulls/{number}/requested_reviewers":   s.listRequestedReviewers,
+		"POST /repos/{owner}/{repo}/pulls/{number}/requested_reviewers":  s.requestReviewers,
+
+		"GET /repos/{owner}/{repo}/actions/workflows":                        s.listWorkflows,
+		"GET /repos/{owner}/{repo}/actions/workflows/{workflow}":             s.getWorkflow,
+		"GET /repos/{owner}/{repo}/actions/workflows/{workflow}/runs":        s.listWorkflowRuns,
+		"POST /repos/{owner}/{repo}/actions/workflows/{workflow}/dispatches": s.dispatchWorkflow,
+		"GET /repos/{owner}/{repo}/actions/runs":                             s.listWorkflowRuns,
+		"GET /repos/{owner}/{repo}/actions/runs/{id}":                        s.getWorkflowRun,
+		"POST /repos/{owner}/{repo}/actions/runs/{id}/rerun":                 s.rerunWorkflowRun,
+		"POST /repos/{owner}/{repo}/actions/runs/{id}/cancel":                s.cancelWorkflowRun,
+	}
+	for pattern, handle := range routes {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			repo := s.repos[repoKey(r.PathValue("owner"), r.PathValue("repo"))]
+			if repo == nil {
+				writeError(w, http.StatusNotFound, "Not Found")
+				return
+			}
+			handle(w, r, repo)
+		})
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			writeError(w, http.StatusUnauthorized, "Requires authentication")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if strings.HasPrefix(r.Host, "raw.") {
+			s.serveRaw(w, r)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) getAuthenticatedUser(w http.ResponseWriter, _ *http.Request) {
+	user := s.user(s.login)
+	user.ID = github.Ptr(int64(1))
+	user.NodeID = github.Ptr("U_1")
+	user.Name = github.Ptr(s.login)
+	writeJSON(w, http.StatusOK, user)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest",
+	})
+}
+
+// writeValidationError writes a 422 Validation Failed error about a field of a resource.
+func writeValidationError(w http.ResponseWriter, resource, field, message string) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]any{
+		"message": "Validation Failed",
+		"errors": []map[string]string{
+			{"resource": resource, "field": field, "code": "custom", "message": message},
+		},
+		"documentation_url": "https://docs.github.com/rest",
+	})
+}
+
+// decodeBody decodes the JSON body of a request, writing a 400 Bad Request on failure.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return false
+	}
+	return true
+}
+
+// paginate returns the page of items selected by the page and per_page query parameters, and
+// sets the Link header to the next page if there is one.
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T) []T {
+	query := r.URL.Query()
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = 30
+	}
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	if end < len(items) {
+		next := *r.URL
+		next.Scheme, next.Host = "https", "api.github.com"
+		query.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = query.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+	return items[start:end]
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
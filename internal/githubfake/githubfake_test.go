@@ -0,0 +1,683 @@
+package githubfake
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v79/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClients(t *testing.T, fake *Server) (*github.Client, *githubv4.Client) {
+	t.Helper()
+	httpClient := &http.Client{Transport: fake.Transport()}
+	return github.NewClient(httpClient).WithAuthToken("test-token"), githubv4.NewClient(&http.Client{
+		Transport: &authTransport{base: fake.Transport()},
+	})
+}
+
+type authTransport struct {
+	base http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer test-token")
+	return t.base.RoundTrip(req)
+}
+
+func Test_RequiresAuthentication(t *testing.T) {
+	fake := New("octocat")
+	defer fake.Close()
+
+	_, _, err := github.NewClient(&http.Client{Transport: fake.Transport()}).Users.Get(context.Background(), "")
+	var errResp *github.ErrorResponse
+	require.ErrorAs(t, err, &errResp)
+	assert.Equal(t, http.StatusUnauthorized, errResp.Response.StatusCode)
+}
+
+func Test_GitData(t *testing.T) {
+	fake := New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{
+		"README.md":   "# Hello\n",
+		"src/main.go": "package main\n",
+	})
+	client, _ := newClients(t, fake)
+	ctx := context.Background()
+
+	ref, _, err := client.Git.GetRef(ctx, "octocat", "hello-world", "refs/heads/main")
+	require.NoError(t, err)
+	base, _, err := client.Git.GetCommit(ctx, "octocat", "hello-world", ref.GetObject().GetSHA())
+	require.NoError(t, err)
+	assert.Equal(t, "Initial commit", base.GetMessage())
+
+	tree, _, err := client.Git.CreateTree(ctx, "octocat", "hello-world", base.GetTree().GetSHA(), []*github.TreeEntry{
+		{Path: github.Ptr("src/main.go"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), Content: github.Ptr("package main\n\nfunc main() {}\n")},
+		{Path: github.Ptr("README.md"), Mode: github.Ptr("100644"), Type: github.Ptr("blob")},
+	})
+	require.NoError(t, err)
+	commit, _, err := client.Git.CreateCommit(ctx, "octocat", "hello-world", github.Commit{
+		Message: github.Ptr("Add main"),
+		Tree:    tree,
+		Parents: []*github.Commit{base},
+	}, nil)
+	require.NoError(t, err)
+	_, _, err = client.Git.UpdateRef(ctx, "octocat", "hello-world", "refs/heads/main", github.UpdateRef{SHA: commit.GetSHA()})
+	require.NoError(t, err)
+
+	content, ok := fake.File("octocat", "hello-world", "main", "src/main.go")
+	require.True(t, ok)
+	assert.Equal(t, "package main\n\nfunc main() {}\n", content)
+	_, ok = fake.File("octocat", "hello-world", "main", "README.md")
+	assert.False(t, ok, "README.md should be deleted")
+	_, ok = fake.File("octocat", "hello-world", base.GetSHA(), "README.md")
+	assert.True(t, ok, "README.md should still exist in the parent commit")
+
+	// Moving the branch back is not a fast forward
+	_, resp, err := client.Git.UpdateRef(ctx, "octocat", "hello-world", "refs/heads/main", github.UpdateRef{SHA: base.GetSHA()})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func Test_EmptyRepository(t *testing.T) {
+	fake := New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "empty", nil)
+	client, _ := newClients(t, fake)
+
+	_, resp, err := client.Git.GetRef(context.Background(), "octocat", "empty", "refs/heads/main")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Contains(t, err.Error(), "Git Repository is empty.")
+}
+
+func Test_Contents(t *testing.T) {
+	fake := New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{"docs/guide.md": "v1\n"})
+	client, _ := newClients(t, fake)
+	ctx := context.Background()
+
+	file, _, _, err := client.Repositories.GetContents(ctx, "octocat", "hello-world", "docs/guide.md", nil)
+	require.NoError(t, err)
+	decoded, err := file.GetContent()
+	require.NoError(t, err)
+	assert.Equal(t, "v1\n", decoded)
+
+	_, dir, _, err := client.Repositories.GetContents(ctx, "octocat", "hello-world", "docs", nil)
+	require.NoError(t, err)
+	require.Len(t, dir, 1)
+	assert.Equal(t, "docs/guide.md", dir[0].GetPath())
+
+	// Updating with a stale SHA conflicts
+	_, resp, err := client.Repositories.CreateFile(ctx, "octocat", "hello-world", "docs/guide.md", &github.RepositoryContentFileOptions{
+		Message: github.Ptr("Update guide"),
+		Content: []byte("v2\n"),
+		SHA:     github.Ptr("0000000000000000000000000000000000000000"),
+	})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	updated, resp, err := client.Repositories.CreateFile(ctx, "octocat", "hello-world", "docs/guide.md", &github.RepositoryContentFileOptions{
+		Message: github.Ptr("Update guide"),
+		Content: []byte("v2\n"),
+		SHA:     file.SHA,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Update guide", updated.GetMessage())
+
+	// The raw content is served from the raw host
+	req, err := http.NewRequest(http.MethodGet, "https://raw.githubusercontent.com/octocat/hello-world/refs/heads/main/docs/guide.md", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "token test-token")
+	rawResp, err := fake.Transport().RoundTrip(req)
+	require.NoError(t, err)
+	defer func() { _ = rawResp.Body.Close() }()
+	raw, err := io.ReadAll(rawResp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rawResp.StatusCode)
+	assert.Equal(t, "v2\n", string(raw))
+}
+
+func Test_PullRequests(t *testing.T) {
+	fake := New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{"greeting.txt": "hello\n"})
+	client, _ := newClients(t, fake)
+	ctx := context.Background()
+
+	branch := func(name, content string) {
+		t.Helper()
+		ref, _, err := client.Git.GetRef(ctx, "octocat", "hello-world", "refs/heads/main")
+		require.NoError(t, err)
+		_, _, err = client.Git.CreateRef(ctx, "octocat", "hello-world", github.CreateRef{Ref: "refs/heads/" + name, SHA: ref.GetObject().GetSHA()})
+		require.NoError(t, err)
+		current, _, _, err := client.Repositories.GetContents(ctx, "octocat", "hello-world", "greeting.txt", &github.RepositoryContentGetOptions{Ref: name})
+		require.NoError(t, err)
+		_, _, err = client.Repositories.UpdateFile(ctx, "octocat", "hello-world", "greeting.txt", &github.RepositoryContentFileOptions{
+			Message: github.Ptr("Change greeting to " + content),
+			Content: []byte(content + "\n"),
+			SHA:     current.SHA,
+			Branch:  github.Ptr(name),
+		})
+		require.NoError(t, err)
+	}
+	branch("hi", "hi")
+	branch("hey", "hey")
+
+	_, resp, err := client.PullRequests.Create(ctx, "octocat", "hello-world", &github.NewPullRequest{Title: github.Ptr("Nothing"), Head: github.Ptr("main"), Base: github.Ptr("main")})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	hi, _, err := client.PullRequests.Create(ctx, "octocat", "hello-world", &github.NewPullRequest{Title: github.Ptr("Say hi"), Head: github.Ptr("hi"), Base: github.Ptr("main")})
+	require.NoError(t, err)
+	hey, _, err := client.PullRequests.Create(ctx, "octocat", "hello-world", &github.NewPullRequest{Title: github.Ptr("Say hey"), Head: github.Ptr("hey"), Base: github.Ptr("main")})
+	require.NoError(t, err)
+	assert.Equal(t, hi.GetNumber()+1, hey.GetNumber())
+
+	files, _, err := client.PullRequests.ListFiles(ctx, "octocat", "hello-world", hi.GetNumber(), nil)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "greeting.txt", files[0].GetFilename())
+	assert.Equal(t, 1, files[0].GetAdditions())
+	assert.Equal(t, 1, files[0].GetDeletions())
+
+	result, _, err := client.PullRequests.Merge(ctx, "octocat", "hello-world", hi.GetNumber(), "", &github.PullRequestOptions{MergeMethod: "squash"})
+	require.NoError(t, err)
+	assert.True(t, result.GetMerged())
+	content, _ := fake.File("octocat", "hello-world", "main", "greeting.txt")
+	assert.Equal(t, "hi\n", content)
+
+	merged, _, err := client.PullRequests.Get(ctx, "octocat", "hello-world", hi.GetNumber())
+	require.NoError(t, err)
+	assert.True(t, merged.GetMerged())
+	assert.Equal(t, "closed", merged.GetState())
+
+	// Both pull requests changed the same line
+	_, resp, err = client.PullRequests.Merge(ctx, "octocat", "hello-world", hey.GetNumber(), "", nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func Test_Issues(t *testing.T) {
+	fake := New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{"README.md": "# Hello\n"})
+	client, _ := newClients(t, fake)
+	ctx := context.Background()
+
+	issue, _, err := client.Issues.Create(ctx, "octocat", "hello-world", &github.IssueRequest{
+		Title:  github.Ptr("Bug"),
+		Labels: &[]string{"bug"},
+	})
+	require.NoError(t, err)
+	require.Len(t, issue.Labels, 1)
+	assert.Equal(t, "bug", issue.Labels[0].GetName())
+
+	_, _, err = client.Issues.CreateComment(ctx, "octocat", "hello-world", issue.GetNumber(), &github.IssueComment{Body: github.Ptr("Confirmed")})
+	require.NoError(t, err)
+	comments, _, err := client.Issues.ListComments(ctx, "octocat", "hello-world", issue.GetNumber(), nil)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "Confirmed", comments[0].GetBody())
+
+	closed, _, err := client.Issues.Edit(ctx, "octocat", "hello-world", issue.GetNumber(), &github.IssueRequest{State: github.Ptr("closed")})
+	require.NoError(t, err)
+	assert.Equal(t, "closed", closed.GetState())
+	assert.Equal(t, "completed", closed.GetStateReason())
+}
+
+func Test_GraphQL(t *testing.T) {
+	fake := New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{"README.md": "# Hello\n"})
+	client, gqlClient := newClients(t, fake)
+	ctx := context.Background()
+
+	for _, title := range []string{"First", "Second", "Third"} {
+		_, _, err := client.Issues.Create(ctx, "octocat", "hello-world", &github.IssueRequest{Title: github.Ptr(title)})
+		require.NoError(t, err)
+	}
+
+	var issues struct {
+		Repository struct {
+			Issues struct {
+				Nodes []struct {
+					Number githubv4.Int
+					Title  githubv4.String
+					State  githubv4.String
+				}
+				PageInfo struct {
+					HasNextPage githubv4.Boolean
+					EndCursor   githubv4.String
+				}
+				TotalCount githubv4.Int
+			} `graphql:"issues(first: $first, after: $after, states: $states, orderBy: {field: CREATED_AT, direction: DESC})"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner":  githubv4.String("octocat"),
+		"repo":   githubv4.String("hello-world"),
+		"first":  githubv4.Int(2),
+		"after":  (*githubv4.String)(nil),
+		"states": []githubv4.IssueState{githubv4.IssueStateOpen},
+	}
+	require.NoError(t, gqlClient.Query(ctx, &issues, vars))
+	require.Len(t, issues.Repository.Issues.Nodes, 2)
+	assert.Equal(t, githubv4.String("Third"), issues.Repository.Issues.Nodes[0].Title)
+	assert.Equal(t, githubv4.String("OPEN"), issues.Repository.Issues.Nodes[0].State)
+	assert.Equal(t, githubv4.Int(3), issues.Repository.Issues.TotalCount)
+	require.True(t, bool(issues.Repository.Issues.PageInfo.HasNextPage))
+
+	vars["after"] = githubv4.NewString(issues.Repository.Issues.PageInfo.EndCursor)
+	require.NoError(t, gqlClient.Query(ctx, &issues, vars))
+	require.Len(t, issues.Repository.Issues.Nodes, 1)
+	assert.Equal(t, githubv4.String("First"), issues.Repository.Issues.Nodes[0].Title)
+	assert.False(t, bool(issues.Repository.Issues.PageInfo.HasNextPage))
+
+	var ids struct {
+		Repository struct {
+			ID    githubv4.ID
+			Issue struct {
+				ID githubv4.ID
+			} `graphql:"issue(number: 1)"`
+			Duplicate struct {
+				ID githubv4.ID
+			} `graphql:"duplicate: issue(number: 2)"`
+		} `graphql:"repository(owner: \"octocat\", name: \"hello-world\")"`
+	}
+	require.NoError(t, gqlClient.Query(ctx, &ids, nil))
+
+	var closeIssue struct {
+		CloseIssue struct {
+			Issue struct {
+				State       githubv4.String
+				StateReason githubv4.String
+			}
+		} `graphql:"closeIssue(input: $input)"`
+	}
+	require.NoError(t, gqlClient.Mutate(ctx, &closeIssue, map[string]any{
+		"issueId":          ids.Repository.Issue.ID,
+		"stateReason":      "DUPLICATE",
+		"duplicateIssueId": ids.Repository.Duplicate.ID,
+	}, nil))
+	assert.Equal(t, githubv4.String("CLOSED"), closeIssue.CloseIssue.Issue.State)
+	assert.Equal(t, githubv4.String("DUPLICATE"), closeIssue.CloseIssue.Issue.StateReason)
+
+	var createLabel struct {
+		CreateLabel struct {
+			Label struct {
+				ID   githubv4.ID
+				Name githubv4.String
+			}
+		} `graphql:"createLabel(input: $input)"`
+	}
+	require.NoError(t, gqlClient.Mutate(ctx, &createLabel, githubv4.CreateLabelInput{
+		RepositoryID: ids.Repository.ID,
+		Name:         "triage",
+		Color:        "ff0000",
+	}, nil))
+	assert.Equal(t, githubv4.String("triage"), createLabel.CreateLabel.Label.Name)
+	err := gqlClient.Mutate(ctx, &createLabel, githubv4.CreateLabelInput{
+		RepositoryID: ids.Repository.ID,
+		Name:         "Triage",
+		Color:        "00ff00",
+	}, nil)
+	require.ErrorContains(t, err, "Name has already been taken")
+
+	var label struct {
+		Repository struct {
+			Label struct {
+				Name  githubv4.String
+				Color githubv4.String
+			} `graphql:"label(name: $name)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	labelVars := map[string]any{"owner": githubv4.String("octocat"), "repo": githubv4.String("hello-world"), "name": githubv4.String("TRIAGE")}
+	require.NoError(t, gqlClient.Query(ctx, &label, labelVars))
+	assert.Equal(t, githubv4.String("ff0000"), label.Repository.Label.Color)
+
+	var deleteLabel struct {
+		DeleteLabel struct {
+			ClientMutationID githubv4.String
+		} `graphql:"deleteLabel(input: $input)"`
+	}
+	require.NoError(t, gqlClient.Mutate(ctx, &deleteLabel, githubv4.DeleteLabelInput{ID: createLabel.CreateLabel.Label.ID}, nil))
+	var deleted struct {
+		Repository struct {
+			Label *struct {
+				Name githubv4.String
+			} `graphql:"label(name: $name)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	require.NoError(t, gqlClient.Query(ctx, &deleted, labelVars))
+	assert.Nil(t, deleted.Repository.Label, "a missing label should be null")
+}
+
+func Test_GraphQLErrors(t *testing.T) {
+	fake := New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{"README.md": "# Hello\n"})
+	_, gqlClient := newClients(t, fake)
+	ctx := context.Background()
+
+	var missingIssue struct {
+		Repository struct {
+			Issue struct {
+				ID githubv4.ID
+			} `graphql:"issue(number: 42)"`
+		} `graphql:"repository(owner: \"octocat\", name: \"hello-world\")"`
+	}
+	err := gqlClient.Query(ctx, &missingIssue, nil)
+	require.ErrorContains(t, err, "Could not resolve to an Issue with the number of 42.")
+
+	var unknownField struct {
+		Repository struct {
+			StargazerCount githubv4.Int
+		} `graphql:"repository(owner: \"octocat\", name: \"hello-world\")"`
+	}
+	err = gqlClient.Query(ctx, &unknownField, nil)
+	require.ErrorContains(t, err, "Field 'stargazerCount' doesn't exist on type 'Repository'")
+}
+
+func Test_ParseQuery(t *testing.T) {
+	op, err := parseQuery(`query($owner:String!$first:Int){repository(owner: $owner, name: "hello"){a: issues(first: $first, orderBy: {field: CREATED_AT}, labels: ["bug", "help wanted"]){nodes{number},totalCount}... on Repository{id}}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "query", op.typ)
+	require.Len(t, op.selections, 1)
+
+	repository := op.selections[0]
+	assert.Equal(t, "repository", repository.name)
+	assert.Equal(t, map[string]any{"owner": variable("owner"), "name": "hello"}, repository.args)
+	require.Len(t, repository.selections, 2)
+
+	issues := repository.selections[0]
+	assert.Equal(t, "a", issues.key())
+	assert.Equal(t, "issues", issues.name)
+	assert.Equal(t, map[string]any{"first": 5.0, "orderBy": map[string]any{"field": "CREATED_AT"}, "labels": []any{"bug", "help wanted"}},
+		bind(issues.args, map[string]any{"first": 5.0}))
+	assert.Len(t, issues.selections, 2)
+
+	id := repository.selections[1]
+	assert.Equal(t, "id", id.name)
+	assert.Equal(t, "Repository", id.on)
+
+	_, err = parseQuery(`{repository(owner: "octocat"`)
+	assert.Error(t, err)
+}
+
+func Test_WorkflowRuns(t *testing.T) {
+	fake := New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{
+		".github/workflows/ci.yml": "name: CI\non: push\n",
+	})
+	client, _ := newClients(t, fake)
+	ctx := context.Background()
+
+	workflows, _, err := client.Actions.ListWorkflows(ctx, "octocat", "hello-world", nil)
+	require.NoError(t, err)
+	require.Len(t, workflows.Workflows, 1)
+	assert.Equal(t, "CI", workflows.Workflows[0].GetName())
+
+	passed := fake.AddWorkflowRun("octocat", "hello-world", WorkflowRun{Workflow: ".github/workflows/ci.yml", Conclusion: "success"})
+	running := fake.AddWorkflowRun("octocat", "hello-world", WorkflowRun{Workflow: ".github/workflows/ci.yml", Status: "in_progress"})
+
+	runs, _, err := client.Actions.ListRepositoryWorkflowRuns(ctx, "octocat", "hello-world", &github.ListWorkflowRunsOptions{Status: "success"})
+	require.NoError(t, err)
+	require.Len(t, runs.WorkflowRuns, 1)
+	assert.Equal(t, passed, runs.WorkflowRuns[0].GetID())
+	assert.Equal(t, "CI", runs.WorkflowRuns[0].GetName())
+
+	// Cancelling is asynchronous, which go-github reports as an AcceptedError
+	_, err = client.Actions.CancelWorkflowRunByID(ctx, "octocat", "hello-world", running)
+	var accepted *github.AcceptedError
+	require.ErrorAs(t, err, &accepted)
+	run, _, err := client.Actions.GetWorkflowRunByID(ctx, "octocat", "hello-world", running)
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", run.GetConclusion())
+
+	resp, err := client.Actions.CancelWorkflowRunByID(ctx, "octocat", "hello-world", passed)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	_, err = client.Actions.CreateWorkflowDispatchEventByFileName(ctx, "octocat", "hello-world", "ci.yml", github.CreateWorkflowDispatchEventRequest{Ref: "main"})
+	require.NoError(t, err)
+	runs, _, err = client.Actions.ListWorkflowRunsByFileName(ctx, "octocat", "hello-world", "ci.yml", &github.ListWorkflowRunsOptions{Event: "workflow_dispatch"})
+	require.NoError(t, err)
+	require.Len(t, runs.WorkflowRuns, 1)
+	assert.Equal(t, "queued", runs.WorkflowRuns[0].GetStatus())
+	assert.Equal(t, 3, runs.WorkflowRuns[0].GetRunNumber())
+}
+
+func Test_Tags(t *testing.T) {
+	fake := New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{"README.md": "# Hello\n"})
+	client, _ := newClients(t, fake)
+	ctx := context.Background()
+
+	main, _, err := client.Git.GetRef(ctx, "octocat", "hello-world", "refs/heads/main")
+	require.NoError(t, err)
+	tag, resp, err := client.Git.CreateTag(ctx, "octocat", "hello-world", github.CreateTag{
+		Tag:     "v1.0.0",
+		Message: "Release v1.0.0",
+		Object:  main.GetObject().GetSHA(),
+		Type:    "commit",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.NotEqual(t, main.GetObject().GetSHA(), tag.GetSHA())
+
+	// The tag isn't listed until it has a reference
+	tags, _, err := client.Repositories.ListTags(ctx, "octocat", "hello-world", nil)
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	_, _, err = client.Git.CreateRef(ctx, "octocat", "hello-world", github.CreateRef{Ref: "refs/tags/v1.0.0", SHA: tag.GetSHA()})
+	require.NoError(t, err)
+	ref, _, err := client.Git.GetRef(ctx, "octocat", "hello-world", "refs/tags/v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "tag", ref.GetObject().GetType())
+	assert.Equal(t, tag.GetSHA(), ref.GetObject().GetSHA())
+
+	got, _, err := client.Git.GetTag(ctx, "octocat", "hello-world", ref.GetObject().GetSHA())
+	require.NoError(t, err)
+	assert.Equal(t, "Release v1.0.0", got.GetMessage())
+	assert.Equal(t, main.GetObject().GetSHA(), got.GetObject().GetSHA())
+
+	// Listed tags and commits are peeled to the tagged commit
+	tags, _, err = client.Repositories.ListTags(ctx, "octocat", "hello-world", nil)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "v1.0.0", tags[0].GetName())
+	assert.Equal(t, main.GetObject().GetSHA(), tags[0].GetCommit().GetSHA())
+	commit, _, err := client.Repositories.GetCommit(ctx, "octocat", "hello-world", "v1.0.0", nil)
+	require.NoError(t, err)
+	assert.Equal(t, main.GetObject().GetSHA(), commit.GetSHA())
+
+	_, err = client.Repositories.Delete(ctx, "octocat", "hello-world")
+	require.NoError(t, err)
+	_, resp, err = client.Repositories.Get(ctx, "octocat", "hello-world")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func Test_Reviews(t *testing.T) {
+	fake := New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{"greeting.txt": "hello\n"})
+	client, gqlClient := newClients(t, fake)
+	ctx := context.Background()
+
+	main, _, err := client.Git.GetRef(ctx, "octocat", "hello-world", "refs/heads/main")
+	require.NoError(t, err)
+	_, _, err = client.Git.CreateRef(ctx, "octocat", "hello-world", github.CreateRef{Ref: "refs/heads/hi", SHA: main.GetObject().GetSHA()})
+	require.NoError(t, err)
+	current, _, _, err := client.Repositories.GetContents(ctx, "octocat", "hello-world", "greeting.txt", &github.RepositoryContentGetOptions{Ref: "hi"})
+	require.NoError(t, err)
+	_, _, err = client.Repositories.UpdateFile(ctx, "octocat", "hello-world", "greeting.txt", &github.RepositoryContentFileOptions{
+		Message: github.Ptr("Say hi"),
+		Content: []byte("hi\n"),
+		SHA:     current.SHA,
+		Branch:  github.Ptr("hi"),
+	})
+	require.NoError(t, err)
+	pr, _, err := client.PullRequests.Create(ctx, "octocat", "hello-world", &github.NewPullRequest{Title: github.Ptr("Say hi"), Head: github.Ptr("hi"), Base: github.Ptr("main")})
+	require.NoError(t, err)
+
+	// Copilot is requested by its reviewer login, and listed as a bot
+	_, resp, err := client.PullRequests.RequestReviewers(ctx, "octocat", "hello-world", pr.GetNumber(), github.ReviewersRequest{Reviewers: []string{"copilot-pull-request-reviewer[bot]"}})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	reviewers, _, err := client.PullRequests.ListReviewers(ctx, "octocat", "hello-world", pr.GetNumber(), nil)
+	require.NoError(t, err)
+	require.Len(t, reviewers.Users, 1)
+	assert.Equal(t, "Copilot", reviewers.Users[0].GetLogin())
+	assert.Equal(t, "Bot", reviewers.Users[0].GetType())
+	_, resp, err = client.PullRequests.RequestReviewers(ctx, "octocat", "hello-world", pr.GetNumber(), github.ReviewersRequest{Reviewers: []string{"octocat"}})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	var pull struct {
+		Repository struct {
+			PullRequest struct {
+				ID githubv4.ID
+			} `graphql:"pullRequest(number: $number)"`
+		} `graphql:"repository(owner: \"octocat\", name: \"hello-world\")"`
+	}
+	require.NoError(t, gqlClient.Query(ctx, &pull, map[string]any{"number": githubv4.Int(pr.GetNumber())}))
+
+	var addReview struct {
+		AddPullRequestReview struct {
+			PullRequestReview struct {
+				ID    githubv4.ID
+				State githubv4.String
+			}
+		} `graphql:"addPullRequestReview(input: $input)"`
+	}
+	require.NoError(t, gqlClient.Mutate(ctx, &addReview, githubv4.AddPullRequestReviewInput{PullRequestID: pull.Repository.PullRequest.ID}, nil))
+	assert.Equal(t, githubv4.String("PENDING"), addReview.AddPullRequestReview.PullRequestReview.State)
+	err = gqlClient.Mutate(ctx, &addReview, githubv4.AddPullRequestReviewInput{PullRequestID: pull.Repository.PullRequest.ID}, nil)
+	require.ErrorContains(t, err, "one pending review")
+	reviewID := addReview.AddPullRequestReview.PullRequestReview.ID
+
+	var addThread struct {
+		AddPullRequestReviewThread struct {
+			Thread *struct {
+				ID githubv4.ID
+			}
+		} `graphql:"addPullRequestReviewThread(input: $input)"`
+	}
+	line := githubv4.Int(1)
+	require.NoError(t, gqlClient.Mutate(ctx, &addThread, githubv4.AddPullRequestReviewThreadInput{
+		PullRequestReviewID: &reviewID,
+		Path:                "greeting.txt",
+		Body:                "Hi!",
+		Line:                &line,
+	}, nil))
+	require.NotNil(t, addThread.AddPullRequestReviewThread.Thread)
+
+	// Comments on files the pull request doesn't change have no thread
+	addThread.AddPullRequestReviewThread.Thread = nil
+	require.NoError(t, gqlClient.Mutate(ctx, &addThread, githubv4.AddPullRequestReviewThreadInput{
+		PullRequestReviewID: &reviewID,
+		Path:                "README.md",
+		Body:                "Hi!",
+		Line:                &line,
+	}, nil))
+	assert.Nil(t, addThread.AddPullRequestReviewThread.Thread)
+
+	var submit struct {
+		SubmitPullRequestReview struct {
+			PullRequestReview struct {
+				State githubv4.String
+			}
+		} `graphql:"submitPullRequestReview(input: $input)"`
+	}
+	err = gqlClient.Mutate(ctx, &submit, githubv4.SubmitPullRequestReviewInput{PullRequestReviewID: &reviewID, Event: githubv4.PullRequestReviewEventApprove}, nil)
+	require.ErrorContains(t, err, "Can not approve your own pull request")
+	require.NoError(t, gqlClient.Mutate(ctx, &submit, githubv4.SubmitPullRequestReviewInput{PullRequestReviewID: &reviewID, Event: githubv4.PullRequestReviewEventComment}, nil))
+	assert.Equal(t, githubv4.String("COMMENTED"), submit.SubmitPullRequestReview.PullRequestReview.State)
+
+	var deleteReview struct {
+		DeletePullRequestReview struct {
+			PullRequestReview struct {
+				ID githubv4.ID
+			}
+		} `graphql:"deletePullRequestReview(input: $input)"`
+	}
+	err = gqlClient.Mutate(ctx, &deleteReview, githubv4.DeletePullRequestReviewInput{PullRequestReviewID: &reviewID}, nil)
+	require.ErrorContains(t, err, "not pending")
+
+	reviews, _, err := client.PullRequests.ListReviews(ctx, "octocat", "hello-world", pr.GetNumber(), nil)
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, "COMMENTED", reviews[0].GetState())
+	comments, _, err := client.PullRequests.ListReviewComments(ctx, "octocat", "hello-world", pr.GetNumber(), reviews[0].GetID(), nil)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "greeting.txt", comments[0].GetPath())
+	assert.Equal(t, 1, comments[0].GetLine())
+	assert.Equal(t, "RIGHT", comments[0].GetSide())
+}
+
+func Test_AssignCopilot(t *testing.T) {
+	fake := New("octocat")
+	defer fake.Close()
+	fake.CreateRepository("octocat", "hello-world", map[string]string{"README.md": "# Hello\n"})
+	client, gqlClient := newClients(t, fake)
+	ctx := context.Background()
+
+	_, _, err := client.Issues.Create(ctx, "octocat", "hello-world", &github.IssueRequest{Title: github.Ptr("Fix it")})
+	require.NoError(t, err)
+
+	var actors struct {
+		Repository struct {
+			Issue struct {
+				ID githubv4.ID
+			} `graphql:"issue(number: 1)"`
+			SuggestedActors struct {
+				Nodes []struct {
+					Bot struct {
+						ID    githubv4.ID
+						Login githubv4.String
+					} `graphql:"... on Bot"`
+				}
+			} `graphql:"suggestedActors(first: 100, capabilities: CAN_BE_ASSIGNED)"`
+		} `graphql:"repository(owner: \"octocat\", name: \"hello-world\")"`
+	}
+	require.NoError(t, gqlClient.Query(ctx, &actors, nil))
+	var copilot githubv4.ID
+	for _, node := range actors.Repository.SuggestedActors.Nodes {
+		if node.Bot.Login == "copilot-swe-agent" {
+			copilot = node.Bot.ID
+		}
+	}
+	require.NotNil(t, copilot)
+
+	var updateIssue struct {
+		UpdateIssue struct {
+			Issue struct {
+				Number githubv4.Int
+			}
+		} `graphql:"updateIssue(input: $input)"`
+	}
+	require.NoError(t, gqlClient.Mutate(ctx, &updateIssue, githubv4.UpdateIssueInput{
+		ID:          actors.Repository.Issue.ID,
+		AssigneeIDs: &[]githubv4.ID{copilot},
+	}, nil))
+	assert.Equal(t, githubv4.Int(1), updateIssue.UpdateIssue.Issue.Number)
+
+	issue, _, err := client.Issues.Get(ctx, "octocat", "hello-world", 1)
+	require.NoError(t, err)
+	require.Len(t, issue.Assignees, 1)
+	assert.Equal(t, "Copilot", issue.Assignees[0].GetLogin())
+	assert.Equal(t, "Bot", issue.Assignees[0].GetType())
+}
@@ -0,0 +1,836 @@
+package githubfake
+
+import (
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The GraphQL API is served by a small executor over the documents githubv4 generates: one
+// operation with its variables, nested selections with aliases, arguments and inline fragments.
+// There is no schema: each object type is an object value resolving its fields by name, and
+// selecting a field it doesn't resolve is an error.
+
+// selection is a field selected in a GraphQL document.
+type selection struct {
+	alias      string
+	name       string
+	args       map[string]any
+	selections []*selection
+	// on is the type condition of the inline fragment the field was selected in
+	on string
+}
+
+// key returns the key of the field in the response.
+func (s *selection) key() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+// variable is a reference to a variable in an argument value.
+type variable string
+
+// enum is an enum value in an argument value.
+type enum string
+
+type operation struct {
+	typ        string
+	selections []*selection
+}
+
+// parser parses GraphQL documents.
+type parser struct {
+	src string
+	pos int
+}
+
+func parseQuery(src string) (op *operation, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			perr, ok := r.(parseError)
+			if !ok {
+				panic(r)
+			}
+			err = perr
+		}
+	}()
+
+	p := &parser{src: src}
+	op = &operation{typ: "query"}
+	if name := p.peekName(); name == "query" || name == "mutation" {
+		op.typ = p.name()
+		if p.peekName() != "" {
+			p.name()
+		}
+		if p.peek() == '(' {
+			p.skipVariableDefinitions()
+		}
+	}
+	op.selections = p.selectionSet("")
+	if p.skipSpace(); p.pos < len(p.src) {
+		p.fail("unexpected %q after the operation", p.src[p.pos:])
+	}
+	return op, nil
+}
+
+type parseError string
+
+func (e parseError) Error() string { return string(e) }
+
+func (p *parser) fail(format string, args ...any) {
+	panic(parseError(fmt.Sprintf("Parse error at offset %d: %s", p.pos, fmt.Sprintf(format, args...))))
+}
+
+// skipSpace skips white space and commas, which are insignificant in GraphQL.
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\n', '\r', ',':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) peek() byte {
+	p.skipSpace()
+	if p.pos == len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) expect(c byte) {
+	if p.peek() != c {
+		p.fail("expected %q", c)
+	}
+	p.pos++
+}
+
+func isNameChar(c byte, first bool) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || !first && '0' <= c && c <= '9'
+}
+
+func (p *parser) peekName() string {
+	p.skipSpace()
+	end := p.pos
+	for end < len(p.src) && isNameChar(p.src[end], end == p.pos) {
+		end++
+	}
+	return p.src[p.pos:end]
+}
+
+func (p *parser) name() string {
+	name := p.peekName()
+	if name == "" {
+		p.fail("expected a name")
+	}
+	p.pos += len(name)
+	return name
+}
+
+// skipVariableDefinitions skips the variable definitions of an operation: variables are typed by
+// the values they are given.
+func (p *parser) skipVariableDefinitions() {
+	p.expect('(')
+	for p.peek() != ')' {
+		if p.pos == len(p.src) {
+			p.fail("unterminated variable definitions")
+		}
+		p.pos++
+	}
+	p.pos++
+}
+
+func (p *parser) selectionSet(on string) []*selection {
+	p.expect('{')
+	var selections []*selection
+	for p.peek() != '}' {
+		if strings.HasPrefix(p.src[p.pos:], "...") {
+			p.pos += len("...")
+			if p.name() != "on" {
+				p.fail("only inline fragments are supported")
+			}
+			selections = append(selections, p.selectionSet(p.name())...)
+			continue
+		}
+
+		sel := &selection{name: p.name(), on: on}
+		if p.peek() == ':' {
+			p.pos++
+			sel.alias, sel.name = sel.name, p.name()
+		}
+		if p.peek() == '(' {
+			p.pos++
+			sel.args = map[string]any{}
+			for p.peek() != ')' {
+				name := p.name()
+				p.expect(':')
+				sel.args[name] = p.value()
+			}
+			p.pos++
+		}
+		if p.peek() == '{' {
+			sel.selections = p.selectionSet("")
+		}
+		selections = append(selections, sel)
+	}
+	p.pos++
+	return selections
+}
+
+func (p *parser) value() any {
+	switch c := p.peek(); {
+	case c == '$':
+		p.pos++
+		return variable(p.name())
+	case c == '"':
+		end := p.pos + 1
+		for end < len(p.src) && p.src[end] != '"' {
+			if p.src[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(p.src) {
+			p.fail("unterminated string")
+		}
+		s, err := strconv.Unquote(p.src[p.pos : end+1])
+		if err != nil {
+			p.fail("invalid string: %v", err)
+		}
+		p.pos = end + 1
+		return s
+	case c == '-' || '0' <= c && c <= '9':
+		end := p.pos + 1
+		for end < len(p.src) && strings.IndexByte("0123456789.eE+-", p.src[end]) >= 0 {
+			end++
+		}
+		n, err := strconv.ParseFloat(p.src[p.pos:end], 64)
+		if err != nil {
+			p.fail("invalid number: %v", err)
+		}
+		p.pos = end
+		return n
+	case c == '[':
+		p.pos++
+		list := []any{}
+		for p.peek() != ']' {
+			list = append(list, p.value())
+		}
+		p.pos++
+		return list
+	case c == '{':
+		p.pos++
+		object := map[string]any{}
+		for p.peek() != '}' {
+			name := p.name()
+			p.expect(':')
+			object[name] = p.value()
+		}
+		p.pos++
+		return object
+	}
+
+	switch name := p.name(); name {
+	case "true", "false":
+		return name == "true"
+	case "null":
+		return nil
+	default:
+		return enum(name)
+	}
+}
+
+// bind replaces the variables in an argument value with their values. Enum values become
+// strings, like enum values given in variables are.
+func bind(value any, variables map[string]any) any {
+	switch value := value.(type) {
+	case variable:
+		return variables[string(value)]
+	case enum:
+		return string(value)
+	case []any:
+		list := make([]any, len(value))
+		for n, v := range value {
+			list[n] = bind(v, variables)
+		}
+		return list
+	case map[string]any:
+		object := make(map[string]any, len(value))
+		for k, v := range value {
+			object[k] = bind(v, variables)
+		}
+		return object
+	default:
+		return value
+	}
+}
+
+// object is a value of a GraphQL object type, which resolves its fields by name.
+type object struct {
+	typ    string
+	fields map[string]func(args map[string]any) (any, error)
+}
+
+// gqlError is an error in a GraphQL response.
+type gqlError struct {
+	Type       string         `json:"type,omitempty"`
+	Path       []any          `json:"path,omitempty"`
+	Message    string         `json:"message"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+func (e *gqlError) Error() string { return e.Message }
+
+func notFound(format string, args ...any) error {
+	return &gqlError{Type: "NOT_FOUND", Message: fmt.Sprintf(format, args...)}
+}
+
+func unprocessable(format string, args ...any) error {
+	return &gqlError{Type: "UNPROCESSABLE", Message: fmt.Sprintf(format, args...)}
+}
+
+// executor executes an operation, collecting the errors of the fields that failed.
+type executor struct {
+	variables map[string]any
+	errors    []*gqlError
+}
+
+func (e *executor) value(v any, selections []*selection, path []any) any {
+	switch v := v.(type) {
+	case *object:
+		if v == nil {
+			return nil
+		}
+		return e.object(v, selections, path)
+	case []*object:
+		list := make([]any, len(v))
+		for n, o := range v {
+			list[n] = e.value(o, selections, append(slices.Clip(path), n))
+		}
+		return list
+	default:
+		return v
+	}
+}
+
+func (e *executor) object(o *object, selections []*selection, path []any) map[string]any {
+	result := map[string]any{}
+	for _, sel := range selections {
+		if sel.on != "" && sel.on != o.typ {
+			continue
+		}
+		fieldPath := append(slices.Clip(path), sel.key())
+		if sel.name == "__typename" {
+			result[sel.key()] = o.typ
+			continue
+		}
+
+		resolve, ok := o.fields[sel.name]
+		if !ok {
+			e.errors = append(e.errors, &gqlError{
+				Path:       fieldPath,
+				Message:    fmt.Sprintf("Field '%s' doesn't exist on type '%s'", sel.name, o.typ),
+				Extensions: map[string]any{"code": "undefinedField", "typeName": o.typ, "fieldName": sel.name},
+			})
+			result[sel.key()] = nil
+			continue
+		}
+		args, _ := bind(sel.args, e.variables).(map[string]any)
+		v, err := resolve(args)
+		if err != nil {
+			gerr, ok := err.(*gqlError)
+			if !ok {
+				gerr = &gqlError{Message: err.Error()}
+			}
+			gerr.Path = fieldPath
+			e.errors = append(e.errors, gerr)
+			result[sel.key()] = nil
+			continue
+		}
+		result[sel.key()] = e.value(v, sel.selections, fieldPath)
+	}
+	return result
+}
+
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	op, err := parseQuery(body.Query)
+	if err != nil {
+		writeJSON(w, http.StatusOK, map[string]any{"errors": []*gqlError{{Message: err.Error()}}})
+		return
+	}
+
+	root := s.queryObject()
+	if op.typ == "mutation" {
+		root = s.mutationObject()
+	}
+	e := &executor{variables: body.Variables}
+	response := map[string]any{"data": e.object(root, op.selections, nil)}
+	if len(e.errors) > 0 {
+		response["errors"] = e.errors
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func stringArg(args map[string]any, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+func intArg(args map[string]any, name string) (int, bool) {
+	n, ok := args[name].(float64)
+	return int(n), ok
+}
+
+func timeValue(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
+
+// connection returns a connection object over nodes, paginated by the first and after arguments.
+func connection(nodes []*object, args map[string]any) *object {
+	start := 0
+	if after := stringArg(args, "after"); after != "" {
+		decoded, _ := base64.StdEncoding.DecodeString(after)
+		if n, err := strconv.Atoi(strings.TrimPrefix(string(decoded), "cursor:")); err == nil {
+			start = min(n+1, len(nodes))
+		}
+	}
+	end := len(nodes)
+	if first, ok := intArg(args, "first"); ok {
+		end = min(start+first, len(nodes))
+	}
+	cursor := func(n int) any {
+		if start == end {
+			return nil
+		}
+		return base64.StdEncoding.EncodeToString([]byte("cursor:" + strconv.Itoa(n)))
+	}
+
+	return &object{typ: "Connection", fields: map[string]func(map[string]any) (any, error){
+		"nodes":      func(map[string]any) (any, error) { return nodes[start:end], nil },
+		"totalCount": func(map[string]any) (any, error) { return len(nodes), nil },
+		"pageInfo": func(map[string]any) (any, error) {
+			return &object{typ: "PageInfo", fields: map[string]func(map[string]any) (any, error){
+				"hasNextPage":     func(map[string]any) (any, error) { return end < len(nodes), nil },
+				"hasPreviousPage": func(map[string]any) (any, error) { return start > 0, nil },
+				"startCursor":     func(map[string]any) (any, error) { return cursor(start), nil },
+				"endCursor":       func(map[string]any) (any, error) { return cursor(end - 1), nil },
+			}}, nil
+		},
+	}}
+}
+
+func (s *Server) queryObject() *object {
+	return &object{typ: "Query", fields: map[string]func(map[string]any) (any, error){
+		"repository": func(args map[string]any) (any, error) {
+			repo := s.repos[repoKey(stringArg(args, "owner"), stringArg(args, "name"))]
+			if repo == nil {
+				return nil, notFound("Could not resolve to a Repository with the name '%s/%s'.", stringArg(args, "owner"), stringArg(args, "name"))
+			}
+			return repo.object(), nil
+		},
+		"viewer": func(map[string]any) (any, error) {
+			return s.userObject(s.login), nil
+		},
+	}}
+}
+
+func (s *Server) userObject(login string) *object {
+	return &object{typ: "User", fields: map[string]func(map[string]any) (any, error){
+		"id":    func(map[string]any) (any, error) { return userNodeID(login), nil },
+		"login": func(map[string]any) (any, error) { return login, nil },
+	}}
+}
+
+// userNodeID returns the global ID of a user. Users aren't nodes of the fake, so their login
+// can be read back from it.
+func userNodeID(login string) string {
+	if login == copilotLogin {
+		return copilotNodeID
+	}
+	return "U_" + login
+}
+
+// actorLogin returns the login of the user or bot with a global ID.
+func actorLogin(id string) (string, error) {
+	if id == copilotNodeID {
+		return copilotLogin, nil
+	}
+	login, ok := strings.CutPrefix(id, "U_")
+	if !ok {
+		return "", notFound("Could not resolve to a node with the global id of '%s'", id)
+	}
+	return login, nil
+}
+
+// copilotObject returns Copilot as the bot assigned to issues.
+func copilotObject() *object {
+	return &object{typ: "Bot", fields: map[string]func(map[string]any) (any, error){
+		"id":    func(map[string]any) (any, error) { return copilotNodeID, nil },
+		"login": func(map[string]any) (any, error) { return copilotAgent, nil },
+	}}
+}
+
+func (r *repository) object() *object {
+	return &object{typ: "Repository", fields: map[string]func(map[string]any) (any, error){
+		"id":            func(map[string]any) (any, error) { return r.nodeID, nil },
+		"databaseId":    func(map[string]any) (any, error) { return r.id, nil },
+		"name":          func(map[string]any) (any, error) { return r.name, nil },
+		"nameWithOwner": func(map[string]any) (any, error) { return r.fullName(), nil },
+		"owner":         func(map[string]any) (any, error) { return r.server.userObject(r.owner), nil },
+		"url":           func(map[string]any) (any, error) { return r.htmlURL(""), nil },
+		"isPrivate":     func(map[string]any) (any, error) { return r.private, nil },
+		"description":   func(map[string]any) (any, error) { return r.description, nil },
+		"issue": func(args map[string]any) (any, error) {
+			number, _ := intArg(args, "number")
+			i := r.issue(number)
+			if i == nil || i.pull != nil {
+				return nil, notFound("Could not resolve to an Issue with the number of %d.", number)
+			}
+			return i.object(), nil
+		},
+		"pullRequest": func(args map[string]any) (any, error) {
+			number, _ := intArg(args, "number")
+			i := r.issue(number)
+			if i == nil || i.pull == nil {
+				return nil, notFound("Could not resolve to a PullRequest with the number of %d.", number)
+			}
+			return i.pullRequestObject(), nil
+		},
+		// Copilot can be assigned to the issues of every repository, along with its owner
+		"suggestedActors": func(args map[string]any) (any, error) {
+			return connection([]*object{r.server.userObject(r.owner), copilotObject()}, args), nil
+		},
+		"label": func(args map[string]any) (any, error) {
+			l := r.label(stringArg(args, "name"))
+			if l == nil {
+				return (*object)(nil), nil
+			}
+			return l.object(), nil
+		},
+		"labels": func(args map[string]any) (any, error) {
+			return connection(labelObjects(r.labels), args), nil
+		},
+		"issues": r.issuesConnection,
+	}}
+}
+
+// issuesConnection resolves the issues of a repository, filtered by the states, labels and
+// filterBy arguments, and ordered by the orderBy argument.
+func (r *repository) issuesConnection(args map[string]any) (any, error) {
+	var states, labels []string
+	for _, state := range asList(args["states"]) {
+		states = append(states, strings.ToLower(state))
+	}
+	labels = asList(args["labels"])
+	filterBy, _ := args["filterBy"].(map[string]any)
+	var since time.Time
+	if s := stringArg(filterBy, "since"); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, unprocessable("Argument 'since' on InputObject 'IssueFilters' has an invalid value (%q).", s)
+		}
+		since = t
+	}
+
+	var issues []*issue
+	for _, i := range r.issues {
+		switch {
+		case i.pull != nil:
+			continue
+		case len(states) > 0 && !slices.Contains(states, i.state):
+			continue
+		case !since.IsZero() && i.updatedAt.Before(since):
+			continue
+		case len(labels) > 0 && !slices.ContainsFunc(i.labels, func(l *label) bool {
+			return slices.ContainsFunc(labels, func(name string) bool { return strings.EqualFold(name, l.name) })
+		}):
+			continue
+		}
+		issues = append(issues, i)
+	}
+
+	orderBy, _ := args["orderBy"].(map[string]any)
+	slices.SortStableFunc(issues, func(a, b *issue) int {
+		var cmp int
+		switch stringArg(orderBy, "field") {
+		case "UPDATED_AT":
+			cmp = a.updatedAt.Compare(b.updatedAt)
+		case "COMMENTS":
+			cmp = len(a.comments) - len(b.comments)
+		default:
+			cmp = a.createdAt.Compare(b.createdAt)
+		}
+		if stringArg(orderBy, "direction") == "DESC" {
+			cmp = -cmp
+		}
+		return cmp
+	})
+
+	nodes := make([]*object, len(issues))
+	for n, i := range issues {
+		nodes[n] = i.object()
+	}
+	return connection(nodes, args), nil
+}
+
+// asList returns the strings of a list argument.
+func asList(value any) []string {
+	var list []string
+	values, _ := value.([]any)
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+func (i *issue) object() *object {
+	s := i.repo.server
+	return &object{typ: "Issue", fields: map[string]func(map[string]any) (any, error){
+		"id":         func(map[string]any) (any, error) { return i.nodeID, nil },
+		"databaseId": func(map[string]any) (any, error) { return i.id, nil },
+		"number":     func(map[string]any) (any, error) { return i.number, nil },
+		"title":      func(map[string]any) (any, error) { return i.title, nil },
+		"body":       func(map[string]any) (any, error) { return i.body, nil },
+		"state":      func(map[string]any) (any, error) { return strings.ToUpper(i.state), nil },
+		"stateReason": func(map[string]any) (any, error) {
+			if i.stateReason == "" {
+				return nil, nil
+			}
+			return strings.ToUpper(i.stateReason), nil
+		},
+		"url":       func(map[string]any) (any, error) { return i.repo.htmlURL("/issues/%d", i.number), nil },
+		"createdAt": func(map[string]any) (any, error) { return timeValue(i.createdAt), nil },
+		"updatedAt": func(map[string]any) (any, error) { return timeValue(i.updatedAt), nil },
+		"closedAt":  func(map[string]any) (any, error) { return timeValue(i.closedAt), nil },
+		"author":    func(map[string]any) (any, error) { return s.userObject(i.author), nil },
+		"labels": func(args map[string]any) (any, error) {
+			return connection(labelObjects(i.labels), args), nil
+		},
+		"assignees": func(args map[string]any) (any, error) {
+			nodes := make([]*object, len(i.assignees))
+			for n, login := range i.assignees {
+				nodes[n] = s.userObject(login)
+			}
+			return connection(nodes, args), nil
+		},
+		"comments": func(args map[string]any) (any, error) {
+			nodes := make([]*object, len(i.comments))
+			for n, c := range i.comments {
+				nodes[n] = c.object(s)
+			}
+			return connection(nodes, args), nil
+		},
+		// The fake doesn't record timeline events, such as cross-references from pull requests
+		"timelineItems": func(args map[string]any) (any, error) {
+			return connection(nil, args), nil
+		},
+	}}
+}
+
+func (i *issue) pullRequestObject() *object {
+	s := i.repo.server
+	return &object{typ: "PullRequest", fields: map[string]func(map[string]any) (any, error){
+		"id":         func(map[string]any) (any, error) { return i.nodeID, nil },
+		"databaseId": func(map[string]any) (any, error) { return i.id, nil },
+		"number":     func(map[string]any) (any, error) { return i.number, nil },
+		"title":      func(map[string]any) (any, error) { return i.title, nil },
+		"body":       func(map[string]any) (any, error) { return i.body, nil },
+		"state": func(map[string]any) (any, error) {
+			if i.pull.merged {
+				return "MERGED", nil
+			}
+			return strings.ToUpper(i.state), nil
+		},
+		"isDraft": func(map[string]any) (any, error) { return i.pull.draft, nil },
+		"url":     func(map[string]any) (any, error) { return i.repo.htmlURL("/pull/%d", i.number), nil },
+		"author":  func(map[string]any) (any, error) { return s.userObject(i.author), nil },
+		"reviews": i.reviewsConnection,
+	}}
+}
+
+func (c *comment) object(s *Server) *object {
+	return &object{typ: "IssueComment", fields: map[string]func(map[string]any) (any, error){
+		"id":         func(map[string]any) (any, error) { return c.nodeID, nil },
+		"databaseId": func(map[string]any) (any, error) { return c.id, nil },
+		"body":       func(map[string]any) (any, error) { return c.body, nil },
+		"author":     func(map[string]any) (any, error) { return s.userObject(c.author), nil },
+		"createdAt":  func(map[string]any) (any, error) { return timeValue(c.createdAt), nil },
+	}}
+}
+
+func (l *label) object() *object {
+	return &object{typ: "Label", fields: map[string]func(map[string]any) (any, error){
+		"id":          func(map[string]any) (any, error) { return l.nodeID, nil },
+		"name":        func(map[string]any) (any, error) { return l.name, nil },
+		"color":       func(map[string]any) (any, error) { return l.color, nil },
+		"description": func(map[string]any) (any, error) { return l.description, nil },
+	}}
+}
+
+func labelObjects(labels []*label) []*object {
+	nodes := make([]*object, len(labels))
+	for n, l := range labels {
+		nodes[n] = l.object()
+	}
+	return nodes
+}
+
+// payload returns the payload object of a mutation, with a field holding its result.
+func payload(typ, field string, result *object) *object {
+	fields := map[string]func(map[string]any) (any, error){
+		"clientMutationId": func(map[string]any) (any, error) { return nil, nil },
+	}
+	if field != "" {
+		fields[field] = func(map[string]any) (any, error) { return result, nil }
+	}
+	return &object{typ: typ, fields: fields}
+}
+
+// node returns the node with a global ID, if it has type T.
+func node[T any](s *Server, id string) (T, error) {
+	n, ok := s.nodes[id].(T)
+	if !ok {
+		return n, notFound("Could not resolve to a node with the global id of '%s'", id)
+	}
+	return n, nil
+}
+
+// issueNode returns the issue with a global ID. Pull requests aren't issues.
+func (s *Server) issueNode(id string) (*issue, error) {
+	i, err := node[*issue](s, id)
+	if err == nil && i.pull != nil {
+		return nil, notFound("Could not resolve to a node with the global id of '%s'", id)
+	}
+	return i, err
+}
+
+func (s *Server) mutationObject() *object {
+	mutation := &object{typ: "Mutation", fields: map[string]func(map[string]any) (any, error){
+		"closeIssue": func(args map[string]any) (any, error) {
+			input, _ := args["input"].(map[string]any)
+			i, err := s.issueNode(stringArg(input, "issueId"))
+			if err != nil {
+				return nil, err
+			}
+			reason := strings.ToLower(stringArg(input, "stateReason"))
+			if reason == "duplicate" {
+				if _, err := s.issueNode(stringArg(input, "duplicateIssueId")); err != nil {
+					return nil, err
+				}
+			}
+			i.setState("closed", reason)
+			return payload("CloseIssuePayload", "issue", i.object()), nil
+		},
+		"reopenIssue": func(args map[string]any) (any, error) {
+			input, _ := args["input"].(map[string]any)
+			i, err := s.issueNode(stringArg(input, "issueId"))
+			if err != nil {
+				return nil, err
+			}
+			i.setState("open", "")
+			return payload("ReopenIssuePayload", "issue", i.object()), nil
+		},
+		"createLabel": func(args map[string]any) (any, error) {
+			input, _ := args["input"].(map[string]any)
+			repo, err := node[*repository](s, stringArg(input, "repositoryId"))
+			if err != nil {
+				return nil, err
+			}
+			name := stringArg(input, "name")
+			switch {
+			case name == "":
+				return nil, unprocessable("Name can't be blank")
+			case repo.label(name) != nil:
+				return nil, unprocessable("Name has already been taken")
+			}
+			l := repo.newLabel(name, stringArg(input, "color"), stringArg(input, "description"))
+			return payload("CreateLabelPayload", "label", l.object()), nil
+		},
+		"updateLabel": func(args map[string]any) (any, error) {
+			input, _ := args["input"].(map[string]any)
+			l, err := node[*label](s, stringArg(input, "id"))
+			if err != nil {
+				return nil, err
+			}
+			if name, ok := input["name"].(string); ok {
+				l.name = name
+			}
+			if color, ok := input["color"].(string); ok {
+				l.color = color
+			}
+			if description, ok := input["description"].(string); ok {
+				l.description = description
+			}
+			return payload("UpdateLabelPayload", "label", l.object()), nil
+		},
+		"deleteLabel": func(args map[string]any) (any, error) {
+			input, _ := args["input"].(map[string]any)
+			id := stringArg(input, "id")
+			l, err := node[*label](s, id)
+			if err != nil {
+				return nil, err
+			}
+			for _, repo := range s.repos {
+				repo.labels = slices.DeleteFunc(repo.labels, func(other *label) bool { return other == l })
+				for _, i := range repo.issues {
+					i.labels = slices.DeleteFunc(i.labels, func(other *label) bool { return other == l })
+				}
+			}
+			delete(s.nodes, id)
+			return payload("DeleteLabelPayload", "", nil), nil
+		},
+		// updateIssue updates the title, body and assignees of an issue. Assigning Copilot with
+		// an agent assignment starts no agent session: it only becomes an assignee.
+		"updateIssue": func(args map[string]any) (any, error) {
+			input, _ := args["input"].(map[string]any)
+			i, err := s.issueNode(stringArg(input, "id"))
+			if err != nil {
+				return nil, err
+			}
+			var assignees []string
+			for _, id := range asList(input["assigneeIds"]) {
+				login, err := actorLogin(id)
+				if err != nil {
+					return nil, err
+				}
+				assignees = append(assignees, login)
+			}
+
+			if title, ok := input["title"].(string); ok {
+				i.title = title
+			}
+			if body, ok := input["body"].(string); ok {
+				i.body = body
+			}
+			if _, ok := input["assigneeIds"]; ok {
+				i.assignees = assignees
+			}
+			i.updatedAt = s.now()
+			return payload("UpdateIssuePayload", "issue", i.object()), nil
+		},
+	}}
+	maps.Copy(mutation.fields, s.reviewMutations())
+	return mutation
+}
@@ -0,0 +1,313 @@
+package githubfake
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v79/github"
+)
+
+type issue struct {
+	repo *repository
+
+	id          int64
+	nodeID      string
+	number      int
+	title       string
+	body        string
+	state       string
+	stateReason string
+	author      string
+	labels      []*label
+	assignees   []string
+	comments    []*comment
+	createdAt   time.Time
+	updatedAt   time.Time
+	closedAt    time.Time
+
+	// pull is set when the issue is a pull request
+	pull *pullRequest
+}
+
+type label struct {
+	id          int64
+	nodeID      string
+	name        string
+	color       string
+	description string
+}
+
+type comment struct {
+	id        int64
+	nodeID    string
+	author    string
+	body      string
+	createdAt time.Time
+}
+
+// newIssue adds an issue, or a pull request with the PR node ID prefix.
+func (r *repository) newIssue(prefix, title, body string) *issue {
+	now := r.server.now()
+	i := &issue{
+		repo:      r,
+		number:    len(r.issues) + 1,
+		title:     title,
+		body:      body,
+		state:     "open",
+		author:    r.server.login,
+		createdAt: now,
+		updatedAt: now,
+	}
+	i.id, i.nodeID = r.server.newID(prefix, i)
+	r.issues = append(r.issues, i)
+	return i
+}
+
+// issue returns the issue or pull request with a number.
+func (r *repository) issue(number int) *issue {
+	if number < 1 || number > len(r.issues) {
+		return nil
+	}
+	return r.issues[number-1]
+}
+
+// pathIssue returns the issue numbered by the number path value, writing 404 Not Found if there
+// is none, or if it isn't a pull request and pull is set.
+func (r *repository) pathIssue(w http.ResponseWriter, req *http.Request, pull bool) *issue {
+	number, _ := strconv.Atoi(req.PathValue("number"))
+	i := r.issue(number)
+	if i == nil || (pull && i.pull == nil) {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return nil
+	}
+	return i
+}
+
+// setState opens or closes an issue. The reason of a closed issue defaults to completed.
+func (i *issue) setState(state, reason string) {
+	now := i.repo.server.now()
+	i.updatedAt = now
+	switch {
+	case state == "closed" && i.state != "closed":
+		i.closedAt = now
+		if reason == "" {
+			reason = "completed"
+		}
+	case state == "open" && i.state != "open":
+		i.closedAt = time.Time{}
+		reason = "reopened"
+	}
+	i.state = state
+	if reason != "" {
+		i.stateReason = reason
+	}
+}
+
+func (i *issue) toGitHub() *github.Issue {
+	kind := "issues"
+	if i.pull != nil {
+		kind = "pull"
+	}
+	result := &github.Issue{
+		ID:        github.Ptr(i.id),
+		NodeID:    github.Ptr(i.nodeID),
+		Number:    github.Ptr(i.number),
+		Title:     github.Ptr(i.title),
+		Body:      github.Ptr(i.body),
+		State:     github.Ptr(i.state),
+		User:      i.repo.server.user(i.author),
+		Labels:    []*github.Label{},
+		Assignees: []*github.User{},
+		Comments:  github.Ptr(len(i.comments)),
+		CreatedAt: &github.Timestamp{Time: i.createdAt},
+		UpdatedAt: &github.Timestamp{Time: i.updatedAt},
+		HTMLURL:   github.Ptr(i.repo.htmlURL("/%s/%d", kind, i.number)),
+		URL:       github.Ptr(i.repo.apiURL("/issues/%d", i.number)),
+	}
+	if i.stateReason != "" {
+		result.StateReason = github.Ptr(i.stateReason)
+	}
+	if !i.closedAt.IsZero() {
+		result.ClosedAt = &github.Timestamp{Time: i.closedAt}
+	}
+	for _, l := range i.labels {
+		result.Labels = append(result.Labels, l.toGitHub())
+	}
+	for _, login := range i.assignees {
+		result.Assignees = append(result.Assignees, i.repo.server.user(login))
+	}
+	if i.pull != nil {
+		result.PullRequestLinks = &github.PullRequestLinks{
+			URL:     github.Ptr(i.repo.apiURL("/pulls/%d", i.number)),
+			HTMLURL: github.Ptr(i.repo.htmlURL("/pull/%d", i.number)),
+		}
+	}
+	return result
+}
+
+func (l *label) toGitHub() *github.Label {
+	return &github.Label{
+		ID:          github.Ptr(l.id),
+		NodeID:      github.Ptr(l.nodeID),
+		Name:        github.Ptr(l.name),
+		Color:       github.Ptr(l.color),
+		Description: github.Ptr(l.description),
+	}
+}
+
+func (r *repository) newLabel(name, color, description string) *label {
+	l := &label{name: name, color: color, description: description}
+	l.id, l.nodeID = r.server.newID("LA", l)
+	r.labels = append(r.labels, l)
+	return l
+}
+
+// label returns the label with a name, ignoring case like GitHub does.
+func (r *repository) label(name string) *label {
+	for _, l := range r.labels {
+		if strings.EqualFold(l.name, name) {
+			return l
+		}
+	}
+	return nil
+}
+
+// labelsByName returns the labels with names, creating those that don't exist like the REST API
+// does when labeling an issue.
+func (r *repository) labelsByName(names []string) []*label {
+	labels := []*label{}
+	for _, name := range names {
+		l := r.label(name)
+		if l == nil {
+			l = r.newLabel(name, "ededed", "")
+		}
+		if !slices.Contains(labels, l) {
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
+func (s *Server) createIssue(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var body struct {
+		Title     string   `json:"title"`
+		Body      string   `json:"body"`
+		Labels    []string `json:"labels"`
+		Assignees []string `json:"assignees"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Title == "" {
+		writeValidationError(w, "Issue", "title", "title is missing")
+		return
+	}
+
+	i := repo.newIssue("I", body.Title, body.Body)
+	i.labels = repo.labelsByName(body.Labels)
+	i.assignees = body.Assignees
+	writeJSON(w, http.StatusCreated, i.toGitHub())
+}
+
+func (s *Server) getIssue(w http.ResponseWriter, r *http.Request, repo *repository) {
+	if i := repo.pathIssue(w, r, false); i != nil {
+		writeJSON(w, http.StatusOK, i.toGitHub())
+	}
+}
+
+func (s *Server) updateIssue(w http.ResponseWriter, r *http.Request, repo *repository) {
+	i := repo.pathIssue(w, r, false)
+	if i == nil {
+		return
+	}
+	var body struct {
+		Title       *string   `json:"title"`
+		Body        *string   `json:"body"`
+		State       *string   `json:"state"`
+		StateReason *string   `json:"state_reason"`
+		Labels      *[]string `json:"labels"`
+		Assignees   *[]string `json:"assignees"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	if body.Title != nil {
+		i.title = *body.Title
+	}
+	if body.Body != nil {
+		i.body = *body.Body
+	}
+	if body.Labels != nil {
+		i.labels = repo.labelsByName(*body.Labels)
+	}
+	if body.Assignees != nil {
+		i.assignees = *body.Assignees
+	}
+	if body.State != nil {
+		if *body.State != "open" && *body.State != "closed" {
+			writeValidationError(w, "Issue", "state", "state is not included in the list")
+			return
+		}
+		reason := ""
+		if body.StateReason != nil {
+			reason = *body.StateReason
+		}
+		i.setState(*body.State, reason)
+	}
+	i.updatedAt = s.now()
+	writeJSON(w, http.StatusOK, i.toGitHub())
+}
+
+func (c *comment) toGitHub(i *issue) *github.IssueComment {
+	return &github.IssueComment{
+		ID:                github.Ptr(c.id),
+		NodeID:            github.Ptr(c.nodeID),
+		Body:              github.Ptr(c.body),
+		User:              i.repo.server.user(c.author),
+		AuthorAssociation: github.Ptr("OWNER"),
+		CreatedAt:         &github.Timestamp{Time: c.createdAt},
+		UpdatedAt:         &github.Timestamp{Time: c.createdAt},
+		HTMLURL:           github.Ptr(i.repo.htmlURL("/issues/%d#issuecomment-%d", i.number, c.id)),
+		URL:               github.Ptr(i.repo.apiURL("/issues/comments/%d", c.id)),
+		IssueURL:          github.Ptr(i.repo.apiURL("/issues/%d", i.number)),
+	}
+}
+
+func (s *Server) listComments(w http.ResponseWriter, r *http.Request, repo *repository) {
+	i := repo.pathIssue(w, r, false)
+	if i == nil {
+		return
+	}
+	comments := []*github.IssueComment{}
+	for _, c := range i.comments {
+		comments = append(comments, c.toGitHub(i))
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, comments))
+}
+
+func (s *Server) createComment(w http.ResponseWriter, r *http.Request, repo *repository) {
+	i := repo.pathIssue(w, r, false)
+	if i == nil {
+		return
+	}
+	var body struct {
+		Body string `json:"body"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Body == "" {
+		writeValidationError(w, "IssueComment", "body", "body is missing")
+		return
+	}
+
+	c := &comment{author: s.login, body: body.Body, createdAt: s.now()}
+	c.id, c.nodeID = s.newID("IC", c)
+	i.comments = append(i.comments, c)
+	i.updatedAt = c.createdAt
+	writeJSON(w, http.StatusCreated, c.toGitHub(i))
+}
@@ -0,0 +1,369 @@
+package githubfake
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v79/github"
+)
+
+type pullRequest struct {
+	head                string
+	base                string
+	draft               bool
+	maintainerCanModify bool
+
+	// headSHA and baseSHA are the commits the pull request was merged or closed with. While it's
+	// open, it follows its branches.
+	headSHA     string
+	baseSHA     string
+	merged      bool
+	mergedAt    time.Time
+	mergeCommit string
+
+	// reviewers are the logins reviews are requested from
+	reviewers []string
+	reviews   []*review
+}
+
+// shas returns the head and base commits of a pull request and their merge base.
+func (i *issue) shas() (head, base, mergeBase string) {
+	head, base = i.pull.headSHA, i.pull.baseSHA
+	if i.state == "open" {
+		head, base = i.repo.refs["refs/heads/"+i.pull.head], i.repo.refs["refs/heads/"+i.pull.base]
+	}
+	mergeBase, _ = i.repo.mergeBase(base, head)
+	return head, base, mergeBase
+}
+
+// changes returns the files changed by a pull request, from the merge base to its head.
+func (i *issue) changes() []change {
+	head, _, mergeBase := i.shas()
+	var from string
+	if c := i.repo.commits[mergeBase]; c != nil {
+		from = c.tree
+	}
+	return i.repo.diffTrees(from, i.repo.commits[head].tree)
+}
+
+// pullCommits returns the commits of a pull request, oldest first.
+func (i *issue) pullCommits() []*commit {
+	head, _, mergeBase := i.shas()
+	var commits []*commit
+	for _, c := range i.repo.ancestors(head) {
+		if mergeBase != "" && i.repo.isAncestor(c.sha, mergeBase) {
+			continue
+		}
+		commits = append([]*commit{c}, commits...)
+	}
+	return commits
+}
+
+// mergedTree returns the tree of the base branch with the changes of a pull request, and
+// whether they merge without conflicts.
+func (i *issue) mergedTree() (string, bool) {
+	head, base, mergeBase := i.shas()
+	if mergeBase == "" {
+		return "", false
+	}
+	return i.repo.mergeTrees(i.repo.commits[mergeBase].tree, i.repo.commits[base].tree, i.repo.commits[head].tree)
+}
+
+func (i *issue) toPullRequest() *github.PullRequest {
+	r := i.repo
+	head, base, _ := i.shas()
+	branch := func(name, sha string) *github.PullRequestBranch {
+		return &github.PullRequestBranch{
+			Label: github.Ptr(r.owner + ":" + name),
+			Ref:   github.Ptr(name),
+			SHA:   github.Ptr(sha),
+			Repo:  r.toGitHub(),
+			User:  r.server.user(r.owner),
+		}
+	}
+
+	var additions, deletions int
+	changes := i.changes()
+	for _, c := range changes {
+		_, added, deleted := r.patch(c)
+		additions += added
+		deletions += deleted
+	}
+
+	issue := i.toGitHub()
+	pr := &github.PullRequest{
+		ID:                  issue.ID,
+		NodeID:              issue.NodeID,
+		Number:              issue.Number,
+		State:               issue.State,
+		Title:               issue.Title,
+		Body:                issue.Body,
+		User:                issue.User,
+		Labels:              issue.Labels,
+		Assignees:           issue.Assignees,
+		CreatedAt:           issue.CreatedAt,
+		UpdatedAt:           issue.UpdatedAt,
+		ClosedAt:            issue.ClosedAt,
+		Draft:               github.Ptr(i.pull.draft),
+		Merged:              github.Ptr(i.pull.merged),
+		MaintainerCanModify: github.Ptr(i.pull.maintainerCanModify),
+		Head:                branch(i.pull.head, head),
+		Base:                branch(i.pull.base, base),
+		Comments:            issue.Comments,
+		Commits:             github.Ptr(len(i.pullCommits())),
+		Additions:           github.Ptr(additions),
+		Deletions:           github.Ptr(deletions),
+		ChangedFiles:        github.Ptr(len(changes)),
+		HTMLURL:             github.Ptr(r.htmlURL("/pull/%d", i.number)),
+		URL:                 github.Ptr(r.apiURL("/pulls/%d", i.number)),
+		DiffURL:             github.Ptr(r.htmlURL("/pull/%d.diff", i.number)),
+		IssueURL:            github.Ptr(r.apiURL("/issues/%d", i.number)),
+		RequestedReviewers:  i.requestedReviewers(),
+	}
+	if i.pull.merged {
+		pr.MergedAt = &github.Timestamp{Time: i.pull.mergedAt}
+		pr.MergeCommitSHA = github.Ptr(i.pull.mergeCommit)
+		pr.MergedBy = r.server.user(r.server.login)
+	}
+	if i.state == "open" {
+		_, mergeable := i.mergedTree()
+		pr.Mergeable = github.Ptr(mergeable)
+		pr.MergeableState = github.Ptr("clean")
+		if !mergeable {
+			pr.MergeableState = github.Ptr("dirty")
+		}
+	}
+	return pr
+}
+
+func (s *Server) createPullRequest(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var body struct {
+		Title               string `json:"title"`
+		Body                string `json:"body"`
+		Head                string `json:"head"`
+		Base                string `json:"base"`
+		Draft               bool   `json:"draft"`
+		MaintainerCanModify bool   `json:"maintainer_can_modify"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	head := body.Head
+	if owner, branch, ok := strings.Cut(head, ":"); ok && strings.EqualFold(owner, repo.owner) {
+		head = branch
+	}
+
+	headSHA, headOK := repo.refs["refs/heads/"+head]
+	baseSHA, baseOK := repo.refs["refs/heads/"+body.Base]
+	switch {
+	case body.Title == "":
+		writeValidationError(w, "PullRequest", "title", "title is missing")
+		return
+	case !baseOK:
+		writeValidationError(w, "PullRequest", "base", "base is invalid")
+		return
+	case !headOK:
+		writeValidationError(w, "PullRequest", "head", "head is invalid")
+		return
+	case repo.isAncestor(headSHA, baseSHA):
+		writeValidationError(w, "PullRequest", "", "No commits between "+body.Base+" and "+head)
+		return
+	}
+	for _, i := range repo.issues {
+		if i.pull != nil && i.state == "open" && i.pull.head == head && i.pull.base == body.Base {
+			writeValidationError(w, "PullRequest", "", "A pull request already exists for "+repo.owner+":"+head+".")
+			return
+		}
+	}
+
+	i := repo.newIssue("PR", body.Title, body.Body)
+	i.pull = &pullRequest{
+		head:                head,
+		base:                body.Base,
+		draft:               body.Draft,
+		maintainerCanModify: body.MaintainerCanModify,
+	}
+	writeJSON(w, http.StatusCreated, i.toPullRequest())
+}
+
+func (s *Server) getPullRequest(w http.ResponseWriter, r *http.Request, repo *repository) {
+	i := repo.pathIssue(w, r, true)
+	if i == nil {
+		return
+	}
+	if strings.Contains(r.Header.Get("Accept"), "diff") {
+		head, _, mergeBase := i.shas()
+		var from string
+		if c := repo.commits[mergeBase]; c != nil {
+			from = c.tree
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(repo.diff(from, repo.commits[head].tree)))
+		return
+	}
+	writeJSON(w, http.StatusOK, i.toPullRequest())
+}
+
+func (s *Server) listPullRequests(w http.ResponseWriter, r *http.Request, repo *repository) {
+	query := r.URL.Query()
+	state := query.Get("state")
+	if state == "" {
+		state = "open"
+	}
+	pulls := []*github.PullRequest{}
+	for n := len(repo.issues); n > 0; n-- {
+		i := repo.issue(n)
+		switch {
+		case i.pull == nil:
+			continue
+		case state != "all" && i.state != state:
+			continue
+		case query.Get("base") != "" && i.pull.base != query.Get("base"):
+			continue
+		case query.Get("head") != "" && !strings.EqualFold(repo.owner+":"+i.pull.head, query.Get("head")):
+			continue
+		}
+		pulls = append(pulls, i.toPullRequest())
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, pulls))
+}
+
+func (s *Server) updatePullRequest(w http.ResponseWriter, r *http.Request, repo *repository) {
+	i := repo.pathIssue(w, r, true)
+	if i == nil {
+		return
+	}
+	var body struct {
+		Title               *string `json:"title"`
+		Body                *string `json:"body"`
+		State               *string `json:"state"`
+		Base                *string `json:"base"`
+		MaintainerCanModify *bool   `json:"maintainer_can_modify"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Base != nil {
+		if _, ok := repo.refs["refs/heads/"+*body.Base]; !ok {
+			writeValidationError(w, "PullRequest", "base", "Proposed base branch '"+*body.Base+"' was not found")
+			return
+		}
+	}
+
+	if body.Title != nil {
+		i.title = *body.Title
+	}
+	if body.Body != nil {
+		i.body = *body.Body
+	}
+	if body.Base != nil {
+		i.pull.base = *body.Base
+	}
+	if body.MaintainerCanModify != nil {
+		i.pull.maintainerCanModify = *body.MaintainerCanModify
+	}
+	if body.State != nil && *body.State != i.state && !i.pull.merged {
+		if *body.State == "closed" {
+			i.pull.headSHA, i.pull.baseSHA, _ = i.shas()
+		}
+		i.setState(*body.State, "")
+		i.stateReason = ""
+	}
+	i.updatedAt = s.now()
+	writeJSON(w, http.StatusOK, i.toPullRequest())
+}
+
+func (s *Server) listPullRequestFiles(w http.ResponseWriter, r *http.Request, repo *repository) {
+	i := repo.pathIssue(w, r, true)
+	if i == nil {
+		return
+	}
+	files := []*github.CommitFile{}
+	for _, c := range i.changes() {
+		files = append(files, repo.commitFile(c))
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, files))
+}
+
+func (s *Server) mergePullRequest(w http.ResponseWriter, r *http.Request, repo *repository) {
+	i := repo.pathIssue(w, r, true)
+	if i == nil {
+		return
+	}
+	var body struct {
+		CommitTitle   string `json:"commit_title"`
+		CommitMessage string `json:"commit_message"`
+		MergeMethod   string `json:"merge_method"`
+		SHA           string `json:"sha"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	head, base, mergeBase := i.shas()
+	tree, mergeable := i.mergedTree()
+	switch {
+	case i.state != "open" || i.pull.draft || !mergeable:
+		writeError(w, http.StatusMethodNotAllowed, "Pull Request is not mergeable")
+		return
+	case body.SHA != "" && body.SHA != head:
+		writeError(w, http.StatusConflict, "Head branch was modified. Review and try the merge again.")
+		return
+	}
+
+	message := func(title, details string) string {
+		if body.CommitTitle != "" {
+			title = body.CommitTitle
+		}
+		if body.CommitMessage != "" {
+			details = body.CommitMessage
+		}
+		return title + "\n\n" + details
+	}
+	var merged *commit
+	switch body.MergeMethod {
+	case "", "merge":
+		title := "Merge pull request #" + strconv.Itoa(i.number) + " from " + repo.owner + "/" + i.pull.head
+		merged = repo.newCommit(tree, message(title, i.title), base, head)
+	case "squash":
+		var details []string
+		for _, c := range i.pullCommits() {
+			details = append(details, "* "+c.message)
+		}
+		merged = repo.newCommit(tree, message(i.title+" (#"+strconv.Itoa(i.number)+")", strings.Join(details, "\n\n")), base)
+	case "rebase":
+		// Replay the commits of the pull request on the base branch
+		current := base
+		for _, c := range i.pullCommits() {
+			parentTree := repo.commits[mergeBase].tree
+			if len(c.parents) > 0 {
+				parentTree = repo.commits[c.parents[0]].tree
+			}
+			replayed, ok := repo.mergeTrees(parentTree, repo.commits[current].tree, c.tree)
+			if !ok {
+				writeError(w, http.StatusMethodNotAllowed, "This branch can't be rebased")
+				return
+			}
+			current = repo.newCommit(replayed, c.message, current).sha
+		}
+		merged = repo.commits[current]
+	default:
+		writeValidationError(w, "PullRequest", "merge_method", "merge_method must be one of merge, squash or rebase")
+		return
+	}
+
+	repo.refs["refs/heads/"+i.pull.base] = merged.sha
+	i.pull.headSHA, i.pull.baseSHA = head, base
+	i.pull.merged = true
+	i.pull.mergedAt = s.now()
+	i.pull.mergeCommit = merged.sha
+	i.setState("closed", "")
+	i.stateReason = ""
+	writeJSON(w, http.StatusOK, &github.PullRequestMergeResult{
+		SHA:     github.Ptr(merged.sha),
+		Merged:  github.Ptr(true),
+		Message: github.Ptr("Pull Request successfully merged"),
+	})
+}
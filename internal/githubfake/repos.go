@@ -0,0 +1,485 @@
+package githubfake
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/go-github/v79/github"
+)
+
+type repository struct {
+	server *Server
+
+	id            int64
+	nodeID        string
+	owner         string
+	name          string
+	description   string
+	private       bool
+	defaultBranch string
+	createdAt     github.Timestamp
+
+	blobs   map[string][]byte
+	trees   map[string][]treeEntry
+	commits map[string]*commit
+	refs    map[string]string
+	tags    map[string]*tag
+
+	// issues holds the issues and pull requests, which share their numbers, by number - 1
+	issues    []*issue
+	labels    []*label
+	workflows map[string]int64
+	runs      []*github.WorkflowRun
+}
+
+func (s *Server) newRepository(owner, name string) *repository {
+	repo := &repository{
+		server:        s,
+		owner:         owner,
+		name:          name,
+		defaultBranch: "main",
+		createdAt:     github.Timestamp{Time: s.now()},
+		blobs:         make(map[string][]byte),
+		trees:         make(map[string][]treeEntry),
+		commits:       make(map[string]*commit),
+		refs:          make(map[string]string),
+		tags:          make(map[string]*tag),
+		workflows:     make(map[string]int64),
+	}
+	repo.id, repo.nodeID = s.newID("R", repo)
+	s.repos[repoKey(owner, name)] = repo
+	return repo
+}
+
+func (r *repository) fullName() string {
+	return r.owner + "/" + r.name
+}
+
+func (r *repository) apiURL(format string, args ...any) string {
+	return "https://api.github.com/repos/" + r.fullName() + fmt.Sprintf(format, args...)
+}
+
+func (r *repository) htmlURL(format string, args ...any) string {
+	return "https://github.com/" + r.fullName() + fmt.Sprintf(format, args...)
+}
+
+func (r *repository) toGitHub() *github.Repository {
+	visibility := "public"
+	if r.private {
+		visibility = "private"
+	}
+	return &github.Repository{
+		ID:            github.Ptr(r.id),
+		NodeID:        github.Ptr(r.nodeID),
+		Owner:         r.server.user(r.owner),
+		Name:          github.Ptr(r.name),
+		FullName:      github.Ptr(r.fullName()),
+		Description:   github.Ptr(r.description),
+		Private:       github.Ptr(r.private),
+		Visibility:    github.Ptr(visibility),
+		DefaultBranch: github.Ptr(r.defaultBranch),
+		HTMLURL:       github.Ptr(r.htmlURL("")),
+		URL:           github.Ptr(r.apiURL("")),
+		CloneURL:      github.Ptr(r.htmlURL(".git")),
+		CreatedAt:     &r.createdAt,
+		UpdatedAt:     &r.createdAt,
+	}
+}
+
+// isEmpty reports whether the repository has no commits yet, which the git data API refuses.
+func (r *repository) isEmpty(w http.ResponseWriter) bool {
+	if len(r.refs) == 0 {
+		writeError(w, http.StatusConflict, "Git Repository is empty.")
+		return true
+	}
+	return false
+}
+
+func (s *Server) createRepository(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Private     bool   `json:"private"`
+		AutoInit    bool   `json:"auto_init"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	owner := r.PathValue("org")
+	if owner == "" {
+		owner = s.login
+	}
+	if body.Name == "" {
+		writeValidationError(w, "Repository", "name", "name is missing")
+		return
+	}
+	if s.repos[repoKey(owner, body.Name)] != nil {
+		writeValidationError(w, "Repository", "name", "name already exists on this account")
+		return
+	}
+
+	repo := s.newRepository(owner, body.Name)
+	repo.description = body.Description
+	repo.private = body.Private
+	if body.AutoInit {
+		readme := "# " + body.Name + "\n"
+		repo.commitFiles(repo.defaultBranch, "Initial commit", map[string]*string{"README.md": &readme})
+	}
+	writeJSON(w, http.StatusCreated, repo.toGitHub())
+}
+
+func (s *Server) getRepository(w http.ResponseWriter, _ *http.Request, repo *repository) {
+	writeJSON(w, http.StatusOK, repo.toGitHub())
+}
+
+func (s *Server) deleteRepository(w http.ResponseWriter, _ *http.Request, repo *repository) {
+	delete(s.repos, repoKey(repo.owner, repo.name))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listTags lists the tags of a repository by name, lightweight and annotated alike, with the
+// commits they point to.
+func (s *Server) listTags(w http.ResponseWriter, r *http.Request, repo *repository) {
+	tags := []*github.RepositoryTag{}
+	for _, ref := range sortedKeys(repo.refs) {
+		name, ok := strings.CutPrefix(ref, "refs/tags/")
+		if !ok {
+			continue
+		}
+		sha, _ := repo.resolve(ref)
+		tags = append(tags, &github.RepositoryTag{
+			Name:       github.Ptr(name),
+			Commit:     &github.Commit{SHA: github.Ptr(sha), URL: github.Ptr(repo.apiURL("/commits/%s", sha))},
+			ZipballURL: github.Ptr(repo.apiURL("/zipball/refs/tags/%s", name)),
+			TarballURL: github.Ptr(repo.apiURL("/tarball/refs/tags/%s", name)),
+		})
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, tags))
+}
+
+func (s *Server) listBranches(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var branches []*github.Branch
+	for _, ref := range sortedKeys(repo.refs) {
+		name, ok := strings.CutPrefix(ref, "refs/heads/")
+		if !ok {
+			continue
+		}
+		branches = append(branches, &github.Branch{
+			Name:      github.Ptr(name),
+			Commit:    &github.RepositoryCommit{SHA: github.Ptr(repo.refs[ref]), URL: github.Ptr(repo.apiURL("/commits/%s", repo.refs[ref]))},
+			Protected: github.Ptr(false),
+		})
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, branches))
+}
+
+// repositoryCommit returns a commit in the shape of the commits API. With files, it includes
+// the files changed from the first parent and their stats.
+func (r *repository) repositoryCommit(c *commit, files bool) *github.RepositoryCommit {
+	result := &github.RepositoryCommit{
+		SHA:     github.Ptr(c.sha),
+		NodeID:  github.Ptr("C_" + c.sha),
+		Commit:  r.gitCommit(c),
+		Author:  r.server.user(r.server.login),
+		HTMLURL: github.Ptr(r.htmlURL("/commit/%s", c.sha)),
+		URL:     github.Ptr(r.apiURL("/commits/%s", c.sha)),
+	}
+	result.Committer = result.Author
+	for _, parent := range c.parents {
+		result.Parents = append(result.Parents, &github.Commit{SHA: github.Ptr(parent), URL: github.Ptr(r.apiURL("/commits/%s", parent))})
+	}
+	if !files {
+		return result
+	}
+
+	var base string
+	if len(c.parents) > 0 {
+		base = r.commits[c.parents[0]].tree
+	}
+	stats := &github.CommitStats{Additions: github.Ptr(0), Deletions: github.Ptr(0), Total: github.Ptr(0)}
+	result.Files = []*github.CommitFile{}
+	for _, change := range r.diffTrees(base, c.tree) {
+		f := r.commitFile(change)
+		*stats.Additions += f.GetAdditions()
+		*stats.Deletions += f.GetDeletions()
+		*stats.Total += f.GetChanges()
+		result.Files = append(result.Files, f)
+	}
+	result.Stats = stats
+	return result
+}
+
+func (r *repository) commitFile(c change) *github.CommitFile {
+	patch, additions, deletions := r.patch(c)
+	sha := c.after.sha
+	if sha == "" {
+		sha = c.before.sha
+	}
+	return &github.CommitFile{
+		SHA:       github.Ptr(sha),
+		Filename:  github.Ptr(c.path),
+		Status:    github.Ptr(c.status),
+		Additions: github.Ptr(additions),
+		Deletions: github.Ptr(deletions),
+		Changes:   github.Ptr(additions + deletions),
+		Patch:     github.Ptr(patch),
+	}
+}
+
+func (s *Server) listCommits(w http.ResponseWriter, r *http.Request, repo *repository) {
+	if repo.isEmpty(w) {
+		return
+	}
+	query := r.URL.Query()
+	sha, ok := repo.resolve(query.Get("sha"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "No commit found for SHA: "+query.Get("sha"))
+		return
+	}
+
+	commits := []*github.RepositoryCommit{}
+	for _, c := range repo.ancestors(sha) {
+		if filter := query.Get("path"); filter != "" && !repo.changes(c, filter) {
+			continue
+		}
+		if author := query.Get("author"); author != "" && author != s.login {
+			continue
+		}
+		commits = append(commits, repo.repositoryCommit(c, false))
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, commits))
+}
+
+// changes reports whether a commit changes the file or directory at path.
+func (r *repository) changes(c *commit, path string) bool {
+	var base string
+	if len(c.parents) > 0 {
+		base = r.commits[c.parents[0]].tree
+	}
+	path = strings.Trim(path, "/")
+	for _, change := range r.diffTrees(base, c.tree) {
+		if change.path == path || strings.HasPrefix(change.path, path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) getCommit(w http.ResponseWriter, r *http.Request, repo *repository) {
+	sha, ok := repo.resolve(r.PathValue("ref"))
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "No commit found for SHA: "+r.PathValue("ref"))
+		return
+	}
+	writeJSON(w, http.StatusOK, repo.repositoryCommit(repo.commits[sha], true))
+}
+
+func (s *Server) getCombinedStatus(w http.ResponseWriter, r *http.Request, repo *repository) {
+	sha, ok := repo.resolve(r.PathValue("ref"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "No commit found for SHA: "+r.PathValue("ref"))
+		return
+	}
+	writeJSON(w, http.StatusOK, &github.CombinedStatus{
+		State:      github.Ptr("pending"),
+		SHA:        github.Ptr(sha),
+		TotalCount: github.Ptr(0),
+		Statuses:   []*github.RepoStatus{},
+	})
+}
+
+// contentsEntry returns a tree entry at path in the shape of the contents API.
+func (r *repository) contentsEntry(entry treeEntry, filePath, ref string, withContent bool) *github.RepositoryContent {
+	typ := "file"
+	if entry.typ == "tree" {
+		typ = "dir"
+	}
+	content := &github.RepositoryContent{
+		Type:    github.Ptr(typ),
+		Name:    github.Ptr(path.Base(filePath)),
+		Path:    github.Ptr(filePath),
+		SHA:     github.Ptr(entry.sha),
+		Size:    github.Ptr(len(r.blobs[entry.sha])),
+		URL:     github.Ptr(r.apiURL("/contents/%s?ref=%s", filePath, ref)),
+		HTMLURL: github.Ptr(r.htmlURL("/%s/%s/%s", typ, ref, filePath)),
+		GitURL:  github.Ptr(r.apiURL("/git/%ss/%s", entry.typ, entry.sha)),
+	}
+	if typ == "file" {
+		content.DownloadURL = github.Ptr(fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s", r.fullName(), ref, filePath))
+		if withContent {
+			content.Encoding = github.Ptr("base64")
+			content.Content = github.Ptr(base64.StdEncoding.EncodeToString(r.blobs[entry.sha]))
+		}
+	}
+	return content
+}
+
+func (s *Server) getContents(w http.ResponseWriter, r *http.Request, repo *repository) {
+	ref := r.URL.Query().Get("ref")
+	sha, ok := repo.resolve(ref)
+	if !ok {
+		if ref == "" {
+			writeError(w, http.StatusNotFound, "This repository is empty.")
+			return
+		}
+		writeError(w, http.StatusNotFound, "No commit found for the ref "+ref)
+		return
+	}
+	if ref == "" {
+		ref = repo.defaultBranch
+	}
+
+	filePath := strings.Trim(r.PathValue("path"), "/")
+	entry, ok := repo.lookup(repo.commits[sha].tree, filePath)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if entry.typ == "blob" {
+		etag := `"` + entry.sha + `"`
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		writeJSON(w, http.StatusOK, repo.contentsEntry(entry, filePath, ref, true))
+		return
+	}
+
+	entries := []*github.RepositoryContent{}
+	for _, e := range repo.trees[entry.sha] {
+		entries = append(entries, repo.contentsEntry(e, strings.TrimPrefix(filePath+"/"+e.name, "/"), ref, false))
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// contentsCommit commits a change of the contents API to a branch, the default branch if empty,
+// after checking the SHA of the file it replaces. It writes the response and returns false on
+// failure.
+func (r *repository) contentsCommit(w http.ResponseWriter, filePath, branch, sha, message string, content *string) (*commit, bool) {
+	if branch == "" {
+		branch = r.defaultBranch
+	}
+	var existing treeEntry
+	exists := false
+	if head, ok := r.refs["refs/heads/"+branch]; ok {
+		existing, exists = r.lookup(r.commits[head].tree, filePath)
+	} else if len(r.refs) > 0 {
+		writeError(w, http.StatusNotFound, "Branch "+branch+" not found")
+		return nil, false
+	}
+
+	switch {
+	case content == nil && !exists:
+		writeError(w, http.StatusNotFound, "Not Found")
+		return nil, false
+	case exists && sha == "":
+		writeValidationError(w, "Content", "sha", `"sha" wasn't supplied.`)
+		return nil, false
+	case exists && sha != existing.sha:
+		writeError(w, http.StatusConflict, filePath+" does not match "+sha)
+		return nil, false
+	}
+	return r.commitFiles(branch, message, map[string]*string{filePath: content}), true
+}
+
+func (s *Server) putContents(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var body struct {
+		Message string `json:"message"`
+		Content []byte `json:"content"`
+		SHA     string `json:"sha"`
+		Branch  string `json:"branch"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Message == "" {
+		writeValidationError(w, "Content", "message", "message is missing")
+		return
+	}
+
+	filePath := strings.Trim(r.PathValue("path"), "/")
+	content := string(body.Content)
+	c, ok := repo.contentsCommit(w, filePath, body.Branch, body.SHA, body.Message, &content)
+	if !ok {
+		return
+	}
+
+	status := http.StatusCreated
+	if body.SHA != "" {
+		status = http.StatusOK
+	}
+	entry, _ := repo.lookup(c.tree, filePath)
+	writeJSON(w, status, &github.RepositoryContentResponse{
+		Content: repo.contentsEntry(entry, filePath, repo.branchOf(c.sha), false),
+		Commit:  *repo.gitCommit(c),
+	})
+}
+
+func (s *Server) deleteContents(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var body struct {
+		Message string `json:"message"`
+		SHA     string `json:"sha"`
+		Branch  string `json:"branch"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	c, ok := repo.contentsCommit(w, strings.Trim(r.PathValue("path"), "/"), body.Branch, body.SHA, body.Message, nil)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, &github.RepositoryContentResponse{Commit: *repo.gitCommit(c)})
+}
+
+// branchOf returns the name of a branch pointing to a commit.
+func (r *repository) branchOf(sha string) string {
+	for _, ref := range sortedKeys(r.refs) {
+		if name, ok := strings.CutPrefix(ref, "refs/heads/"); ok && r.refs[ref] == sha {
+			return name
+		}
+	}
+	return sha
+}
+
+// serveRaw serves raw.githubusercontent.com/{owner}/{repo}/{ref}/{path}, where the ref can be a
+// commit SHA, a branch or tag name, or a fully qualified ref, any of which can contain slashes.
+func (s *Server) serveRaw(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 3)
+	if r.Method != http.MethodGet || len(parts) < 3 {
+		http.Error(w, "404: Not Found", http.StatusNotFound)
+		return
+	}
+	repo := s.repos[repoKey(parts[0], parts[1])]
+	if repo == nil {
+		http.Error(w, "404: Not Found", http.StatusNotFound)
+		return
+	}
+
+	segments := strings.Split(parts[2], "/")
+	for i := len(segments) - 1; i > 0; i-- {
+		sha, ok := repo.resolve(strings.Join(segments[:i], "/"))
+		if !ok {
+			continue
+		}
+		entry, ok := repo.lookup(repo.commits[sha].tree, strings.Join(segments[i:], "/"))
+		if !ok || entry.typ != "blob" {
+			break
+		}
+		content := repo.blobs[entry.sha]
+		contentType := "application/octet-stream"
+		if utf8.Valid(content) && !strings.ContainsRune(string(content), 0) {
+			contentType = "text/plain; charset=utf-8"
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(content)
+		return
+	}
+	http.Error(w, "404: Not Found", http.StatusNotFound)
+}
@@ -0,0 +1,345 @@
+package githubfake
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v79/github"
+)
+
+// Copilot is a bot with different logins depending on where it appears: it's requested as a
+// reviewer as copilotReviewer, suggested as an assignee as copilotAgent, and listed as a reviewer
+// or an assignee as copilotLogin.
+const (
+	copilotLogin    = "Copilot"
+	copilotReviewer = "copilot-pull-request-reviewer[bot]"
+	copilotAgent    = "copilot-swe-agent"
+	copilotNodeID   = "BOT_copilot"
+)
+
+type review struct {
+	pull *issue
+
+	id          int64
+	nodeID      string
+	author      string
+	state       string
+	body        string
+	commitID    string
+	submittedAt time.Time
+	comments    []*reviewComment
+}
+
+type reviewComment struct {
+	id          int64
+	nodeID      string
+	path        string
+	body        string
+	subjectType string
+	line        int
+	side        string
+	startLine   int
+	startSide   string
+	createdAt   time.Time
+}
+
+// reviewStates are the states of the reviews submitted with each event.
+var reviewStates = map[string]string{
+	"COMMENT":         "COMMENTED",
+	"APPROVE":         "APPROVED",
+	"REQUEST_CHANGES": "CHANGES_REQUESTED",
+}
+
+func (rv *review) toGitHub() *github.PullRequestReview {
+	i := rv.pull
+	result := &github.PullRequestReview{
+		ID:                github.Ptr(rv.id),
+		NodeID:            github.Ptr(rv.nodeID),
+		User:              i.repo.server.user(rv.author),
+		Body:              github.Ptr(rv.body),
+		State:             github.Ptr(rv.state),
+		CommitID:          github.Ptr(rv.commitID),
+		AuthorAssociation: github.Ptr("OWNER"),
+		HTMLURL:           github.Ptr(i.repo.htmlURL("/pull/%d#pullrequestreview-%d", i.number, rv.id)),
+		PullRequestURL:    github.Ptr(i.repo.apiURL("/pulls/%d", i.number)),
+	}
+	if !rv.submittedAt.IsZero() {
+		result.SubmittedAt = &github.Timestamp{Time: rv.submittedAt}
+	}
+	return result
+}
+
+func (c *reviewComment) toGitHub(rv *review) *github.PullRequestComment {
+	i := rv.pull
+	result := &github.PullRequestComment{
+		ID:                  github.Ptr(c.id),
+		NodeID:              github.Ptr(c.nodeID),
+		PullRequestReviewID: github.Ptr(rv.id),
+		Path:                github.Ptr(c.path),
+		Body:                github.Ptr(c.body),
+		SubjectType:         github.Ptr(strings.ToLower(c.subjectType)),
+		CommitID:            github.Ptr(rv.commitID),
+		User:                i.repo.server.user(rv.author),
+		AuthorAssociation:   github.Ptr("OWNER"),
+		CreatedAt:           &github.Timestamp{Time: c.createdAt},
+		UpdatedAt:           &github.Timestamp{Time: c.createdAt},
+		HTMLURL:             github.Ptr(i.repo.htmlURL("/pull/%d#discussion_r%d", i.number, c.id)),
+		URL:                 github.Ptr(i.repo.apiURL("/pulls/comments/%d", c.id)),
+		PullRequestURL:      github.Ptr(i.repo.apiURL("/pulls/%d", i.number)),
+	}
+	if c.line > 0 {
+		result.Line = github.Ptr(c.line)
+		result.Side = github.Ptr(c.side)
+	}
+	if c.startLine > 0 {
+		result.StartLine = github.Ptr(c.startLine)
+		result.StartSide = github.Ptr(c.startSide)
+	}
+	return result
+}
+
+// reviewer returns the login a reviewer is requested by as the login it's listed by.
+func reviewer(login string) string {
+	if login == copilotReviewer {
+		return copilotLogin
+	}
+	return login
+}
+
+func (s *Server) listReviews(w http.ResponseWriter, r *http.Request, repo *repository) {
+	i := repo.pathIssue(w, r, true)
+	if i == nil {
+		return
+	}
+	reviews := []*github.PullRequestReview{}
+	for _, rv := range i.pull.reviews {
+		reviews = append(reviews, rv.toGitHub())
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, reviews))
+}
+
+func (s *Server) listReviewComments(w http.ResponseWriter, r *http.Request, repo *repository) {
+	i := repo.pathIssue(w, r, true)
+	if i == nil {
+		return
+	}
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	index := slices.IndexFunc(i.pull.reviews, func(rv *review) bool { return rv.id == id })
+	if index < 0 {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	rv := i.pull.reviews[index]
+	comments := []*github.PullRequestComment{}
+	for _, c := range rv.comments {
+		comments = append(comments, c.toGitHub(rv))
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, comments))
+}
+
+func (s *Server) listRequestedReviewers(w http.ResponseWriter, r *http.Request, repo *repository) {
+	i := repo.pathIssue(w, r, true)
+	if i == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, &github.Reviewers{Users: i.requestedReviewers(), Teams: []*github.Team{}})
+}
+
+// requestReviewers requests reviews from users. Requesting a review from Copilot adds it as a
+// reviewer, but it never submits a review.
+func (s *Server) requestReviewers(w http.ResponseWriter, r *http.Request, repo *repository) {
+	i := repo.pathIssue(w, r, true)
+	if i == nil {
+		return
+	}
+	var body github.ReviewersRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if len(body.TeamReviewers) > 0 {
+		writeValidationError(w, "PullRequest", "team_reviewers", "Team reviewers are not supported")
+		return
+	}
+	for _, login := range body.Reviewers {
+		if strings.EqualFold(login, i.author) {
+			writeError(w, http.StatusUnprocessableEntity, "Review cannot be requested from pull request author.")
+			return
+		}
+	}
+	for _, login := range body.Reviewers {
+		if login = reviewer(login); !slices.Contains(i.pull.reviewers, login) {
+			i.pull.reviewers = append(i.pull.reviewers, login)
+		}
+	}
+	i.updatedAt = s.now()
+	writeJSON(w, http.StatusCreated, i.toPullRequest())
+}
+
+func (i *issue) requestedReviewers() []*github.User {
+	users := []*github.User{}
+	for _, login := range i.pull.reviewers {
+		users = append(users, i.repo.server.user(login))
+	}
+	return users
+}
+
+// pendingReviewNode returns the review with a global ID, if it's pending.
+func (s *Server) pendingReviewNode(id string) (*review, error) {
+	rv, err := node[*review](s, id)
+	if err != nil {
+		return nil, err
+	}
+	if rv.state != "PENDING" {
+		return nil, unprocessable("Review is not pending")
+	}
+	return rv, nil
+}
+
+// reviewMutations returns the mutations of pull request reviews and their threads.
+func (s *Server) reviewMutations() map[string]func(map[string]any) (any, error) {
+	return map[string]func(map[string]any) (any, error){
+		"addPullRequestReview": func(args map[string]any) (any, error) {
+			input, _ := args["input"].(map[string]any)
+			i, err := node[*issue](s, stringArg(input, "pullRequestId"))
+			if err != nil || i.pull == nil {
+				return nil, notFound("Could not resolve to a node with the global id of '%s'", stringArg(input, "pullRequestId"))
+			}
+			event := stringArg(input, "event")
+			state, ok := reviewStates[event]
+			switch {
+			case event == "":
+				state = "PENDING"
+				if slices.ContainsFunc(i.pull.reviews, func(rv *review) bool { return rv.author == s.login && rv.state == "PENDING" }) {
+					return nil, unprocessable("User can only have one pending review per pull request")
+				}
+			case !ok:
+				return nil, unprocessable("Argument 'event' on InputObject 'AddPullRequestReviewInput' has an invalid value (%s).", event)
+			case event == "APPROVE" && i.author == s.login:
+				return nil, unprocessable("Can not approve your own pull request")
+			}
+
+			commitID := stringArg(input, "commitOID")
+			if commitID == "" {
+				commitID, _, _ = i.shas()
+			}
+			rv := &review{pull: i, author: s.login, state: state, body: stringArg(input, "body"), commitID: commitID}
+			rv.id, rv.nodeID = s.newID("PRR", rv)
+			if state != "PENDING" {
+				rv.submittedAt = s.now()
+			}
+			i.pull.reviews = append(i.pull.reviews, rv)
+			return payload("AddPullRequestReviewPayload", "pullRequestReview", rv.object()), nil
+		},
+		"submitPullRequestReview": func(args map[string]any) (any, error) {
+			input, _ := args["input"].(map[string]any)
+			rv, err := s.pendingReviewNode(stringArg(input, "pullRequestReviewId"))
+			if err != nil {
+				return nil, err
+			}
+			event := stringArg(input, "event")
+			state, ok := reviewStates[event]
+			switch {
+			case !ok:
+				return nil, unprocessable("Argument 'event' on InputObject 'SubmitPullRequestReviewInput' has an invalid value (%s).", event)
+			case event == "APPROVE" && rv.pull.author == s.login:
+				return nil, unprocessable("Can not approve your own pull request")
+			}
+			rv.state = state
+			if body, ok := input["body"].(string); ok {
+				rv.body = body
+			}
+			rv.submittedAt = s.now()
+			return payload("SubmitPullRequestReviewPayload", "pullRequestReview", rv.object()), nil
+		},
+		"deletePullRequestReview": func(args map[string]any) (any, error) {
+			input, _ := args["input"].(map[string]any)
+			id := stringArg(input, "pullRequestReviewId")
+			rv, err := s.pendingReviewNode(id)
+			if err != nil {
+				return nil, err
+			}
+			rv.pull.pull.reviews = slices.DeleteFunc(rv.pull.pull.reviews, func(other *review) bool { return other == rv })
+			delete(s.nodes, id)
+			return payload("DeletePullRequestReviewPayload", "pullRequestReview", rv.object()), nil
+		},
+		"addPullRequestReviewThread": func(args map[string]any) (any, error) {
+			input, _ := args["input"].(map[string]any)
+			rv, err := s.pendingReviewNode(stringArg(input, "pullRequestReviewId"))
+			if err != nil {
+				return nil, err
+			}
+			// Like GitHub, a comment on a file the pull request doesn't change isn't added, and
+			// the payload has no thread
+			path := stringArg(input, "path")
+			if !slices.ContainsFunc(rv.pull.changes(), func(c change) bool { return c.path == path }) {
+				return payload("AddPullRequestReviewThreadPayload", "thread", nil), nil
+			}
+
+			c := &reviewComment{
+				path:        path,
+				body:        stringArg(input, "body"),
+				subjectType: stringArg(input, "subjectType"),
+				side:        stringArg(input, "side"),
+				startSide:   stringArg(input, "startSide"),
+				createdAt:   s.now(),
+			}
+			if c.subjectType == "" {
+				c.subjectType = "LINE"
+			}
+			if c.subjectType == "LINE" {
+				c.line, _ = intArg(input, "line")
+				c.startLine, _ = intArg(input, "startLine")
+				if c.side == "" {
+					c.side = "RIGHT"
+				}
+				if c.startLine > 0 && c.startSide == "" {
+					c.startSide = c.side
+				}
+			}
+			c.id, c.nodeID = s.newID("PRRC", c)
+			rv.comments = append(rv.comments, c)
+			return payload("AddPullRequestReviewThreadPayload", "thread", c.threadObject()), nil
+		},
+	}
+}
+
+func (rv *review) object() *object {
+	s := rv.pull.repo.server
+	return &object{typ: "PullRequestReview", fields: map[string]func(map[string]any) (any, error){
+		"id":         func(map[string]any) (any, error) { return rv.nodeID, nil },
+		"databaseId": func(map[string]any) (any, error) { return rv.id, nil },
+		"state":      func(map[string]any) (any, error) { return rv.state, nil },
+		"body":       func(map[string]any) (any, error) { return rv.body, nil },
+		"author":     func(map[string]any) (any, error) { return s.userObject(rv.author), nil },
+		"url": func(map[string]any) (any, error) {
+			return rv.pull.repo.htmlURL("/pull/%d#pullrequestreview-%d", rv.pull.number, rv.id), nil
+		},
+		"submittedAt": func(map[string]any) (any, error) { return timeValue(rv.submittedAt), nil },
+	}}
+}
+
+// threadObject returns the review thread a comment starts.
+func (c *reviewComment) threadObject() *object {
+	return &object{typ: "PullRequestReviewThread", fields: map[string]func(map[string]any) (any, error){
+		"id":          func(map[string]any) (any, error) { return "PRRT_" + strconv.FormatInt(c.id, 10), nil },
+		"path":        func(map[string]any) (any, error) { return c.path, nil },
+		"subjectType": func(map[string]any) (any, error) { return c.subjectType, nil },
+		"isResolved":  func(map[string]any) (any, error) { return false, nil },
+	}}
+}
+
+// reviewsConnection resolves the reviews of a pull request, in the order they were created,
+// filtered by the author argument.
+func (i *issue) reviewsConnection(args map[string]any) (any, error) {
+	author := stringArg(args, "author")
+	var nodes []*object
+	for _, rv := range i.pull.reviews {
+		if author == "" || strings.EqualFold(rv.author, author) {
+			nodes = append(nodes, rv.object())
+		}
+	}
+	return connection(nodes, args), nil
+}
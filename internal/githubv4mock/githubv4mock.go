@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
+	"sync"
+	"time"
 )
 
 type Matcher struct {
@@ -14,6 +17,11 @@ type Matcher struct {
 	Variables map[string]any
 
 	Response GQLResponse
+
+	// then holds the responses to the requests matched after the first one, in order
+	then []GQLResponse
+	// partial is set when only the variables of the matcher are compared
+	partial bool
 }
 
 // NewQueryMatcher constructs a new matcher for the provided query and variables.
@@ -62,11 +70,79 @@ func NewMutationMatcher(mutation any, input any, variables map[string]any, respo
 	}
 }
 
+// Then returns a copy of the matcher which responds to the requests it matches in sequence: the
+// first with its response, and the following ones with responses, in order. Once the sequence is
+// exhausted, the last response is repeated. This is useful to serve the pages of a paginated query.
+func (m Matcher) Then(responses ...GQLResponse) Matcher {
+	m.then = append(append([]GQLResponse(nil), m.then...), responses...)
+	return m
+}
+
+// WithPartialVariables returns a copy of the matcher which ignores the request variables it has no
+// value for. Objects, such as mutation inputs, are compared the same way: only their fields the
+// matcher has a value for must be equal.
+func (m Matcher) WithPartialVariables() Matcher {
+	m.partial = true
+	return m
+}
+
+// responses returns the sequence of responses of the matcher.
+func (m Matcher) responses() []GQLResponse {
+	return append([]GQLResponse{m.Response}, m.then...)
+}
+
+// VariableMatcher matches the value of a variable in place of an expected value, in the
+// variables of a Matcher. Construct one with Match or Any.
+type VariableMatcher struct {
+	// typ is the type of the variable, which the query is constructed with
+	typ   reflect.Type
+	match func(value any) bool
+}
+
+// Match returns a VariableMatcher for a variable of type T, which matches the values that match
+// returns true for. The value, decoded from the request as JSON, is converted back to T first.
+// For example, to match the first page of a paginated query:
+//
+//	"after": githubv4mock.Match(func(after *githubv4.String) bool { return after == nil }),
+func Match[T any](match func(T) bool) VariableMatcher {
+	return VariableMatcher{
+		typ: reflect.TypeFor[T](),
+		match: func(value any) bool {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return false
+			}
+			var v T
+			if err := json.Unmarshal(data, &v); err != nil {
+				return false
+			}
+			return match(v)
+		},
+	}
+}
+
+// Any returns a VariableMatcher for a variable of type T which matches any value.
+func Any[T any]() VariableMatcher {
+	return Match(func(T) bool { return true })
+}
+
 type GQLResponse struct {
 	Data   map[string]any `json:"data"`
-	Errors []struct {
-		Message string `json:"message"`
-	} `json:"errors,omitempty"`
+	Errors []GQLError     `json:"errors,omitempty"`
+
+	// status and body replace the response with an HTTP error when status is set
+	status int
+	body   string
+	// delay is how long to wait before responding
+	delay time.Duration
+}
+
+// GQLError is an error in a GraphQL response. GitHub sets Type to a code such as NOT_FOUND,
+// FORBIDDEN or RATE_LIMITED.
+type GQLError struct {
+	Type    string `json:"type,omitempty"`
+	Path    []any  `json:"path,omitempty"`
+	Message string `json:"message"`
 }
 
 // DataResponse is the happy path response constructor for a mocked GraphQL request.
@@ -76,13 +152,11 @@ func DataResponse(data map[string]any) GQLResponse {
 	}
 }
 
-// ErrorResponse is the unhappy path response constructor for a mocked GraphQL request.\
-// Note that for the moment it is only possible to return a single error message.
+// ErrorResponse is the unhappy path response constructor for a mocked GraphQL request.
+// It returns a single error message. Use TypedErrorResponse or WithErrors for more detailed errors.
 func ErrorResponse(errorMsg string) GQLResponse {
 	return GQLResponse{
-		Errors: []struct {
-			Message string `json:"message"`
-		}{
+		Errors: []GQLError{
 			{
 				Message: errorMsg,
 			},
@@ -90,6 +164,43 @@ func ErrorResponse(errorMsg string) GQLResponse {
 	}
 }
 
+// TypedErrorResponse is the response constructor for a mocked GraphQL request failing with an
+// error of a type, such as NOT_FOUND or FORBIDDEN.
+func TypedErrorResponse(errorType, errorMsg string) GQLResponse {
+	return GQLResponse{
+		Errors: []GQLError{
+			{
+				Type:    errorType,
+				Message: errorMsg,
+			},
+		},
+	}
+}
+
+// HTTPErrorResponse is the response constructor for a mocked GraphQL request failing at the HTTP
+// level, with a status other than 200 OK, such as 502 Bad Gateway.
+func HTTPErrorResponse(status int, body string) GQLResponse {
+	return GQLResponse{
+		status: status,
+		body:   body,
+	}
+}
+
+// WithErrors returns a copy of the response with errors added, for example to return the data
+// that could be resolved together with errors about the fields that could not.
+func (r GQLResponse) WithErrors(errs ...GQLError) GQLResponse {
+	r.Errors = append(append([]GQLError(nil), r.Errors...), errs...)
+	return r
+}
+
+// WithDelay returns a copy of the response which is sent after a delay. If the request is
+// cancelled during the delay, for example by a context deadline, the request fails with the
+// context error.
+func (r GQLResponse) WithDelay(delay time.Duration) GQLResponse {
+	r.delay = delay
+	return r
+}
+
 // githubv4InputStructToMap converts a struct to a map[string]any, it uses JSON marshalling rather than reflection
 // to do so, because the json struct tags are used in the real implementation to produce the variable key names,
 // and we need to ensure that when variable matching occurs in the http handler, the keys correctly match.
@@ -146,62 +257,210 @@ func githubv4InputStructToMap(s any) (map[string]any, error) {
 //	  StateReason *IssueClosedStateReason `json:"stateReason,omitempty"`
 //	}
 //
-// This client does not currently provide a mechanism for out-of-band errors e.g. returning a 500,
-// and errors are constrained to GQL errors returned in the response body with a 200 status code.
+// Variables that don't need an exact value can be matched with Match or Any, and matchers can ignore
+// variables with WithPartialVariables. Errors are injected with the TypedErrorResponse,
+// HTTPErrorResponse and GQLResponse.WithDelay responses.
+//
+// To check that every matcher was used, use NewMock and Mock.AssertExpectations instead.
 func NewMockedHTTPClient(ms ...Matcher) *http.Client {
-	matchers := make(map[string]Matcher, len(ms))
-	for _, m := range ms {
-		matchers[m.Request] = m
+	return NewMock(ms...).Client()
+}
+
+// Mock is a mock GraphQL server which records the requests it receives, so that the
+// expectations set up by its matchers can be checked at the end of a test.
+type Mock struct {
+	mu       sync.Mutex
+	matchers []*matcherState
+	// unexpected describes the requests no matcher matched
+	unexpected []string
+}
+
+type matcherState struct {
+	Matcher
+	calls int
+}
+
+// NewMock creates a mock GraphQL server with matchers. Matchers are tried in order, and a request
+// is served by the first matcher that matches it and has responses left in its sequence, or else
+// by the last matcher that matches it.
+func NewMock(ms ...Matcher) *Mock {
+	m := &Mock{}
+	for _, matcher := range ms {
+		m.matchers = append(m.matchers, &matcherState{Matcher: matcher})
 	}
+	return m
+}
 
+// Client returns an HTTP client which sends requests to the mock.
+func (m *Mock) Client() *http.Client {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
+	mux.HandleFunc("/graphql", m.serveGraphQL)
 
-		gqlRequest, err := parseBody(r.Body)
-		if err != nil {
-			http.Error(w, "invalid request body", http.StatusBadRequest)
-			return
+	return &http.Client{Transport: &localRoundTripper{
+		handler: mux,
+	}}
+}
+
+// TestingT is the subset of testing.TB used to report unmet expectations.
+type TestingT interface {
+	Errorf(format string, args ...any)
+}
+
+// AssertExpectations reports an error for each unmet expectation of the mock, returning
+// whether all of them were met. Call it at the end of a test:
+//
+//	mock := githubv4mock.NewMock(matchers...)
+//	defer mock.AssertExpectations(t)
+func (m *Mock) AssertExpectations(t TestingT) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	unmet := m.UnmetExpectations()
+	for _, expectation := range unmet {
+		t.Errorf("githubv4mock: %s", expectation)
+	}
+	return len(unmet) == 0
+}
+
+// UnmetExpectations describes the matchers that were never matched, the sequences of responses
+// that were not used up, and the requests that no matcher matched.
+func (m *Mock) UnmetExpectations() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var unmet []string
+	for _, state := range m.matchers {
+		responses := len(state.responses())
+		switch {
+		case state.calls == 0:
+			unmet = append(unmet, fmt.Sprintf("expected a request for %s with variables %v, but none was made", state.Request, state.Variables))
+		case state.calls < responses:
+			unmet = append(unmet, fmt.Sprintf("expected %d requests for %s with variables %v, got %d", responses, state.Request, state.Variables, state.calls))
 		}
-		defer func() { _ = r.Body.Close() }()
+	}
+	for _, request := range m.unexpected {
+		unmet = append(unmet, "unexpected request: "+request)
+	}
+	return unmet
+}
 
-		matcher, ok := matchers[gqlRequest.Query]
-		if !ok {
-			http.Error(w, fmt.Sprintf("no matcher found for query %s", gqlRequest.Query), http.StatusNotFound)
+func (m *Mock) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	gqlRequest, err := parseBody(r.Body)
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer func() { _ = r.Body.Close() }()
+
+	response, status, message := m.match(gqlRequest)
+	if status != http.StatusOK {
+		http.Error(w, message, status)
+		return
+	}
+
+	if response.delay > 0 {
+		timer := time.NewTimer(response.delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
 			return
 		}
+	}
 
-		if len(gqlRequest.Variables) > 0 {
-			if len(gqlRequest.Variables) != len(matcher.Variables) {
-				http.Error(w, "variables do not have the same length", http.StatusBadRequest)
-				return
-			}
+	if response.status != 0 {
+		http.Error(w, response.body, response.status)
+		return
+	}
 
-			for k, v := range matcher.Variables {
-				if !objectsAreEqualValues(v, gqlRequest.Variables[k]) {
-					http.Error(w, "variable does not match", http.StatusBadRequest)
-					return
-				}
-			}
+	responseBody, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, "error marshalling response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(responseBody)
+}
+
+// match returns the response to a request, or the status and message of the error explaining
+// why no matcher matched it.
+func (m *Mock) match(req gqlRequest) (GQLResponse, int, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var candidates, matching []*matcherState
+	for _, state := range m.matchers {
+		if state.Request == req.Query {
+			candidates = append(candidates, state)
 		}
+	}
+	if len(candidates) == 0 {
+		m.unexpected = append(m.unexpected, fmt.Sprintf("%s with variables %v", req.Query, req.Variables))
+		return GQLResponse{}, http.StatusNotFound, fmt.Sprintf("no matcher found for query %s", req.Query)
+	}
 
-		responseBody, err := json.Marshal(matcher.Response)
-		if err != nil {
-			http.Error(w, "error marshalling response", http.StatusInternalServerError)
-			return
+	message := "variable does not match"
+	for _, state := range candidates {
+		if len(req.Variables) > 0 && !state.partial && len(req.Variables) != len(state.Variables) {
+			message = "variables do not have the same length"
+			continue
+		}
+		if len(req.Variables) > 0 && !variablesMatch(state.Variables, req.Variables, state.partial) {
+			continue
 		}
+		matching = append(matching, state)
+	}
+	if len(matching) == 0 {
+		m.unexpected = append(m.unexpected, fmt.Sprintf("%s with variables %v", req.Query, req.Variables))
+		return GQLResponse{}, http.StatusBadRequest, message
+	}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write(responseBody)
-	})
+	state := matching[len(matching)-1]
+	for _, candidate := range matching {
+		if candidate.calls < len(candidate.responses()) {
+			state = candidate
+			break
+		}
+	}
+	responses := state.responses()
+	response := responses[min(state.calls, len(responses)-1)]
+	state.calls++
+	return response, http.StatusOK, ""
+}
 
-	return &http.Client{Transport: &localRoundTripper{
-		handler: mux,
-	}}
+// variablesMatch reports whether the variables of a request match the expected ones.
+func variablesMatch(expected, actual map[string]any, partial bool) bool {
+	for k, v := range expected {
+		value, ok := actual[k]
+		if !ok && partial {
+			return false
+		}
+		if !valueMatches(v, value, partial) {
+			return false
+		}
+	}
+	return true
+}
+
+func valueMatches(expected, actual any, partial bool) bool {
+	if vm, ok := expected.(VariableMatcher); ok {
+		return vm.match(actual)
+	}
+	if partial {
+		expectedObject, ok := expected.(map[string]any)
+		actualObject, isObject := actual.(map[string]any)
+		if ok && isObject {
+			return variablesMatch(expectedObject, actualObject, partial)
+		}
+	}
+	return objectsAreEqualValues(expected, actual)
 }
 
 type gqlRequest struct {
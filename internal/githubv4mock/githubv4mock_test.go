@@ -0,0 +1,232 @@
+package githubv4mock
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type issuesQuery struct {
+	Repository struct {
+		Issues struct {
+			Nodes []struct {
+				Number githubv4.Int
+			}
+			PageInfo struct {
+				HasNextPage githubv4.Boolean
+				EndCursor   githubv4.String
+			}
+		} `graphql:"issues(first: $first, after: $after)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+func issuesPage(hasNextPage bool, numbers ...int) GQLResponse {
+	nodes := []any{}
+	for _, n := range numbers {
+		nodes = append(nodes, map[string]any{"number": n})
+	}
+	return DataResponse(map[string]any{
+		"repository": map[string]any{
+			"issues": map[string]any{
+				"nodes":    nodes,
+				"pageInfo": map[string]any{"hasNextPage": hasNextPage, "endCursor": fmt.Sprintf("cursor-%d", len(numbers))},
+			},
+		},
+	})
+}
+
+// recorder is a TestingT recording the errors reported to it.
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func Test_SequencedResponsesAndVariableMatchers(t *testing.T) {
+	mock := NewMock(
+		NewQueryMatcher(issuesQuery{}, map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"first": Any[githubv4.Int](),
+			"after": Any[*githubv4.String](),
+		}, issuesPage(true, 1, 2)).Then(issuesPage(false, 3)),
+	)
+	defer mock.AssertExpectations(t)
+	client := githubv4.NewClient(mock.Client())
+
+	var numbers []int
+	vars := map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+		"first": githubv4.Int(2),
+		"after": (*githubv4.String)(nil),
+	}
+	for {
+		var query issuesQuery
+		require.NoError(t, client.Query(context.Background(), &query, vars))
+		for _, node := range query.Repository.Issues.Nodes {
+			numbers = append(numbers, int(node.Number))
+		}
+		if !query.Repository.Issues.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = githubv4.NewString(query.Repository.Issues.PageInfo.EndCursor)
+	}
+	assert.Equal(t, []int{1, 2, 3}, numbers)
+}
+
+func Test_MatchersAreTriedInOrder(t *testing.T) {
+	vars := func(after VariableMatcher) map[string]any {
+		return map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"first": githubv4.Int(2),
+			"after": after,
+		}
+	}
+	mock := NewMock(
+		NewQueryMatcher(issuesQuery{}, vars(Match(func(after *githubv4.String) bool { return after == nil })), issuesPage(true, 1, 2)),
+		NewQueryMatcher(issuesQuery{}, vars(Match(func(after *githubv4.String) bool { return after != nil && *after == "cursor-2" })), issuesPage(false, 3)),
+	)
+	client := githubv4.NewClient(mock.Client())
+
+	var query issuesQuery
+	requestVars := map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+		"first": githubv4.Int(2),
+		"after": githubv4.NewString("cursor-2"),
+	}
+	require.NoError(t, client.Query(context.Background(), &query, requestVars))
+	require.Len(t, query.Repository.Issues.Nodes, 1)
+	assert.Equal(t, githubv4.Int(3), query.Repository.Issues.Nodes[0].Number)
+
+	requestVars["after"] = githubv4.NewString("cursor-4")
+	err := client.Query(context.Background(), &query, requestVars)
+	require.ErrorContains(t, err, "variable does not match")
+
+	unmet := mock.UnmetExpectations()
+	require.Len(t, unmet, 2)
+	assert.Contains(t, unmet[0], "but none was made")
+	assert.Contains(t, unmet[1], "unexpected request")
+}
+
+func Test_PartialVariables(t *testing.T) {
+	var mutation struct {
+		CloseIssue struct {
+			Issue struct {
+				ID githubv4.ID
+			}
+		} `graphql:"closeIssue(input: $input)"`
+	}
+	mock := NewMock(
+		NewMutationMatcher(mutation, githubv4.CloseIssueInput{IssueID: "I_1"}, nil,
+			DataResponse(map[string]any{"closeIssue": map[string]any{"issue": map[string]any{"id": "I_1"}}}),
+		).WithPartialVariables(),
+	)
+	defer mock.AssertExpectations(t)
+	client := githubv4.NewClient(mock.Client())
+
+	reason := githubv4.IssueClosedStateReasonNotPlanned
+	err := client.Mutate(context.Background(), &mutation, githubv4.CloseIssueInput{
+		IssueID:          "I_1",
+		StateReason:      &reason,
+		ClientMutationID: githubv4.NewString("mutation"),
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, githubv4.ID("I_1"), mutation.CloseIssue.Issue.ID)
+}
+
+func Test_ErrorResponses(t *testing.T) {
+	var query struct {
+		Viewer struct {
+			Login githubv4.String
+		}
+	}
+
+	tests := []struct {
+		name        string
+		response    GQLResponse
+		expectedErr string
+		login       string
+	}{
+		{
+			name:        "typed GraphQL error",
+			response:    TypedErrorResponse("FORBIDDEN", "Resource not accessible by integration"),
+			expectedErr: "Resource not accessible by integration",
+		},
+		{
+			name: "data with errors",
+			response: DataResponse(map[string]any{"viewer": map[string]any{"login": "octocat"}}).
+				WithErrors(GQLError{Type: "NOT_FOUND", Path: []any{"viewer", "organization"}, Message: "Could not resolve to an Organization"}),
+			expectedErr: "Could not resolve to an Organization",
+			login:       "octocat",
+		},
+		{
+			name:        "HTTP failure",
+			response:    HTTPErrorResponse(http.StatusBadGateway, "upstream unavailable"),
+			expectedErr: "non-200 OK status code: 502 Bad Gateway",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(NewMockedHTTPClient(NewQueryMatcher(query, nil, tc.response)))
+			query.Viewer.Login = ""
+			err := client.Query(context.Background(), &query, nil)
+			require.ErrorContains(t, err, tc.expectedErr)
+			assert.Equal(t, githubv4.String(tc.login), query.Viewer.Login)
+		})
+	}
+}
+
+func Test_Delay(t *testing.T) {
+	var query struct {
+		Viewer struct {
+			Login githubv4.String
+		}
+	}
+	response := DataResponse(map[string]any{"viewer": map[string]any{"login": "octocat"}})
+	client := githubv4.NewClient(NewMockedHTTPClient(NewQueryMatcher(query, nil, response.WithDelay(time.Minute))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := client.Query(ctx, &query, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	client = githubv4.NewClient(NewMockedHTTPClient(NewQueryMatcher(query, nil, response.WithDelay(time.Millisecond))))
+	require.NoError(t, client.Query(context.Background(), &query, nil))
+	assert.Equal(t, githubv4.String("octocat"), query.Viewer.Login)
+}
+
+func Test_AssertExpectations(t *testing.T) {
+	var query struct {
+		Viewer struct {
+			Login githubv4.String
+		}
+	}
+	response := DataResponse(map[string]any{"viewer": map[string]any{"login": "octocat"}})
+	mock := NewMock(NewQueryMatcher(query, nil, response).Then(response, response))
+	client := githubv4.NewClient(mock.Client())
+	require.NoError(t, client.Query(context.Background(), &query, nil))
+
+	r := &recorder{}
+	assert.False(t, mock.AssertExpectations(r))
+	require.Len(t, r.errors, 1)
+	assert.Contains(t, r.errors[0], "expected 3 requests")
+	assert.Contains(t, r.errors[0], "got 1")
+
+	require.NoError(t, client.Query(context.Background(), &query, nil))
+	require.NoError(t, client.Query(context.Background(), &query, nil))
+	// The last response is repeated once the sequence is exhausted
+	require.NoError(t, client.Query(context.Background(), &query, nil))
+	assert.True(t, mock.AssertExpectations(t))
+}
@@ -1,6 +1,8 @@
 // Ths contents of this file are taken from https://github.com/shurcooL/graphql/blob/ed46e5a4646634fc16cb07c3b8db389542cc8847/graphql_test.go#L155-L165
 // because they are not exported by the module, and we would like to use them in building the githubv4mock test utility.
 //
+// There is a modification to fail the round trip with the context error when the request is cancelled while it is handled.
+//
 // The original license, copied from https://github.com/shurcooL/graphql/blob/ed46e5a4646634fc16cb07c3b8db389542cc8847/LICENSE
 //
 // MIT License
@@ -40,5 +42,8 @@ type localRoundTripper struct {
 func (l localRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	w := httptest.NewRecorder()
 	l.handler.ServeHTTP(w, req)
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
 	return w.Result(), nil
 }
@@ -1,6 +1,8 @@
 // Ths contents of this file are taken from https://github.com/shurcooL/graphql/blob/ed46e5a4646634fc16cb07c3b8db389542cc8847/query.go
 // because they are not exported by the module, and we would like to use them in building the githubv4mock test utility.
 //
+// There is a modification to queryArguments to take the type of a variable matched by a VariableMatcher from the matcher.
+//
 // The original license, copied from https://github.com/shurcooL/graphql/blob/ed46e5a4646634fc16cb07c3b8db389542cc8847/LICENSE
 //
 // MIT License
@@ -69,7 +71,11 @@ func queryArguments(variables map[string]any) string {
 		_, _ = io.WriteString(&buf, "$")
 		_, _ = io.WriteString(&buf, k)
 		_, _ = io.WriteString(&buf, ":")
-		writeArgumentType(&buf, reflect.TypeOf(variables[k]), true)
+		t := reflect.TypeOf(variables[k])
+		if vm, ok := variables[k].(VariableMatcher); ok {
+			t = vm.typ
+		}
+		writeArgumentType(&buf, t, true)
 		// Don't insert a comma here.
 		// Commas in GraphQL are insignificant, and we want minified output.
 		// See https://spec.graphql.org/October2021/#sec-Insignificant-Commas.
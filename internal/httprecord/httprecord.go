@@ -0,0 +1,415 @@
+// Package httprecord records HTTP traffic into fixture files and replays it, so tests that talk
+// to the GitHub API can run offline.
+//
+// A Recorder is an http.RoundTripper. In record mode it sends requests with the underlying
+// transport and keeps each request and response, with tokens scrubbed, to be written to the
+// fixture file by Save. In replay mode it answers requests with the recorded responses, without
+// any network access, and reports requests that don't match a recorded one with a diff against
+// the closest recorded request.
+package httprecord
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Mode selects whether a Recorder records or replays traffic.
+type Mode string
+
+const (
+	// ModeRecord sends requests to the server and records them
+	ModeRecord Mode = "record"
+	// ModeReplay answers requests with recorded responses
+	ModeReplay Mode = "replay"
+)
+
+// Redacted replaces secrets in recorded traffic.
+const Redacted = "REDACTED"
+
+// tokenPattern matches GitHub tokens, which are scrubbed even if they aren't passed as secrets.
+var tokenPattern = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`)
+
+// recordedHeaders are the response headers kept in fixtures. Other headers, like cookies and
+// rate limits, change on every request and aren't needed to replay the responses.
+var recordedHeaders = []string{
+	"Content-Type",
+	"Link",
+	"Location",
+	"X-Accepted-Oauth-Scopes",
+	"X-Oauth-Scopes",
+}
+
+// Fixture is the content of a fixture file.
+type Fixture struct {
+	// Interactions are the requests and responses, in the order they were sent
+	Interactions []Interaction `json:"interactions"`
+	// Values are the values generated while recording, like unique names, see Recorder.Value
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// Interaction is a request and the response the server sent to it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. Headers aren't recorded, as they hold credentials and don't
+// select what the GitHub API returns beyond what the URL and body do.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   Body   `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is a request or response body. JSON bodies are kept as JSON in fixtures, so they are
+// readable and diff well, and other bodies as strings.
+type Body []byte
+
+// MarshalJSON writes JSON bodies as they are, and other bodies as a JSON string.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if len(b) == 0 {
+		return []byte("null"), nil
+	}
+	// JSON strings are written as strings too, to tell them apart when reading the fixture
+	if json.Valid(b) && !bytes.HasPrefix(bytes.TrimSpace(b), []byte(`"`)) {
+		return b, nil
+	}
+	return json.Marshal(string(b))
+}
+
+// UnmarshalJSON reads a body written by MarshalJSON.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*b = nil
+		return nil
+	}
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		*b = Body(text)
+		return nil
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return err
+	}
+	*b = compact.Bytes()
+	return nil
+}
+
+// Options configures a Recorder.
+type Options struct {
+	// Mode selects whether to record or replay traffic
+	Mode Mode
+	// Transport sends requests in record mode. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+	// Secrets are replaced with Redacted in recorded traffic, in addition to GitHub tokens
+	Secrets []string
+}
+
+// Recorder is an http.RoundTripper that records traffic into a fixture file or replays it.
+// It is safe for concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	secrets   []string
+
+	mu      sync.Mutex
+	fixture Fixture
+	// used marks the interactions that were replayed
+	used []bool
+	// errs are the requests and values that had no recording in replay mode
+	errs []error
+}
+
+// New returns a Recorder for the fixture file at path. In replay mode, the fixture file is read
+// and must exist.
+func New(path string, opts Options) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      opts.Mode,
+		transport: opts.Transport,
+		fixture:   Fixture{Interactions: []Interaction{}},
+	}
+	if r.transport == nil {
+		r.transport = http.DefaultTransport
+	}
+	for _, secret := range opts.Secrets {
+		if secret != "" {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+
+	switch opts.Mode {
+	case ModeRecord:
+		return r, nil
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.fixture); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.fixture.Interactions))
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unknown mode %q, expected %q or %q", opts.Mode, ModeRecord, ModeReplay)
+	}
+}
+
+// scrub replaces the secrets and GitHub tokens in s with Redacted.
+func (r *Recorder) scrub(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Redacted)
+	}
+	return tokenPattern.ReplaceAllString(s, Redacted)
+}
+
+// RoundTrip records or replays a request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+	recorded := Request{
+		Method: req.Method,
+		URL:    r.scrub(normalizeURL(req.URL)),
+		Body:   Body(r.scrub(string(body))),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	// Send the request with its original body
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	header := http.Header{}
+	for _, name := range recordedHeaders {
+		for _, value := range resp.Header.Values(name) {
+			header.Add(name, r.scrub(value))
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fixture.Interactions = append(r.fixture.Interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       Body(r.scrub(string(respBody))),
+		},
+	})
+	return resp, nil
+}
+
+// replay answers a request with the first unused interaction whose request matches it.
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.fixture.Interactions {
+		if r.used[i] || !requestsMatch(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+		resp := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+			StatusCode:    resp.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        resp.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(resp.Body)),
+			ContentLength: int64(len(resp.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	err := r.mismatch(recorded)
+	r.errs = append(r.errs, err)
+	return nil, err
+}
+
+// mismatch describes a request without a recording, with a diff against the closest unused
+// recorded request: the first with the same method and path, or else the next one.
+func (r *Recorder) mismatch(actual Request) error {
+	closest := -1
+	for i, interaction := range r.fixture.Interactions {
+		if r.used[i] {
+			continue
+		}
+		if closest == -1 {
+			closest = i
+		}
+		if interaction.Request.Method == actual.Method && urlPath(interaction.Request.URL) == urlPath(actual.URL) {
+			closest = i
+			break
+		}
+	}
+	if closest == -1 {
+		return fmt.Errorf("httprecord: no recorded interaction left for %s %s in %s", actual.Method, actual.URL, r.path)
+	}
+
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(formatRequest(r.fixture.Interactions[closest].Request)),
+		B:        difflib.SplitLines(formatRequest(actual)),
+		FromFile: "recorded",
+		ToFile:   "actual",
+		Context:  3,
+	})
+	return fmt.Errorf("httprecord: no recorded interaction matches %s %s in %s, closest is #%d:\n%s",
+		actual.Method, actual.URL, r.path, closest, diff)
+}
+
+// Value returns a value that must be the same when replaying, like a unique name derived from the
+// time. In record mode, generate is called and its result kept in the fixture. In replay mode,
+// the recorded value is returned.
+func (r *Recorder) Value(name string, generate func() string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		if value, ok := r.fixture.Values[name]; ok {
+			return value
+		}
+		r.errs = append(r.errs, fmt.Errorf("httprecord: no recorded value %q in %s", name, r.path))
+		return generate()
+	}
+
+	if r.fixture.Values == nil {
+		r.fixture.Values = make(map[string]string)
+	}
+	value := generate()
+	r.fixture.Values[name] = value
+	return value
+}
+
+// Save writes the recorded traffic to the fixture file in record mode. It does nothing in replay
+// mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	// Keep URLs and Link headers readable
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r.fixture); err != nil {
+		return fmt.Errorf("failed to marshal fixture: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, data.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// Err reports, in replay mode, the requests and values that had no recording, and the recorded
+// interactions that were not replayed. It returns nil if the traffic matched the fixture.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	errs := append([]error{}, r.errs...)
+	for i, used := range r.used {
+		if !used {
+			request := r.fixture.Interactions[i].Request
+			errs = append(errs, fmt.Errorf("httprecord: recorded interaction #%d %s %s in %s was not replayed",
+				i, request.Method, request.URL, r.path))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// requestsMatch reports whether two requests are the same, comparing JSON bodies by value.
+func requestsMatch(a, b Request) bool {
+	if a.Method != b.Method || a.URL != b.URL {
+		return false
+	}
+	if bytes.Equal(a.Body, b.Body) {
+		return true
+	}
+	var aValue, bValue any
+	if json.Unmarshal(a.Body, &aValue) != nil || json.Unmarshal(b.Body, &bValue) != nil {
+		return false
+	}
+	aJSON, _ := json.Marshal(aValue)
+	bJSON, _ := json.Marshal(bValue)
+	return bytes.Equal(aJSON, bJSON)
+}
+
+// normalizeURL returns the URL with its query parameters sorted, so they match in any order.
+func normalizeURL(u *url.URL) string {
+	normalized := *u
+	query := u.Query()
+	for _, values := range query {
+		sort.Strings(values)
+	}
+	normalized.RawQuery = query.Encode()
+	return normalized.String()
+}
+
+// urlPath returns the URL without its query.
+func urlPath(rawURL string) string {
+	path, _, _ := strings.Cut(rawURL, "?")
+	return path
+}
+
+// formatRequest writes a request for diffing, with its JSON body indented.
+func formatRequest(req Request) string {
+	var body bytes.Buffer
+	if err := json.Indent(&body, req.Body, "", "  "); err != nil {
+		body.Reset()
+		body.Write(req.Body)
+	}
+	return fmt.Sprintf("%s %s\n\n%s\n", req.Method, req.URL, body.String())
+}
@@ -0,0 +1,442 @@
+package toolsnaps
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Severity classifies a schema change by its effect on existing clients.
+type Severity string
+
+const (
+	// SeverityBreaking is a change that can break a client built against the snapshot.
+	SeverityBreaking Severity = "breaking"
+	// SeverityCompatible is a change that existing clients can ignore.
+	SeverityCompatible Severity = "compatible"
+)
+
+// Change is a single classified difference between a tool's snapshot and its current schema.
+type Change struct {
+	Tool        string   `json:"tool"`
+	Path        string   `json:"path,omitempty"`
+	Severity    Severity `json:"severity"`
+	Description string   `json:"description"`
+}
+
+func (c Change) String() string {
+	if c.Path == "" {
+		return fmt.Sprintf("%s: %s", c.Tool, c.Description)
+	}
+	return fmt.Sprintf("%s: %s: %s", c.Tool, c.Path, c.Description)
+}
+
+// Breaking returns the breaking changes in changes.
+func Breaking(changes []Change) []Change {
+	var breaking []Change
+	for _, c := range changes {
+		if c.Severity == SeverityBreaking {
+			breaking = append(breaking, c)
+		}
+	}
+	return breaking
+}
+
+// CompareDir compares tools with the snapshots in dir and classifies every difference. tools maps
+// each tool name to its variants, such as the versions of a tool selected by feature flags, which
+// share a snapshot; the variant closest to the snapshot is compared. A tool without a snapshot is a
+// compatible addition and a snapshot without a tool is a breaking removal. Changes are sorted by
+// tool and path.
+func CompareDir(dir string, tools map[string][]any) ([]Change, error) {
+	snapPaths, err := filepath.Glob(filepath.Join(dir, "*.snap"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	snapshots := make(map[string]string, len(snapPaths))
+	for _, path := range snapPaths {
+		snapshots[strings.TrimSuffix(filepath.Base(path), ".snap")] = path
+	}
+
+	var changes []Change
+	for name, variants := range tools {
+		path, ok := snapshots[name]
+		if !ok {
+			changes = append(changes, Change{Tool: name, Severity: SeverityCompatible, Description: "tool added"})
+			continue
+		}
+		snapJSON, err := os.ReadFile(path) //nolint:gosec // snapshots are read from a directory chosen by the caller
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot for %s: %w", name, err)
+		}
+		var closest []Change
+		for i, tool := range variants {
+			toolJSON, err := json.Marshal(tool)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal tool %s: %w", name, err)
+			}
+			toolChanges, err := Compare(name, snapJSON, toolJSON)
+			if err != nil {
+				return nil, err
+			}
+			if i == 0 || len(toolChanges) < len(closest) {
+				closest = toolChanges
+			}
+		}
+		changes = append(changes, closest...)
+	}
+	for name := range snapshots {
+		if _, ok := tools[name]; !ok {
+			changes = append(changes, Change{Tool: name, Severity: SeverityBreaking, Description: "tool removed"})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Tool != changes[j].Tool {
+			return changes[i].Tool < changes[j].Tool
+		}
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// Compare classifies the differences between the snapshot of a tool and its current JSON.
+//
+// In the input schema, a change that rejects arguments the snapshot accepted is breaking: a removed
+// property, a newly required property, a narrowed type or enum, or a tighter bound. In the output
+// schema the direction is reversed, so a change that returns values the snapshot did not describe
+// is breaking, and so is a removed property. Descriptions, titles and icons never break clients.
+// Changes the classifier does not understand are reported as breaking.
+func Compare(toolName string, snapshot, current []byte) ([]Change, error) {
+	var before, after map[string]any
+	if err := json.Unmarshal(snapshot, &before); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot JSON for %s: %w", toolName, err)
+	}
+	if err := json.Unmarshal(current, &after); err != nil {
+		return nil, fmt.Errorf("failed to parse tool JSON for %s: %w", toolName, err)
+	}
+
+	c := &classifier{tool: toolName}
+	for _, key := range unionKeys(before, after) {
+		oldValue, newValue := before[key], after[key]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		switch key {
+		case "description", "icons", "title":
+			c.add(key, SeverityCompatible, "%s changed", key)
+		case "annotations":
+			c.compareAnnotations(asObject(oldValue), asObject(newValue))
+		case "inputSchema", "outputSchema":
+			c.compareRootSchema(key, oldValue, newValue)
+		default:
+			c.add(key, SeverityBreaking, "%s changed", key)
+		}
+	}
+	return c.changes, nil
+}
+
+type classifier struct {
+	tool    string
+	changes []Change
+}
+
+func (c *classifier) add(path string, severity Severity, format string, args ...any) {
+	c.changes = append(c.changes, Change{
+		Tool:        c.tool,
+		Path:        path,
+		Severity:    severity,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// hintDefaults are the values the MCP specification assumes for absent hints. Each is the value
+// that promises the least to a client, so a hint changing to its default is breaking.
+var hintDefaults = map[string]bool{
+	"readOnlyHint":    false,
+	"destructiveHint": true,
+	"idempotentHint":  false,
+	"openWorldHint":   true,
+}
+
+func (c *classifier) compareAnnotations(before, after map[string]any) {
+	for _, key := range unionKeys(before, after) {
+		oldValue, newValue := before[key], after[key]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		path := "annotations." + key
+		unsafe, isHint := hintDefaults[key]
+		if !isHint {
+			c.add(path, SeverityCompatible, "%s changed", key)
+			continue
+		}
+		oldHint, newHint := hintValue(key, oldValue), hintValue(key, newValue)
+		switch {
+		case oldHint == newHint:
+			c.add(path, SeverityCompatible, "%s made explicit", key)
+		case newHint == unsafe:
+			c.add(path, SeverityBreaking, "%s changed to %t", key, newHint)
+		default:
+			c.add(path, SeverityCompatible, "%s changed to %t", key, newHint)
+		}
+	}
+}
+
+func hintValue(key string, value any) bool {
+	if b, ok := value.(bool); ok {
+		return b
+	}
+	return hintDefaults[key]
+}
+
+func (c *classifier) compareRootSchema(key string, before, after any) {
+	output := key == "outputSchema"
+	switch {
+	case before == nil && output:
+		c.add(key, SeverityCompatible, "output schema added")
+	case after == nil && output:
+		c.add(key, SeverityBreaking, "output schema removed")
+	default:
+		c.compareSchema(key, asObject(before), asObject(after), output)
+	}
+}
+
+// narrowed returns the severity of a change that allows fewer values than before.
+func narrowed(output bool) Severity {
+	if output {
+		return SeverityCompatible
+	}
+	return SeverityBreaking
+}
+
+// widened returns the severity of a change that allows more values than before.
+func widened(output bool) Severity {
+	if output {
+		return SeverityBreaking
+	}
+	return SeverityCompatible
+}
+
+func (c *classifier) compareSchema(path string, before, after map[string]any, output bool) {
+	for _, key := range unionKeys(before, after) {
+		oldValue, newValue := before[key], after[key]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		keyPath := path + "." + key
+		switch key {
+		case "description", "title", "examples":
+			c.add(keyPath, SeverityCompatible, "%s changed", key)
+		case "default":
+			if output {
+				c.add(keyPath, SeverityCompatible, "default changed")
+			} else {
+				c.add(keyPath, SeverityBreaking, "default changed from %s to %s", formatValue(oldValue), formatValue(newValue))
+			}
+		case "type":
+			c.compareTypes(keyPath, oldValue, newValue, output)
+		case "enum":
+			c.compareEnums(keyPath, oldValue, newValue, output)
+		case "properties":
+			c.compareProperties(keyPath, asObject(oldValue), asObject(newValue), output)
+		case "required":
+			c.compareRequired(path, oldValue, newValue, output)
+		case "items":
+			c.compareSubschema(keyPath, oldValue, newValue, output)
+		case "additionalProperties":
+			c.compareAdditionalProperties(keyPath, oldValue, newValue, output)
+		case "minimum", "minLength", "minItems":
+			c.compareBound(keyPath, key, oldValue, newValue, output, true)
+		case "maximum", "maxLength", "maxItems":
+			c.compareBound(keyPath, key, oldValue, newValue, output, false)
+		case "format", "pattern":
+			if newValue == nil {
+				c.add(keyPath, widened(output), "%s removed", key)
+			} else {
+				c.add(keyPath, narrowed(output), "%s changed to %s", key, formatValue(newValue))
+			}
+		default:
+			c.add(keyPath, SeverityBreaking, "%s changed", key)
+		}
+	}
+}
+
+func (c *classifier) compareSubschema(path string, before, after any, output bool) {
+	switch {
+	case before == nil:
+		c.add(path, narrowed(output), "schema added")
+	case after == nil:
+		c.add(path, widened(output), "schema removed")
+	default:
+		c.compareSchema(path, asObject(before), asObject(after), output)
+	}
+}
+
+func (c *classifier) compareTypes(path string, before, after any, output bool) {
+	switch {
+	case before == nil:
+		c.add(path, narrowed(output), "type restricted to %s", formatValue(after))
+		return
+	case after == nil:
+		c.add(path, widened(output), "type restriction removed")
+		return
+	}
+	removed, added := diffValues(asList(before), asList(after))
+	if len(removed) > 0 {
+		c.add(path, narrowed(output), "type %s removed", formatValues(removed))
+	}
+	if len(added) > 0 {
+		c.add(path, widened(output), "type %s added", formatValues(added))
+	}
+}
+
+func (c *classifier) compareEnums(path string, before, after any, output bool) {
+	switch {
+	case before == nil:
+		c.add(path, narrowed(output), "values restricted to %s", formatValues(asList(after)))
+		return
+	case after == nil:
+		c.add(path, widened(output), "value restriction removed")
+		return
+	}
+	removed, added := diffValues(asList(before), asList(after))
+	if len(removed) > 0 {
+		c.add(path, narrowed(output), "values %s removed", formatValues(removed))
+	}
+	if len(added) > 0 {
+		c.add(path, widened(output), "values %s added", formatValues(added))
+	}
+}
+
+func (c *classifier) compareProperties(path string, before, after map[string]any, output bool) {
+	for _, name := range unionKeys(before, after) {
+		oldValue, oldOK := before[name]
+		newValue, newOK := after[name]
+		propertyPath := path + "." + name
+		switch {
+		case !newOK:
+			// Removing an argument breaks the clients that send it, and removing a result
+			// property breaks the clients that read it.
+			c.add(propertyPath, SeverityBreaking, "property removed")
+		case !oldOK:
+			c.add(propertyPath, SeverityCompatible, "property added")
+		case !reflect.DeepEqual(oldValue, newValue):
+			c.compareSchema(propertyPath, asObject(oldValue), asObject(newValue), output)
+		}
+	}
+}
+
+func (c *classifier) compareRequired(path string, before, after any, output bool) {
+	removed, added := diffValues(asList(before), asList(after))
+	for _, name := range added {
+		c.add(fmt.Sprintf("%s.properties.%v", path, name), narrowed(output), "property is now required")
+	}
+	for _, name := range removed {
+		c.add(fmt.Sprintf("%s.properties.%v", path, name), widened(output), "property is no longer required")
+	}
+}
+
+func (c *classifier) compareAdditionalProperties(path string, before, after any, output bool) {
+	oldSchema, oldIsSchema := before.(map[string]any)
+	newSchema, newIsSchema := after.(map[string]any)
+	switch {
+	case oldIsSchema && newIsSchema:
+		c.compareSchema(path, oldSchema, newSchema, output)
+	case after == false:
+		c.add(path, narrowed(output), "additional properties disallowed")
+	case before == false:
+		c.add(path, widened(output), "additional properties allowed")
+	case newIsSchema:
+		c.add(path, narrowed(output), "additional properties restricted")
+	default:
+		c.add(path, widened(output), "additional properties restriction removed")
+	}
+}
+
+func (c *classifier) compareBound(path, key string, before, after any, output, lower bool) {
+	oldBound, oldOK := before.(float64)
+	newBound, newOK := after.(float64)
+	switch {
+	case !newOK:
+		c.add(path, widened(output), "%s removed", key)
+	case !oldOK, lower && newBound > oldBound, !lower && newBound < oldBound:
+		c.add(path, narrowed(output), "%s tightened to %v", key, newBound)
+	default:
+		c.add(path, widened(output), "%s relaxed to %v", key, newBound)
+	}
+}
+
+// diffValues returns the values of before missing from after, and the values of after missing from
+// before.
+func diffValues(before, after []any) (removed, added []any) {
+	contains := func(values []any, v any) bool {
+		for _, candidate := range values {
+			if reflect.DeepEqual(candidate, v) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, v := range before {
+		if !contains(after, v) {
+			removed = append(removed, v)
+		}
+	}
+	for _, v := range after {
+		if !contains(before, v) {
+			added = append(added, v)
+		}
+	}
+	return removed, added
+}
+
+func unionKeys(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func asObject(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// asList returns v as a list, treating a single value, such as a type name, as a list of one.
+func asList(v any) []any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		return v
+	default:
+		return []any{v}
+	}
+}
+
+func formatValue(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func formatValues(values []any) string {
+	formatted := make([]string, len(values))
+	for i, v := range values {
+		formatted[i] = formatValue(v)
+	}
+	return strings.Join(formatted, ", ")
+}
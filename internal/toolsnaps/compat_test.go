@@ -0,0 +1,199 @@
+package toolsnaps
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const baseTool = `{
+  "name": "issue_write",
+  "description": "Create or update an issue.",
+  "annotations": {"title": "Write issue", "readOnlyHint": false},
+  "inputSchema": {
+    "type": "object",
+    "properties": {
+      "owner": {"type": "string", "description": "Repository owner"},
+      "title": {"type": "string", "description": "Issue title"},
+      "state": {"type": "string", "enum": ["open", "closed"]},
+      "perPage": {"type": "number", "minimum": 1, "maximum": 100}
+    },
+    "required": ["owner"]
+  },
+  "outputSchema": {
+    "type": "object",
+    "properties": {
+      "url": {"type": "string"},
+      "state": {"type": "string", "enum": ["open", "closed"]}
+    },
+    "required": ["url"]
+  }
+}`
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		expected []Change
+	}{
+		{
+			name:    "unchanged",
+			current: baseTool,
+		},
+		{
+			name: "description changes are compatible",
+			current: replace(baseTool,
+				`"Create or update an issue."`, `"Create or update a GitHub issue."`,
+				`"Issue title"`, `"The title of the issue"`),
+			expected: []Change{
+				{Path: "description", Severity: SeverityCompatible, Description: "description changed"},
+				{Path: "inputSchema.properties.title.description", Severity: SeverityCompatible, Description: "description changed"},
+			},
+		},
+		{
+			name: "removed property is breaking",
+			current: replace(baseTool,
+				`"title": {"type": "string", "description": "Issue title"},`, ``),
+			expected: []Change{
+				{Path: "inputSchema.properties.title", Severity: SeverityBreaking, Description: "property removed"},
+			},
+		},
+		{
+			name: "added optional property is compatible",
+			current: replace(baseTool,
+				`"owner": {"type": "string", "description": "Repository owner"},`,
+				`"owner": {"type": "string", "description": "Repository owner"}, "body": {"type": "string"},`),
+			expected: []Change{
+				{Path: "inputSchema.properties.body", Severity: SeverityCompatible, Description: "property added"},
+			},
+		},
+		{
+			name:    "newly required property is breaking",
+			current: replace(baseTool, `"required": ["owner"]`, `"required": ["owner", "title"]`),
+			expected: []Change{
+				{Path: "inputSchema.properties.title", Severity: SeverityBreaking, Description: "property is now required"},
+			},
+		},
+		{
+			name:    "narrowed input enum is breaking and widened is compatible",
+			current: replace(baseTool, `"state": {"type": "string", "enum": ["open", "closed"]},`, `"state": {"type": "string", "enum": ["open", "all"]},`),
+			expected: []Change{
+				{Path: "inputSchema.properties.state.enum", Severity: SeverityBreaking, Description: `values "closed" removed`},
+				{Path: "inputSchema.properties.state.enum", Severity: SeverityCompatible, Description: `values "all" added`},
+			},
+		},
+		{
+			name: "type change is breaking",
+			current: replace(baseTool,
+				`"perPage": {"type": "number"`, `"perPage": {"type": "string"`),
+			expected: []Change{
+				{Path: "inputSchema.properties.perPage.type", Severity: SeverityBreaking, Description: `type "number" removed`},
+				{Path: "inputSchema.properties.perPage.type", Severity: SeverityCompatible, Description: `type "string" added`},
+			},
+		},
+		{
+			name:    "tighter bounds are breaking",
+			current: replace(baseTool, `"minimum": 1, "maximum": 100`, `"minimum": 0, "maximum": 50`),
+			expected: []Change{
+				{Path: "inputSchema.properties.perPage.maximum", Severity: SeverityBreaking, Description: "maximum tightened to 50"},
+				{Path: "inputSchema.properties.perPage.minimum", Severity: SeverityCompatible, Description: "minimum relaxed to 0"},
+			},
+		},
+		{
+			name: "output schema changes are classified in the opposite direction",
+			current: replace(baseTool,
+				`"state": {"type": "string", "enum": ["open", "closed"]}
+    },
+    "required": ["url"]`,
+				`"state": {"type": "string", "enum": ["open", "closed", "merged"]}
+    },
+    "required": []`),
+			expected: []Change{
+				{Path: "outputSchema.properties.state.enum", Severity: SeverityBreaking, Description: `values "merged" added`},
+				{Path: "outputSchema.properties.url", Severity: SeverityBreaking, Description: "property is no longer required"},
+			},
+		},
+		{
+			name: "removed output property is breaking",
+			current: replace(baseTool, `"url": {"type": "string"},
+      `, ``),
+			expected: []Change{
+				{Path: "outputSchema.properties.url", Severity: SeverityBreaking, Description: "property removed"},
+			},
+		},
+		{
+			name:    "gaining the read-only hint is compatible",
+			current: replace(baseTool, `"annotations": {"title": "Write issue", "readOnlyHint": false}`, `"annotations": {"title": "Write issue", "readOnlyHint": true, "destructiveHint": true}`),
+			expected: []Change{
+				{Path: "annotations.destructiveHint", Severity: SeverityCompatible, Description: "destructiveHint made explicit"},
+				{Path: "annotations.readOnlyHint", Severity: SeverityCompatible, Description: "readOnlyHint changed to true"},
+			},
+		},
+		{
+			name:    "unknown keywords are breaking",
+			current: replace(baseTool, `"owner": {"type": "string",`, `"owner": {"type": "string", "const": "github",`),
+			expected: []Change{
+				{Path: "inputSchema.properties.owner.const", Severity: SeverityBreaking, Description: "const changed"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changes, err := Compare("issue_write", []byte(baseTool), []byte(tc.current))
+			require.NoError(t, err)
+			for i := range tc.expected {
+				tc.expected[i].Tool = "issue_write"
+			}
+			assert.ElementsMatch(t, tc.expected, changes)
+		})
+	}
+}
+
+func TestCompareReadOnlyHintRemoved(t *testing.T) {
+	snapshot := `{"name": "get_me", "annotations": {"readOnlyHint": true}}`
+	changes, err := Compare("get_me", []byte(snapshot), []byte(`{"name": "get_me", "annotations": {}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Tool: "get_me", Path: "annotations.readOnlyHint", Severity: SeverityBreaking, Description: "readOnlyHint changed to false"},
+	}, changes)
+}
+
+func TestCompareDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "issue_write.snap"), []byte(baseTool), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "old_tool.snap"), []byte(`{"name": "old_tool"}`), 0600))
+
+	var issueWrite map[string]any
+	require.NoError(t, json.Unmarshal([]byte(replace(baseTool, `"Issue title"`, `"The title of the issue"`)), &issueWrite))
+
+	changes, err := CompareDir(dir, map[string][]any{
+		"issue_write": {map[string]any{"name": "issue_write"}, issueWrite},
+		"new_tool":    {map[string]any{"name": "new_tool"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Change{
+		{Tool: "issue_write", Path: "inputSchema.properties.title.description", Severity: SeverityCompatible, Description: "description changed"},
+		{Tool: "new_tool", Severity: SeverityCompatible, Description: "tool added"},
+		{Tool: "old_tool", Severity: SeverityBreaking, Description: "tool removed"},
+	}, changes)
+	assert.Equal(t, []Change{changes[2]}, Breaking(changes))
+	assert.Equal(t, "old_tool: tool removed", changes[2].String())
+}
+
+// replace applies old/new string pairs to s, failing loudly if an old string is missing.
+func replace(s string, pairs ...string) string {
+	for i := 0; i < len(pairs); i += 2 {
+		if !strings.Contains(s, pairs[i]) {
+			panic("replace: missing " + pairs[i])
+		}
+		s = strings.ReplaceAll(s, pairs[i], pairs[i+1])
+	}
+	return s
+}
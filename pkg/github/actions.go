@@ -49,6 +49,82 @@ const (
 	actionsMethodDeleteWorkflowRunLogs    = "delete_workflow_run_logs"
 )
 
+// Output schemas of actions tools whose results are ad-hoc objects rather than API objects.
+var (
+	workflowDispatchOutputSchema = ObjectOutputSchema("Confirmation that the workflow run was queued.", map[string]*jsonschema.Schema{
+		"message":       {Type: "string"},
+		"workflow_type": {Type: "string"},
+		"workflow_id":   {Type: "string"},
+		"ref":           {Type: "string"},
+		"inputs":        {Types: []string{"null", "object"}},
+		"status":        {Type: "string"},
+		"status_code":   {Type: "integer"},
+	})
+	workflowRunActionOutputSchema = ObjectOutputSchema("Confirmation that the request was accepted.", map[string]*jsonschema.Schema{
+		"message":     {Type: "string"},
+		"run_id":      {Type: "integer"},
+		"status":      {Type: "string"},
+		"status_code": {Type: "integer"},
+	})
+	workflowRunLogsURLOutputSchema = ObjectOutputSchema("Download URL for the complete workflow run logs.", map[string]*jsonschema.Schema{
+		"logs_url":         {Type: "string"},
+		"message":          {Type: "string"},
+		"note":             {Type: "string"},
+		"warning":          {Type: "string"},
+		"optimization_tip": {Type: "string"},
+	})
+	artifactDownloadOutputSchema = ObjectOutputSchema("Download URL for the artifact.", map[string]*jsonschema.Schema{
+		"download_url": {Type: "string"},
+		"message":      {Type: "string"},
+		"note":         {Type: "string"},
+		"artifact_id":  {Type: "integer"},
+	})
+	jobLogsOutputSchema = AnyOfOutputSchema(
+		jobLogOutputSchema("Logs of a single job, with either logs_content or logs_url."),
+		ObjectOutputSchema("Logs of the failed jobs of a workflow run (failed_only), with an entry per failed job.", map[string]*jsonschema.Schema{
+			"message":     {Type: "string"},
+			"run_id":      {Type: "integer"},
+			"total_jobs":  {Type: "integer"},
+			"failed_jobs": {Type: "integer"},
+			"logs": {
+				Types: []string{"null", "array"},
+				Items: jobLogOutputSchema("Logs of a failed job, or the error retrieving them."),
+			},
+			"return_format": {
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"content": {Type: "boolean"},
+					"urls":    {Type: "boolean"},
+				},
+			},
+		}),
+	)
+)
+
+// jobLogOutputSchema describes the logs of a single job as returned by getJobLogData. Failed jobs
+// whose logs could not be retrieved carry an error instead.
+func jobLogOutputSchema(description string) *jsonschema.Schema {
+	return ObjectOutputSchema(description, map[string]*jsonschema.Schema{
+		"job_id":          {Type: "integer"},
+		"job_name":        {Type: "string"},
+		"message":         {Type: "string"},
+		"logs_content":    {Type: "string"},
+		"original_length": {Type: "integer"},
+		"logs_url":        {Type: "string"},
+		"note":            {Type: "string"},
+		"error":           {Type: "string"},
+	})
+}
+
+// workflowJobsOutputSchema describes the jobs of a workflow run, which list_workflow_jobs returns
+// with an optimization_tip.
+func workflowJobsOutputSchema(description string) *jsonschema.Schema {
+	return ObjectOutputSchema(description, map[string]*jsonschema.Schema{
+		"jobs":             APIObjectOutputSchema[github.Jobs]("Jobs as returned by the GitHub REST API, with total_count and jobs."),
+		"optimization_tip": {Type: "string"},
+	})
+}
+
 // ListWorkflows creates a tool to list workflows in a repository
 func ListWorkflows(t translations.TranslationHelperFunc) inventory.ServerTool {
 	tool := NewTool(
@@ -60,7 +136,7 @@ func ListWorkflows(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_LIST_WORKFLOWS_USER_TITLE", "List workflows"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithFields(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -73,7 +149,8 @@ func ListWorkflows(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 				},
 				Required: []string{"owner", "repo"},
-			}),
+			})),
+			OutputSchema: APIObjectOutputSchema[github.Workflows]("Workflows of the repository as returned by the GitHub REST API, with total_count and workflows."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -109,12 +186,7 @@ func ListWorkflows(t translations.TranslationHelperFunc) inventory.ServerTool {
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(workflows)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, workflows), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -132,7 +204,7 @@ func ListWorkflowRuns(t translations.TranslationHelperFunc) inventory.ServerTool
 				Title:        t("TOOL_LIST_WORKFLOW_RUNS_USER_TITLE", "List workflow runs"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithFields(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -200,7 +272,8 @@ func ListWorkflowRuns(t translations.TranslationHelperFunc) inventory.ServerTool
 					},
 				},
 				Required: []string{"owner", "repo", "workflow_id"},
-			}),
+			})),
+			OutputSchema: APIObjectOutputSchema[github.WorkflowRuns]("Workflow runs as returned by the GitHub REST API, with total_count and workflow_runs."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -264,12 +337,7 @@ func ListWorkflowRuns(t translations.TranslationHelperFunc) inventory.ServerTool
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(workflowRuns)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, workflowRuns), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -313,6 +381,7 @@ func RunWorkflow(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner", "repo", "workflow_id", "ref"},
 			},
+			OutputSchema: workflowDispatchOutputSchema,
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -377,12 +446,7 @@ func RunWorkflow(t translations.TranslationHelperFunc) inventory.ServerTool {
 				"status_code":   resp.StatusCode,
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, result), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -400,7 +464,7 @@ func GetWorkflowRun(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_GET_WORKFLOW_RUN_USER_TITLE", "Get workflow run"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFields(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -417,7 +481,8 @@ func GetWorkflowRun(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 				},
 				Required: []string{"owner", "repo", "run_id"},
-			},
+			}),
+			OutputSchema: APIObjectOutputSchema[github.WorkflowRun]("Workflow run as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -446,12 +511,7 @@ func GetWorkflowRun(t translations.TranslationHelperFunc) inventory.ServerTool {
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(workflowRun)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, workflowRun), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -487,6 +547,7 @@ func GetWorkflowRunLogs(t translations.TranslationHelperFunc) inventory.ServerTo
 				},
 				Required: []string{"owner", "repo", "run_id"},
 			},
+			OutputSchema: workflowRunLogsURLOutputSchema,
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -525,12 +586,7 @@ func GetWorkflowRunLogs(t translations.TranslationHelperFunc) inventory.ServerTo
 				"optimization_tip": "Use: get_job_logs with parameters {run_id: " + fmt.Sprintf("%d", runID) + ", failed_only: true} for more efficient failed job debugging",
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, result), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -571,6 +627,7 @@ func ListWorkflowJobs(t translations.TranslationHelperFunc) inventory.ServerTool
 				},
 				Required: []string{"owner", "repo", "run_id"},
 			}),
+			OutputSchema: workflowJobsOutputSchema("Jobs of the workflow run, with jobs and an optimization_tip."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -626,12 +683,7 @@ func ListWorkflowJobs(t translations.TranslationHelperFunc) inventory.ServerTool
 				"optimization_tip": "For debugging failed jobs, consider using get_job_logs with failed_only=true and run_id=" + fmt.Sprintf("%d", runID) + " to get logs directly without needing to list jobs first",
 			}
 
-			r, err := json.Marshal(response)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, response), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -684,6 +736,7 @@ func GetJobLogs(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner", "repo"},
 			},
+			OutputSchema: jobLogsOutputSchema,
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -776,8 +829,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 			"total_jobs":  len(jobs.Jobs),
 			"failed_jobs": 0,
 		}
-		r, _ := json.Marshal(result)
-		return utils.NewToolResultText(string(r)), nil, nil
+		return MarshalledTextResult(ctx, result), nil, nil
 	}
 
 	// Collect logs for all failed jobs
@@ -807,12 +859,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 		"return_format": map[string]bool{"content": returnContent, "urls": !returnContent},
 	}
 
-	r, err := json.Marshal(result)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, result), nil, nil
 }
 
 // handleSingleJobLogs gets logs for a single job
@@ -822,12 +869,7 @@ func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo
 		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get job logs", resp, err), nil, nil
 	}
 
-	r, err := json.Marshal(jobResult)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, jobResult), nil, nil
 }
 
 // getJobLogData retrieves log data for a single job, either as URL or content
@@ -933,6 +975,7 @@ func RerunWorkflowRun(t translations.TranslationHelperFunc) inventory.ServerTool
 				},
 				Required: []string{"owner", "repo", "run_id"},
 			},
+			OutputSchema: workflowRunActionOutputSchema,
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -968,12 +1011,7 @@ func RerunWorkflowRun(t translations.TranslationHelperFunc) inventory.ServerTool
 				"status_code": resp.StatusCode,
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, result), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -1009,6 +1047,7 @@ func RerunFailedJobs(t translations.TranslationHelperFunc) inventory.ServerTool
 				},
 				Required: []string{"owner", "repo", "run_id"},
 			},
+			OutputSchema: workflowRunActionOutputSchema,
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1044,12 +1083,7 @@ func RerunFailedJobs(t translations.TranslationHelperFunc) inventory.ServerTool
 				"status_code": resp.StatusCode,
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, result), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -1085,6 +1119,7 @@ func CancelWorkflowRun(t translations.TranslationHelperFunc) inventory.ServerToo
 				},
 				Required: []string{"owner", "repo", "run_id"},
 			},
+			OutputSchema: workflowRunActionOutputSchema,
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1122,12 +1157,7 @@ func CancelWorkflowRun(t translations.TranslationHelperFunc) inventory.ServerToo
 				"status_code": resp.StatusCode,
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, result), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -1145,7 +1175,7 @@ func ListWorkflowRunArtifacts(t translations.TranslationHelperFunc) inventory.Se
 				Title:        t("TOOL_LIST_WORKFLOW_RUN_ARTIFACTS_USER_TITLE", "List workflow artifacts"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithFields(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -1162,7 +1192,8 @@ func ListWorkflowRunArtifacts(t translations.TranslationHelperFunc) inventory.Se
 					},
 				},
 				Required: []string{"owner", "repo", "run_id"},
-			}),
+			})),
+			OutputSchema: APIObjectOutputSchema[github.ArtifactList]("Artifacts of the workflow run as returned by the GitHub REST API, with total_count and artifacts."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1203,12 +1234,7 @@ func ListWorkflowRunArtifacts(t translations.TranslationHelperFunc) inventory.Se
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(artifacts)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, artifacts), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -1244,6 +1270,7 @@ func DownloadWorkflowRunArtifact(t translations.TranslationHelperFunc) inventory
 				},
 				Required: []string{"owner", "repo", "artifact_id"},
 			},
+			OutputSchema: artifactDownloadOutputSchema,
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1281,12 +1308,7 @@ func DownloadWorkflowRunArtifact(t translations.TranslationHelperFunc) inventory
 				"artifact_id":  artifactID,
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, result), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -1323,6 +1345,7 @@ func DeleteWorkflowRunLogs(t translations.TranslationHelperFunc) inventory.Serve
 				},
 				Required: []string{"owner", "repo", "run_id"},
 			},
+			OutputSchema: workflowRunActionOutputSchema,
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1358,12 +1381,7 @@ func DeleteWorkflowRunLogs(t translations.TranslationHelperFunc) inventory.Serve
 				"status_code": resp.StatusCode,
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, result), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -1399,6 +1417,7 @@ func GetWorkflowRunUsage(t translations.TranslationHelperFunc) inventory.ServerT
 				},
 				Required: []string{"owner", "repo", "run_id"},
 			},
+			OutputSchema: APIObjectOutputSchema[github.WorkflowRunUsage]("Billable time of the workflow run as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1427,12 +1446,7 @@ func GetWorkflowRunUsage(t translations.TranslationHelperFunc) inventory.ServerT
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(usage)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, usage), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedActions
@@ -1564,6 +1578,12 @@ Use this tool to list workflows in a repository, or list workflow runs, jobs, an
 				},
 				Required: []string{"method", "owner", "repo"},
 			},
+			OutputSchema: AnyOfOutputSchema(
+				APIObjectOutputSchema[github.Workflows]("Workflows as returned by the GitHub REST API (method list_workflows)."),
+				APIObjectOutputSchema[github.WorkflowRuns]("Workflow runs as returned by the GitHub REST API (method list_workflow_runs)."),
+				workflowJobsOutputSchema("Jobs of the workflow run (method list_workflow_jobs)."),
+				APIObjectOutputSchema[github.ArtifactList]("Artifacts as returned by the GitHub REST API (method list_workflow_run_artifacts)."),
+			),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1683,6 +1703,14 @@ Use this tool to get details about individual workflows, workflow runs, jobs, an
 				},
 				Required: []string{"method", "owner", "repo", "resource_id"},
 			},
+			OutputSchema: AnyOfOutputSchema(
+				APIObjectOutputSchema[github.Workflow]("Workflow as returned by the GitHub REST API (method get_workflow)."),
+				APIObjectOutputSchema[github.WorkflowRun]("Workflow run as returned by the GitHub REST API (method get_workflow_run)."),
+				APIObjectOutputSchema[github.WorkflowJob]("Workflow job as returned by the GitHub REST API (method get_workflow_job)."),
+				APIObjectOutputSchema[github.WorkflowRunUsage]("Billable time of the workflow run as returned by the GitHub REST API (method get_workflow_run_usage)."),
+				artifactDownloadOutputSchema,
+				workflowRunLogsURLOutputSchema,
+			),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1797,6 +1825,7 @@ func ActionsRunTrigger(t translations.TranslationHelperFunc) inventory.ServerToo
 				},
 				Required: []string{"method", "owner", "repo"},
 			},
+			OutputSchema: AnyOfOutputSchema(workflowDispatchOutputSchema, workflowRunActionOutputSchema),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1912,6 +1941,7 @@ For single job logs, provide job_id. For all failed jobs in a run, provide run_i
 				},
 				Required: []string{"owner", "repo"},
 			},
+			OutputSchema: jobLogsOutputSchema,
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1999,12 +2029,7 @@ func getWorkflow(ctx context.Context, client *github.Client, owner, repo, resour
 	}
 
 	defer func() { _ = resp.Body.Close() }()
-	r, err := json.Marshal(workflow)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal workflow: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, workflow), nil, nil
 }
 
 func getWorkflowRun(ctx context.Context, client *github.Client, owner, repo string, resourceID int64) (*mcp.CallToolResult, any, error) {
@@ -2013,11 +2038,7 @@ func getWorkflowRun(ctx context.Context, client *github.Client, owner, repo stri
 		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow run", resp, err), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
-	r, err := json.Marshal(workflowRun)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal workflow run: %w", err)
-	}
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, workflowRun), nil, nil
 }
 
 func getWorkflowJob(ctx context.Context, client *github.Client, owner, repo string, resourceID int64) (*mcp.CallToolResult, any, error) {
@@ -2026,11 +2047,7 @@ func getWorkflowJob(ctx context.Context, client *github.Client, owner, repo stri
 		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow job", resp, err), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
-	r, err := json.Marshal(workflowJob)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal workflow job: %w", err)
-	}
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, workflowJob), nil, nil
 }
 
 func listWorkflows(ctx context.Context, client *github.Client, owner, repo string, pagination PaginationParams) (*mcp.CallToolResult, any, error) {
@@ -2045,12 +2062,7 @@ func listWorkflows(ctx context.Context, client *github.Client, owner, repo strin
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	r, err := json.Marshal(workflows)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal workflows: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, workflows), nil, nil
 }
 
 func listWorkflowRuns(ctx context.Context, client *github.Client, args map[string]any, owner, repo, resourceID string, pagination PaginationParams) (*mcp.CallToolResult, any, error) {
@@ -2095,12 +2107,7 @@ func listWorkflowRuns(ctx context.Context, client *github.Client, args map[strin
 	}
 
 	defer func() { _ = resp.Body.Close() }()
-	r, err := json.Marshal(workflowRuns)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal workflow runs: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, workflowRuns), nil, nil
 }
 
 func listWorkflowJobs(ctx context.Context, client *github.Client, args map[string]any, owner, repo string, resourceID int64, pagination PaginationParams) (*mcp.CallToolResult, any, error) {
@@ -2134,12 +2141,7 @@ func listWorkflowJobs(ctx context.Context, client *github.Client, args map[strin
 	}
 
 	defer func() { _ = resp.Body.Close() }()
-	r, err := json.Marshal(response)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal workflow jobs: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, response), nil, nil
 }
 
 func listWorkflowArtifacts(ctx context.Context, client *github.Client, owner, repo string, resourceID int64, pagination PaginationParams) (*mcp.CallToolResult, any, error) {
@@ -2154,12 +2156,7 @@ func listWorkflowArtifacts(ctx context.Context, client *github.Client, owner, re
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	r, err := json.Marshal(artifacts)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, artifacts), nil, nil
 }
 
 func downloadWorkflowArtifact(ctx context.Context, client *github.Client, owner, repo string, resourceID int64) (*mcp.CallToolResult, any, error) {
@@ -2178,12 +2175,7 @@ func downloadWorkflowArtifact(ctx context.Context, client *github.Client, owner,
 		"artifact_id":  resourceID,
 	}
 
-	r, err := json.Marshal(result)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, result), nil, nil
 }
 
 func getWorkflowRunLogsURL(ctx context.Context, client *github.Client, owner, repo string, runID int64) (*mcp.CallToolResult, any, error) {
@@ -2203,12 +2195,7 @@ func getWorkflowRunLogsURL(ctx context.Context, client *github.Client, owner, re
 		"optimization_tip": "Use: get_job_logs with parameters {run_id: " + fmt.Sprintf("%d", runID) + ", failed_only: true} for more efficient failed job debugging",
 	}
 
-	r, err := json.Marshal(result)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, result), nil, nil
 }
 
 func getWorkflowRunUsage(ctx context.Context, client *github.Client, owner, repo string, resourceID int64) (*mcp.CallToolResult, any, error) {
@@ -2218,12 +2205,7 @@ func getWorkflowRunUsage(ctx context.Context, client *github.Client, owner, repo
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	r, err := json.Marshal(usage)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, usage), nil, nil
 }
 
 func runWorkflow(ctx context.Context, client *github.Client, owner, repo, workflowID, ref string, inputs map[string]interface{}) (*mcp.CallToolResult, any, error) {
@@ -2259,12 +2241,7 @@ func runWorkflow(ctx context.Context, client *github.Client, owner, repo, workfl
 		"status_code":   resp.StatusCode,
 	}
 
-	r, err := json.Marshal(result)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, result), nil, nil
 }
 
 func rerunWorkflowRun(ctx context.Context, client *github.Client, owner, repo string, runID int64) (*mcp.CallToolResult, any, error) {
@@ -2281,12 +2258,7 @@ func rerunWorkflowRun(ctx context.Context, client *github.Client, owner, repo st
 		"status_code": resp.StatusCode,
 	}
 
-	r, err := json.Marshal(result)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, result), nil, nil
 }
 
 func rerunFailedJobs(ctx context.Context, client *github.Client, owner, repo string, runID int64) (*mcp.CallToolResult, any, error) {
@@ -2303,12 +2275,7 @@ func rerunFailedJobs(ctx context.Context, client *github.Client, owner, repo str
 		"status_code": resp.StatusCode,
 	}
 
-	r, err := json.Marshal(result)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, result), nil, nil
 }
 
 func cancelWorkflowRun(ctx context.Context, client *github.Client, owner, repo string, runID int64) (*mcp.CallToolResult, any, error) {
@@ -2328,12 +2295,7 @@ func cancelWorkflowRun(ctx context.Context, client *github.Client, owner, repo s
 		"status_code": resp.StatusCode,
 	}
 
-	r, err := json.Marshal(result)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, result), nil, nil
 }
 
 func deleteWorkflowRunLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64) (*mcp.CallToolResult, any, error) {
@@ -2350,10 +2312,5 @@ func deleteWorkflowRunLogs(ctx context.Context, client *github.Client, owner, re
 		"status_code": resp.StatusCode,
 	}
 
-	r, err := json.Marshal(result)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, result), nil, nil
 }
@@ -0,0 +1,484 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// MaxItemsParameter is the name of the argument that makes a paginated tool fetch pages until
+	// it has collected that many items or reached the end of the results.
+	MaxItemsParameter = "max_items"
+	// ContinuationParameter is the name of the argument that resumes a max_items call where the
+	// previous call stopped.
+	ContinuationParameter = "continuation"
+
+	// maxItemsLimit caps the item budget of a single call.
+	maxItemsLimit = 500
+	// maxAutoPaginationPages caps the number of pages a single call fetches, in case a tool returns
+	// fewer items per page than requested. A continuation token is returned when it is reached.
+	maxAutoPaginationPages = 20
+)
+
+// paginationArguments are the arguments that select a page. They are ignored when checking that
+// a continuation token is used with the same arguments as the call that returned it.
+var paginationArguments = []string{"page", "perPage", "after", MaxItemsParameter, ContinuationParameter, OutputFormatParameter, FieldsParameter}
+
+// withMaxItems adds the max_items and continuation arguments to a paginated tool.
+func withMaxItems(schema *jsonschema.Schema) *jsonschema.Schema {
+	schema.Properties[MaxItemsParameter] = &jsonschema.Schema{
+		Type:        "number",
+		Description: fmt.Sprintf("Fetch pages automatically until this many items are collected or the results end, and return them together (min 1, max %d). Use instead of page and after.", maxItemsLimit),
+		Minimum:     jsonschema.Ptr(1.0),
+		Maximum:     jsonschema.Ptr(float64(maxItemsLimit)),
+	}
+	schema.Properties[ContinuationParameter] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Continuation token returned by a previous call with max_items, to fetch the next items. Pass the same other arguments as that call.",
+	}
+	return schema
+}
+
+// withoutMaxItems removes the max_items and continuation arguments from a paginated tool whose
+// result is not a single list, such as a commit with a page of its files.
+func withoutMaxItems(schema *jsonschema.Schema) *jsonschema.Schema {
+	delete(schema.Properties, MaxItemsParameter)
+	delete(schema.Properties, ContinuationParameter)
+	return schema
+}
+
+// maxItemsMethods lists the methods that support max_items for tools that dispatch on a method
+// argument. Only methods that return a single paginated list can be merged page by page.
+var maxItemsMethods = map[string][]string{
+	"issue_read":        {"get_comments", "get_sub_issues"},
+	"pull_request_read": {"get_files", "get_review_comments", "get_comments"},
+}
+
+// withMaxItemsMethods notes in the max_items and continuation arguments of a method-dispatching
+// tool which of its methods support them (see maxItemsMethods).
+func withMaxItemsMethods(schema *jsonschema.Schema, toolName string) *jsonschema.Schema {
+	methods := make([]string, len(maxItemsMethods[toolName]))
+	for i, method := range maxItemsMethods[toolName] {
+		methods[i] = "'" + method + "'"
+	}
+	note := fmt.Sprintf(" Only used for the %s methods.", strings.Join(methods, ", "))
+	for _, name := range []string{MaxItemsParameter, ContinuationParameter} {
+		property := *schema.Properties[name]
+		property.Description += note
+		schema.Properties[name] = &property
+	}
+	return schema
+}
+
+// hasMaxItemsParameter reports whether the tool accepts the max_items argument.
+func hasMaxItemsParameter(tool mcp.Tool) bool {
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	if !ok || schema == nil {
+		return false
+	}
+	_, ok = schema.Properties[MaxItemsParameter]
+	return ok
+}
+
+// pagePosition identifies where a page of results starts: a REST page number or a cursor for
+// GraphQL and cursor-based REST endpoints, plus the number of items of that page that have
+// already been returned.
+type pagePosition struct {
+	Page  int    `json:"p,omitempty"`
+	After string `json:"a,omitempty"`
+	Skip  int    `json:"s,omitempty"`
+}
+
+// continuationToken is the decoded form of the opaque continuation argument.
+type continuationToken struct {
+	Tool     string       `json:"t"`
+	Args     string       `json:"h"`
+	PerPage  int          `json:"n"`
+	MaxItems int          `json:"m"`
+	Position pagePosition `json:"pos"`
+}
+
+func (c continuationToken) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeContinuationToken(s string) (continuationToken, error) {
+	var token continuationToken
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(data, &token)
+	}
+	if err != nil {
+		return continuationToken{}, fmt.Errorf("invalid continuation token")
+	}
+	return token, nil
+}
+
+// argumentsHash fingerprints the arguments of a call, ignoring the ones that select a page, so
+// that continuation tokens cannot be mixed up between different queries.
+func argumentsHash(args map[string]json.RawMessage) string {
+	h := fnv.New64a()
+	for _, name := range slices.Sorted(maps.Keys(args)) {
+		if slices.Contains(paginationArguments, name) {
+			continue
+		}
+		fmt.Fprintf(h, "%s=%s;", name, args[name])
+	}
+	return strconv.FormatUint(h.Sum64(), 36)
+}
+
+// autoPaginationRequest is the parsed max_items mode of a tool call.
+type autoPaginationRequest struct {
+	args     map[string]json.RawMessage
+	hash     string
+	perPage  int
+	maxItems int
+	start    pagePosition
+}
+
+// parseAutoPagination returns the max_items mode of a call, or nil if neither max_items nor
+// continuation was passed.
+func parseAutoPagination(tool mcp.Tool, req *mcp.CallToolRequest) (*autoPaginationRequest, error) {
+	if req == nil || req.Params == nil || len(req.Params.Arguments) == 0 {
+		return nil, nil
+	}
+	var args map[string]json.RawMessage
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil || args == nil {
+		// Malformed arguments are reported by the tool handler itself.
+		return nil, nil
+	}
+	_, hasMaxItems := args[MaxItemsParameter]
+	_, hasContinuation := args[ContinuationParameter]
+	if !hasMaxItems && !hasContinuation {
+		return nil, nil
+	}
+
+	var generic map[string]any
+	_ = json.Unmarshal(req.Params.Arguments, &generic)
+	if methods, ok := maxItemsMethods[tool.Name]; ok {
+		method, _ := generic["method"].(string)
+		if !slices.Contains(methods, method) {
+			return nil, fmt.Errorf("%s is only supported by the %s methods of %s", MaxItemsParameter, strings.Join(methods, ", "), tool.Name)
+		}
+	}
+	maxItems, err := OptionalIntParam(generic, MaxItemsParameter)
+	if err != nil {
+		return nil, err
+	}
+	perPage, err := OptionalIntParam(generic, "perPage")
+	if err != nil {
+		return nil, err
+	}
+	continuation, err := OptionalParam[string](generic, ContinuationParameter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &autoPaginationRequest{args: args, hash: argumentsHash(args)}
+	if continuation != "" {
+		token, err := decodeContinuationToken(continuation)
+		if err != nil {
+			return nil, err
+		}
+		if token.Tool != tool.Name || token.Args != p.hash {
+			return nil, fmt.Errorf("continuation token was returned for a different tool or different arguments")
+		}
+		p.start = token.Position
+		p.perPage = token.PerPage
+		p.maxItems = token.MaxItems
+	} else {
+		page, err := OptionalIntParamWithDefault(generic, "page", 1)
+		if err != nil {
+			return nil, err
+		}
+		after, err := OptionalParam[string](generic, "after")
+		if err != nil {
+			return nil, err
+		}
+		p.start = pagePosition{Page: page, After: after}
+	}
+
+	if maxItems != 0 {
+		p.maxItems = maxItems
+	}
+	if p.maxItems < 1 || p.maxItems > maxItemsLimit {
+		return nil, fmt.Errorf("%s must be between 1 and %d", MaxItemsParameter, maxItemsLimit)
+	}
+	if perPage != 0 && continuation == "" {
+		p.perPage = perPage
+	}
+	if p.perPage == 0 {
+		p.perPage = min(p.maxItems, 100)
+	}
+	return p, nil
+}
+
+// pageArguments returns the raw arguments of the call that fetches the page at pos.
+func (p *autoPaginationRequest) pageArguments(tool mcp.Tool, pos pagePosition) json.RawMessage {
+	args := maps.Clone(p.args)
+	for _, name := range paginationArguments {
+		if name != OutputFormatParameter && name != FieldsParameter {
+			delete(args, name)
+		}
+	}
+	properties := tool.InputSchema.(*jsonschema.Schema).Properties
+	if _, ok := properties["perPage"]; ok {
+		args["perPage"] = json.RawMessage(strconv.Itoa(p.perPage))
+	}
+	if _, ok := properties["page"]; ok && pos.Page > 0 {
+		args["page"] = json.RawMessage(strconv.Itoa(pos.Page))
+	}
+	if pos.After != "" {
+		after, _ := json.Marshal(pos.After)
+		args["after"] = after
+	}
+	data, _ := json.Marshal(args)
+	return data
+}
+
+// linkRecorder is an http.RoundTripper that remembers the Link header of the last REST response
+// that had one, so that the next page can be found without changing tool handlers.
+type linkRecorder struct {
+	next http.RoundTripper
+
+	mu   sync.Mutex
+	link string
+}
+
+func (r *linkRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err == nil && resp.Header.Get("Link") != "" {
+		r.mu.Lock()
+		r.link = resp.Header.Get("Link")
+		r.mu.Unlock()
+	}
+	return resp, err
+}
+
+// takeLink returns and resets the recorded Link header.
+func (r *linkRecorder) takeLink() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	link := r.link
+	r.link = ""
+	return link
+}
+
+// nextPageFromLink returns the position of the page linked as rel="next" in a Link header.
+func nextPageFromLink(link string) (pagePosition, bool) {
+	for _, part := range strings.Split(link, ",") {
+		target, params, ok := strings.Cut(part, ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			return pagePosition{}, false
+		}
+		query := u.Query()
+		if after := query.Get("after"); after != "" {
+			return pagePosition{After: after}, true
+		}
+		if page, err := strconv.Atoi(query.Get("page")); err == nil {
+			return pagePosition{Page: page}, true
+		}
+	}
+	return pagePosition{}, false
+}
+
+// paginatingDeps records the Link headers of the REST calls made by a tool handler.
+type paginatingDeps struct {
+	ToolDependencies
+	recorder *linkRecorder
+}
+
+// GetClient returns a copy of the REST client whose transport records Link headers.
+func (d paginatingDeps) GetClient(ctx context.Context) (*github.Client, error) {
+	client, err := d.ToolDependencies.GetClient(ctx)
+	if err != nil || client == nil {
+		return client, err
+	}
+	httpClient := client.Client()
+	if d.recorder.next == nil {
+		d.recorder.next = httpClient.Transport
+		if d.recorder.next == nil {
+			d.recorder.next = http.DefaultTransport
+		}
+	}
+	httpClient.Transport = d.recorder
+
+	recording := github.NewClient(httpClient)
+	recording.BaseURL = client.BaseURL
+	recording.UploadURL = client.UploadURL
+	recording.UserAgent = client.UserAgent
+	return recording, nil
+}
+
+// resultPage is the structured content of one page, split into its list of items and the
+// remaining fields.
+type resultPage struct {
+	listKey string
+	items   []json.RawMessage
+	fields  map[string]json.RawMessage
+}
+
+// splitResultPage finds the list of items in the structured content of a result: either a list
+// result wrapped under "items", or the only list field of an object such as workflow_runs.
+func splitResultPage(result *mcp.CallToolResult) (*resultPage, error) {
+	data, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	var listKey string
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		trimmed := strings.TrimSpace(string(fields[key]))
+		if strings.HasPrefix(trimmed, "[") || (key == structuredItemsKey && trimmed == "null") {
+			if listKey != "" {
+				return nil, fmt.Errorf("result has more than one list")
+			}
+			listKey = key
+		}
+	}
+	if listKey == "" {
+		return nil, fmt.Errorf("result has no list of items")
+	}
+
+	page := &resultPage{listKey: listKey, fields: fields}
+	if err := json.Unmarshal(fields[listKey], &page.items); err != nil {
+		return nil, err
+	}
+	delete(fields, listKey)
+	return page, nil
+}
+
+// nextPageFromPageInfo returns the position of the next page from a GraphQL pageInfo field.
+func (p *resultPage) nextPageFromPageInfo() (pagePosition, bool) {
+	var pageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	}
+	if raw, ok := p.fields["pageInfo"]; !ok || json.Unmarshal(raw, &pageInfo) != nil {
+		return pagePosition{}, false
+	}
+	if !pageInfo.HasNextPage || pageInfo.EndCursor == "" {
+		return pagePosition{}, false
+	}
+	return pagePosition{After: pageInfo.EndCursor}, true
+}
+
+// autoPaginate calls a paginated tool handler page by page until it has collected the requested
+// number of items or reached the end of the results. The items are returned together as a single
+// result, followed by a continuation token when more items are available.
+func autoPaginate(
+	ctx context.Context,
+	deps ToolDependencies,
+	tool mcp.Tool,
+	req *mcp.CallToolRequest,
+	p *autoPaginationRequest,
+	call func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest) (*mcp.CallToolResult, error),
+) (*mcp.CallToolResult, error) {
+	recorder := &linkRecorder{}
+	pagingDeps := paginatingDeps{ToolDependencies: deps, recorder: recorder}
+
+	// Pages are fetched in full, since their lists and pageInfo drive the pagination, and the
+	// field selection is applied to the merged result only.
+	pageCtx := contextWithoutFields(ctx)
+
+	var merged *resultPage
+	var next *pagePosition
+	pos := p.start
+	for pages := 1; ; pages++ {
+		params := *req.Params
+		params.Arguments = p.pageArguments(tool, pos)
+		pageReq := *req
+		pageReq.Params = &params
+
+		result, err := call(pageCtx, pagingDeps, &pageReq)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+		if result.StructuredContent == nil {
+			// Nothing to merge, so this tool cannot be paginated automatically.
+			return result, nil
+		}
+		page, err := splitResultPage(result)
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("%s is not supported by %s: %s", MaxItemsParameter, tool.Name, err)), nil
+		}
+
+		items := page.items[min(pos.Skip, len(page.items)):]
+		if merged == nil {
+			merged = &resultPage{listKey: page.listKey}
+		}
+		// Everything but the list, such as counts and pageInfo, is taken from the last page.
+		merged.fields = page.fields
+
+		remaining := p.maxItems - len(merged.items)
+		if len(items) > remaining {
+			merged.items = append(merged.items, items[:remaining]...)
+			next = &pagePosition{Page: pos.Page, After: pos.After, Skip: pos.Skip + remaining}
+			break
+		}
+		merged.items = append(merged.items, items...)
+
+		nextPos, ok := nextPageFromLink(recorder.takeLink())
+		if !ok {
+			nextPos, ok = page.nextPageFromPageInfo()
+		}
+		if !ok || len(page.items) == 0 {
+			break
+		}
+		if len(merged.items) == p.maxItems || pages == maxAutoPaginationPages {
+			next = &nextPos
+			break
+		}
+		pos = nextPos
+	}
+
+	if merged.items == nil {
+		merged.items = []json.RawMessage{}
+	}
+	var value any = merged.items
+	if merged.listKey != structuredItemsKey || len(merged.fields) > 0 {
+		fields := maps.Clone(merged.fields)
+		fields[merged.listKey], _ = json.Marshal(merged.items)
+		value = fields
+	}
+
+	result := MarshalledTextResult(ctx, value)
+	if next != nil && !result.IsError {
+		token := continuationToken{
+			Tool:     tool.Name,
+			Args:     p.hash,
+			PerPage:  p.perPage,
+			MaxItems: p.maxItems,
+			Position: *next,
+		}.encode()
+		result.Meta = mcp.Meta{ContinuationParameter: token}
+		result.Content = append(result.Content, &mcp.TextContent{
+			Text: fmt.Sprintf("More results are available. To get the next %d items, call %s again with the same arguments and %s set to %q.", p.maxItems, tool.Name, ContinuationParameter, token),
+		})
+	}
+	return result, nil
+}
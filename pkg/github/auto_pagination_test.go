@@ -0,0 +1,309 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NextPageFromLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     string
+		expected pagePosition
+		found    bool
+	}{
+		{
+			name:     "page numbers",
+			link:     `<https://api.github.com/repositories/1/tags?page=3&per_page=2>; rel="next", <https://api.github.com/repositories/1/tags?page=5&per_page=2>; rel="last"`,
+			expected: pagePosition{Page: 3},
+			found:    true,
+		},
+		{
+			name:     "cursor",
+			link:     `<https://api.github.com/repos/o/r/code-scanning/alerts?after=Y3Vyc29y&per_page=2>; rel="next"`,
+			expected: pagePosition{After: "Y3Vyc29y"},
+			found:    true,
+		},
+		{
+			name: "last page",
+			link: `<https://api.github.com/repositories/1/tags?page=1&per_page=2>; rel="first", <https://api.github.com/repositories/1/tags?page=2&per_page=2>; rel="prev"`,
+		},
+		{
+			name: "no link",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pos, found := nextPageFromLink(tc.link)
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.expected, pos)
+		})
+	}
+}
+
+// tagPagesHandler serves five tags, two per page, with REST Link headers.
+func tagPagesHandler(t *testing.T, requestedPages *[]int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		require.Equal(t, 2, perPage)
+		*requestedPages = append(*requestedPages, page)
+
+		var tags []*github.RepositoryTag
+		for i := (page - 1) * perPage; i < min(page*perPage, 5); i++ {
+			tags = append(tags, &github.RepositoryTag{Name: github.Ptr(fmt.Sprintf("v%d", i+1))})
+		}
+		if page < 3 {
+			w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com/repositories/1/tags?page=%d&per_page=2>; rel="next"`, page+1))
+		}
+		w.WriteHeader(http.StatusOK)
+		require.NoError(t, json.NewEncoder(w).Encode(tags))
+	}
+}
+
+func Test_AutoPaginationREST(t *testing.T) {
+	serverTool := ListTags(translations.NullTranslationHelper)
+
+	var requestedPages []int
+	client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposTagsByOwnerByRepo: tagPagesHandler(t, &requestedPages),
+	}))
+	deps := BaseDeps{Client: client}
+	handler := serverTool.Handler(deps)
+	ctx := ContextWithDeps(context.Background(), deps)
+
+	// The first call stops in the middle of the second page
+	request := createMCPRequest(map[string]any{
+		"owner":     "owner",
+		"repo":      "repo",
+		"perPage":   float64(2),
+		"max_items": float64(3),
+		"fields":    []any{"name"},
+	})
+	result, err := handler(ctx, &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, []int{1, 2}, requestedPages)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, `[{"name":"v1"},{"name":"v2"},{"name":"v3"}]`, result.Content[0].(*mcp.TextContent).Text)
+	assertStructuredContent(t, serverTool.Tool, result)
+
+	token, ok := result.Meta[ContinuationParameter].(string)
+	require.True(t, ok, "expected a continuation token")
+	assert.Contains(t, result.Content[1].(*mcp.TextContent).Text, token)
+
+	// The continuation resumes with the rest of the second page
+	requestedPages = nil
+	request = createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"continuation": token,
+		"fields":       []any{"name"},
+	})
+	result, err = handler(ctx, &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, []int{2, 3}, requestedPages)
+	assert.Equal(t, `[{"name":"v4"},{"name":"v5"}]`, getTextResult(t, result).Text)
+	assert.Len(t, result.Content, 1)
+	assert.Nil(t, result.Meta)
+
+	// The continuation cannot be used for another repository
+	request = createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "other",
+		"continuation": token,
+	})
+	result, err = handler(ctx, &request)
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "continuation token was returned for a different tool or different arguments")
+}
+
+func Test_AutoPaginationGraphQL(t *testing.T) {
+	// A stand-in for GraphQL tools, returning three pages of two items with pageInfo.
+	var requestedCursors []string
+	st := NewTool(ToolsetMetadataIssues, mcp.Tool{
+		Name:         "list_things",
+		Annotations:  &mcp.ToolAnnotations{ReadOnlyHint: true},
+		InputSchema:  WithCursorPagination(&jsonschema.Schema{Type: "object", Properties: map[string]*jsonschema.Schema{}}),
+		OutputSchema: &jsonschema.Schema{Type: "object"},
+	}, nil, func(ctx context.Context, _ ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		pagination, err := OptionalCursorPaginationParams(args)
+		require.NoError(t, err)
+		requestedCursors = append(requestedCursors, pagination.After)
+
+		page := 0
+		if pagination.After != "" {
+			page, _ = strconv.Atoi(pagination.After)
+		}
+		things := []map[string]any{}
+		for i := page * pagination.PerPage; i < (page+1)*pagination.PerPage; i++ {
+			things = append(things, map[string]any{"id": i})
+		}
+		return MarshalledTextResult(ctx, map[string]any{
+			"things": things,
+			"pageInfo": map[string]any{
+				"hasNextPage": page < 2,
+				"endCursor":   strconv.Itoa(page + 1),
+			},
+			"totalCount": 6,
+		}), nil, nil
+	})
+
+	deps := stubDeps{}
+	handler := st.Handler(deps)
+	request := createMCPRequest(map[string]any{"max_items": float64(10), "perPage": float64(2)})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	assert.Equal(t, []string{"", "1", "2"}, requestedCursors)
+	assert.JSONEq(t, `{
+		"things": [{"id":0},{"id":1},{"id":2},{"id":3},{"id":4},{"id":5}],
+		"pageInfo": {"hasNextPage": false, "endCursor": "3"},
+		"totalCount": 6
+	}`, getTextResult(t, result).Text)
+	assert.Nil(t, result.Meta)
+}
+
+func Test_AutoPaginationWithFields(t *testing.T) {
+	// A GraphQL stand-in that accepts fields, so every page carries pageInfo until it is projected.
+	var requestedCursors []string
+	st := NewTool(ToolsetMetadataIssues, mcp.Tool{
+		Name:        "list_things",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+		InputSchema: WithFields(WithCursorPagination(&jsonschema.Schema{Type: "object", Properties: map[string]*jsonschema.Schema{}})),
+		OutputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"things": {Type: "array", Items: &jsonschema.Schema{
+					Type:       "object",
+					Properties: map[string]*jsonschema.Schema{"id": {Type: "integer"}, "name": {Type: "string"}},
+				}},
+				"pageInfo": {Type: "object", Properties: map[string]*jsonschema.Schema{
+					"hasNextPage": {Type: "boolean"},
+					"endCursor":   {Type: "string"},
+				}},
+			},
+		},
+	}, nil, func(ctx context.Context, _ ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		pagination, err := OptionalCursorPaginationParams(args)
+		require.NoError(t, err)
+		requestedCursors = append(requestedCursors, pagination.After)
+
+		page := 0
+		if pagination.After != "" {
+			page, _ = strconv.Atoi(pagination.After)
+		}
+		things := []map[string]any{}
+		for i := page * pagination.PerPage; i < (page+1)*pagination.PerPage; i++ {
+			things = append(things, map[string]any{"id": i, "name": fmt.Sprintf("thing %d", i)})
+		}
+		return MarshalledTextResult(ctx, map[string]any{
+			"things": things,
+			"pageInfo": map[string]any{
+				"hasNextPage": page < 2,
+				"endCursor":   strconv.Itoa(page + 1),
+			},
+		}), nil, nil
+	})
+
+	deps := stubDeps{}
+	handler := st.Handler(deps)
+	request := createMCPRequest(map[string]any{"max_items": float64(3), "perPage": float64(2), "fields": []any{"things.id"}})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	assert.Equal(t, []string{"", "1"}, requestedCursors)
+	assert.JSONEq(t, `{"things": [{"id":0},{"id":1},{"id":2}]}`, result.Content[0].(*mcp.TextContent).Text)
+	_, ok := result.Meta[ContinuationParameter].(string)
+	assert.True(t, ok, "the continuation token survives the field selection")
+}
+
+func Test_AutoPaginationInvalidArguments(t *testing.T) {
+	serverTool := ListTags(translations.NullTranslationHelper)
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(nil))}
+	handler := serverTool.Handler(deps)
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expectedErr string
+	}{
+		{
+			name:        "max_items too large",
+			args:        map[string]any{"owner": "owner", "repo": "repo", "max_items": float64(1000)},
+			expectedErr: "max_items must be between 1 and 500",
+		},
+		{
+			name:        "malformed continuation",
+			args:        map[string]any{"owner": "owner", "repo": "repo", "continuation": "not a token"},
+			expectedErr: "invalid continuation token",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.args)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErr)
+		})
+	}
+}
+
+func Test_AutoPaginationMethods(t *testing.T) {
+	serverTool := IssueRead(translations.NullTranslationHelper)
+	schema := serverTool.Tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties[MaxItemsParameter].Description, "Only used for the 'get_comments', 'get_sub_issues' methods.")
+
+	client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposIssuesCommentsByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusOK, []*github.IssueComment{
+			{Body: github.Ptr("first")},
+			{Body: github.Ptr("second")},
+		}),
+	}))
+	deps := BaseDeps{Client: client, Flags: stubFeatureFlags(nil)}
+	handler := serverTool.Handler(deps)
+	ctx := ContextWithDeps(context.Background(), deps)
+
+	// Methods that return a single list are paginated
+	request := createMCPRequest(map[string]any{
+		"method":       "get_comments",
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+		"max_items":    float64(1),
+	})
+	result, err := handler(ctx, &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "first")
+	assert.NotContains(t, text, "second")
+
+	// Other methods reject max_items
+	request = createMCPRequest(map[string]any{
+		"method":       "get",
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+		"max_items":    float64(10),
+	})
+	result, err = handler(ctx, &request)
+	require.NoError(t, err)
+	assert.Equal(t, "max_items is only supported by the get_comments, get_sub_issues methods of issue_read", getErrorResult(t, result).Text)
+}
@@ -50,7 +50,8 @@ func GetMe(t translations.TranslationHelperFunc) inventory.ServerTool {
 			},
 			// Use json.RawMessage to ensure "properties" is included even when empty.
 			// OpenAI strict mode requires the properties field to be present.
-			InputSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+			InputSchema:  json.RawMessage(`{"type":"object","properties":{}}`),
+			OutputSchema: OutputSchema[MinimalUser](),
 		},
 		nil,
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
@@ -95,7 +96,7 @@ func GetMe(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 			}
 
-			return MarshalledTextResult(minimalUser), nil, nil
+			return MarshalledTextResult(ctx, minimalUser), nil, nil
 		},
 	)
 }
@@ -130,6 +131,7 @@ func GetTeams(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 				},
 			},
+			OutputSchema: OutputSchema[[]OrganizationTeams](),
 		},
 		[]scopes.Scope{scopes.ReadOrg},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -204,7 +206,7 @@ func GetTeams(t translations.TranslationHelperFunc) inventory.ServerTool {
 				organizations = append(organizations, orgTeams)
 			}
 
-			return MarshalledTextResult(organizations), nil, nil
+			return MarshalledTextResult(ctx, organizations), nil, nil
 		},
 	)
 }
@@ -233,6 +235,7 @@ func GetTeamMembers(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"org", "team_slug"},
 			},
+			OutputSchema: OutputSchema[[]string](),
 		},
 		[]scopes.Scope{scopes.ReadOrg},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -275,7 +278,7 @@ func GetTeamMembers(t translations.TranslationHelperFunc) inventory.ServerTool {
 				members = append(members, string(member.Login))
 			}
 
-			return MarshalledTextResult(members), nil, nil
+			return MarshalledTextResult(ctx, members), nil, nil
 		},
 	)
 }
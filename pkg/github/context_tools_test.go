@@ -115,6 +115,7 @@ func Test_GetMe(t *testing.T) {
 
 			require.False(t, result.IsError)
 			textContent := getTextResult(t, result)
+			assertStructuredContent(t, tool, result)
 
 			// Unmarshal and verify the result
 			var returnedUser MinimalUser
@@ -353,6 +354,7 @@ func Test_GetTeams(t *testing.T) {
 
 			require.False(t, result.IsError)
 			textContent := getTextResult(t, result)
+			assertStructuredContent(t, tool, result)
 
 			var organizations []OrganizationTeams
 			err = json.Unmarshal([]byte(textContent.Text), &organizations)
@@ -496,6 +498,7 @@ func Test_GetTeamMembers(t *testing.T) {
 
 			require.False(t, result.IsError)
 			textContent := getTextResult(t, result)
+			assertStructuredContent(t, tool, result)
 
 			var members []string
 			err = json.Unmarshal([]byte(textContent.Text), &members)
@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/gitrepo"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// CurrentRepositorySourceRoots is the source of a repository found in the client's MCP roots.
+	CurrentRepositorySourceRoots = "roots"
+	// CurrentRepositorySourceWorkingDirectory is the source of a repository found in the
+	// server's working directory.
+	CurrentRepositorySourceWorkingDirectory = "working_directory"
+)
+
+// listRootsTimeout bounds how long the client can take to answer a roots/list request.
+const listRootsTimeout = 5 * time.Second
+
+// CurrentRepository is the repository of the local checkout the user is working in.
+type CurrentRepository struct {
+	Owner    string `json:"owner"`
+	Repo     string `json:"repo"`
+	FullName string `json:"full_name"`
+	// Remote is the name of the git remote pointing to the repository.
+	Remote string `json:"remote"`
+	// Path is the root of the local checkout.
+	Path string `json:"path"`
+	// Source is where the checkout was found, either the client's roots or the server's
+	// working directory.
+	Source string `json:"source"`
+}
+
+// Instructions tells the model to use the repository as the default.
+func (r CurrentRepository) Instructions() string {
+	return fmt.Sprintf("The server was started in a checkout of %s, which is the default repository when the user does not name one.", r.FullName)
+}
+
+// RepositoryDetector finds the repository the user is working in from the git remotes of the
+// client's MCP roots or the server's working directory. Only remotes on the configured GitHub
+// host are considered.
+type RepositoryDetector struct {
+	hostname   string
+	workingDir string
+}
+
+// NewRepositoryDetector creates a RepositoryDetector for the given GitHub host, in the same form
+// as the --gh-host flag, with an empty host meaning github.com. An empty workingDir disables the
+// fallback to the server's working directory.
+func NewRepositoryDetector(host, workingDir string) *RepositoryDetector {
+	return &RepositoryDetector{hostname: repositoryHostname(host), workingDir: workingDir}
+}
+
+// repositoryHostname returns the hostname that git remotes use for a GitHub host.
+func repositoryHostname(host string) string {
+	if host == "" {
+		return "github.com"
+	}
+	u, err := url.Parse(host)
+	if err != nil || u.Hostname() == "" {
+		return strings.ToLower(host)
+	}
+	if strings.HasSuffix(u.Hostname(), "github.com") {
+		return "github.com"
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// Detect returns the repository of the first client root that is in a checkout of a GitHub
+// repository, falling back to the server's working directory. It returns nil if neither is in such
+// a checkout. Clients that do not support roots are treated as having none.
+func (d *RepositoryDetector) Detect(ctx context.Context, session *mcp.ServerSession) (*CurrentRepository, error) {
+	if session != nil {
+		ctx, cancel := context.WithTimeout(ctx, listRootsTimeout)
+		defer cancel()
+		if roots, err := session.ListRoots(ctx, nil); err == nil {
+			for _, root := range roots.Roots {
+				dir, ok := rootPath(root.URI)
+				if !ok {
+					continue
+				}
+				repo, err := gitrepo.Find(dir, d.hostname)
+				if err != nil {
+					return nil, err
+				}
+				if repo != nil {
+					return newCurrentRepository(repo, CurrentRepositorySourceRoots), nil
+				}
+			}
+		}
+	}
+	return d.FromWorkingDirectory()
+}
+
+// FromWorkingDirectory returns the repository of the checkout containing the server's working
+// directory, or nil if it is not in a checkout of a GitHub repository.
+func (d *RepositoryDetector) FromWorkingDirectory() (*CurrentRepository, error) {
+	if d.workingDir == "" {
+		return nil, nil
+	}
+	repo, err := gitrepo.Find(d.workingDir, d.hostname)
+	if err != nil || repo == nil {
+		return nil, err
+	}
+	return newCurrentRepository(repo, CurrentRepositorySourceWorkingDirectory), nil
+}
+
+func newCurrentRepository(repo *gitrepo.Repository, source string) *CurrentRepository {
+	return &CurrentRepository{
+		Owner:    repo.Owner,
+		Repo:     repo.Name,
+		FullName: repo.FullName(),
+		Remote:   repo.Remote,
+		Path:     repo.Dir,
+		Source:   source,
+	}
+}
+
+// rootPath returns the local path of a file:// root URI.
+func rootPath(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" || u.Path == "" {
+		return "", false
+	}
+	path := u.Path
+	if runtime.GOOS == "windows" {
+		// file:///C:/src is parsed with the path /C:/src
+		path = strings.TrimPrefix(path, "/")
+	}
+	return path, true
+}
+
+// repositoryDetectorContextKey is the context key for the RepositoryDetector.
+type repositoryDetectorContextKey struct{}
+
+// ContextWithRepositoryDetector returns a new context in which get_current_repository uses the
+// given detector. Servers that do not run next to the user's checkout leave it out.
+func ContextWithRepositoryDetector(ctx context.Context, detector *RepositoryDetector) context.Context {
+	return context.WithValue(ctx, repositoryDetectorContextKey{}, detector)
+}
+
+// RepositoryDetectorFromContext returns the RepositoryDetector in the context, if any.
+func RepositoryDetectorFromContext(ctx context.Context) (*RepositoryDetector, bool) {
+	detector, ok := ctx.Value(repositoryDetectorContextKey{}).(*RepositoryDetector)
+	return detector, ok && detector != nil
+}
+
+// GetCurrentRepository creates a tool to get the repository of the local checkout the user is
+// working in.
+func GetCurrentRepository(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataContext,
+		mcp.Tool{
+			Name:        "get_current_repository",
+			Description: t("TOOL_GET_CURRENT_REPOSITORY_DESCRIPTION", "Get the GitHub repository of the local checkout the user is working in, from the git remotes of the client's workspace roots or the server's working directory. Use it as the default owner and repo when the user refers to the current repository or does not name one."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_CURRENT_REPOSITORY_USER_TITLE", "Get the current repository"),
+				ReadOnlyHint: true,
+			},
+			// Use json.RawMessage to ensure "properties" is included even when empty.
+			// OpenAI strict mode requires the properties field to be present.
+			InputSchema:  json.RawMessage(`{"type":"object","properties":{}}`),
+			OutputSchema: OutputSchema[CurrentRepository](),
+		},
+		nil,
+		func(ctx context.Context, _ ToolDependencies, req *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+			detector, ok := RepositoryDetectorFromContext(ctx)
+			if !ok {
+				return utils.NewToolResultError("the current repository is not available on this server, ask the user which repository to use"), nil, nil
+			}
+
+			repo, err := detector.Detect(ctx, req.Session)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to detect the current repository", err), nil, nil
+			}
+			if repo == nil {
+				return utils.NewToolResultError(fmt.Sprintf("no checkout with a remote on %s was found in the client's roots or the server's working directory, ask the user which repository to use", detector.hostname)), nil, nil
+			}
+
+			return MarshalledTextResult(ctx, repo), nil, nil
+		},
+	)
+}
@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createCheckout creates a git checkout in a temporary directory with an origin remote.
+func createCheckout(t *testing.T, remoteURL string) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0o755))
+	config := "[remote \"origin\"]\n\turl = " + remoteURL + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "config"), []byte(config), 0o600))
+	return dir
+}
+
+func Test_GetCurrentRepository(t *testing.T) {
+	serverTool := GetCurrentRepository(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_current_repository", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "get_current_repository tool should be read-only")
+
+	checkout := createCheckout(t, "git@github.com:octo/hello-world.git")
+
+	tests := []struct {
+		name               string
+		detector           *RepositoryDetector
+		expectedRepository *CurrentRepository
+		expectedErrMsg     string
+	}{
+		{
+			name:     "repository of the working directory",
+			detector: NewRepositoryDetector("", filepath.Join(checkout, ".git")),
+			expectedRepository: &CurrentRepository{
+				Owner:    "octo",
+				Repo:     "hello-world",
+				FullName: "octo/hello-world",
+				Remote:   "origin",
+				Path:     checkout,
+				Source:   CurrentRepositorySourceWorkingDirectory,
+			},
+		},
+		{
+			name:           "remote on another host",
+			detector:       NewRepositoryDetector("https://ghes.example.com", checkout),
+			expectedErrMsg: "no checkout with a remote on ghes.example.com was found",
+		},
+		{
+			name:           "no working directory",
+			detector:       NewRepositoryDetector("", ""),
+			expectedErrMsg: "no checkout with a remote on github.com was found",
+		},
+		{
+			name:           "no detector",
+			expectedErrMsg: "the current repository is not available on this server",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := stubDeps{}
+			ctx := ContextWithDeps(context.Background(), deps)
+			if tc.detector != nil {
+				ctx = ContextWithRepositoryDetector(ctx, tc.detector)
+			}
+			request := createMCPRequest(map[string]any{})
+			result, err := serverTool.Handler(deps)(ctx, &request)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assertStructuredContent(t, tool, result)
+			var repo CurrentRepository
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &repo))
+			assert.Equal(t, *tc.expectedRepository, repo)
+		})
+	}
+}
+
+func Test_GetCurrentRepository_Roots(t *testing.T) {
+	ctx := context.Background()
+	workingDir := createCheckout(t, "https://github.com/octo/server.git")
+	rootCheckout := createCheckout(t, "https://github.com/octo/client.git")
+	detector := NewRepositoryDetector("https://github.com", workingDir)
+
+	deps := stubDeps{}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	server.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			return next(ContextWithRepositoryDetector(ContextWithDeps(ctx, deps), detector), method, req)
+		}
+	})
+	serverTool := GetCurrentRepository(translations.NullTranslationHelper)
+	serverTool.RegisterFunc(server, deps)
+
+	callTool := func(t *testing.T, roots ...string) CurrentRepository {
+		t.Helper()
+		client := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil)
+		for _, root := range roots {
+			client.AddRoots(&mcp.Root{URI: (&url.URL{Scheme: "file", Path: filepath.ToSlash(root)}).String()})
+		}
+		serverTransport, clientTransport := mcp.NewInMemoryTransports()
+		_, err := server.Connect(ctx, serverTransport, nil)
+		require.NoError(t, err)
+		session, err := client.Connect(ctx, clientTransport, nil)
+		require.NoError(t, err)
+		defer func() { _ = session.Close() }()
+
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "get_current_repository"})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		var repo CurrentRepository
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &repo))
+		return repo
+	}
+
+	t.Run("uses the client's roots", func(t *testing.T) {
+		repo := callTool(t, t.TempDir(), rootCheckout)
+		assert.Equal(t, "octo/client", repo.FullName)
+		assert.Equal(t, CurrentRepositorySourceRoots, repo.Source)
+		assert.Equal(t, rootCheckout, repo.Path)
+	})
+
+	t.Run("falls back to the working directory", func(t *testing.T) {
+		repo := callTool(t, t.TempDir())
+		assert.Equal(t, "octo/server", repo.FullName)
+		assert.Equal(t, CurrentRepositorySourceWorkingDirectory, repo.Source)
+	})
+}
+
+func Test_repositoryHostname(t *testing.T) {
+	tests := map[string]string{
+		"":                          "github.com",
+		"https://github.com":        "github.com",
+		"https://api.github.com":    "github.com",
+		"https://octocorp.ghe.com":  "octocorp.ghe.com",
+		"https://GHES.example.com/": "ghes.example.com",
+		"http://ghes.internal:8080": "ghes.internal",
+	}
+	for host, expected := range tests {
+		assert.Equal(t, expected, repositoryHostname(host), host)
+	}
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/octicons"
+	"go.yaml.in/yaml/v3"
+)
+
+// CustomToolsetConfig is the definition of a custom toolset in a custom toolsets file.
+type CustomToolsetConfig struct {
+	Description  string   `yaml:"description"`
+	Icon         string   `yaml:"icon"`
+	Instructions string   `yaml:"instructions"`
+	Tools        []string `yaml:"tools"`
+}
+
+// UnmarshalYAML accepts either a full definition or just the list of tools.
+func (c *CustomToolsetConfig) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		return value.Decode(&c.Tools)
+	}
+	type plain CustomToolsetConfig
+	return value.Decode((*plain)(c))
+}
+
+// LoadCustomToolsets reads custom toolsets from a YAML or JSON file mapping toolset IDs to their
+// definition, for example:
+//
+//	release:
+//	  description: Cut releases
+//	  icon: tag
+//	  instructions: Check the latest release before creating a new one.
+//	  tools: [list_tags, get_latest_release, list_commits, create_pull_request]
+//	triage: [list_issues, issue_read, label_write]
+//
+// The tools are validated when the inventory is built.
+func LoadCustomToolsets(path string) ([]inventory.CustomToolset, error) {
+	data, err := os.ReadFile(path) //#nosec G304 -- path is set by the user running the server
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom toolsets: %w", err)
+	}
+	return ParseCustomToolsets(data)
+}
+
+// ParseCustomToolsets parses custom toolsets in the format read by LoadCustomToolsets.
+func ParseCustomToolsets(data []byte) ([]inventory.CustomToolset, error) {
+	var configs map[string]CustomToolsetConfig
+	if err := yaml.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse custom toolsets: %w", err)
+	}
+
+	icons := octicons.RequiredIcons()
+	toolsets := make([]inventory.CustomToolset, 0, len(configs))
+	for id, config := range configs {
+		if config.Icon != "" && !slices.Contains(icons, config.Icon) {
+			return nil, fmt.Errorf("custom toolset %q has unknown icon %q", id, config.Icon)
+		}
+
+		metadata := inventory.ToolsetMetadata{
+			ID:          inventory.ToolsetID(id),
+			Description: config.Description,
+			Icon:        config.Icon,
+		}
+		if instructions := config.Instructions; instructions != "" {
+			metadata.InstructionsFunc = func(_ *inventory.Inventory) string { return instructions }
+		}
+		toolsets = append(toolsets, inventory.CustomToolset{ToolsetMetadata: metadata, Tools: config.Tools})
+	}
+
+	sort.Slice(toolsets, func(i, j int) bool { return toolsets[i].ID < toolsets[j].ID })
+	return toolsets, nil
+}
@@ -0,0 +1,60 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseCustomToolsets(t *testing.T) {
+	toolsets, err := ParseCustomToolsets([]byte(`
+release:
+  description: Cut releases
+  icon: tag
+  instructions: Check the latest release before creating a new one.
+  tools: [list_tags, get_latest_release, list_commits, create_pull_request]
+triage: [list_issues, issue_read]
+`))
+	require.NoError(t, err)
+	require.Len(t, toolsets, 2)
+
+	release := toolsets[0]
+	assert.Equal(t, "release", string(release.ID))
+	assert.Equal(t, "Cut releases", release.Description)
+	assert.Equal(t, "tag", release.Icon)
+	assert.Equal(t, []string{"list_tags", "get_latest_release", "list_commits", "create_pull_request"}, release.Tools)
+	require.NotNil(t, release.InstructionsFunc)
+	assert.Equal(t, "Check the latest release before creating a new one.", release.InstructionsFunc(nil))
+
+	triage := toolsets[1]
+	assert.Equal(t, "triage", string(triage.ID))
+	assert.Equal(t, []string{"list_issues", "issue_read"}, triage.Tools)
+	assert.Nil(t, triage.InstructionsFunc)
+
+	// The toolsets can be enabled like built-in ones
+	inv, err := NewInventory(translations.NullTranslationHelper).
+		WithToolsets([]string{"release"}).
+		WithCustomToolsets(toolsets).
+		Build()
+	require.NoError(t, err)
+	var names []string
+	for _, tool := range inv.AvailableTools(context.Background()) {
+		names = append(names, tool.Tool.Name)
+	}
+	assert.ElementsMatch(t, release.Tools, names)
+
+	// JSON is accepted too
+	toolsets, err = ParseCustomToolsets([]byte(`{"triage": {"tools": ["list_issues"]}}`))
+	require.NoError(t, err)
+	require.Len(t, toolsets, 1)
+	assert.Equal(t, []string{"list_issues"}, toolsets[0].Tools)
+
+	_, err = ParseCustomToolsets([]byte(`release: {icon: not-an-icon, tools: [list_tags]}`))
+	assert.ErrorContains(t, err, `custom toolset "release" has unknown icon "not-an-icon"`)
+
+	_, err = ParseCustomToolsets([]byte(`release: [list_tags`))
+	assert.ErrorContains(t, err, "failed to parse custom toolsets")
+}
@@ -27,7 +27,7 @@ func GetDependabotAlert(t translations.TranslationHelperFunc) inventory.ServerTo
 				Title:        t("TOOL_GET_DEPENDABOT_ALERT_USER_TITLE", "Get dependabot alert"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFields(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -44,7 +44,8 @@ func GetDependabotAlert(t translations.TranslationHelperFunc) inventory.ServerTo
 					},
 				},
 				Required: []string{"owner", "repo", "alertNumber"},
-			},
+			}),
+			OutputSchema: APIObjectOutputSchema[github.DependabotAlert]("Dependabot alert as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.SecurityEvents},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -84,12 +85,7 @@ func GetDependabotAlert(t translations.TranslationHelperFunc) inventory.ServerTo
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get alert", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(alert)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal alert", err), nil, err
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, alert), nil, nil
 		},
 	)
 }
@@ -104,7 +100,7 @@ func ListDependabotAlerts(t translations.TranslationHelperFunc) inventory.Server
 				Title:        t("TOOL_LIST_DEPENDABOT_ALERTS_USER_TITLE", "List dependabot alerts"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFields(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -128,7 +124,8 @@ func ListDependabotAlerts(t translations.TranslationHelperFunc) inventory.Server
 					},
 				},
 				Required: []string{"owner", "repo"},
-			},
+			}),
+			OutputSchema: APIListOutputSchema[github.DependabotAlert]("Dependabot alerts as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.SecurityEvents},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -175,12 +172,7 @@ func ListDependabotAlerts(t translations.TranslationHelperFunc) inventory.Server
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list alerts", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(alerts)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal alerts", err), nil, err
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, alerts), nil, nil
 		},
 	)
 }
@@ -2,15 +2,18 @@ package github
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/outputformat"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/scopes"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
 	gogithub "github.com/google/go-github/v79/github"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/shurcooL/githubv4"
@@ -77,9 +80,12 @@ type ToolDependencies interface {
 	// GetFlags returns feature flags
 	GetFlags() FeatureFlags
 
-	// GetContentWindowSize returns the content window size for log truncation
+	// GetContentWindowSize returns the content window size, in lines, for log and result truncation
 	GetContentWindowSize() int
 
+	// GetOutputFormat returns the default text format of tool results
+	GetOutputFormat() outputformat.Format
+
 	// IsFeatureEnabled checks if a feature flag is enabled.
 	IsFeatureEnabled(ctx context.Context, flagName string) bool
 }
@@ -98,6 +104,7 @@ type BaseDeps struct {
 	T                 translations.TranslationHelperFunc
 	Flags             FeatureFlags
 	ContentWindowSize int
+	OutputFormat      outputformat.Format
 
 	// Feature flag checker for runtime checks
 	featureChecker inventory.FeatureFlagChecker
@@ -115,6 +122,7 @@ func NewBaseDeps(
 	t translations.TranslationHelperFunc,
 	flags FeatureFlags,
 	contentWindowSize int,
+	outputFormat outputformat.Format,
 	featureChecker inventory.FeatureFlagChecker,
 ) *BaseDeps {
 	return &BaseDeps{
@@ -125,6 +133,7 @@ func NewBaseDeps(
 		T:                 t,
 		Flags:             flags,
 		ContentWindowSize: contentWindowSize,
+		OutputFormat:      outputFormat,
 		featureChecker:    featureChecker,
 	}
 }
@@ -156,6 +165,9 @@ func (d BaseDeps) GetFlags() FeatureFlags { return d.Flags }
 // GetContentWindowSize implements ToolDependencies.
 func (d BaseDeps) GetContentWindowSize() int { return d.ContentWindowSize }
 
+// GetOutputFormat implements ToolDependencies.
+func (d BaseDeps) GetOutputFormat() outputformat.Format { return d.OutputFormat }
+
 // IsFeatureEnabled checks if a feature flag is enabled.
 // Returns false if the feature checker is nil, flag name is empty, or an error occurs.
 // This allows tools to conditionally change behavior based on feature flags.
@@ -180,6 +192,11 @@ func (d BaseDeps) IsFeatureEnabled(ctx context.Context, flagName string) bool {
 //
 // The handler function receives deps extracted from context via MustDepsFromContext.
 // Ensure ContextWithDeps is called to inject deps before any tool handlers are invoked.
+// The handler's context also carries the output format of the call (see ContextWithOutputFormat),
+// and read-only tools with an output schema accept an output_format argument to override it.
+// Tools whose input schema uses WithFields have their results projected onto the requested
+// fields, and paginated tools fetch several pages when called with max_items. Text results larger
+// than the content window are truncated, and the rest can be fetched with read_more.
 //
 // requiredScopes specifies the minimum OAuth scopes needed for this tool.
 // AcceptedScopes are automatically derived using the scope hierarchy (e.g., if
@@ -190,9 +207,38 @@ func NewTool[In, Out any](
 	requiredScopes []scopes.Scope,
 	handler func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, Out, error),
 ) inventory.ServerTool {
+	projectable := hasFieldsParameter(tool)
+	paginated := hasMaxItemsParameter(tool)
+	tool = withOutputFormatParameter(tool)
+	if projectable {
+		tool = withProjectableOutputSchema(tool)
+	}
 	st := inventory.NewServerToolWithContextHandler(tool, toolset, func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, Out, error) {
 		deps := MustDepsFromContext(ctx)
-		return handler(ctx, deps, req, args)
+		var zero Out
+		ctx, err := contextWithCallOptions(ctx, deps, tool, projectable, req)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), zero, nil
+		}
+		if paginated {
+			pagination, err := parseAutoPagination(tool, req)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), zero, nil
+			}
+			if pagination != nil {
+				result, err := autoPaginate(ctx, deps, tool, req, pagination, func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					var pageArgs In
+					if err := json.Unmarshal(req.Params.Arguments, &pageArgs); err != nil {
+						return nil, err
+					}
+					result, _, err := handler(ctx, deps, req, pageArgs)
+					return result, err
+				})
+				return truncateResult(deps, req, result), zero, err
+			}
+		}
+		result, out, err := handler(ctx, deps, req, args)
+		return truncateResult(deps, req, result), out, err
 	})
 	st.RequiredScopes = scopes.ToStringSlice(requiredScopes...)
 	st.AcceptedScopes = scopes.ExpandScopes(requiredScopes...)
@@ -213,11 +259,42 @@ func NewToolFromHandler(
 	requiredScopes []scopes.Scope,
 	handler func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest) (*mcp.CallToolResult, error),
 ) inventory.ServerTool {
+	projectable := hasFieldsParameter(tool)
+	paginated := hasMaxItemsParameter(tool)
+	tool = withOutputFormatParameter(tool)
+	if projectable {
+		tool = withProjectableOutputSchema(tool)
+	}
 	st := inventory.NewServerToolWithRawContextHandler(tool, toolset, func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		deps := MustDepsFromContext(ctx)
-		return handler(ctx, deps, req)
+		ctx, err := contextWithCallOptions(ctx, deps, tool, projectable, req)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil
+		}
+		if paginated {
+			pagination, err := parseAutoPagination(tool, req)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil
+			}
+			if pagination != nil {
+				result, err := autoPaginate(ctx, deps, tool, req, pagination, handler)
+				return truncateResult(deps, req, result), err
+			}
+		}
+		result, err := handler(ctx, deps, req)
+		return truncateResult(deps, req, result), err
 	})
 	st.RequiredScopes = scopes.ToStringSlice(requiredScopes...)
 	st.AcceptedScopes = scopes.ExpandScopes(requiredScopes...)
 	return st
 }
+
+// contextWithCallOptions applies the output_format and fields arguments of a tool call to the
+// context that MarshalledTextResult renders results with.
+func contextWithCallOptions(ctx context.Context, deps ToolDependencies, tool mcp.Tool, projectable bool, req *mcp.CallToolRequest) (context.Context, error) {
+	ctx, err := contextWithCallOutputFormat(ctx, deps, req)
+	if err != nil || !projectable {
+		return ctx, err
+	}
+	return contextWithCallFields(ctx, tool, req)
+}
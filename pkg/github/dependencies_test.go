@@ -27,6 +27,7 @@ func TestIsFeatureEnabled_WithEnabledFlag(t *testing.T) {
 		translations.NullTranslationHelper,
 		github.FeatureFlags{},
 		0,       // contentWindowSize
+		"",      // outputFormat
 		checker, // featureChecker
 	)
 
@@ -51,6 +52,7 @@ func TestIsFeatureEnabled_WithoutChecker(t *testing.T) {
 		translations.NullTranslationHelper,
 		github.FeatureFlags{},
 		0,   // contentWindowSize
+		"",  // outputFormat
 		nil, // featureChecker (nil)
 	)
 
@@ -75,6 +77,7 @@ func TestIsFeatureEnabled_EmptyFlagName(t *testing.T) {
 		translations.NullTranslationHelper,
 		github.FeatureFlags{},
 		0,       // contentWindowSize
+		"",      // outputFormat
 		checker, // featureChecker
 	)
 
@@ -99,6 +102,7 @@ func TestIsFeatureEnabled_CheckerError(t *testing.T) {
 		translations.NullTranslationHelper,
 		github.FeatureFlags{},
 		0,       // contentWindowSize
+		"",      // outputFormat
 		checker, // featureChecker
 	)
 
@@ -0,0 +1,148 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/octicons"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+	"github.com/yosida95/uritemplate/v3"
+)
+
+var discussionResourceURITemplate = uritemplate.MustNew("discussion://{owner}/{repo}/{number}")
+
+// GetDiscussionResource defines the resource template for reading a discussion and its comments as Markdown.
+func GetDiscussionResource(t translations.TranslationHelperFunc) inventory.ServerResourceTemplate {
+	return inventory.NewServerResourceTemplate(
+		ToolsetMetadataDiscussions,
+		mcp.ResourceTemplate{
+			Name:        "discussion",
+			URITemplate: discussionResourceURITemplate.Raw(),
+			Description: t("RESOURCE_DISCUSSION_DESCRIPTION", "Discussion with its comments, rendered as Markdown"),
+			MIMEType:    markdownMIMEType,
+			Icons:       octicons.Icons("comment-discussion"),
+		},
+		func(_ any) mcp.ResourceHandler {
+			return DiscussionResourceHandler(discussionResourceURITemplate)
+		},
+	)
+}
+
+// discussionResourceQuery fetches a discussion together with its first page of comments.
+type discussionResourceQuery struct {
+	Repository struct {
+		Discussion struct {
+			Number     githubv4.Int
+			Title      githubv4.String
+			Body       githubv4.String
+			URL        githubv4.String `graphql:"url"`
+			Closed     githubv4.Boolean
+			IsAnswered githubv4.Boolean
+			CreatedAt  githubv4.DateTime
+			UpdatedAt  githubv4.DateTime
+			Author     struct {
+				Login githubv4.String
+			}
+			Category struct {
+				Name githubv4.String
+			}
+			Comments struct {
+				TotalCount githubv4.Int
+				Nodes      []struct {
+					Body      githubv4.String
+					CreatedAt githubv4.DateTime
+					Author    struct {
+						Login githubv4.String
+					}
+				}
+			} `graphql:"comments(first: 100)"`
+		} `graphql:"discussion(number: $discussionNumber)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// DiscussionResourceHandler returns a handler that renders a discussion and its comments as Markdown.
+// It retrieves ToolDependencies from the context at call time via MustDepsFromContext.
+func DiscussionResourceHandler(resourceURITemplate *uritemplate.Template) mcp.ResourceHandler {
+	return func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		deps := MustDepsFromContext(ctx)
+		owner, repo, discussionNumber, err := resourceRepoAndNumber(resourceURITemplate, request.Params.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := deps.GetGQLClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+		}
+
+		var q discussionResourceQuery
+		vars := map[string]any{
+			"owner":            githubv4.String(owner),
+			"repo":             githubv4.String(repo),
+			"discussionNumber": githubv4.Int(discussionNumber), //nolint:gosec // Discussion numbers are always small positive integers
+		}
+		if err := client.Query(ctx, &q, vars); err != nil {
+			return nil, fmt.Errorf("failed to get discussion: %w", err)
+		}
+		d := q.Repository.Discussion
+
+		isSafe, err := resourceContentIsSafe(ctx, deps, string(d.Author.Login), owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		if !isSafe {
+			return nil, fmt.Errorf("access to discussion is restricted by lockdown mode")
+		}
+
+		title := sanitize.Sanitize(string(d.Title))
+
+		var sb strings.Builder
+		writeFrontMatter(&sb, []frontMatterField{
+			{"number", int(d.Number)},
+			{"title", title},
+			{"category", string(d.Category.Name)},
+			{"closed", bool(d.Closed)},
+			{"answered", bool(d.IsAnswered)},
+			{"author", string(d.Author.Login)},
+			{"comments", int(d.Comments.TotalCount)},
+			{"created_at", formatResourceTime(d.CreatedAt.Time)},
+			{"updated_at", formatResourceTime(d.UpdatedAt.Time)},
+			{"url", string(d.URL)},
+		})
+		fmt.Fprintf(&sb, "\n# %s\n\n", title)
+		if body := sanitize.Sanitize(string(d.Body)); body != "" {
+			sb.WriteString(body)
+			sb.WriteString("\n")
+		}
+
+		type comment struct{ author, createdAt, body string }
+		comments := make([]comment, 0, len(d.Comments.Nodes))
+		for _, c := range d.Comments.Nodes {
+			isSafe, err := resourceContentIsSafe(ctx, deps, string(c.Author.Login), owner, repo)
+			if err != nil {
+				return nil, err
+			}
+			if isSafe {
+				comments = append(comments, comment{string(c.Author.Login), formatResourceTime(c.CreatedAt.Time), string(c.Body)})
+			}
+		}
+		writeCommentsMarkdown(&sb, len(comments), int(d.Comments.TotalCount)-len(d.Comments.Nodes), func(i int) (string, string, string) {
+			return comments[i].author, comments[i].createdAt, comments[i].body
+		})
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{
+					URI:      request.Params.URI,
+					MIMEType: markdownMIMEType,
+					Text:     sb.String(),
+				},
+			},
+		}, nil
+	}
+}
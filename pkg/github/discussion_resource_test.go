@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DiscussionResource(t *testing.T) {
+	resource := GetDiscussionResource(translations.NullTranslationHelper)
+	assert.Equal(t, "discussion", resource.Template.Name)
+	assert.Equal(t, "discussion://{owner}/{repo}/{number}", resource.Template.URITemplate)
+	assert.Equal(t, "discussions", string(resource.Toolset.ID))
+
+	vars := map[string]any{
+		"owner":            githubv4.String("owner"),
+		"repo":             githubv4.String("repo"),
+		"discussionNumber": githubv4.Int(5),
+	}
+	discussion := func(author string) map[string]any {
+		return map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{
+				"number":     5,
+				"title":      "How do I configure this?",
+				"body":       "Looking for guidance.",
+				"url":        "https://github.com/owner/repo/discussions/5",
+				"closed":     false,
+				"isAnswered": true,
+				"createdAt":  "2024-05-01T10:00:00Z",
+				"updatedAt":  "2024-05-02T10:00:00Z",
+				"author":     map[string]any{"login": author},
+				"category":   map[string]any{"name": "Q&A"},
+				"comments": map[string]any{
+					"totalCount": 2,
+					"nodes": []map[string]any{
+						{"body": "Use the config file.", "createdAt": "2024-05-01T11:00:00Z", "author": map[string]any{"login": "maintainer"}},
+						{"body": "Untrusted reply", "createdAt": "2024-05-01T12:00:00Z", "author": map[string]any{"login": "testuser"}},
+					},
+				},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		author          string
+		lockdownEnabled bool
+		expectError     string
+		expectContains  []string
+		expectMissing   []string
+	}{
+		{
+			name:   "renders discussion with comments",
+			author: "maintainer",
+			expectContains: []string{
+				"number: 5\ntitle: \"How do I configure this?\"\ncategory: \"Q&A\"\nclosed: false\nanswered: true\nauthor: \"maintainer\"\ncomments: 2\n",
+				"created_at: \"2024-05-01T10:00:00Z\"\n",
+				"# How do I configure this?\n\nLooking for guidance.\n",
+				"### @maintainer commented on 2024-05-01T11:00:00Z\n\nUse the config file.\n",
+				"Untrusted reply",
+			},
+		},
+		{
+			name:            "lockdown filters untrusted comments",
+			author:          "maintainer",
+			lockdownEnabled: true,
+			expectContains:  []string{"Use the config file."},
+			expectMissing:   []string{"Untrusted reply"},
+		},
+		{
+			name:            "lockdown rejects untrusted author",
+			author:          "testuser",
+			lockdownEnabled: true,
+			expectError:     "access to discussion is restricted by lockdown mode",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher := githubv4mock.NewQueryMatcher(discussionResourceQuery{}, vars, githubv4mock.DataResponse(discussion(tc.author)))
+			deps := BaseDeps{
+				GQLClient:       githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher)),
+				RepoAccessCache: repoAccessCache,
+				Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": tc.lockdownEnabled}),
+			}
+			handler := resource.Handler(deps)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &mcp.ReadResourceRequest{
+				Params: &mcp.ReadResourceParams{URI: "discussion://owner/repo/5"},
+			})
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Contents, 1)
+			assert.Equal(t, "text/markdown", result.Contents[0].MIMEType)
+			for _, s := range tc.expectContains {
+				assert.Contains(t, result.Contents[0].Text, s)
+			}
+			for _, s := range tc.expectMissing {
+				assert.NotContains(t, result.Contents[0].Text, s)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/github/github-mcp-server/pkg/inventory"
@@ -63,6 +62,13 @@ type NodeFragment struct {
 	URL githubv4.String `graphql:"url"`
 }
 
+type discussionCommentNode struct {
+	Body   githubv4.String
+	Author struct {
+		Login githubv4.String
+	}
+}
+
 type PageInfoFragment struct {
 	HasNextPage     bool
 	HasPreviousPage bool
@@ -161,6 +167,11 @@ func ListDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool
 				},
 				Required: []string{"owner"},
 			}),
+			OutputSchema: ObjectOutputSchema("Discussions of the repository, with discussions, pageInfo and totalCount.", map[string]*jsonschema.Schema{
+				"discussions": APIListOutputSchema[github.Discussion]("").Properties[structuredItemsKey],
+				"pageInfo":    graphQLPageInfoOutputSchema(),
+				"totalCount":  {Type: "integer"},
+			}),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -255,6 +266,17 @@ func ListDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool
 				totalCount = fragment.TotalCount
 			}
 
+			policy, err := lockdownPolicyFor(deps)
+			if err != nil {
+				return nil, nil, err
+			}
+			discussions, err = filterLockdown(ctx, policy, discussions, func(discussion *github.Discussion) authoredContent {
+				return authoredContent{login: discussion.GetUser().GetLogin(), owner: owner, repo: repo}
+			})
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+
 			// Create response with pagination info
 			response := map[string]interface{}{
 				"discussions": discussions,
@@ -267,11 +289,7 @@ func ListDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool
 				"totalCount": totalCount,
 			}
 
-			out, err := json.Marshal(response)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal discussions: %w", err)
-			}
-			return utils.NewToolResultText(string(out)), nil, nil
+			return MarshalledTextResult(ctx, response), nil, nil
 		},
 	)
 }
@@ -304,6 +322,20 @@ func GetDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner", "repo", "discussionNumber"},
 			},
+			OutputSchema: ObjectOutputSchema("Discussion. answerChosenAt is only present once an answer is chosen.", map[string]*jsonschema.Schema{
+				"number":     {Type: "integer"},
+				"title":      {Type: "string"},
+				"body":       {Type: "string"},
+				"url":        {Type: "string"},
+				"closed":     {Type: "boolean"},
+				"isAnswered": {Type: "boolean"},
+				"createdAt":  {Type: "string", Format: "date-time"},
+				"category": {
+					Type:       "object",
+					Properties: map[string]*jsonschema.Schema{"name": {Type: "string"}},
+				},
+				"answerChosenAt": {Type: "string", Format: "date-time"},
+			}),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -335,6 +367,9 @@ func GetDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
 						Category       struct {
 							Name githubv4.String
 						} `graphql:"category"`
+						Author struct {
+							Login githubv4.String
+						}
 					} `graphql:"discussion(number: $discussionNumber)"`
 				} `graphql:"repository(owner: $owner, name: $repo)"`
 			}
@@ -348,6 +383,18 @@ func GetDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
 			}
 			d := q.Repository.Discussion
 
+			policy, err := lockdownPolicyFor(deps)
+			if err != nil {
+				return nil, nil, err
+			}
+			isSafeContent, err := policy.isSafe(ctx, authoredContent{login: string(d.Author.Login), owner: params.Owner, repo: params.Repo})
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+			if !isSafeContent {
+				return utils.NewToolResultError("access to discussion details is restricted by lockdown mode"), nil, nil
+			}
+
 			// Build response as map to include fields not present in go-github's Discussion struct.
 			// The go-github library's Discussion type lacks isAnswered and answerChosenAt fields,
 			// so we use map[string]interface{} for the response (consistent with other functions
@@ -370,12 +417,7 @@ func GetDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
 				response["answerChosenAt"] = d.AnswerChosenAt.Time
 			}
 
-			out, err := json.Marshal(response)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal discussion: %w", err)
-			}
-
-			return utils.NewToolResultText(string(out)), nil, nil
+			return MarshalledTextResult(ctx, response), nil, nil
 		},
 	)
 }
@@ -408,6 +450,11 @@ func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.Serve
 				},
 				Required: []string{"owner", "repo", "discussionNumber"},
 			}),
+			OutputSchema: ObjectOutputSchema("Comments of the discussion, with comments, pageInfo and totalCount.", map[string]*jsonschema.Schema{
+				"comments":   APIListOutputSchema[github.IssueComment]("").Properties[structuredItemsKey],
+				"pageInfo":   graphQLPageInfoOutputSchema(),
+				"totalCount": {Type: "integer"},
+			}),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -451,9 +498,7 @@ func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.Serve
 				Repository struct {
 					Discussion struct {
 						Comments struct {
-							Nodes []struct {
-								Body githubv4.String
-							}
+							Nodes    []discussionCommentNode
 							PageInfo struct {
 								HasNextPage     githubv4.Boolean
 								HasPreviousPage githubv4.Boolean
@@ -480,8 +525,19 @@ func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.Serve
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
+			policy, err := lockdownPolicyFor(deps)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes, err := filterLockdown(ctx, policy, q.Repository.Discussion.Comments.Nodes, func(c discussionCommentNode) authoredContent {
+				return authoredContent{login: string(c.Author.Login), owner: params.Owner, repo: params.Repo}
+			})
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+
 			var comments []*github.IssueComment
-			for _, c := range q.Repository.Discussion.Comments.Nodes {
+			for _, c := range nodes {
 				comments = append(comments, &github.IssueComment{Body: github.Ptr(string(c.Body))})
 			}
 
@@ -497,12 +553,7 @@ func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.Serve
 				"totalCount": q.Repository.Discussion.Comments.TotalCount,
 			}
 
-			out, err := json.Marshal(response)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal comments: %w", err)
-			}
-
-			return utils.NewToolResultText(string(out)), nil, nil
+			return MarshalledTextResult(ctx, response), nil, nil
 		},
 	)
 }
@@ -531,6 +582,20 @@ func ListDiscussionCategories(t translations.TranslationHelperFunc) inventory.Se
 				},
 				Required: []string{"owner"},
 			},
+			OutputSchema: ObjectOutputSchema("Discussion categories of the repository, with categories, pageInfo and totalCount.", map[string]*jsonschema.Schema{
+				"categories": {
+					Types: []string{"null", "array"},
+					Items: &jsonschema.Schema{
+						Type: "object",
+						Properties: map[string]*jsonschema.Schema{
+							"id":   {Type: "string"},
+							"name": {Type: "string"},
+						},
+					},
+				},
+				"pageInfo":   graphQLPageInfoOutputSchema(),
+				"totalCount": {Type: "integer"},
+			}),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -599,11 +664,7 @@ func ListDiscussionCategories(t translations.TranslationHelperFunc) inventory.Se
 				"totalCount": q.Repository.DiscussionCategories.TotalCount,
 			}
 
-			out, err := json.Marshal(response)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal discussion categories: %w", err)
-			}
-			return utils.NewToolResultText(string(out)), nil, nil
+			return MarshalledTextResult(ctx, response), nil, nil
 		},
 	)
 }
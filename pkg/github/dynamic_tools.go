@@ -2,10 +2,11 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"math"
 
 	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/tooldiscovery"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/github/github-mcp-server/pkg/utils"
 	"github.com/google/jsonschema-go/jsonschema"
@@ -54,6 +55,10 @@ func DynamicTools(r *inventory.Inventory) []inventory.ServerTool {
 		ListAvailableToolsets(),
 		GetToolsetsTools(r),
 		EnableToolset(r),
+		DisableToolset(r),
+		EnableTool(),
+		DisableTool(),
+		SearchTools(),
 	}
 }
 
@@ -79,6 +84,7 @@ func EnableToolset(r *inventory.Inventory) inventory.ServerTool {
 				},
 				Required: []string{"toolset"},
 			},
+			OutputSchema: OutputSchema[MessageResponse](),
 		},
 		func(deps DynamicToolDependencies) mcp.ToolHandlerFor[map[string]any, any] {
 			return func(_ context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -94,19 +100,190 @@ func EnableToolset(r *inventory.Inventory) inventory.ServerTool {
 				}
 
 				if deps.Inventory.IsToolsetEnabled(toolsetID) {
-					return utils.NewToolResultText(fmt.Sprintf("Toolset %s is already enabled", toolsetName)), nil, nil
+					return MessageResult(fmt.Sprintf("Toolset %s is already enabled", toolsetName)), nil, nil
 				}
 
-				// Mark the toolset as enabled so IsToolsetEnabled returns true
-				deps.Inventory.EnableToolset(toolsetID)
+				toolCount := enableToolset(deps, toolsetID)
+				return MessageResult(fmt.Sprintf("Toolset %s enabled with %d tools", toolsetName, toolCount)), nil, nil
+			}
+		},
+	)
+}
+
+// enableToolset marks a toolset as enabled and registers its tools with the server, returning
+// the number of tools registered.
+func enableToolset(deps DynamicToolDependencies, toolsetID inventory.ToolsetID) int {
+	// Mark the toolset as enabled so IsToolsetEnabled returns true
+	deps.Inventory.EnableToolset(toolsetID)
+
+	// Get tools for this toolset and register them with the managed deps
+	toolsForToolset := deps.Inventory.ToolsForToolset(toolsetID)
+	for _, st := range toolsForToolset {
+		st.RegisterFunc(deps.Server, deps.ToolDeps)
+	}
+	return len(toolsForToolset)
+}
+
+// DisableToolset creates a tool that disables a toolset at runtime.
+func DisableToolset(r *inventory.Inventory) inventory.ServerTool {
+	return NewDynamicTool(
+		ToolsetMetadataDynamic,
+		mcp.Tool{
+			Name:        "disable_toolset",
+			Description: "Disable one of the enabled toolsets of the GitHub MCP server when its tools are no longer needed, to keep the list of tools short. Tools enabled individually with enable_tool stay available",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Disable a toolset",
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"toolset": {
+						Type:        "string",
+						Description: "The name of the toolset to disable",
+						Enum:        toolsetIDsEnum(r),
+					},
+				},
+				Required: []string{"toolset"},
+			},
+			OutputSchema: OutputSchema[MessageResponse](),
+		},
+		func(deps DynamicToolDependencies) mcp.ToolHandlerFor[map[string]any, any] {
+			return func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+				toolsetName, err := RequiredParam[string](args, "toolset")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				toolsetID := inventory.ToolsetID(toolsetName)
+
+				if !deps.Inventory.HasToolset(toolsetID) {
+					return utils.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil, nil
+				}
+
+				if !deps.Inventory.IsToolsetEnabled(toolsetID) {
+					return MessageResult(fmt.Sprintf("Toolset %s is already disabled", toolsetName)), nil, nil
+				}
+
+				deps.Inventory.DisableToolset(toolsetID)
+
+				// Remove the tools of this toolset that are not enabled individually
+				var removed []string
+				for _, st := range deps.Inventory.ToolsForToolset(toolsetID) {
+					if !deps.Inventory.IsToolEnabled(ctx, st.Tool.Name) {
+						removed = append(removed, st.Tool.Name)
+					}
+				}
+				deps.Server.RemoveTools(removed...)
+
+				return MessageResult(fmt.Sprintf("Toolset %s disabled, %d tools removed", toolsetName, len(removed))), nil, nil
+			}
+		},
+	)
+}
+
+// EnableTool creates a tool that enables a single tool at runtime, without the rest of its toolset.
+func EnableTool() inventory.ServerTool {
+	return NewDynamicTool(
+		ToolsetMetadataDynamic,
+		mcp.Tool{
+			Name:        "enable_tool",
+			Description: "Enable a single tool of the GitHub MCP server without enabling the rest of its toolset. Use search_tools or get_toolset_tools first to find the name of the tool",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Enable a tool",
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"tool": {
+						Type:        "string",
+						Description: "The name of the tool to enable",
+					},
+				},
+				Required: []string{"tool"},
+			},
+			OutputSchema: OutputSchema[MessageResponse](),
+		},
+		func(deps DynamicToolDependencies) mcp.ToolHandlerFor[map[string]any, any] {
+			return func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+				toolName, err := RequiredParam[string](args, "tool")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				resolved, _ := deps.Inventory.ResolveToolAliases([]string{toolName})
+				toolName = resolved[0]
+				if _, _, err := deps.Inventory.FindToolByName(toolName); err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("Tool %s not found", toolName)), nil, nil
+				}
+
+				if deps.Inventory.IsToolEnabled(ctx, toolName) {
+					return MessageResult(fmt.Sprintf("Tool %s is already enabled", toolName)), nil, nil
+				}
+
+				deps.Inventory.EnableTool(toolName)
+				if !deps.Inventory.IsToolEnabled(ctx, toolName) {
+					// For example a write tool in read-only mode
+					return utils.NewToolResultError(fmt.Sprintf("Tool %s is not available in this server configuration", toolName)), nil, nil
+				}
+
+				for _, st := range deps.Inventory.AvailableTools(ctx) {
+					if st.Tool.Name == toolName {
+						st.RegisterFunc(deps.Server, deps.ToolDeps)
+					}
+				}
 
-				// Get tools for this toolset and register them with the managed deps
-				toolsForToolset := deps.Inventory.ToolsForToolset(toolsetID)
-				for _, st := range toolsForToolset {
-					st.RegisterFunc(deps.Server, deps.ToolDeps)
+				return MessageResult(fmt.Sprintf("Tool %s enabled", toolName)), nil, nil
+			}
+		},
+	)
+}
+
+// DisableTool creates a tool that disables a single tool at runtime.
+func DisableTool() inventory.ServerTool {
+	return NewDynamicTool(
+		ToolsetMetadataDynamic,
+		mcp.Tool{
+			Name:        "disable_tool",
+			Description: "Disable a single tool of the GitHub MCP server when it is no longer needed, even if the rest of its toolset stays enabled",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Disable a tool",
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"tool": {
+						Type:        "string",
+						Description: "The name of the tool to disable",
+					},
+				},
+				Required: []string{"tool"},
+			},
+			OutputSchema: OutputSchema[MessageResponse](),
+		},
+		func(deps DynamicToolDependencies) mcp.ToolHandlerFor[map[string]any, any] {
+			return func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+				toolName, err := RequiredParam[string](args, "tool")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				resolved, _ := deps.Inventory.ResolveToolAliases([]string{toolName})
+				toolName = resolved[0]
+				if _, _, err := deps.Inventory.FindToolByName(toolName); err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("Tool %s not found", toolName)), nil, nil
 				}
 
-				return utils.NewToolResultText(fmt.Sprintf("Toolset %s enabled with %d tools", toolsetName, len(toolsForToolset))), nil, nil
+				if !deps.Inventory.IsToolEnabled(ctx, toolName) {
+					return MessageResult(fmt.Sprintf("Tool %s is already disabled", toolName)), nil, nil
+				}
+
+				deps.Inventory.DisableTool(toolName)
+				deps.Server.RemoveTools(toolName)
+
+				return MessageResult(fmt.Sprintf("Tool %s disabled", toolName)), nil, nil
 			}
 		},
 	)
@@ -127,9 +304,10 @@ func ListAvailableToolsets() inventory.ServerTool {
 				Type:       "object",
 				Properties: map[string]*jsonschema.Schema{},
 			},
+			OutputSchema: OutputSchema[[]map[string]string](),
 		},
 		func(deps DynamicToolDependencies) mcp.ToolHandlerFor[map[string]any, any] {
-			return func(_ context.Context, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+			return func(ctx context.Context, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
 				toolsetIDs := deps.Inventory.ToolsetIDs()
 				descriptions := deps.Inventory.ToolsetDescriptions()
 
@@ -144,12 +322,7 @@ func ListAvailableToolsets() inventory.ServerTool {
 					payload = append(payload, t)
 				}
 
-				r, err := json.Marshal(payload)
-				if err != nil {
-					return nil, nil, fmt.Errorf("failed to marshal features: %w", err)
-				}
-
-				return utils.NewToolResultText(string(r)), nil, nil
+				return MarshalledTextResult(ctx, payload), nil, nil
 			}
 		},
 	)
@@ -177,9 +350,10 @@ func GetToolsetsTools(r *inventory.Inventory) inventory.ServerTool {
 				},
 				Required: []string{"toolset"},
 			},
+			OutputSchema: OutputSchema[[]map[string]string](),
 		},
 		func(deps DynamicToolDependencies) mcp.ToolHandlerFor[map[string]any, any] {
-			return func(_ context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			return func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 				toolsetName, err := RequiredParam[string](args, "toolset")
 				if err != nil {
 					return utils.NewToolResultError(err.Error()), nil, nil
@@ -205,12 +379,131 @@ func GetToolsetsTools(r *inventory.Inventory) inventory.ServerTool {
 					payload = append(payload, tool)
 				}
 
-				r, err := json.Marshal(payload)
+				return MarshalledTextResult(ctx, payload), nil, nil
+			}
+		},
+	)
+}
+
+// ToolSearchMatch is a tool found by search_tools.
+type ToolSearchMatch struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	Toolset        string   `json:"toolset"`
+	ToolsetEnabled bool     `json:"toolset_enabled"`
+	Score          float64  `json:"score"`
+	MatchedIn      []string `json:"matched_in,omitempty"`
+}
+
+// SearchToolsResponse is the structured content of search_tools.
+type SearchToolsResponse struct {
+	Tools          []ToolSearchMatch `json:"tools"`
+	EnabledToolset string            `json:"enabled_toolset,omitempty"`
+}
+
+// NewToolSearchIndex indexes every tool of the inventory that could be enabled, including the
+// tools of toolsets that are not enabled, together with the description of their toolset.
+func NewToolSearchIndex(r *inventory.Inventory) *tooldiscovery.Index {
+	descriptions := r.ToolsetDescriptions()
+	seen := make(map[string]bool)
+	var docs []tooldiscovery.Document
+	for _, toolsetID := range r.ToolsetIDs() {
+		for _, st := range r.ToolsForToolset(toolsetID) {
+			// Feature-flagged variants of a tool share its name
+			if seen[st.Tool.Name] {
+				continue
+			}
+			seen[st.Tool.Name] = true
+			// Custom toolsets list tools of other toolsets, so use the tool's own toolset
+			docs = append(docs, tooldiscovery.Document{
+				Tool:               st.Tool,
+				Toolset:            string(st.Toolset.ID),
+				ToolsetDescription: descriptions[st.Toolset.ID],
+			})
+		}
+	}
+	return tooldiscovery.NewIndex(docs)
+}
+
+// SearchTools creates a tool that searches every tool of the inventory, including the tools of
+// toolsets that are not enabled yet. The search index is built once when the tool is registered.
+func SearchTools() inventory.ServerTool {
+	return NewDynamicTool(
+		ToolsetMetadataDynamic,
+		mcp.Tool{
+			Name:        "search_tools",
+			Description: "Search all the tools this GitHub MCP server can offer, including the ones in toolsets that are not enabled yet, and return the best matches with their toolset. Use this to find the right tool for a task instead of guessing which toolset to enable. Set enable to also enable the toolset of the best match",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Search tools",
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "What you want to do, e.g. 'list workflow runs' or 'create a gist'",
+					},
+					"max_results": {
+						Type:        "number",
+						Description: fmt.Sprintf("Maximum number of tools to return (default %d)", tooldiscovery.DefaultMaxSearchResults),
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(20.0),
+					},
+					"enable": {
+						Type:        "boolean",
+						Description: "Enable the toolset of the best match, so that it can be called right away",
+					},
+				},
+				Required: []string{"query"},
+			},
+			OutputSchema: OutputSchema[SearchToolsResponse](),
+		},
+		func(deps DynamicToolDependencies) mcp.ToolHandlerFor[map[string]any, any] {
+			index := NewToolSearchIndex(deps.Inventory)
+			return func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+				query, err := RequiredParam[string](args, "query")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				maxResults, err := OptionalIntParam(args, "max_results")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if maxResults < 0 || maxResults > 20 {
+					return utils.NewToolResultError("max_results must be between 1 and 20"), nil, nil
+				}
+				enable, err := OptionalParam[bool](args, "enable")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				results, err := index.Search(query, tooldiscovery.SearchOptions{MaxResults: maxResults})
 				if err != nil {
-					return nil, nil, fmt.Errorf("failed to marshal features: %w", err)
+					return utils.NewToolResultErrorFromErr("failed to search tools", err), nil, nil
+				}
+
+				response := SearchToolsResponse{Tools: make([]ToolSearchMatch, 0, len(results))}
+				if enable && len(results) > 0 {
+					toolsetID := inventory.ToolsetID(results[0].Toolset)
+					if !deps.Inventory.IsToolsetEnabled(toolsetID) {
+						enableToolset(deps, toolsetID)
+						response.EnabledToolset = string(toolsetID)
+					}
+				}
+				for _, r := range results {
+					toolsetID := inventory.ToolsetID(r.Toolset)
+					response.Tools = append(response.Tools, ToolSearchMatch{
+						Name:           r.Tool.Name,
+						Description:    r.Tool.Description,
+						Toolset:        string(toolsetID),
+						ToolsetEnabled: deps.Inventory.IsToolsetEnabled(toolsetID),
+						Score:          math.Round(r.Score*100) / 100,
+						MatchedIn:      r.MatchedIn,
+					})
 				}
 
-				return utils.NewToolResultText(string(r)), nil, nil
+				return MarshalledTextResult(ctx, response), nil, nil
 			}
 		},
 	)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -136,7 +137,7 @@ func TestDynamicTools_EnableToolset(t *testing.T) {
 	deps := DynamicToolDependencies{
 		Server:    server,
 		Inventory: reg,
-		ToolDeps:  NewBaseDeps(nil, nil, nil, nil, translations.NullTranslationHelper, FeatureFlags{}, 0, nil),
+		ToolDeps:  NewBaseDeps(nil, nil, nil, nil, translations.NullTranslationHelper, FeatureFlags{}, 0, "", nil),
 		T:         translations.NullTranslationHelper,
 	}
 
@@ -171,6 +172,37 @@ func TestDynamicTools_EnableToolset(t *testing.T) {
 	assert.Contains(t, textContent2.Text, "already enabled")
 }
 
+func TestDynamicTools_EnableCustomToolset(t *testing.T) {
+	// Build a registry with a custom toolset and no toolsets enabled (dynamic mode)
+	reg, err := NewInventory(translations.NullTranslationHelper).
+		WithToolsets([]string{}).
+		WithCustomToolsets([]inventory.CustomToolset{{
+			ToolsetMetadata: inventory.ToolsetMetadata{ID: "release"},
+			Tools:           []string{"list_tags", "get_latest_release"},
+		}}).
+		Build()
+	require.NoError(t, err)
+
+	deps := DynamicToolDependencies{
+		Server:    mcp.NewServer(&mcp.Implementation{Name: "test"}, nil),
+		Inventory: reg,
+		ToolDeps:  NewBaseDeps(nil, nil, nil, nil, translations.NullTranslationHelper, FeatureFlags{}, 0, "", nil),
+		T:         translations.NullTranslationHelper,
+	}
+
+	// The custom toolset is offered by enable_toolset
+	tool := EnableToolset(reg)
+	assert.Contains(t, tool.Tool.InputSchema.(*jsonschema.Schema).Properties["toolset"].Enum, inventory.ToolsetID("release"))
+
+	result, err := tool.Handler(deps)(context.Background(), createDynamicRequest(map[string]any{
+		"toolset": "release",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "Toolset release enabled with 2 tools", getTextResult(t, result).Text)
+	assert.True(t, reg.IsToolEnabled(context.Background(), "list_tags"))
+	assert.False(t, reg.IsToolsetEnabled("repos"))
+}
+
 func TestDynamicTools_EnableToolset_InvalidToolset(t *testing.T) {
 	// Build a registry with no toolsets enabled (dynamic mode)
 	reg, err := NewInventory(translations.NullTranslationHelper).
@@ -234,3 +266,149 @@ func TestDynamicTools_ToolsetsEnum(t *testing.T) {
 		}
 	}
 }
+
+func TestDynamicTools_SearchTools(t *testing.T) {
+	// Build a registry with no toolsets enabled (dynamic mode)
+	reg, err := NewInventory(translations.NullTranslationHelper).
+		WithToolsets([]string{}).
+		Build()
+	require.NoError(t, err)
+
+	// Create a mock server
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+
+	// Create dynamic tool dependencies
+	deps := DynamicToolDependencies{
+		Server:    server,
+		Inventory: reg,
+		ToolDeps:  NewBaseDeps(nil, nil, nil, nil, translations.NullTranslationHelper, FeatureFlags{}, 0, "", nil),
+		T:         translations.NullTranslationHelper,
+	}
+
+	tool := SearchTools()
+	handler := tool.Handler(deps)
+
+	// Search finds tools of toolsets that are not enabled
+	result, err := handler(context.Background(), createDynamicRequest(map[string]any{
+		"query":       "list workflow runs",
+		"max_results": 5,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response SearchToolsResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &response))
+	require.NotEmpty(t, response.Tools)
+	assert.LessOrEqual(t, len(response.Tools), 5)
+	assert.Equal(t, "list_workflow_runs", response.Tools[0].Name)
+	assert.Equal(t, "actions", response.Tools[0].Toolset)
+	assert.False(t, response.Tools[0].ToolsetEnabled)
+	assert.Empty(t, response.EnabledToolset)
+	assert.False(t, reg.IsToolsetEnabled(inventory.ToolsetID("actions")))
+
+	// With enable, the toolset of the best match is enabled in the same call
+	result, err = handler(context.Background(), createDynamicRequest(map[string]any{
+		"query":  "list workflow runs",
+		"enable": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	response = SearchToolsResponse{}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &response))
+	assert.Equal(t, "actions", response.EnabledToolset)
+	assert.True(t, response.Tools[0].ToolsetEnabled)
+	assert.True(t, reg.IsToolsetEnabled(inventory.ToolsetID("actions")))
+
+	// A missing query is an error
+	result, err = handler(context.Background(), createDynamicRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestDynamicTools_DisableToolsetAndTools(t *testing.T) {
+	ctx := context.Background()
+
+	// Build a registry with the repos toolset enabled
+	reg, err := NewInventory(translations.NullTranslationHelper).
+		WithToolsets([]string{"repos"}).
+		Build()
+	require.NoError(t, err)
+
+	toolDeps := NewBaseDeps(nil, nil, nil, nil, translations.NullTranslationHelper, FeatureFlags{}, 0, "", nil)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, &mcp.ServerOptions{
+		Capabilities: &mcp.ServerCapabilities{Tools: &mcp.ToolCapabilities{ListChanged: true}},
+	})
+	reg.RegisterTools(ctx, server, toolDeps)
+
+	// Connect a client to observe tools/list_changed notifications
+	listChanged := make(chan struct{}, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "client"}, &mcp.ClientOptions{
+		ToolListChangedHandler: func(context.Context, *mcp.ToolListChangedRequest) { listChanged <- struct{}{} },
+	})
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err = server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = session.Close() }()
+
+	listedTools := func() map[string]bool {
+		result, err := session.ListTools(ctx, nil)
+		require.NoError(t, err)
+		names := make(map[string]bool, len(result.Tools))
+		for _, tool := range result.Tools {
+			names[tool.Name] = true
+		}
+		return names
+	}
+	awaitListChanged := func() {
+		select {
+		case <-listChanged:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected a tools/list_changed notification")
+		}
+	}
+	require.True(t, listedTools()["get_commit"])
+
+	deps := DynamicToolDependencies{
+		Server:    server,
+		Inventory: reg,
+		ToolDeps:  toolDeps,
+		T:         translations.NullTranslationHelper,
+	}
+	call := func(tool inventory.ServerTool, args map[string]any) string {
+		result, err := tool.Handler(deps)(ctx, createDynamicRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError, "unexpected error: %s", result.Content[0].(*mcp.TextContent).Text)
+		return result.Content[0].(*mcp.TextContent).Text
+	}
+
+	// Enable a single tool of a disabled toolset
+	assert.Equal(t, "Tool get_me enabled", call(EnableTool(), map[string]any{"tool": "get_me"}))
+	awaitListChanged()
+	assert.True(t, listedTools()["get_me"])
+	assert.False(t, reg.IsToolsetEnabled(inventory.ToolsetID("context")))
+
+	// Disable a single tool of an enabled toolset
+	assert.Equal(t, "Tool get_commit disabled", call(DisableTool(), map[string]any{"tool": "get_commit"}))
+	awaitListChanged()
+	assert.False(t, listedTools()["get_commit"])
+	assert.Contains(t, call(DisableTool(), map[string]any{"tool": "get_commit"}), "already disabled")
+
+	// Disable the whole toolset
+	assert.Contains(t, call(DisableToolset(reg), map[string]any{"toolset": "repos"}), "Toolset repos disabled")
+	awaitListChanged()
+	tools := listedTools()
+	assert.False(t, tools["list_commits"])
+	assert.True(t, tools["get_me"], "tools enabled individually should stay")
+	assert.False(t, reg.IsToolsetEnabled(inventory.ToolsetID("repos")))
+	assert.Contains(t, call(DisableToolset(reg), map[string]any{"toolset": "repos"}), "already disabled")
+
+	// Unknown tools are reported
+	enableTool := EnableTool()
+	result, err := enableTool.Handler(deps)(ctx, createDynamicRequest(map[string]any{"tool": "nonexistent"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "not found")
+}
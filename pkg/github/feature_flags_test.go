@@ -103,6 +103,7 @@ func TestHelloWorld_ConditionalBehavior_Featureflag(t *testing.T) {
 				translations.NullTranslationHelper,
 				FeatureFlags{},
 				0,
+				"",
 				checker,
 			)
 
@@ -165,6 +166,7 @@ func TestHelloWorld_ConditionalBehavior_Config(t *testing.T) {
 				translations.NullTranslationHelper,
 				FeatureFlags{InsidersMode: tt.insidersMode},
 				0,
+				"",
 				nil,
 			)
 
@@ -0,0 +1,286 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FieldsParameter is the name of the argument that projects tool results onto a subset of fields.
+const FieldsParameter = "fields"
+
+// fieldsParameterSchema is shared by every tool that accepts the fields argument. NewTool uses its
+// identity to recognize those tools, since some tools have an unrelated argument of the same name.
+var fieldsParameterSchema = &jsonschema.Schema{
+	Type:        "array",
+	Description: "Only return these fields of the result, e.g. [\"number\", \"title\", \"user.login\"]. Nested fields are separated by dots, and paths through lists apply to every element. A jq-like expression such as \"{number, title}\" is also accepted.",
+	Items: &jsonschema.Schema{
+		Type: "string",
+	},
+}
+
+// WithFields adds the fields argument to a tool. The tool must declare an OutputSchema, which the
+// requested fields are validated against, and return its result through MarshalledTextResult.
+func WithFields(schema *jsonschema.Schema) *jsonschema.Schema {
+	schema.Properties[FieldsParameter] = fieldsParameterSchema
+	return schema
+}
+
+// hasFieldsParameter reports whether the tool accepts the fields argument added by WithFields.
+func hasFieldsParameter(tool mcp.Tool) bool {
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	return ok && schema != nil && schema.Properties[FieldsParameter] == fieldsParameterSchema
+}
+
+// fieldSelection is a tree of the requested fields. A nil subtree selects the whole field.
+type fieldSelection map[string]fieldSelection
+
+// parseFields parses the fields argument into a fieldSelection. Each entry is a dot-separated
+// path, optionally starting with a dot and using [] to step into lists as in jq, and entries may
+// be combined into a single "{a, b.c}" expression.
+func parseFields(fields []string) (fieldSelection, error) {
+	selection := fieldSelection{}
+	for _, entry := range fields {
+		entry = strings.TrimSpace(entry)
+		if strings.HasPrefix(entry, "{") && strings.HasSuffix(entry, "}") {
+			entry = entry[1 : len(entry)-1]
+		}
+		for _, path := range strings.Split(entry, ",") {
+			if err := selection.add(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(selection) == 0 {
+		return nil, fmt.Errorf("fields must select at least one field")
+	}
+	return selection, nil
+}
+
+func (s fieldSelection) add(path string) error {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(path), ".")
+	segments := strings.Split(trimmed, ".")
+
+	node := s
+	for i, segment := range segments {
+		segment = strings.TrimSuffix(strings.TrimSpace(segment), "[]")
+		if segment == "" || strings.ContainsAny(segment, "[]{} ") {
+			return fmt.Errorf("invalid field path %q", strings.TrimSpace(path))
+		}
+
+		child, exists := node[segment]
+		switch {
+		case exists && child == nil:
+			// A parent of this path is already selected in full.
+			return nil
+		case i == len(segments)-1:
+			node[segment] = nil
+			return nil
+		case !exists:
+			child = fieldSelection{}
+			node[segment] = child
+		}
+		node = child
+	}
+	return nil
+}
+
+// validate checks that every selected field is declared by the output schema. Objects whose
+// schema does not declare properties, such as nested GitHub API objects, accept any field.
+func (s fieldSelection) validate(schema *jsonschema.Schema) error {
+	return s.validateAt(schema, "")
+}
+
+func (s fieldSelection) validateAt(schema *jsonschema.Schema, prefix string) error {
+	if schema == nil {
+		return nil
+	}
+	// Fields select inside lists, so the "items" wrapper of list results is not part of the paths.
+	if items, ok := schema.Properties[structuredItemsKey]; ok && len(schema.Properties) == 1 && prefix == "" {
+		schema = items
+	}
+	if len(schema.AnyOf) > 0 {
+		var firstErr error
+		for _, alternative := range schema.AnyOf {
+			err := s.validateAt(alternative, prefix)
+			if err == nil {
+				return nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+	if schema.Items != nil {
+		return s.validateAt(schema.Items, prefix)
+	}
+	if len(schema.Properties) == 0 {
+		return nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(s)) {
+		property, ok := schema.Properties[name]
+		if !ok {
+			return fmt.Errorf("unknown field %q, available fields are: %s", prefix+name, strings.Join(slices.Sorted(maps.Keys(schema.Properties)), ", "))
+		}
+		if s[name] != nil {
+			if err := s[name].validateAt(property, prefix+name+"."); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// project returns the parts of the decoded JSON value v that are selected.
+func (s fieldSelection) project(v any) any {
+	switch v := v.(type) {
+	case []any:
+		projected := make([]any, len(v))
+		for i, element := range v {
+			projected[i] = s.project(element)
+		}
+		return projected
+	case map[string]any:
+		projected := make(map[string]any, len(s))
+		for name, child := range s {
+			value, ok := v[name]
+			if !ok {
+				continue
+			}
+			if child != nil {
+				value = child.project(value)
+			}
+			projected[name] = value
+		}
+		return projected
+	default:
+		return v
+	}
+}
+
+// withProjectableOutputSchema relaxes the output schema of a tool that accepts the fields
+// argument: a projected result only contains the requested fields, so no property is required
+// other than the "items" wrapper of list results.
+// It panics if the output schema does not declare the fields of the result, since requested
+// fields could then not be validated and a typo would silently return empty results.
+func withProjectableOutputSchema(tool mcp.Tool) mcp.Tool {
+	schema, _ := tool.OutputSchema.(*jsonschema.Schema)
+	if !declaresFields(schema) {
+		panic(fmt.Sprintf("tool %s accepts the %s argument but its output schema declares no fields", tool.Name, FieldsParameter))
+	}
+	relaxed := schema.CloneSchemas()
+	relaxRequired(relaxed)
+	tool.OutputSchema = relaxed
+	return tool
+}
+
+// declaresFields reports whether schema declares the top-level fields of the results it
+// describes, looking through the "items" wrapper, lists and every anyOf alternative.
+func declaresFields(schema *jsonschema.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	if items, ok := schema.Properties[structuredItemsKey]; ok && len(schema.Properties) == 1 {
+		schema = items
+	}
+	if len(schema.AnyOf) > 0 {
+		for _, alternative := range schema.AnyOf {
+			if !declaresFields(alternative) {
+				return false
+			}
+		}
+		return true
+	}
+	if schema.Items != nil {
+		return declaresFields(schema.Items)
+	}
+	return len(schema.Properties) > 0
+}
+
+func relaxRequired(schema *jsonschema.Schema) {
+	if schema == nil {
+		return
+	}
+	if _, ok := schema.Properties[structuredItemsKey]; !ok || len(schema.Properties) != 1 {
+		schema.Required = nil
+	}
+	for _, property := range schema.Properties {
+		relaxRequired(property)
+	}
+	for _, alternative := range schema.AnyOf {
+		relaxRequired(alternative)
+	}
+	relaxRequired(schema.Items)
+	relaxRequired(schema.AdditionalProperties)
+}
+
+// projectJSON applies the selection to the JSON document data.
+func (s fieldSelection) projectJSON(data []byte) ([]byte, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(s.project(v))
+}
+
+// fieldsContextKey is the context key for the field selection of the current tool call.
+type fieldsContextKey struct{}
+
+// fieldsFromContext returns the field selection of the current tool call, if any.
+func fieldsFromContext(ctx context.Context) fieldSelection {
+	selection, _ := ctx.Value(fieldsContextKey{}).(fieldSelection)
+	return selection
+}
+
+// contextWithoutFields returns ctx without a field selection, so results are rendered in full.
+func contextWithoutFields(ctx context.Context) context.Context {
+	if fieldsFromContext(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, fieldsContextKey{}, fieldSelection(nil))
+}
+
+// contextWithCallFields parses and validates the fields argument of a tool call and stores the
+// selection in the context, where MarshalledTextResult applies it.
+func contextWithCallFields(ctx context.Context, tool mcp.Tool, req *mcp.CallToolRequest) (context.Context, error) {
+	if req == nil || req.Params == nil || len(req.Params.Arguments) == 0 {
+		return ctx, nil
+	}
+
+	var args struct {
+		Fields json.RawMessage `json:"fields"`
+	}
+	// Malformed arguments are reported by the tool handler itself.
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil || len(args.Fields) == 0 || string(args.Fields) == "null" {
+		return ctx, nil
+	}
+
+	var fields []string
+	if err := json.Unmarshal(args.Fields, &fields); err != nil {
+		// Be lenient with clients that send a single expression instead of a list.
+		var expression string
+		if err := json.Unmarshal(args.Fields, &expression); err != nil {
+			return ctx, fmt.Errorf("fields must be a list of field paths")
+		}
+		fields = []string{expression}
+	}
+
+	selection, err := parseFields(fields)
+	if err != nil {
+		return ctx, err
+	}
+	if schema, ok := tool.OutputSchema.(*jsonschema.Schema); ok && schema != nil {
+		if err := selection.validate(schema); err != nil {
+			return ctx, err
+		}
+	}
+	return context.WithValue(ctx, fieldsContextKey{}, selection), nil
+}
@@ -0,0 +1,252 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		fields      []string
+		expected    fieldSelection
+		expectedErr string
+	}{
+		{
+			name:     "paths",
+			fields:   []string{"number", "user.login", "labels.name"},
+			expected: fieldSelection{"number": nil, "user": {"login": nil}, "labels": {"name": nil}},
+		},
+		{
+			name:     "jq-like expression",
+			fields:   []string{"{.number, .title, .labels[].name}"},
+			expected: fieldSelection{"number": nil, "title": nil, "labels": {"name": nil}},
+		},
+		{
+			name:     "whole field wins over nested fields",
+			fields:   []string{"user.login", "user", "user.id"},
+			expected: fieldSelection{"user": nil},
+		},
+		{
+			name:        "empty path",
+			fields:      []string{"number,,title"},
+			expectedErr: `invalid field path ""`,
+		},
+		{
+			name:        "invalid path",
+			fields:      []string{"user..login"},
+			expectedErr: `invalid field path "user..login"`,
+		},
+		{
+			name:        "no fields",
+			fields:      []string{},
+			expectedErr: "fields must select at least one field",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			selection, err := parseFields(tc.fields)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, selection)
+		})
+	}
+}
+
+func Test_FieldSelectionValidate(t *testing.T) {
+	pullRequests := APIListOutputSchema[github.PullRequest]("")
+	workflowRuns := APIObjectOutputSchema[github.WorkflowRuns]("")
+
+	tests := []struct {
+		name        string
+		schema      *jsonschema.Schema
+		fields      []string
+		expectedErr string
+	}{
+		{
+			name:   "list elements",
+			schema: pullRequests,
+			fields: []string{"number", "title", "user.login"},
+		},
+		{
+			name:        "typo in list element field",
+			schema:      pullRequests,
+			fields:      []string{"number", "titel"},
+			expectedErr: `unknown field "titel", available fields are: `,
+		},
+		{
+			name:   "nested list",
+			schema: workflowRuns,
+			fields: []string{"total_count", "workflow_runs.name", "workflow_runs.head_commit.message"},
+		},
+		{
+			name:        "typo in nested list",
+			schema:      workflowRuns,
+			fields:      []string{"workflow_runs.nme"},
+			expectedErr: `unknown field "workflow_runs.nme"`,
+		},
+		{
+			name:   "opaque schema accepts any field",
+			schema: &jsonschema.Schema{Type: "object"},
+			fields: []string{"whatever.you.like"},
+		},
+		{
+			name:        "errors inside alternatives keep the nested path",
+			schema:      AnyOfOutputSchema(OutputSchema[TreeResponse]()),
+			fields:      []string{"tree.nme"},
+			expectedErr: `unknown field "tree.nme"`,
+		},
+		{
+			name:   "any of the alternatives",
+			schema: AnyOfOutputSchema(OutputSchema[MessageResponse](), OutputSchema[[]MinimalBranch]()),
+			fields: []string{"name"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			selection, err := parseFields(tc.fields)
+			require.NoError(t, err)
+
+			err = selection.validate(tc.schema)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_ListPullRequestsFields(t *testing.T) {
+	serverTool := ListPullRequests(translations.NullTranslationHelper)
+
+	mockPRs := []*github.PullRequest{
+		{
+			Number:  github.Ptr(42),
+			Title:   github.Ptr("First PR"),
+			State:   github.Ptr("open"),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42"),
+			User:    &github.User{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1))},
+		},
+		{
+			Number: github.Ptr(43),
+			Title:  github.Ptr("Second PR"),
+			State:  github.Ptr("closed"),
+			User:   &github.User{Login: github.Ptr("hubot"), ID: github.Ptr(int64(2))},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]any
+		expectedText       string
+		expectedStructured string
+		expectedErr        string
+	}{
+		{
+			name: "projects each pull request",
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"fields": []any{"number", "user.login"},
+			},
+			expectedText:       `[{"number":42,"user":{"login":"octocat"}},{"number":43,"user":{"login":"hubot"}}]`,
+			expectedStructured: `{"items":[{"number":42,"user":{"login":"octocat"}},{"number":43,"user":{"login":"hubot"}}]}`,
+		},
+		{
+			name: "combines with the output format",
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"fields":        "{number, title}",
+				"output_format": "compact",
+			},
+			expectedText:       "number,title\n42,First PR\n43,Second PR",
+			expectedStructured: `{"items":[{"number":42,"title":"First PR"},{"number":43,"title":"Second PR"}]}`,
+		},
+		{
+			name: "unknown field",
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"fields": []any{"numbr"},
+			},
+			expectedErr: `unknown field "numbr"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposPullsByOwnerByRepo: mockResponse(t, http.StatusOK, mockPRs),
+			}))
+			deps := BaseDeps{Client: client}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectedErr != "" {
+				errorResult := getErrorResult(t, result)
+				assert.Contains(t, errorResult.Text, tc.expectedErr)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+
+			// structuredContent is projected as well, and still matches the relaxed output schema
+			assertStructuredContent(t, serverTool.Tool, result)
+			structured, err := json.Marshal(result.StructuredContent)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedStructured, string(structured))
+		})
+	}
+}
+
+func Test_withProjectableOutputSchema(t *testing.T) {
+	original := OutputSchema[[]MinimalBranch]()
+	require.NotEmpty(t, original.Properties[structuredItemsKey].Items.Required)
+
+	tool := withProjectableOutputSchema(mcp.Tool{OutputSchema: original})
+	relaxed := tool.OutputSchema.(*jsonschema.Schema)
+
+	assert.Equal(t, []string{structuredItemsKey}, relaxed.Required)
+	assert.Empty(t, relaxed.Properties[structuredItemsKey].Items.Required)
+	// The cached schema is shared between tools and must stay unchanged
+	assert.NotEmpty(t, original.Properties[structuredItemsKey].Items.Required)
+}
+
+func Test_withProjectableOutputSchemaRequiresFields(t *testing.T) {
+	// Fields cannot be validated against a schema without properties, so a typo would silently
+	// project every result to an empty object.
+	assert.Panics(t, func() {
+		withProjectableOutputSchema(mcp.Tool{Name: "get_thing", OutputSchema: &jsonschema.Schema{Type: "object"}})
+	})
+	assert.Panics(t, func() {
+		withProjectableOutputSchema(mcp.Tool{Name: "list_things", OutputSchema: itemsOutputSchema(&jsonschema.Schema{Type: "array", Items: &jsonschema.Schema{Type: "object"}})})
+	})
+	assert.Panics(t, func() {
+		withProjectableOutputSchema(mcp.Tool{Name: "get_thing"})
+	})
+	assert.NotPanics(t, func() {
+		withProjectableOutputSchema(mcp.Tool{Name: "list_branches", OutputSchema: OutputSchema[[]MinimalBranch]()})
+	})
+}
@@ -28,7 +28,7 @@ func ListGists(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_LIST_GISTS", "List Gists"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithFields(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"username": {
@@ -40,7 +40,8 @@ func ListGists(t translations.TranslationHelperFunc) inventory.ServerTool {
 						Description: "Only gists updated after this time (ISO 8601 timestamp)",
 					},
 				},
-			}),
+			})),
+			OutputSchema: APIListOutputSchema[github.Gist]("Gists as returned by the GitHub REST API."),
 		},
 		nil,
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -94,12 +95,18 @@ func ListGists(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list gists", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(gists)
+			policy, err := lockdownPolicyFor(deps)
+			if err != nil {
+				return nil, nil, err
+			}
+			gists, err = filterLockdown(ctx, policy, gists, func(gist *github.Gist) authoredContent {
+				return authoredContent{login: gist.GetOwner().GetLogin()}
+			})
 			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, gists), nil, nil
 		},
 	)
 }
@@ -115,7 +122,7 @@ func GetGist(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_GET_GIST", "Get Gist Content"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFields(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"gist_id": {
@@ -124,7 +131,8 @@ func GetGist(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 				},
 				Required: []string{"gist_id"},
-			},
+			}),
+			OutputSchema: APIObjectOutputSchema[github.Gist]("Gist as returned by the GitHub REST API, including file contents."),
 		},
 		nil,
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -152,12 +160,19 @@ func GetGist(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get gist", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(gist)
+			policy, err := lockdownPolicyFor(deps)
+			if err != nil {
+				return nil, nil, err
+			}
+			isSafeContent, err := policy.isSafe(ctx, authoredContent{login: gist.GetOwner().GetLogin()})
 			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+			if !isSafeContent {
+				return utils.NewToolResultError("access to gist is restricted by lockdown mode"), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, gist), nil, nil
 		},
 	)
 }
@@ -196,6 +211,7 @@ func CreateGist(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"filename", "content"},
 			},
+			OutputSchema: OutputSchema[MinimalResponse](),
 		},
 		[]scopes.Scope{scopes.Gist},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -255,12 +271,7 @@ func CreateGist(t translations.TranslationHelperFunc) inventory.ServerTool {
 				URL: createdGist.GetHTMLURL(),
 			}
 
-			r, err := json.Marshal(minimalResponse)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, minimalResponse), nil, nil
 		},
 	)
 }
@@ -298,6 +309,7 @@ func UpdateGist(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"gist_id", "filename", "content"},
 			},
+			OutputSchema: OutputSchema[MinimalResponse](),
 		},
 		[]scopes.Scope{scopes.Gist},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -356,12 +368,7 @@ func UpdateGist(t translations.TranslationHelperFunc) inventory.ServerTool {
 				URL: updatedGist.GetHTMLURL(),
 			}
 
-			r, err := json.Marshal(minimalResponse)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, minimalResponse), nil, nil
 		},
 	)
 }
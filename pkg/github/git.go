@@ -3,7 +3,6 @@ package github
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"strings"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
@@ -76,6 +75,7 @@ func GetRepositoryTree(t translations.TranslationHelperFunc) inventory.ServerToo
 				},
 				Required: []string{"owner", "repo"},
 			},
+			OutputSchema: OutputSchema[TreeResponse](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -166,12 +166,7 @@ func GetRepositoryTree(t translations.TranslationHelperFunc) inventory.ServerToo
 				Count:     len(filteredEntries),
 			}
 
-			r, err := json.Marshal(response)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, response), nil, nil
 		},
 	)
 }
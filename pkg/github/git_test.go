@@ -147,6 +147,7 @@ func Test_GetRepositoryTree(t *testing.T) {
 
 				// Parse the result and get the text content
 				textContent := getTextResult(t, result)
+				assertStructuredContent(t, toolDef.Tool, result)
 
 				// Parse the JSON response
 				var treeResponse map[string]interface{}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	testifymock "github.com/stretchr/testify/mock"
@@ -21,6 +22,7 @@ const (
 	// User endpoints
 	GetUser                        = "GET /user"
 	GetUserStarred                 = "GET /user/starred"
+	GetUserOrgs                    = "GET /user/orgs"
 	GetUsersGistsByUsername        = "GET /users/{username}/gists"
 	GetUsersStarredByUsername      = "GET /users/{username}/starred"
 	PutUserStarredByOwnerByRepo    = "PUT /user/starred/{owner}/{repo}"
@@ -61,6 +63,8 @@ const (
 	PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber          = "POST /repos/{owner}/{repo}/issues/{issue_number}/sub_issues"
 	DeleteReposIssuesSubIssueByOwnerByRepoByIssueNumber         = "DELETE /repos/{owner}/{repo}/issues/{issue_number}/sub_issue"
 	PatchReposIssuesSubIssuesPriorityByOwnerByRepoByIssueNumber = "PATCH /repos/{owner}/{repo}/issues/{issue_number}/sub_issues/priority"
+	GetReposLabelsByOwnerByRepo                                 = "GET /repos/{owner}/{repo}/labels"
+	GetReposAssigneesByOwnerByRepo                              = "GET /repos/{owner}/{repo}/assignees"
 
 	// Pull request endpoints
 	GetReposPullsByOwnerByRepo                                = "GET /repos/{owner}/{repo}/pulls"
@@ -299,6 +303,23 @@ func getTextResult(t *testing.T, result *mcp.CallToolResult) *mcp.TextContent {
 	return textContent
 }
 
+// assertStructuredContent validates that a successful tool result carries structuredContent
+// matching the output schema declared by the tool.
+func assertStructuredContent(t *testing.T, tool mcp.Tool, result *mcp.CallToolResult) {
+	t.Helper()
+	require.NotNil(t, result.StructuredContent, "expected tool call result to have structuredContent")
+	schema, ok := tool.OutputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "expected tool to declare an output schema")
+	resolved, err := schema.Resolve(nil)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	var structured any
+	require.NoError(t, json.Unmarshal(data, &structured))
+	assert.NoError(t, resolved.Validate(structured))
+}
+
 func getErrorResult(t *testing.T, result *mcp.CallToolResult) *mcp.TextContent {
 	res := getTextResult(t, result)
 	require.True(t, result.IsError, "expected tool call result to be an error")
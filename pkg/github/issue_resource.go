@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/octicons"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yosida95/uritemplate/v3"
+)
+
+var issueResourceURITemplate = uritemplate.MustNew("issue://{owner}/{repo}/{number}")
+
+// GetIssueResource defines the resource template for reading an issue and its comments as Markdown.
+func GetIssueResource(t translations.TranslationHelperFunc) inventory.ServerResourceTemplate {
+	return inventory.NewServerResourceTemplate(
+		ToolsetMetadataIssues,
+		mcp.ResourceTemplate{
+			Name:        "issue",
+			URITemplate: issueResourceURITemplate.Raw(),
+			Description: t("RESOURCE_ISSUE_DESCRIPTION", "Issue with its comments, rendered as Markdown"),
+			MIMEType:    markdownMIMEType,
+			Icons:       octicons.Icons("issue-opened"),
+		},
+		func(_ any) mcp.ResourceHandler {
+			return IssueResourceHandler(issueResourceURITemplate)
+		},
+	)
+}
+
+// IssueResourceHandler returns a handler that renders an issue and its comments as Markdown.
+// It retrieves ToolDependencies from the context at call time via MustDepsFromContext.
+func IssueResourceHandler(resourceURITemplate *uritemplate.Template) mcp.ResourceHandler {
+	return func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		deps := MustDepsFromContext(ctx)
+		owner, repo, issueNumber, err := resourceRepoAndNumber(resourceURITemplate, request.Params.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := deps.GetClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		issue, _, err := client.Issues.Get(ctx, owner, repo, issueNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue: %w", err)
+		}
+		// The issues API also resolves pull request numbers
+		if issue.IsPullRequest() {
+			return nil, fmt.Errorf("#%d in %s/%s is a pull request, read it with pr://%s/%s/%d", issueNumber, owner, repo, owner, repo, issueNumber)
+		}
+
+		isSafe, err := resourceContentIsSafe(ctx, deps, issue.GetUser().GetLogin(), owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		if !isSafe {
+			return nil, fmt.Errorf("access to issue details is restricted by lockdown mode")
+		}
+
+		comments, err := listResourceIssueComments(ctx, client, owner, repo, issueNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue comments: %w", err)
+		}
+
+		safeComments := make([]*github.IssueComment, 0, len(comments))
+		for _, comment := range comments {
+			isSafe, err := resourceContentIsSafe(ctx, deps, comment.GetUser().GetLogin(), owner, repo)
+			if err != nil {
+				return nil, err
+			}
+			if isSafe {
+				safeComments = append(safeComments, comment)
+			}
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{
+					URI:      request.Params.URI,
+					MIMEType: markdownMIMEType,
+					Text:     renderIssueMarkdown(issue, safeComments, issue.GetComments()-len(comments)),
+				},
+			},
+		}, nil
+	}
+}
+
+// renderIssueMarkdown renders an issue and its comments as Markdown with metadata front-matter.
+// omittedComments is the number of comments that were not fetched.
+func renderIssueMarkdown(issue *github.Issue, comments []*github.IssueComment, omittedComments int) string {
+	labels := make([]string, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		labels = append(labels, label.GetName())
+	}
+	assignees := make([]string, 0, len(issue.Assignees))
+	for _, assignee := range issue.Assignees {
+		assignees = append(assignees, assignee.GetLogin())
+	}
+
+	title := sanitize.Sanitize(issue.GetTitle())
+
+	var sb strings.Builder
+	writeFrontMatter(&sb, []frontMatterField{
+		{"number", issue.GetNumber()},
+		{"title", title},
+		{"state", issue.GetState()},
+		{"state_reason", issue.GetStateReason()},
+		{"author", issue.GetUser().GetLogin()},
+		{"labels", labels},
+		{"assignees", assignees},
+		{"milestone", issue.GetMilestone().GetTitle()},
+		{"comments", issue.GetComments()},
+		{"created_at", formatResourceTime(issue.GetCreatedAt().Time)},
+		{"updated_at", formatResourceTime(issue.GetUpdatedAt().Time)},
+		{"url", issue.GetHTMLURL()},
+	})
+
+	fmt.Fprintf(&sb, "\n# %s\n\n", title)
+	if body := sanitize.Sanitize(issue.GetBody()); body != "" {
+		sb.WriteString(body)
+		sb.WriteString("\n")
+	}
+	writeCommentsMarkdown(&sb, len(comments), omittedComments, func(i int) (string, string, string) {
+		c := comments[i]
+		return c.GetUser().GetLogin(), formatResourceTime(c.GetCreatedAt().Time), c.GetBody()
+	})
+
+	return sb.String()
+}
@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IssueResource(t *testing.T) {
+	resource := GetIssueResource(translations.NullTranslationHelper)
+	assert.Equal(t, "issue", resource.Template.Name)
+	assert.Equal(t, "issue://{owner}/{repo}/{number}", resource.Template.URITemplate)
+	assert.Equal(t, "issues", string(resource.Toolset.ID))
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockIssue := &github.Issue{
+		Number:    github.Ptr(42),
+		Title:     github.Ptr("Crash on <b>start</b>"),
+		Body:      github.Ptr("Steps to reproduce: run it."),
+		State:     github.Ptr("open"),
+		User:      &github.User{Login: github.Ptr("maintainer")},
+		Labels:    []*github.Label{{Name: github.Ptr("bug")}},
+		CreatedAt: &github.Timestamp{Time: createdAt},
+		HTMLURL:   github.Ptr("https://github.com/owner/repo/issues/42"),
+	}
+	mockComments := []*github.IssueComment{
+		{Body: github.Ptr("Confirmed."), User: &github.User{Login: github.Ptr("maintainer")}},
+		{Body: github.Ptr("Untrusted comment"), User: &github.User{Login: github.Ptr("testuser")}},
+	}
+
+	tests := []struct {
+		name            string
+		uri             string
+		lockdownEnabled bool
+		issue           *github.Issue
+		expectError     string
+		expectContains  []string
+		expectMissing   []string
+	}{
+		{
+			name:  "renders issue with front-matter and comments",
+			uri:   "issue://owner/repo/42",
+			issue: mockIssue,
+			expectContains: []string{
+				"---\nnumber: 42\ntitle: \"Crash on <b>start</b>\"\nstate: \"open\"\nauthor: \"maintainer\"\nlabels: [\"bug\"]\n",
+				"created_at: \"2024-01-02T03:04:05Z\"\n",
+				"url: \"https://github.com/owner/repo/issues/42\"\n---\n",
+				"Steps to reproduce: run it.",
+				"### @maintainer commented on",
+				"Confirmed.",
+				"Untrusted comment",
+			},
+		},
+		{
+			name:            "lockdown filters untrusted comments",
+			uri:             "issue://owner/repo/42",
+			lockdownEnabled: true,
+			issue:           mockIssue,
+			expectContains:  []string{"Confirmed."},
+			expectMissing:   []string{"Untrusted comment"},
+		},
+		{
+			name:            "lockdown rejects untrusted issue author",
+			uri:             "issue://owner/repo/42",
+			lockdownEnabled: true,
+			issue: &github.Issue{
+				Number: github.Ptr(42),
+				Title:  github.Ptr("Injected"),
+				User:   &github.User{Login: github.Ptr("testuser")},
+			},
+			expectError: "access to issue details is restricted by lockdown mode",
+		},
+		{
+			name: "notes comments that were not fetched",
+			uri:  "issue://owner/repo/42",
+			issue: &github.Issue{
+				Number:   github.Ptr(42),
+				Title:    github.Ptr("Long thread"),
+				User:     &github.User{Login: github.Ptr("maintainer")},
+				Comments: github.Ptr(1005),
+			},
+			expectContains: []string{"Confirmed.", "\n_1003 more comments omitted._\n"},
+		},
+		{
+			name: "rejects pull requests",
+			uri:  "issue://owner/repo/42",
+			issue: &github.Issue{
+				Number:           github.Ptr(42),
+				User:             &github.User{Login: github.Ptr("maintainer")},
+				PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/42")},
+			},
+			expectError: "#42 in owner/repo is a pull request, read it with pr://owner/repo/42",
+		},
+		{
+			name:        "invalid number",
+			uri:         "issue://owner/repo/abc",
+			issue:       mockIssue,
+			expectError: "invalid number in URI",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposIssuesByOwnerByRepoByIssueNumber:         mockResponse(t, http.StatusOK, tc.issue),
+				GetReposIssuesCommentsByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusOK, mockComments),
+			}))
+			deps := BaseDeps{
+				Client:          client,
+				RepoAccessCache: repoAccessCache,
+				Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": tc.lockdownEnabled}),
+			}
+			handler := resource.Handler(deps)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &mcp.ReadResourceRequest{
+				Params: &mcp.ReadResourceParams{URI: tc.uri},
+			})
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Contents, 1)
+			assert.Equal(t, "text/markdown", result.Contents[0].MIMEType)
+			assert.Equal(t, tc.uri, result.Contents[0].URI)
+			for _, s := range tc.expectContains {
+				assert.Contains(t, result.Contents[0].Text, s)
+			}
+			for _, s := range tc.expectMissing {
+				assert.NotContains(t, result.Contents[0].Text, s)
+			}
+		})
+	}
+}
+
+func Test_codeFence(t *testing.T) {
+	assert.Equal(t, "```", codeFence("no backticks"))
+	assert.Equal(t, "````", codeFence("has ``` inside"))
+	assert.Equal(t, "`````", codeFence("a ```` b ` c"))
+}
+
+func Test_markdownCodeSpan(t *testing.T) {
+	assert.Equal(t, "`main.go`", markdownCodeSpan("main.go"))
+	assert.Equal(t, "`a\\|b.go`", markdownCodeSpan("a|b.go"))
+	assert.Equal(t, "``a`b.go``", markdownCodeSpan("a`b.go"))
+	assert.Equal(t, "`` `quoted` ``", markdownCodeSpan("`quoted`"))
+}
+
+func Test_listResourceIssueComments(t *testing.T) {
+	var pages []string
+	client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposIssuesCommentsByOwnerByRepoByIssueNumber: func(w http.ResponseWriter, r *http.Request) {
+			page := r.URL.Query().Get("page")
+			pages = append(pages, page)
+			if page == "" {
+				w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/issues/42/comments?page=2>; rel="next"`)
+				mockResponse(t, http.StatusOK, []*github.IssueComment{{Body: github.Ptr("first")}})(w, r)
+				return
+			}
+			mockResponse(t, http.StatusOK, []*github.IssueComment{{Body: github.Ptr("second")}})(w, r)
+		},
+	}))
+
+	comments, err := listResourceIssueComments(context.Background(), client, "owner", "repo", 42)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "second", comments[1].GetBody())
+	assert.Equal(t, []string{"", "2"}, pages)
+}
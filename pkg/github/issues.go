@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -262,7 +261,7 @@ Options are:
 		},
 		Required: []string{"method", "owner", "repo", "issue_number"},
 	}
-	WithPagination(schema)
+	withMaxItemsMethods(WithPagination(schema), "issue_read")
 
 	return NewTool(
 		ToolsetMetadataIssues,
@@ -274,6 +273,12 @@ Options are:
 				ReadOnlyHint: true,
 			},
 			InputSchema: schema,
+			OutputSchema: AnyOfOutputSchema(
+				APIObjectOutputSchema[github.Issue]("Issue as returned by the GitHub REST API (method get)."),
+				APIListOutputSchema[github.IssueComment]("Issue comments as returned by the GitHub REST API (method get_comments)."),
+				APIListOutputSchema[github.SubIssue]("Sub-issues as returned by the GitHub REST API (method get_sub_issues)."),
+				labelsOutputSchema("Labels of the issue, with labels and totalCount (method get_labels)."),
+			),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -344,20 +349,16 @@ func GetIssue(ctx context.Context, client *github.Client, cache *lockdown.RepoAc
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get issue", resp, body), nil
 	}
 
-	if flags.LockdownMode {
-		if cache == nil {
-			return nil, fmt.Errorf("lockdown cache is not configured")
-		}
-		login := issue.GetUser().GetLogin()
-		if login != "" {
-			isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
-			if err != nil {
-				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
-			}
-			if !isSafeContent {
-				return utils.NewToolResultError("access to issue details is restricted by lockdown mode"), nil
-			}
-		}
+	policy, err := newLockdownPolicy(flags, cache)
+	if err != nil {
+		return nil, err
+	}
+	isSafeContent, err := policy.isSafe(ctx, authoredContent{login: issue.GetUser().GetLogin(), owner: owner, repo: repo})
+	if err != nil {
+		return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
+	}
+	if !isSafeContent {
+		return utils.NewToolResultError("access to issue details is restricted by lockdown mode"), nil
 	}
 
 	// Sanitize title/body on response
@@ -370,12 +371,7 @@ func GetIssue(ctx context.Context, client *github.Client, cache *lockdown.RepoAc
 		}
 	}
 
-	r, err := json.Marshal(issue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal issue: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, issue), nil
 }
 
 func GetIssueComments(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner string, repo string, issueNumber int, pagination PaginationParams, flags FeatureFlags) (*mcp.CallToolResult, error) {
@@ -399,37 +395,18 @@ func GetIssueComments(ctx context.Context, client *github.Client, cache *lockdow
 		}
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get issue comments", resp, body), nil
 	}
-	if flags.LockdownMode {
-		if cache == nil {
-			return nil, fmt.Errorf("lockdown cache is not configured")
-		}
-		filteredComments := make([]*github.IssueComment, 0, len(comments))
-		for _, comment := range comments {
-			user := comment.User
-			if user == nil {
-				continue
-			}
-			login := user.GetLogin()
-			if login == "" {
-				continue
-			}
-			isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
-			if err != nil {
-				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
-			}
-			if isSafeContent {
-				filteredComments = append(filteredComments, comment)
-			}
-		}
-		comments = filteredComments
+	policy, err := newLockdownPolicy(flags, cache)
+	if err != nil {
+		return nil, err
 	}
-
-	r, err := json.Marshal(comments)
+	comments, err = filterLockdown(ctx, policy, comments, func(comment *github.IssueComment) authoredContent {
+		return authoredContent{login: comment.GetUser().GetLogin(), owner: owner, repo: repo}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, comments), nil
 }
 
 func GetSubIssues(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner string, repo string, issueNumber int, pagination PaginationParams, featureFlags FeatureFlags) (*mcp.CallToolResult, error) {
@@ -459,37 +436,18 @@ func GetSubIssues(ctx context.Context, client *github.Client, cache *lockdown.Re
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list sub-issues", resp, body), nil
 	}
 
-	if featureFlags.LockdownMode {
-		if cache == nil {
-			return nil, fmt.Errorf("lockdown cache is not configured")
-		}
-		filteredSubIssues := make([]*github.SubIssue, 0, len(subIssues))
-		for _, subIssue := range subIssues {
-			user := subIssue.User
-			if user == nil {
-				continue
-			}
-			login := user.GetLogin()
-			if login == "" {
-				continue
-			}
-			isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
-			if err != nil {
-				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
-			}
-			if isSafeContent {
-				filteredSubIssues = append(filteredSubIssues, subIssue)
-			}
-		}
-		subIssues = filteredSubIssues
+	policy, err := newLockdownPolicy(featureFlags, cache)
+	if err != nil {
+		return nil, err
 	}
-
-	r, err := json.Marshal(subIssues)
+	subIssues, err = filterLockdown(ctx, policy, subIssues, func(subIssue *github.SubIssue) authoredContent {
+		return authoredContent{login: subIssue.User.GetLogin(), owner: owner, repo: repo}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, subIssues), nil
 }
 
 func GetIssueLabels(ctx context.Context, client *githubv4.Client, owner string, repo string, issueNumber int) (*mcp.CallToolResult, error) {
@@ -536,12 +494,7 @@ func GetIssueLabels(ctx context.Context, client *githubv4.Client, owner string,
 		"totalCount": int(query.Repository.Issue.Labels.TotalCount),
 	}
 
-	out, err := json.Marshal(response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(out)), nil
+	return MarshalledTextResult(ctx, response), nil
 
 }
 
@@ -566,6 +519,7 @@ func ListIssueTypes(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner"},
 			},
+			OutputSchema: APIListOutputSchema[github.IssueType]("Issue types of the organization as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.ReadOrg},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -592,12 +546,7 @@ func ListIssueTypes(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list issue types", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(issueTypes)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal issue types", err), nil, nil
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, issueTypes), nil, nil
 		})
 }
 
@@ -634,6 +583,7 @@ func AddIssueComment(t translations.TranslationHelperFunc) inventory.ServerTool
 				},
 				Required: []string{"owner", "repo", "issue_number", "body"},
 			},
+			OutputSchema: APIObjectOutputSchema[github.IssueComment]("The created issue comment as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -676,12 +626,7 @@ func AddIssueComment(t translations.TranslationHelperFunc) inventory.ServerTool
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to create comment", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(createdComment)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, createdComment), nil, nil
 		})
 }
 
@@ -739,6 +684,7 @@ Options are:
 				},
 				Required: []string{"method", "owner", "repo", "issue_number", "sub_issue_id"},
 			},
+			OutputSchema: APIObjectOutputSchema[github.SubIssue]("The affected sub-issue as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -824,12 +770,7 @@ func AddSubIssue(ctx context.Context, client *github.Client, owner string, repo
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to add sub-issue", resp, body), nil
 	}
 
-	r, err := json.Marshal(subIssue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, subIssue), nil
 
 }
 
@@ -856,12 +797,7 @@ func RemoveSubIssue(ctx context.Context, client *github.Client, owner string, re
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to remove sub-issue", resp, body), nil
 	}
 
-	r, err := json.Marshal(subIssue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, subIssue), nil
 }
 
 func ReprioritizeSubIssue(ctx context.Context, client *github.Client, owner string, repo string, issueNumber int, subIssueID int, afterID int, beforeID int) (*mcp.CallToolResult, error) {
@@ -905,12 +841,7 @@ func ReprioritizeSubIssue(ctx context.Context, client *github.Client, owner stri
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to reprioritize sub-issue", resp, body), nil
 	}
 
-	r, err := json.Marshal(subIssue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, subIssue), nil
 }
 
 // SearchIssues creates a tool to search for issues.
@@ -966,11 +897,12 @@ func SearchIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_SEARCH_ISSUES_USER_TITLE", "Search issues"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  WithFields(schema),
+			OutputSchema: APIObjectOutputSchema[github.IssuesSearchResult]("Search results as returned by the GitHub REST API, with total_count, incomplete_results and items."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-			result, err := searchHandler(ctx, deps.GetClient, args, "issue", "failed to search issues")
+			result, err := searchHandler(ctx, deps, args, "issue", "failed to search issues")
 			return result, nil, err
 		})
 }
@@ -1057,6 +989,7 @@ Options are:
 				},
 				Required: []string{"method", "owner", "repo"},
 			},
+			OutputSchema: OutputSchema[MinimalResponse](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1204,12 +1137,7 @@ func CreateIssue(ctx context.Context, client *github.Client, owner string, repo
 		URL: issue.GetHTMLURL(),
 	}
 
-	r, err := json.Marshal(minimalResponse)
-	if err != nil {
-		return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil
-	}
-
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, minimalResponse), nil
 }
 
 func UpdateIssue(ctx context.Context, client *github.Client, gqlClient *githubv4.Client, owner string, repo string, issueNumber int, title string, body string, assignees []string, labels []string, milestoneNum int, issueType string, state string, stateReason string, duplicateOf int) (*mcp.CallToolResult, error) {
@@ -1329,12 +1257,21 @@ func UpdateIssue(ctx context.Context, client *github.Client, gqlClient *githubv4
 		URL: updatedIssue.GetHTMLURL(),
 	}
 
-	r, err := json.Marshal(minimalResponse)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
+	return MarshalledTextResult(ctx, minimalResponse), nil
+}
 
-	return utils.NewToolResultText(string(r)), nil
+// listIssuesOutputSchema describes the response of list_issues: the issues converted from GraphQL
+// to their REST API shape, together with the GraphQL pagination details.
+func listIssuesOutputSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "object",
+		Description: "Issues of the repository, with issues, pageInfo and totalCount.",
+		Properties: map[string]*jsonschema.Schema{
+			"issues":     APIListOutputSchema[github.Issue]("").Properties[structuredItemsKey],
+			"pageInfo":   graphQLPageInfoOutputSchema(),
+			"totalCount": {Type: "integer"},
+		},
+	}
 }
 
 // ListIssues creates a tool to list and filter repository issues
@@ -1390,7 +1327,8 @@ func ListIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_LIST_ISSUES_USER_TITLE", "List issues"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  WithFields(schema),
+			OutputSchema: listIssuesOutputSchema(),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1560,6 +1498,17 @@ func ListIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
 				totalCount = fragment.TotalCount
 			}
 
+			policy, err := lockdownPolicyFor(deps)
+			if err != nil {
+				return nil, nil, err
+			}
+			issues, err = filterLockdown(ctx, policy, issues, func(issue *github.Issue) authoredContent {
+				return authoredContent{login: issue.GetUser().GetLogin(), owner: owner, repo: repo}
+			})
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+
 			// Create response with issues
 			response := map[string]interface{}{
 				"issues": issues,
@@ -1571,11 +1520,7 @@ func ListIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				"totalCount": totalCount,
 			}
-			out, err := json.Marshal(response)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal issues: %w", err)
-			}
-			return utils.NewToolResultText(string(out)), nil, nil
+			return MarshalledTextResult(ctx, response), nil, nil
 		})
 }
 
@@ -1755,6 +1700,23 @@ func AssignCopilotToIssue(t translations.TranslationHelperFunc) inventory.Server
 				},
 				Required: []string{"owner", "repo", "issue_number"},
 			},
+			OutputSchema: ObjectOutputSchema("Assignment result, with either the pull_request Copilot opened or a note while the pull request is pending.", map[string]*jsonschema.Schema{
+				"message":      {Type: "string"},
+				"issue_number": {Type: "integer"},
+				"issue_url":    {Type: "string"},
+				"owner":        {Type: "string"},
+				"repo":         {Type: "string"},
+				"pull_request": {
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"number": {Type: "integer"},
+						"url":    {Type: "string"},
+						"title":  {Type: "string"},
+						"state":  {Type: "string"},
+					},
+				},
+				"note": {Type: "string"},
+			}),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, request *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1982,12 +1944,7 @@ func AssignCopilotToIssue(t translations.TranslationHelperFunc) inventory.Server
 				result["note"] = "The pull request may still be in progress. Once created, the PR number can be used to check job status, or check the issue timeline for updates."
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return utils.NewToolResultError(fmt.Sprintf("failed to marshal response: %s", err)), nil, nil
-			}
-
-			return utils.NewToolResultText(string(r)), result, nil
+			return MarshalledTextResult(ctx, result), result, nil
 		})
 }
 
@@ -64,6 +64,12 @@ func (rt *repoAccessMockTransport) RoundTrip(req *http.Request) (*http.Response,
 	}
 	_ = req.Body.Close()
 
+	if strings.HasPrefix(payload.Query, "{viewer{") {
+		return repoAccessResponse(map[string]any{
+			"viewer": map[string]any{"login": "viewer"},
+		})
+	}
+
 	owner := toString(payload.Variables["owner"])
 	repo := toString(payload.Variables["name"])
 	username := toString(payload.Variables["username"])
@@ -83,16 +89,18 @@ func (rt *repoAccessMockTransport) RoundTrip(req *http.Request) (*http.Response,
 		})
 	}
 
-	responseBody, err := json.Marshal(map[string]any{
-		"data": map[string]any{
-			"repository": map[string]any{
-				"isPrivate": value.isPrivate,
-				"collaborators": map[string]any{
-					"edges": edges,
-				},
+	return repoAccessResponse(map[string]any{
+		"repository": map[string]any{
+			"isPrivate": value.isPrivate,
+			"collaborators": map[string]any{
+				"edges": edges,
 			},
 		},
 	})
+}
+
+func repoAccessResponse(data map[string]any) (*http.Response, error) {
+	responseBody, err := json.Marshal(map[string]any{"data": data})
 	if err != nil {
 		return nil, err
 	}
@@ -1585,6 +1593,104 @@ func Test_UpdateIssue(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "Failed to find issues",
 		},
+		{
+			name: "close mutation forbidden",
+			mockedRESTClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PatchReposIssuesByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusOK, mockBaseIssue),
+			}),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					struct {
+						Repository struct {
+							Issue struct {
+								ID githubv4.ID
+							} `graphql:"issue(number: $issueNumber)"`
+						} `graphql:"repository(owner: $owner, name: $repo)"`
+					}{},
+					map[string]any{
+						"owner":       githubv4.String("owner"),
+						"repo":        githubv4.String("repo"),
+						"issueNumber": githubv4.Int(123),
+					},
+					issueIDQueryResponse,
+				),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						CloseIssue struct {
+							Issue struct {
+								ID     githubv4.ID
+								Number githubv4.Int
+								URL    githubv4.String
+								State  githubv4.String
+							}
+						} `graphql:"closeIssue(input: $input)"`
+					}{},
+					CloseIssueInput{
+						IssueID: "I_kwDOA0xdyM50BPaO",
+					},
+					nil,
+					githubv4mock.TypedErrorResponse("FORBIDDEN", "Resource not accessible by integration"),
+				).WithPartialVariables(),
+			),
+			requestArgs: map[string]interface{}{
+				"method":       "update",
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(123),
+				"state":        "closed",
+			},
+			expectError:    true,
+			expectedErrMsg: "Failed to close issue: Resource not accessible by integration",
+		},
+		{
+			name: "reopen mutation fails with a server error",
+			mockedRESTClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PatchReposIssuesByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusOK, mockBaseIssue),
+			}),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					struct {
+						Repository struct {
+							Issue struct {
+								ID githubv4.ID
+							} `graphql:"issue(number: $issueNumber)"`
+						} `graphql:"repository(owner: $owner, name: $repo)"`
+					}{},
+					map[string]any{
+						"owner":       githubv4.String("owner"),
+						"repo":        githubv4.String("repo"),
+						"issueNumber": githubv4.Int(123),
+					},
+					issueIDQueryResponse,
+				),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						ReopenIssue struct {
+							Issue struct {
+								ID     githubv4.ID
+								Number githubv4.Int
+								URL    githubv4.String
+								State  githubv4.String
+							}
+						} `graphql:"reopenIssue(input: $input)"`
+					}{},
+					githubv4.ReopenIssueInput{
+						IssueID: "I_kwDOA0xdyM50BPaO",
+					},
+					nil,
+					githubv4mock.HTTPErrorResponse(http.StatusBadGateway, "upstream unavailable"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":       "update",
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(123),
+				"state":        "open",
+			},
+			expectError:    true,
+			expectedErrMsg: "Failed to reopen issue: non-200 OK status code: 502 Bad Gateway",
+		},
 		{
 			name: "close as duplicate with combined non-state updates",
 			mockedRESTClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
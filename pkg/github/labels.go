@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -16,6 +15,27 @@ import (
 	"github.com/shurcooL/githubv4"
 )
 
+// labelOutputSchema describes a label as returned by the GraphQL label tools.
+func labelOutputSchema(description string) *jsonschema.Schema {
+	return ObjectOutputSchema(description, map[string]*jsonschema.Schema{
+		"id":          {Type: "string"},
+		"name":        {Type: "string"},
+		"color":       {Type: "string"},
+		"description": {Type: "string"},
+	})
+}
+
+// labelsOutputSchema describes a list of labels with their totalCount.
+func labelsOutputSchema(description string) *jsonschema.Schema {
+	return ObjectOutputSchema(description, map[string]*jsonschema.Schema{
+		"labels": {
+			Types: []string{"null", "array"},
+			Items: labelOutputSchema(""),
+		},
+		"totalCount": {Type: "integer"},
+	})
+}
+
 // GetLabel retrieves a specific label by name from a GitHub repository
 func GetLabel(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
@@ -45,6 +65,7 @@ func GetLabel(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner", "repo", "name"},
 			},
+			OutputSchema: labelOutputSchema("Label of the repository."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -100,12 +121,7 @@ func GetLabel(t translations.TranslationHelperFunc) inventory.ServerTool {
 				"description": string(query.Repository.Label.Description),
 			}
 
-			out, err := json.Marshal(label)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal label: %w", err)
-			}
-
-			return utils.NewToolResultText(string(out)), nil, nil
+			return MarshalledTextResult(ctx, label), nil, nil
 		},
 	)
 }
@@ -143,6 +159,7 @@ func ListLabels(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner", "repo"},
 			},
+			OutputSchema: labelsOutputSchema("Labels of the repository, with labels and totalCount."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -199,12 +216,7 @@ func ListLabels(t translations.TranslationHelperFunc) inventory.ServerTool {
 				"totalCount": int(query.Repository.Labels.TotalCount),
 			}
 
-			out, err := json.Marshal(response)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal labels: %w", err)
-			}
-
-			return utils.NewToolResultText(string(out)), nil, nil
+			return MarshalledTextResult(ctx, response), nil, nil
 		},
 	)
 }
@@ -255,6 +267,7 @@ func LabelWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"method", "owner", "repo", "name"},
 			},
+			OutputSchema: OutputSchema[MessageResponse](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -326,7 +339,7 @@ func LabelWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to create label", err), nil, nil
 				}
 
-				return utils.NewToolResultText(fmt.Sprintf("label '%s' created successfully", mutation.CreateLabel.Label.Name)), nil, nil
+				return MessageResult(fmt.Sprintf("label '%s' created successfully", mutation.CreateLabel.Label.Name)), nil, nil
 
 			case "update":
 				// Validate required params for update
@@ -369,7 +382,7 @@ func LabelWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to update label", err), nil, nil
 				}
 
-				return utils.NewToolResultText(fmt.Sprintf("label '%s' updated successfully", mutation.UpdateLabel.Label.Name)), nil, nil
+				return MessageResult(fmt.Sprintf("label '%s' updated successfully", mutation.UpdateLabel.Label.Name)), nil, nil
 
 			case "delete":
 				// Get the label ID
@@ -392,7 +405,7 @@ func LabelWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to delete label", err), nil, nil
 				}
 
-				return utils.NewToolResultText(fmt.Sprintf("label '%s' deleted successfully", name)), nil, nil
+				return MessageResult(fmt.Sprintf("label '%s' deleted successfully", name)), nil, nil
 
 			default:
 				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: create, update, delete", method)), nil, nil
@@ -0,0 +1,108 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/google/go-github/v79/github"
+)
+
+// authoredContent identifies the author of user-authored content and the repository it belongs
+// to. Content outside a repository, such as a gist, has an empty owner and repo.
+type authoredContent struct {
+	login string
+	owner string
+	repo  string
+}
+
+// lockdownPolicy applies the lockdown mode author-trust policy to user-authored content. The zero
+// value, which is returned when lockdown mode is disabled, trusts all content.
+type lockdownPolicy struct {
+	cache *lockdown.RepoAccessCache
+}
+
+// newLockdownPolicy returns the policy for flags. It fails when lockdown mode is enabled without a
+// repo access cache.
+func newLockdownPolicy(flags FeatureFlags, cache *lockdown.RepoAccessCache) (lockdownPolicy, error) {
+	if !flags.LockdownMode {
+		return lockdownPolicy{}, nil
+	}
+	if cache == nil {
+		return lockdownPolicy{}, errors.New("lockdown cache is not configured")
+	}
+	return lockdownPolicy{cache: cache}, nil
+}
+
+// lockdownPolicyFor returns the lockdown policy of deps.
+func lockdownPolicyFor(deps ToolDependencies) (lockdownPolicy, error) {
+	return newLockdownPolicy(deps.GetFlags(), deps.GetRepoAccessCache())
+}
+
+// enabled reports whether the policy filters content.
+func (p lockdownPolicy) enabled() bool {
+	return p.cache != nil
+}
+
+// isSafe reports whether content may be returned. When lockdown mode is enabled, content in a
+// repository is safe under the rules of lockdown.RepoAccessCache.IsSafeContent and content outside a
+// repository under those of IsSafeUserContent. Content whose author is not linked to a GitHub
+// account, such as a commit by an unknown email address, has no login to check and is safe.
+func (p lockdownPolicy) isSafe(ctx context.Context, content authoredContent) (bool, error) {
+	if !p.enabled() || content.login == "" {
+		return true, nil
+	}
+	if content.owner == "" || content.repo == "" {
+		return p.cache.IsSafeUserContent(ctx, content.login)
+	}
+	return p.cache.IsSafeContent(ctx, content.login, content.owner, content.repo)
+}
+
+// filterLockdown returns the items whose content is safe under p, in their original order. It
+// returns items unchanged when lockdown mode is disabled.
+func filterLockdown[T any](ctx context.Context, p lockdownPolicy, items []T, content func(T) authoredContent) ([]T, error) {
+	if !p.enabled() {
+		return items, nil
+	}
+	filtered := make([]T, 0, len(items))
+	for _, item := range items {
+		isSafe, err := p.isSafe(ctx, content(item))
+		if err != nil {
+			return nil, err
+		}
+		if isSafe {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
+
+// commitContent returns the authored content of a commit in owner/repo. The committer stands in for
+// an author who is not linked to a GitHub account.
+func commitContent(commit *github.RepositoryCommit, owner, repo string) authoredContent {
+	login := commit.GetAuthor().GetLogin()
+	if login == "" {
+		login = commit.GetCommitter().GetLogin()
+	}
+	return authoredContent{login: login, owner: owner, repo: repo}
+}
+
+// repositoryFromAPIURL returns the owner and name of the repository of a REST API URL, such as the
+// repository_url of a search result or the subject URL of a notification.
+func repositoryFromAPIURL(apiURL string) (owner, repo string) {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return "", ""
+	}
+	parts := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	// GitHub Enterprise Server serves the API under /api/v3
+	if len(parts) > 2 && parts[0] == "api" && parts[1] == "v3" {
+		parts = parts[2:]
+	}
+	if len(parts) < 3 || parts[0] != "repos" {
+		return "", ""
+	}
+	return parts[1], parts[2]
+}
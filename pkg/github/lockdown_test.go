@@ -0,0 +1,496 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// In the repo access mock, testuser has read access to owner/repo, so their content is untrusted,
+// while maintainer gets the default write access.
+const (
+	trustedLogin   = "maintainer"
+	untrustedLogin = "testuser"
+)
+
+func Test_LockdownPolicy(t *testing.T) {
+	ctx := context.Background()
+	items := []authoredContent{
+		{login: trustedLogin, owner: "owner", repo: "repo"},
+		{login: untrustedLogin, owner: "owner", repo: "repo"},
+		{login: "", owner: "owner", repo: "repo"},
+		{login: "Copilot"},
+		{login: untrustedLogin},
+	}
+	identity := func(c authoredContent) authoredContent { return c }
+
+	t.Run("disabled policy keeps all content", func(t *testing.T) {
+		policy, err := newLockdownPolicy(stubFeatureFlags(nil), nil)
+		require.NoError(t, err)
+		assert.False(t, policy.enabled())
+
+		filtered, err := filterLockdown(ctx, policy, items, identity)
+		require.NoError(t, err)
+		assert.Equal(t, items, filtered)
+	})
+
+	t.Run("enabled policy requires a cache", func(t *testing.T) {
+		_, err := newLockdownPolicy(stubFeatureFlags(map[string]bool{"lockdown-mode": true}), nil)
+		require.EqualError(t, err, "lockdown cache is not configured")
+	})
+
+	t.Run("enabled policy keeps trusted content", func(t *testing.T) {
+		policy, err := lockdownPolicyFor(BaseDeps{
+			RepoAccessCache: repoAccessCache,
+			Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": true}),
+		})
+		require.NoError(t, err)
+		assert.True(t, policy.enabled())
+
+		filtered, err := filterLockdown(ctx, policy, items, identity)
+		require.NoError(t, err)
+		assert.Equal(t, []authoredContent{
+			{login: trustedLogin, owner: "owner", repo: "repo"},
+			{login: "", owner: "owner", repo: "repo"},
+			{login: "Copilot"},
+		}, filtered)
+	})
+}
+
+func Test_RepositoryFromAPIURL(t *testing.T) {
+	tests := []struct {
+		url   string
+		owner string
+		repo  string
+	}{
+		{url: "https://api.github.com/repos/octo-org/octo-repo", owner: "octo-org", repo: "octo-repo"},
+		{url: "https://api.github.com/repos/octo-org/octo-repo/issues/42", owner: "octo-org", repo: "octo-repo"},
+		{url: "https://ghes.example.com/api/v3/repos/octo-org/octo-repo/releases/1", owner: "octo-org", repo: "octo-repo"},
+		{url: "https://api.github.com/users/octocat"},
+		{url: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			owner, repo := repositoryFromAPIURL(tc.url)
+			assert.Equal(t, tc.owner, owner)
+			assert.Equal(t, tc.repo, repo)
+		})
+	}
+}
+
+// Test_LockdownCoverage checks that every tool returning user-authored content applies the lockdown
+// policy: trusted content is returned, and untrusted content is filtered out of lists or restricts
+// access to single items.
+func Test_LockdownCoverage(t *testing.T) {
+	discussionsQuery := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,createdAt,updatedAt,closed,isAnswered,answerChosenAt,author{login},category{name},url},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	discussionQuery := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,closed,isAnswered,answerChosenAt,url,category{name},author{login}}}}"
+	commentsQuery := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{body,author{login}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
+	discussionVars := map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)}
+	issuesQuery := "query($after:String$direction:OrderDirection!$first:Int!$orderBy:IssueOrderField!$owner:String!$repo:String!$states:[IssueState!]!){repository(owner: $owner, name: $repo){issues(first: $first, after: $after, states: $states, orderBy: {field: $orderBy, direction: $direction}){nodes{number,title,body,state,databaseId,author{login},createdAt,updatedAt,labels(first: 100){nodes{name,id,description}},comments{totalCount}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	listVars := map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil)}
+	discussion := func(login string) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{
+				"number": 1,
+				"title":  "Discussion by " + login,
+				"body":   "Body by " + login,
+				"author": map[string]any{"login": login},
+			}},
+		})
+	}
+
+	user := func(login string) *github.User { return &github.User{Login: github.Ptr(login)} }
+	commit := func(login string) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:    github.Ptr("sha-" + login),
+			Author: user(login),
+			Commit: &github.Commit{Message: github.Ptr("Commit by " + login)},
+		}
+	}
+	// A commit whose author is not linked to a GitHub account is checked against its committer
+	unlinkedCommit := func(committer string) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:       github.Ptr("sha-unlinked-" + committer),
+			Committer: user(committer),
+			Commit:    &github.Commit{Message: github.Ptr("Commit committed by " + committer)},
+		}
+	}
+	release := func(login string) *github.RepositoryRelease {
+		return &github.RepositoryRelease{TagName: github.Ptr("v1"), Body: github.Ptr("Release by " + login), Author: user(login)}
+	}
+	searchResult := func(logins ...string) *github.IssuesSearchResult {
+		result := &github.IssuesSearchResult{Total: github.Ptr(len(logins))}
+		for _, login := range logins {
+			result.Issues = append(result.Issues, &github.Issue{
+				Title:         github.Ptr("Issue by " + login),
+				User:          user(login),
+				RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+			})
+		}
+		return result
+	}
+	notification := func(id, login string, issueNumber string) *github.Notification {
+		return &github.Notification{
+			ID:         github.Ptr(id),
+			Repository: &github.Repository{Name: github.Ptr("repo"), Owner: user("owner"), Private: github.Ptr(false)},
+			Subject: &github.NotificationSubject{
+				Title: github.Ptr("Issue by " + login),
+				URL:   github.Ptr("https://api.github.com/repos/owner/repo/issues/" + issueNumber),
+				Type:  github.Ptr("Issue"),
+			},
+		}
+	}
+	// Subjects without an author only carry a title
+	unauthoredNotification := func(id, subjectType string) *github.Notification {
+		return &github.Notification{
+			ID:         github.Ptr(id),
+			Repository: &github.Repository{Name: github.Ptr("repo"), Owner: user("owner"), Private: github.Ptr(false)},
+			Subject:    &github.NotificationSubject{Title: github.Ptr(subjectType + " notification"), Type: github.Ptr(subjectType)},
+		}
+	}
+	// owner2/repo2 is private, so its subjects are returned without being fetched
+	privateNotification := &github.Notification{
+		ID:         github.Ptr("3"),
+		Repository: &github.Repository{Name: github.Ptr("repo2"), Owner: user("owner2"), Private: github.Ptr(true)},
+		Subject: &github.NotificationSubject{
+			Title: github.Ptr("Private issue by " + untrustedLogin),
+			URL:   github.Ptr("https://api.github.com/repos/owner2/repo2/issues/3"),
+			Type:  github.Ptr("Issue"),
+		},
+	}
+	// Issue 1 is opened by the trusted user and issue 2 by the untrusted one. Issue 9 was deleted.
+	notificationSubjects := func(w http.ResponseWriter, r *http.Request) {
+		login := trustedLogin
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/2"):
+			login = untrustedLogin
+		case strings.HasSuffix(r.URL.Path, "/9"):
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+			return
+		}
+		mockResponse(t, http.StatusOK, &github.Issue{User: user(login)})(w, r)
+	}
+
+	tests := []struct {
+		name         string
+		tool         inventory.ServerTool
+		handlers     map[string]http.HandlerFunc
+		gqlMatchers  []githubv4mock.Matcher
+		args         map[string]any
+		expected     string
+		notExpected  string
+		restrictedBy string
+	}{
+		{
+			name: "list_discussions",
+			tool: ListDiscussions(translations.NullTranslationHelper),
+			gqlMatchers: []githubv4mock.Matcher{githubv4mock.NewQueryMatcher(discussionsQuery, listVars, githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussions": map[string]any{
+					"nodes": []any{
+						map[string]any{"number": 1, "title": "Discussion by " + trustedLogin, "author": map[string]any{"login": trustedLogin}},
+						map[string]any{"number": 2, "title": "Discussion by " + untrustedLogin, "author": map[string]any{"login": untrustedLogin}},
+					},
+				}},
+			}))},
+			args:        map[string]any{"owner": "owner", "repo": "repo"},
+			expected:    "Discussion by " + trustedLogin,
+			notExpected: "Discussion by " + untrustedLogin,
+		},
+		{
+			name:        "get_discussion by a maintainer",
+			tool:        GetDiscussion(translations.NullTranslationHelper),
+			gqlMatchers: []githubv4mock.Matcher{githubv4mock.NewQueryMatcher(discussionQuery, discussionVars, discussion(trustedLogin))},
+			args:        map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)},
+			expected:    "Body by " + trustedLogin,
+		},
+		{
+			name:         "get_discussion by an external user",
+			tool:         GetDiscussion(translations.NullTranslationHelper),
+			gqlMatchers:  []githubv4mock.Matcher{githubv4mock.NewQueryMatcher(discussionQuery, discussionVars, discussion(untrustedLogin))},
+			args:         map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)},
+			restrictedBy: "access to discussion details is restricted by lockdown mode",
+		},
+		{
+			name: "get_discussion_comments",
+			tool: GetDiscussionComments(translations.NullTranslationHelper),
+			gqlMatchers: []githubv4mock.Matcher{githubv4mock.NewQueryMatcher(commentsQuery, map[string]any{
+				"owner": "owner", "repo": "repo", "discussionNumber": float64(1), "first": float64(30), "after": (*string)(nil),
+			}, githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussion": map[string]any{"comments": map[string]any{
+					"nodes": []any{
+						map[string]any{"body": "Comment by " + trustedLogin, "author": map[string]any{"login": trustedLogin}},
+						map[string]any{"body": "Comment by " + untrustedLogin, "author": map[string]any{"login": untrustedLogin}},
+					},
+				}}},
+			}))},
+			args:        map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)},
+			expected:    "Comment by " + trustedLogin,
+			notExpected: "Comment by " + untrustedLogin,
+		},
+		{
+			name: "list_gists",
+			tool: ListGists(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetGists: mockResponse(t, http.StatusOK, []*github.Gist{
+					{ID: github.Ptr("1"), Description: github.Ptr("Gist by Copilot"), Owner: user("Copilot")},
+					{ID: github.Ptr("2"), Description: github.Ptr("Gist by " + untrustedLogin), Owner: user(untrustedLogin)},
+				}),
+			},
+			args:        map[string]any{},
+			expected:    "Gist by Copilot",
+			notExpected: "Gist by " + untrustedLogin,
+		},
+		{
+			name: "get_gist by the viewer",
+			tool: GetGist(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetGistsByGistID: mockResponse(t, http.StatusOK, &github.Gist{ID: github.Ptr("3"), Description: github.Ptr("Gist by viewer"), Owner: user("viewer")}),
+			},
+			args:     map[string]any{"gist_id": "3"},
+			expected: "Gist by viewer",
+		},
+		{
+			name: "get_gist by another user",
+			tool: GetGist(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetGistsByGistID: mockResponse(t, http.StatusOK, &github.Gist{ID: github.Ptr("2"), Owner: user(untrustedLogin)}),
+			},
+			args:         map[string]any{"gist_id": "2"},
+			restrictedBy: "access to gist is restricted by lockdown mode",
+		},
+		{
+			name: "get_commit by a maintainer",
+			tool: GetCommit(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetReposCommitsByOwnerByRepoByRef: mockResponse(t, http.StatusOK, commit(trustedLogin)),
+			},
+			args:     map[string]any{"owner": "owner", "repo": "repo", "sha": "main"},
+			expected: "Commit by " + trustedLogin,
+		},
+		{
+			name: "get_commit by an external user",
+			tool: GetCommit(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetReposCommitsByOwnerByRepoByRef: mockResponse(t, http.StatusOK, commit(untrustedLogin)),
+			},
+			args:         map[string]any{"owner": "owner", "repo": "repo", "sha": "main"},
+			restrictedBy: "access to commit details is restricted by lockdown mode",
+		},
+		{
+			name: "list_commits",
+			tool: ListCommits(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetReposCommitsByOwnerByRepo: mockResponse(t, http.StatusOK, []*github.RepositoryCommit{commit(trustedLogin), commit(untrustedLogin)}),
+			},
+			args:        map[string]any{"owner": "owner", "repo": "repo"},
+			expected:    "Commit by " + trustedLogin,
+			notExpected: "Commit by " + untrustedLogin,
+		},
+		{
+			name: "list_commits without linked authors",
+			tool: ListCommits(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetReposCommitsByOwnerByRepo: mockResponse(t, http.StatusOK, []*github.RepositoryCommit{unlinkedCommit(trustedLogin), unlinkedCommit(untrustedLogin)}),
+			},
+			args:        map[string]any{"owner": "owner", "repo": "repo"},
+			expected:    "Commit committed by " + trustedLogin,
+			notExpected: "Commit committed by " + untrustedLogin,
+		},
+		{
+			name: "get_commit without a linked author or committer",
+			tool: GetCommit(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetReposCommitsByOwnerByRepoByRef: mockResponse(t, http.StatusOK, &github.RepositoryCommit{
+					SHA:    github.Ptr("sha-unknown"),
+					Commit: &github.Commit{Message: github.Ptr("Commit by an unknown email address")},
+				}),
+			},
+			args:     map[string]any{"owner": "owner", "repo": "repo", "sha": "main"},
+			expected: "Commit by an unknown email address",
+		},
+		{
+			name: "list_issues",
+			tool: ListIssues(translations.NullTranslationHelper),
+			gqlMatchers: []githubv4mock.Matcher{githubv4mock.NewQueryMatcher(issuesQuery, map[string]any{
+				"owner": "owner", "repo": "repo", "states": []any{"OPEN", "CLOSED"}, "orderBy": "CREATED_AT", "direction": "DESC", "first": float64(30), "after": (*string)(nil),
+			}, githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"issues": map[string]any{
+					"nodes": []any{
+						map[string]any{"number": 1, "title": "Issue by " + trustedLogin, "author": map[string]any{"login": trustedLogin}},
+						map[string]any{"number": 2, "title": "Issue by " + untrustedLogin, "author": map[string]any{"login": untrustedLogin}},
+					},
+				}},
+			}))},
+			args:        map[string]any{"owner": "owner", "repo": "repo"},
+			expected:    "Issue by " + trustedLogin,
+			notExpected: "Issue by " + untrustedLogin,
+		},
+		{
+			name: "list_pull_requests",
+			tool: ListPullRequests(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetReposPullsByOwnerByRepo: mockResponse(t, http.StatusOK, []*github.PullRequest{
+					{Number: github.Ptr(1), Title: github.Ptr("Pull request by " + trustedLogin), User: user(trustedLogin)},
+					{Number: github.Ptr(2), Title: github.Ptr("Pull request by " + untrustedLogin), User: user(untrustedLogin)},
+				}),
+			},
+			args:        map[string]any{"owner": "owner", "repo": "repo"},
+			expected:    "Pull request by " + trustedLogin,
+			notExpected: "Pull request by " + untrustedLogin,
+		},
+		{
+			name: "list_releases",
+			tool: ListReleases(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetReposReleasesByOwnerByRepo: mockResponse(t, http.StatusOK, []*github.RepositoryRelease{release(trustedLogin), release(untrustedLogin)}),
+			},
+			args:        map[string]any{"owner": "owner", "repo": "repo"},
+			expected:    "Release by " + trustedLogin,
+			notExpected: "Release by " + untrustedLogin,
+		},
+		{
+			name: "get_latest_release by an external user",
+			tool: GetLatestRelease(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetReposReleasesLatestByOwnerByRepo: mockResponse(t, http.StatusOK, release(untrustedLogin)),
+			},
+			args:         map[string]any{"owner": "owner", "repo": "repo"},
+			restrictedBy: "access to release details is restricted by lockdown mode",
+		},
+		{
+			name: "get_release_by_tag by a maintainer",
+			tool: GetReleaseByTag(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetReposReleasesTagsByOwnerByRepoByTag: mockResponse(t, http.StatusOK, release(trustedLogin)),
+			},
+			args:     map[string]any{"owner": "owner", "repo": "repo", "tag": "v1"},
+			expected: "Release by " + trustedLogin,
+		},
+		{
+			name: "list_notifications",
+			tool: ListNotifications(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetNotifications: mockResponse(t, http.StatusOK, []*github.Notification{
+					notification("1", trustedLogin, "1"),
+					notification("2", untrustedLogin, "2"),
+				}),
+				GetReposIssuesByOwnerByRepoByIssueNumber: notificationSubjects,
+			},
+			args:        map[string]any{},
+			expected:    "Issue by " + trustedLogin,
+			notExpected: "Issue by " + untrustedLogin,
+		},
+		{
+			name: "list_notifications withholds deleted subjects",
+			tool: ListNotifications(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetNotifications: mockResponse(t, http.StatusOK, []*github.Notification{
+					notification("1", trustedLogin, "1"),
+					notification("9", "ghost", "9"),
+				}),
+				GetReposIssuesByOwnerByRepoByIssueNumber: notificationSubjects,
+			},
+			args:        map[string]any{},
+			expected:    "Issue by " + trustedLogin,
+			notExpected: "Issue by ghost",
+		},
+		{
+			name: "list_notifications of a private repository",
+			tool: ListNotifications(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetNotifications: mockResponse(t, http.StatusOK, []*github.Notification{privateNotification}),
+			},
+			args:     map[string]any{},
+			expected: "Private issue by " + untrustedLogin,
+		},
+		{
+			name: "list_notifications withholds discussions",
+			tool: ListNotifications(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetNotifications: mockResponse(t, http.StatusOK, []*github.Notification{
+					unauthoredNotification("4", "CheckSuite"),
+					unauthoredNotification("5", "Discussion"),
+				}),
+			},
+			args:        map[string]any{},
+			expected:    "CheckSuite notification",
+			notExpected: "Discussion notification",
+		},
+		{
+			name: "get_notification_details of a discussion",
+			tool: GetNotificationDetails(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetNotificationsThreadsByThreadID: mockResponse(t, http.StatusOK, unauthoredNotification("5", "Discussion")),
+			},
+			args:         map[string]any{"notificationID": "5"},
+			restrictedBy: "access to notification details is restricted by lockdown mode",
+		},
+		{
+			name: "get_notification_details of an external user's issue",
+			tool: GetNotificationDetails(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetNotificationsThreadsByThreadID:        mockResponse(t, http.StatusOK, notification("2", untrustedLogin, "2")),
+				GetReposIssuesByOwnerByRepoByIssueNumber: notificationSubjects,
+			},
+			args:         map[string]any{"notificationID": "2"},
+			restrictedBy: "access to notification details is restricted by lockdown mode",
+		},
+		{
+			name: "search_issues",
+			tool: SearchIssues(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetSearchIssues: mockResponse(t, http.StatusOK, searchResult(trustedLogin, untrustedLogin)),
+			},
+			args:        map[string]any{"query": "crash"},
+			expected:    "Issue by " + trustedLogin,
+			notExpected: "Issue by " + untrustedLogin,
+		},
+		{
+			name: "search_pull_requests",
+			tool: SearchPullRequests(translations.NullTranslationHelper),
+			handlers: map[string]http.HandlerFunc{
+				GetSearchIssues: mockResponse(t, http.StatusOK, searchResult(untrustedLogin, trustedLogin)),
+			},
+			args:        map[string]any{"query": "crash"},
+			expected:    "Issue by " + trustedLogin,
+			notExpected: "Issue by " + untrustedLogin,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				Client:          github.NewClient(MockHTTPClientWithHandlers(tc.handlers)),
+				GQLClient:       githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.gqlMatchers...)),
+				RepoAccessCache: repoAccessCache,
+				Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": true}),
+			}
+			handler := tc.tool.Handler(deps)
+			request := createMCPRequest(tc.args)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			text := getTextResult(t, result).Text
+
+			if tc.restrictedBy != "" {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.restrictedBy, text)
+				return
+			}
+			require.False(t, result.IsError, text)
+			assert.Contains(t, text, tc.expected)
+			if tc.notExpected != "" {
+				assert.NotContains(t, text, tc.notExpected)
+			}
+		})
+	}
+}
@@ -2,11 +2,12 @@ package github
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
@@ -36,7 +37,7 @@ func ListNotifications(t translations.TranslationHelperFunc) inventory.ServerToo
 				Title:        t("TOOL_LIST_NOTIFICATIONS_USER_TITLE", "List notifications"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithFields(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"filter": {
@@ -61,7 +62,8 @@ func ListNotifications(t translations.TranslationHelperFunc) inventory.ServerToo
 						Description: "Optional repository name. If provided with owner, only notifications for this repository are listed.",
 					},
 				},
-			}),
+			})),
+			OutputSchema: APIListOutputSchema[github.Notification]("Notifications as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Notifications},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -151,13 +153,17 @@ func ListNotifications(t translations.TranslationHelperFunc) inventory.ServerToo
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get notifications", resp, body), nil, nil
 			}
 
-			// Marshal response to JSON
-			r, err := json.Marshal(notifications)
+			policy, err := lockdownPolicyFor(deps)
+			if err != nil {
+				return nil, nil, err
+			}
+			notifications, err = filterNotifications(ctx, policy, client, notifications)
 			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			// Marshal response to JSON
+			return MarshalledTextResult(ctx, notifications), nil, nil
 		},
 	)
 }
@@ -188,6 +194,7 @@ func DismissNotification(t translations.TranslationHelperFunc) inventory.ServerT
 				},
 				Required: []string{"threadID", "state"},
 			},
+			OutputSchema: OutputSchema[MessageResponse](),
 		},
 		[]scopes.Scope{scopes.Notifications},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -239,7 +246,7 @@ func DismissNotification(t translations.TranslationHelperFunc) inventory.ServerT
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, fmt.Sprintf("failed to mark notification as %s", state), resp, body), nil, nil
 			}
 
-			return utils.NewToolResultText(fmt.Sprintf("Notification marked as %s", state)), nil, nil
+			return MessageResult(fmt.Sprintf("Notification marked as %s", state)), nil, nil
 		},
 	)
 }
@@ -272,6 +279,7 @@ func MarkAllNotificationsRead(t translations.TranslationHelperFunc) inventory.Se
 					},
 				},
 			},
+			OutputSchema: OutputSchema[MessageResponse](),
 		},
 		[]scopes.Scope{scopes.Notifications},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -331,7 +339,7 @@ func MarkAllNotificationsRead(t translations.TranslationHelperFunc) inventory.Se
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to mark all notifications as read", resp, body), nil, nil
 			}
 
-			return utils.NewToolResultText("All notifications marked as read"), nil, nil
+			return MessageResult("All notifications marked as read"), nil, nil
 		},
 	)
 }
@@ -357,6 +365,7 @@ func GetNotificationDetails(t translations.TranslationHelperFunc) inventory.Serv
 				},
 				Required: []string{"notificationID"},
 			},
+			OutputSchema: APIObjectOutputSchema[github.Notification]("Notification thread as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Notifications},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -388,16 +397,164 @@ func GetNotificationDetails(t translations.TranslationHelperFunc) inventory.Serv
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get notification details", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(thread)
+			policy, err := lockdownPolicyFor(deps)
 			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
+				return nil, nil, err
+			}
+			safeThreads, err := filterNotifications(ctx, policy, client, []*github.Notification{thread})
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+			if len(safeThreads) == 0 {
+				return utils.NewToolResultError("access to notification details is restricted by lockdown mode"), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, thread), nil, nil
 		},
 	)
 }
 
+// authoredNotificationSubjects are the notification subject types whose author is returned by
+// the subject's API URL: issues and pull requests name it user, releases and commits author.
+var authoredNotificationSubjects = map[string]bool{
+	"Issue":       true,
+	"PullRequest": true,
+	"Release":     true,
+	"Commit":      true,
+}
+
+// maxConcurrentSubjectFetches bounds the requests made at once to resolve notification authors.
+const maxConcurrentSubjectFetches = 5
+
+// filterNotifications returns the notifications whose subject title is safe under p, in their
+// original order. Notifications of private repositories are safe without further requests. In
+// public repositories, the authors of authored subjects are fetched once per subject, discussions
+// are withheld because the REST API does not expose their author, and subjects generated by GitHub,
+// such as check suites and security alerts, are safe. Subjects that were deleted or transferred
+// since the notification was sent have no author to check, so their notifications are withheld.
+func filterNotifications(ctx context.Context, p lockdownPolicy, client *github.Client, notifications []*github.Notification) ([]*github.Notification, error) {
+	if !p.enabled() {
+		return notifications, nil
+	}
+
+	var subjectURLs []string
+	for _, notification := range notifications {
+		if !notification.GetRepository().GetPrivate() && authoredNotificationSubjects[notification.GetSubject().GetType()] {
+			subjectURLs = append(subjectURLs, notification.GetSubject().GetURL())
+		}
+	}
+	authors, err := notificationSubjectAuthors(ctx, client, subjectURLs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification subject: %w", err)
+	}
+
+	filtered := make([]*github.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		subject := notification.GetSubject()
+		isSafe := true
+		switch {
+		case notification.GetRepository().GetPrivate():
+			// Lockdown mode trusts all content of private repositories
+		case authoredNotificationSubjects[subject.GetType()]:
+			login, found := authors[subject.GetURL()]
+			if !found {
+				isSafe = false
+				break
+			}
+			isSafe, err = p.isSafe(ctx, authoredContent{
+				login: login,
+				owner: notification.GetRepository().GetOwner().GetLogin(),
+				repo:  notification.GetRepository().GetName(),
+			})
+			if err != nil {
+				return nil, err
+			}
+		case subject.GetType() == "Discussion":
+			isSafe = false
+		}
+		if isSafe {
+			filtered = append(filtered, notification)
+		}
+	}
+	return filtered, nil
+}
+
+// notificationSubjectAuthors fetches the author login of each subject URL concurrently. Duplicate
+// and empty URLs are skipped, and so are subjects that no longer exist: the result has no entry
+// for them. Any other failed fetch fails the whole call.
+func notificationSubjectAuthors(ctx context.Context, client *github.Client, subjectURLs []string) (map[string]string, error) {
+	authors := make(map[string]string, len(subjectURLs))
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	slots := make(chan struct{}, maxConcurrentSubjectFetches)
+	for _, subjectURL := range subjectURLs {
+		if _, seen := authors[subjectURL]; seen || subjectURL == "" {
+			continue
+		}
+		authors[subjectURL] = ""
+		wg.Add(1)
+		go func(subjectURL string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			login, found, err := notificationSubjectAuthor(ctx, client, subjectURL)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				if firstErr == nil {
+					firstErr = err
+				}
+			case !found:
+				delete(authors, subjectURL)
+			default:
+				authors[subjectURL] = login
+			}
+		}(subjectURL)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return authors, nil
+}
+
+// notificationSubjectAuthor returns the author login of the subject at subjectURL, and whether
+// the subject exists: a deleted or transferred subject responds with 404 Not Found or 410 Gone.
+// The committer stands in for a commit author who is not linked to a GitHub account.
+func notificationSubjectAuthor(ctx context.Context, client *github.Client, subjectURL string) (string, bool, error) {
+	req, err := client.NewRequest(http.MethodGet, subjectURL, nil)
+	if err != nil {
+		return "", false, err
+	}
+	var authored struct {
+		User      *github.User `json:"user"`
+		Author    *github.User `json:"author"`
+		Committer *github.User `json:"committer"`
+	}
+	resp, err := client.Do(ctx, req, &authored)
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && (errResp.Response.StatusCode == http.StatusNotFound || errResp.Response.StatusCode == http.StatusGone) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	for _, author := range []*github.User{authored.User, authored.Author, authored.Committer} {
+		if login := author.GetLogin(); login != "" {
+			return login, true, nil
+		}
+	}
+	return "", true, nil
+}
+
 // Enum values for ManageNotificationSubscription action
 const (
 	NotificationActionIgnore = "ignore"
@@ -431,6 +588,7 @@ func ManageNotificationSubscription(t translations.TranslationHelperFunc) invent
 				},
 				Required: []string{"notificationID", "action"},
 			},
+			OutputSchema: AnyOfOutputSchema(APIObjectOutputSchema[github.Subscription]("Thread subscription as returned by the GitHub REST API."), OutputSchema[MessageResponse]()),
 		},
 		[]scopes.Scope{scopes.Notifications},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -483,14 +641,10 @@ func ManageNotificationSubscription(t translations.TranslationHelperFunc) invent
 
 			if action == NotificationActionDelete {
 				// Special case for delete as there is no response body
-				return utils.NewToolResultText("Notification subscription deleted"), nil, nil
+				return MessageResult("Notification subscription deleted"), nil, nil
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
-			}
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, result), nil, nil
 		},
 	)
 }
@@ -531,6 +685,7 @@ func ManageRepositoryNotificationSubscription(t translations.TranslationHelperFu
 				},
 				Required: []string{"owner", "repo", "action"},
 			},
+			OutputSchema: AnyOfOutputSchema(APIObjectOutputSchema[github.Subscription]("Repository subscription as returned by the GitHub REST API."), OutputSchema[MessageResponse]()),
 		},
 		[]scopes.Scope{scopes.Notifications},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -590,14 +745,10 @@ func ManageRepositoryNotificationSubscription(t translations.TranslationHelperFu
 
 			if action == RepositorySubscriptionActionDelete {
 				// Special case for delete as there is no response body
-				return utils.NewToolResultText("Repository subscription deleted"), nil, nil
+				return MessageResult("Repository subscription deleted"), nil, nil
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
-			}
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, result), nil, nil
 		},
 	)
 }
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/github/github-mcp-server/pkg/outputformat"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// OutputFormatParameter is the name of the argument that overrides the server's output format
+// for a single tool call.
+const OutputFormatParameter = "output_format"
+
+// outputFormatContextKey is the context key for the output format of the current tool call.
+type outputFormatContextKey struct{}
+
+// ContextWithOutputFormat returns a new context in which MarshalledTextResult renders results in
+// the given format.
+func ContextWithOutputFormat(ctx context.Context, format outputformat.Format) context.Context {
+	return context.WithValue(ctx, outputFormatContextKey{}, format)
+}
+
+// OutputFormatFromContext returns the output format of the current tool call. Without one, it
+// falls back to the server's default format from the ToolDependencies in the context, and then
+// to JSON.
+func OutputFormatFromContext(ctx context.Context) outputformat.Format {
+	if format, ok := ctx.Value(outputFormatContextKey{}).(outputformat.Format); ok && format != "" {
+		return format
+	}
+	if deps, ok := DepsFromContext(ctx); ok && deps.GetOutputFormat() != "" {
+		return deps.GetOutputFormat()
+	}
+	return outputformat.JSON
+}
+
+// withOutputFormatParameter adds the output_format argument to read-only tools that return
+// structured results. Write tools are left alone since their results are small, and keeping the
+// argument off them keeps tools/list shorter.
+func withOutputFormatParameter(tool mcp.Tool) mcp.Tool {
+	if tool.Annotations == nil || !tool.Annotations.ReadOnlyHint || tool.OutputSchema == nil {
+		return tool
+	}
+	var schema *jsonschema.Schema
+	switch inputSchema := tool.InputSchema.(type) {
+	case *jsonschema.Schema:
+		if inputSchema == nil {
+			return tool
+		}
+		schema = inputSchema.CloneSchemas()
+	case json.RawMessage:
+		if err := json.Unmarshal(inputSchema, &schema); err != nil {
+			return tool
+		}
+	default:
+		return tool
+	}
+
+	formats := make([]any, len(outputformat.Formats))
+	for i, format := range outputformat.Formats {
+		formats[i] = string(format)
+	}
+
+	if schema.Properties == nil {
+		schema.Properties = map[string]*jsonschema.Schema{}
+	}
+	schema.Properties[OutputFormatParameter] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Format of the text result: json, compact or markdown. Defaults to the server's output format",
+		Enum:        formats,
+	}
+	tool.InputSchema = schema
+	return tool
+}
+
+// contextWithCallOutputFormat resolves the output format of a tool call, preferring the
+// output_format argument over the server's default, and stores it in the context.
+func contextWithCallOutputFormat(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest) (context.Context, error) {
+	format := deps.GetOutputFormat()
+
+	if req != nil && req.Params != nil && len(req.Params.Arguments) > 0 {
+		var args struct {
+			OutputFormat *string `json:"output_format"`
+		}
+		// Malformed arguments are reported by the tool handler itself.
+		if err := json.Unmarshal(req.Params.Arguments, &args); err == nil && args.OutputFormat != nil {
+			override, err := outputformat.Parse(*args.OutputFormat)
+			if err != nil {
+				return ctx, err
+			}
+			format = override
+		}
+	}
+
+	return ContextWithOutputFormat(ctx, format), nil
+}
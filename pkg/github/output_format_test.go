@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/outputformat"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type outputFormatTestItem struct {
+	Name  string `json:"name"`
+	Stars int    `json:"stars"`
+}
+
+func outputFormatTestTool(readOnly bool) mcp.Tool {
+	return mcp.Tool{
+		Name:        "list_things",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: readOnly},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {Type: "string"},
+			},
+		},
+		OutputSchema: OutputSchema[[]outputFormatTestItem](),
+	}
+}
+
+func Test_OutputFormatParameter(t *testing.T) {
+	readOnlyTool := outputFormatTestTool(true)
+	st := NewTool(ToolsetMetadataRepos, readOnlyTool, nil, func(_ context.Context, _ ToolDependencies, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+		return nil, nil, nil
+	})
+
+	schema, ok := st.Tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok)
+	require.Contains(t, schema.Properties, OutputFormatParameter)
+	assert.Equal(t, []any{"json", "compact", "markdown"}, schema.Properties[OutputFormatParameter].Enum)
+	assert.NotContains(t, readOnlyTool.InputSchema.(*jsonschema.Schema).Properties, OutputFormatParameter, "the original schema must not be modified")
+
+	writeTool := NewTool(ToolsetMetadataRepos, outputFormatTestTool(false), nil, func(_ context.Context, _ ToolDependencies, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+		return nil, nil, nil
+	})
+	assert.NotContains(t, writeTool.Tool.InputSchema.(*jsonschema.Schema).Properties, OutputFormatParameter)
+}
+
+func Test_OutputFormat(t *testing.T) {
+	items := []outputFormatTestItem{{Name: "a", Stars: 1}, {Name: "b", Stars: 2}}
+	st := NewTool(ToolsetMetadataRepos, outputFormatTestTool(true), nil, func(ctx context.Context, _ ToolDependencies, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+		return MarshalledTextResult(ctx, items), nil, nil
+	})
+
+	tests := []struct {
+		name          string
+		defaultFormat outputformat.Format
+		requestArgs   map[string]any
+		expectedText  string
+		expectedErr   string
+	}{
+		{
+			name:         "defaults to json",
+			requestArgs:  map[string]any{},
+			expectedText: `[{"name":"a","stars":1},{"name":"b","stars":2}]`,
+		},
+		{
+			name:          "server default",
+			defaultFormat: outputformat.Compact,
+			requestArgs:   map[string]any{},
+			expectedText:  "name,stars\na,1\nb,2",
+		},
+		{
+			name:          "per-call override",
+			defaultFormat: outputformat.Compact,
+			requestArgs:   map[string]any{"output_format": "markdown"},
+			expectedText:  "| name | stars |\n| --- | --- |\n| a | 1 |\n| b | 2 |",
+		},
+		{
+			name:        "invalid override",
+			requestArgs: map[string]any{"output_format": "yaml"},
+			expectedErr: `unknown output format "yaml"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := stubDeps{outputFormat: tc.defaultFormat}
+			handler := st.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectedErr != "" {
+				errorResult := getErrorResult(t, result)
+				assert.Contains(t, errorResult.Text, tc.expectedErr)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+
+			// Structured content stays JSON regardless of the text format
+			structured, err := json.Marshal(result.StructuredContent)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"items":[{"name":"a","stars":1},{"name":"b","stars":2}]}`, string(structured))
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// structuredItemsKey is the key under which results that are not JSON objects, such as lists,
+// are wrapped in structuredContent. MCP requires structured content to be a JSON object.
+const structuredItemsKey = "items"
+
+// outputSchemaTypeSchemas overrides schema inference for types that marshal to a different JSON
+// type than their Go shape suggests.
+var outputSchemaTypeSchemas = map[reflect.Type]*jsonschema.Schema{
+	reflect.TypeFor[time.Time]():        {Type: "string", Format: "date-time"},
+	reflect.TypeFor[github.Timestamp](): {Type: "string", Format: "date-time"},
+}
+
+// outputSchemaCache memoizes inferred schemas so that building the tool inventory per request
+// does not repeat reflection. Cached schemas are shared between tools and must not be mutated.
+var outputSchemaCache sync.Map // map[reflect.Type]*jsonschema.Schema
+
+// OutputSchema returns the output schema for a tool whose result is T marshalled by
+// MarshalledTextResult. Struct and map types describe the structured content directly; any other
+// type, such as a slice, is wrapped in an object under the "items" key.
+// It panics if no schema can be inferred, since tool definitions are static.
+func OutputSchema[T any]() *jsonschema.Schema {
+	typ := reflect.TypeFor[T]()
+	if cached, ok := outputSchemaCache.Load(typ); ok {
+		return cached.(*jsonschema.Schema)
+	}
+
+	schema, err := jsonschema.For[T](&jsonschema.ForOptions{TypeSchemas: outputSchemaTypeSchemas})
+	if err != nil {
+		panic(fmt.Sprintf("failed to infer output schema for %s: %v", typ, err))
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Struct || typ.Kind() == reflect.Map {
+		schema.Type = "object"
+		schema.Types = nil
+	} else {
+		schema = itemsOutputSchema(schema)
+	}
+
+	cached, _ := outputSchemaCache.LoadOrStore(reflect.TypeFor[T](), schema)
+	return cached.(*jsonschema.Schema)
+}
+
+// ObjectOutputSchema returns the output schema for results that are objects built by the tool
+// itself, such as confirmations and GraphQL results, rather than types in this package. The
+// properties declare the top-level fields of the object.
+func ObjectOutputSchema(description string, properties map[string]*jsonschema.Schema) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "object",
+		Description: description,
+		Properties:  properties,
+	}
+}
+
+// graphQLPageInfoOutputSchema describes the pageInfo of results paginated with GraphQL cursors.
+func graphQLPageInfoOutputSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"hasNextPage":     {Type: "boolean"},
+			"hasPreviousPage": {Type: "boolean"},
+			"startCursor":     {Type: "string"},
+			"endCursor":       {Type: "string"},
+		},
+	}
+}
+
+// APIObjectOutputSchema returns the output schema for results that are GitHub API objects of type
+// T passed through unchanged. It declares the top-level fields of T, so that clients know which fields exist and arguments
+// such as fields can be validated, but leaves nested objects undescribed: the GitHub API types are
+// too large and too recursive to describe in full. Lists of objects directly inside T, such as the
+// workflow_runs of github.WorkflowRuns, have their top-level fields declared as well.
+func APIObjectOutputSchema[T any](description string) *jsonschema.Schema {
+	schema := apiObjectSchema(reflect.TypeFor[T](), true)
+	schema.Description = description
+	return schema
+}
+
+// APIListOutputSchema is like APIObjectOutputSchema for results that are lists of T.
+func APIListOutputSchema[T any](description string) *jsonschema.Schema {
+	return itemsOutputSchema(&jsonschema.Schema{
+		Types:       []string{"null", "array"},
+		Description: description,
+		Items:       apiObjectSchema(reflect.TypeFor[T](), false),
+	})
+}
+
+// apiObjectSchema describes the JSON fields of the struct type t. Fields that are themselves
+// lists of structs are described one level deep when expandLists is set.
+func apiObjectSchema(t reflect.Type, expandLists bool) *jsonschema.Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	schema := &jsonschema.Schema{Type: "object", Properties: map[string]*jsonschema.Schema{}}
+	if t.Kind() != reflect.Struct {
+		return schema
+	}
+	addAPIObjectProperties(schema, t, expandLists)
+	return schema
+}
+
+func addAPIObjectProperties(schema *jsonschema.Schema, t reflect.Type, expandLists bool) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addAPIObjectProperties(schema, embedded, expandLists)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = apiFieldSchema(field.Type, !strings.Contains(opts, "omitempty"), expandLists)
+	}
+}
+
+// apiFieldSchema describes a single field. Nested objects are only described by their type.
+func apiFieldSchema(t reflect.Type, nullable bool, expandLists bool) *jsonschema.Schema {
+	schema := &jsonschema.Schema{}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+	default:
+		nullable = false
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if override, ok := outputSchemaTypeSchemas[t]; ok {
+		schema.Type = override.Type
+		schema.Format = override.Format
+	} else {
+		switch t.Kind() {
+		case reflect.String:
+			schema.Type = "string"
+		case reflect.Bool:
+			schema.Type = "boolean"
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			schema.Type = "integer"
+		case reflect.Float32, reflect.Float64:
+			schema.Type = "number"
+		case reflect.Struct, reflect.Map:
+			schema.Type = "object"
+		case reflect.Slice, reflect.Array:
+			if t == reflect.TypeFor[json.RawMessage]() {
+				return schema
+			}
+			schema.Type = "array"
+			elem := t.Elem()
+			for elem.Kind() == reflect.Pointer {
+				elem = elem.Elem()
+			}
+			if expandLists && elem.Kind() == reflect.Struct && outputSchemaTypeSchemas[elem] == nil {
+				schema.Items = apiObjectSchema(elem, false)
+			}
+		default:
+			// Interfaces and other kinds can hold any JSON value.
+			return schema
+		}
+	}
+
+	if nullable {
+		schema.Types = []string{"null", schema.Type}
+		schema.Type = ""
+	}
+	return schema
+}
+
+// AnyOfOutputSchema returns the output schema for tools whose result shape depends on their
+// arguments, such as tools with a method parameter.
+func AnyOfOutputSchema(schemas ...*jsonschema.Schema) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:  "object",
+		AnyOf: schemas,
+	}
+}
+
+func itemsOutputSchema(items *jsonschema.Schema) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			structuredItemsKey: items,
+		},
+		Required: []string{structuredItemsKey},
+	}
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+)
+
+// toolsetPermissions maps toolsets to the fine-grained permission that their repository scopes
+// (repo, public_repo and security_events) stand for. Toolsets that are not listed only need
+// repository metadata as far as the filter is concerned. Discussions are left out because they
+// are only served by GraphQL, so their permission cannot be probed with a cheap read.
+var toolsetPermissions = map[inventory.ToolsetID]scopes.Permission{
+	ToolsetMetadataRepos.ID:            scopes.PermissionContents,
+	ToolsetMetadataGit.ID:              scopes.PermissionContents,
+	ToolsetMetadataIssues.ID:           scopes.PermissionIssues,
+	ToolsetMetadataPullRequests.ID:     scopes.PermissionPullRequests,
+	ToolsetMetadataActions.ID:          scopes.PermissionActions,
+	ToolsetMetadataCodeSecurity.ID:     scopes.PermissionCodeScanningAlerts,
+	ToolsetMetadataDependabot.ID:       scopes.PermissionDependabotAlerts,
+	ToolsetMetadataSecretProtection.ID: scopes.PermissionSecretScanningAlerts,
+	ToolsetMetadataStargazers.ID:       scopes.PermissionStarring,
+}
+
+// repositoryScopes are the OAuth scopes whose fine-grained permission depends on the toolset.
+var repositoryScopes = map[string]bool{
+	string(scopes.Repo):           true,
+	string(scopes.PublicRepo):     true,
+	string(scopes.SecurityEvents): true,
+}
+
+// scopePermissions maps the OAuth scopes that are not repository scopes to fine-grained
+// permissions that the prober can detect. gist and the package scopes are left out: listing
+// gists needs no permission, so the gists permission cannot be told apart by reading, and
+// packages are not available to fine-grained tokens.
+var scopePermissions = map[string]scopes.Permission{
+	string(scopes.Notifications): scopes.PermissionNotifications,
+	string(scopes.ReadOrg):       scopes.PermissionMembers,
+	string(scopes.WriteOrg):      scopes.PermissionMembers,
+	string(scopes.AdminOrg):      scopes.PermissionMembers,
+	string(scopes.ReadProject):   scopes.PermissionOrganizationProjects,
+	string(scopes.Project):       scopes.PermissionOrganizationProjects,
+}
+
+// ToolPermissions returns the fine-grained permissions that stand for the tool's required OAuth
+// scopes. Repository scopes map to the permission of the tool's toolset, and scopes without a
+// fine-grained equivalent, such as user, are left out.
+func ToolPermissions(tool *inventory.ServerTool) []scopes.Permission {
+	var permissions []scopes.Permission
+	for _, scope := range tool.RequiredScopes {
+		var permission scopes.Permission
+		switch {
+		case repositoryScopes[scope]:
+			permission = scopes.PermissionMetadata
+			if p, ok := toolsetPermissions[tool.Toolset.ID]; ok {
+				permission = p
+			}
+		case scopePermissions[scope] != "":
+			permission = scopePermissions[scope]
+		default:
+			continue
+		}
+		if !slices.Contains(permissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
+
+// CreateToolPermissionFilter creates an inventory.ToolFilter that filters tools based on the
+// probed permissions of a fine-grained personal access token or GitHub App installation token.
+// It is the equivalent of CreateToolScopeFilter for tokens without OAuth scopes.
+//
+// The filter returns false (exclude tool) only if a permission that the tool needs is known to
+// be denied. Permissions that could not be probed are assumed to be granted, and read-only tools
+// requiring only repo/public_repo scopes are kept since they work on public repos.
+//
+// Example usage:
+//
+//	permissions, err := scopes.NewPermissionProber(scopes.PermissionProberOptions{}).ProbePermissions(ctx, token)
+//	if err != nil {
+//	    // Handle error - maybe skip filtering
+//	}
+//	filter := github.CreateToolPermissionFilter(permissions)
+//	inventory := github.NewInventory(t).WithFilter(filter).Build()
+func CreateToolPermissionFilter(permissions scopes.TokenPermissions) inventory.ToolFilter {
+	return func(_ context.Context, tool *inventory.ServerTool) (bool, error) {
+		if tool.Tool.Annotations != nil && tool.Tool.Annotations.ReadOnlyHint && onlyRequiresRepoScopes(tool.AcceptedScopes) {
+			return true, nil
+		}
+		for _, permission := range ToolPermissions(tool) {
+			if permissions.IsDenied(permission) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+}
@@ -0,0 +1,76 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findTool(t *testing.T, name string) *inventory.ServerTool {
+	t.Helper()
+	for _, tool := range AllTools(translations.NullTranslationHelper) {
+		if tool.Tool.Name == name {
+			return &tool
+		}
+	}
+	t.Fatalf("tool %s not found", name)
+	return nil
+}
+
+func TestToolPermissions(t *testing.T) {
+	tests := []struct {
+		tool     string
+		expected []scopes.Permission
+	}{
+		{tool: "get_me", expected: nil},
+		{tool: "create_or_update_file", expected: []scopes.Permission{scopes.PermissionContents}},
+		{tool: "issue_write", expected: []scopes.Permission{scopes.PermissionIssues}},
+		{tool: "create_pull_request", expected: []scopes.Permission{scopes.PermissionPullRequests}},
+		{tool: "list_code_scanning_alerts", expected: []scopes.Permission{scopes.PermissionCodeScanningAlerts}},
+		{tool: "list_dependabot_alerts", expected: []scopes.Permission{scopes.PermissionDependabotAlerts}},
+		{tool: "list_notifications", expected: []scopes.Permission{scopes.PermissionNotifications}},
+		{tool: "create_gist", expected: nil},
+		{tool: "list_discussions", expected: []scopes.Permission{scopes.PermissionMetadata}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tool, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ToolPermissions(findTool(t, tc.tool)))
+		})
+	}
+}
+
+func TestCreateToolPermissionFilter(t *testing.T) {
+	permissions := scopes.TokenPermissions{
+		scopes.PermissionContents:      true,
+		scopes.PermissionIssues:        false,
+		scopes.PermissionNotifications: false,
+	}
+	filter := CreateToolPermissionFilter(permissions)
+
+	tests := []struct {
+		name     string
+		tool     string
+		expected bool
+	}{
+		{name: "tool without scopes is visible", tool: "get_me", expected: true},
+		{name: "tool with granted permission is visible", tool: "create_or_update_file", expected: true},
+		{name: "tool with denied permission is hidden", tool: "issue_write", expected: false},
+		{name: "read-only repo tool works on public repos", tool: "list_issues", expected: true},
+		{name: "tool with unknown permission is visible", tool: "create_pull_request", expected: true},
+		{name: "read-only tool with denied non-repo permission is hidden", tool: "list_notifications", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			visible, err := filter(context.Background(), findTool(t, tc.tool))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, visible)
+		})
+	}
+}
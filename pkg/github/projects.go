@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -44,6 +43,39 @@ const (
 	projectsMethodDeleteProjectItem = "delete_project_item"
 )
 
+// projectListOutputSchema describes the response of the project list tools: the listed results
+// under key, together with the pagination details.
+func projectListOutputSchema(description, key string, results *jsonschema.Schema) *jsonschema.Schema {
+	return ObjectOutputSchema(description, map[string]*jsonschema.Schema{
+		key:        results,
+		"pageInfo": OutputSchema[pageInfo]().CloneSchemas(),
+	})
+}
+
+func listProjectsOutputSchema() *jsonschema.Schema {
+	return projectListOutputSchema("Projects, with projects and pageInfo.",
+		"projects", OutputSchema[[]MinimalProject]().Properties[structuredItemsKey])
+}
+
+// projectsListOutputSchema describes the response of projects_list, which lists projects of both
+// owner types with a note when owner_type is omitted.
+func projectsListOutputSchema() *jsonschema.Schema {
+	projects := listProjectsOutputSchema()
+	projects.Description = "Projects, with projects and pageInfo (method list_projects). Listing projects without owner_type also includes a note."
+	projects.Properties["note"] = &jsonschema.Schema{Type: "string"}
+	return AnyOfOutputSchema(projects, listProjectFieldsOutputSchema(), listProjectItemsOutputSchema())
+}
+
+func listProjectFieldsOutputSchema() *jsonschema.Schema {
+	return projectListOutputSchema("Project fields as returned by the GitHub REST API, with fields and pageInfo.",
+		"fields", APIListOutputSchema[github.ProjectV2Field]("").Properties[structuredItemsKey])
+}
+
+func listProjectItemsOutputSchema() *jsonschema.Schema {
+	return projectListOutputSchema("Project items as returned by the GitHub REST API, with items and pageInfo.",
+		"items", APIListOutputSchema[github.ProjectV2Item]("").Properties[structuredItemsKey])
+}
+
 func ListProjects(t translations.TranslationHelperFunc) inventory.ServerTool {
 	tool := NewTool(
 		ToolsetMetadataProjects,
@@ -85,6 +117,7 @@ func ListProjects(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner_type", "owner"},
 			},
+			OutputSchema: listProjectsOutputSchema(),
 		},
 		[]scopes.Scope{scopes.ReadProject},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -152,12 +185,7 @@ func ListProjects(t translations.TranslationHelperFunc) inventory.ServerTool {
 				"pageInfo": buildPageInfo(resp),
 			}
 
-			r, err := json.Marshal(response)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, response), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedProjects
@@ -193,6 +221,7 @@ func GetProject(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"project_number", "owner_type", "owner"},
 			},
+			OutputSchema: OutputSchema[MinimalProject](),
 		},
 		[]scopes.Scope{scopes.ReadProject},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -243,12 +272,7 @@ func GetProject(t translations.TranslationHelperFunc) inventory.ServerTool {
 			}
 
 			minimalProject := convertToMinimalProject(project)
-			r, err := json.Marshal(minimalProject)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, minimalProject), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedProjects
@@ -296,6 +320,7 @@ func ListProjectFields(t translations.TranslationHelperFunc) inventory.ServerToo
 				},
 				Required: []string{"owner_type", "owner", "project_number"},
 			},
+			OutputSchema: listProjectFieldsOutputSchema(),
 		},
 		[]scopes.Scope{scopes.ReadProject},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -352,12 +377,7 @@ func ListProjectFields(t translations.TranslationHelperFunc) inventory.ServerToo
 				"pageInfo": buildPageInfo(resp),
 			}
 
-			r, err := json.Marshal(response)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, response), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedProjects
@@ -397,6 +417,7 @@ func GetProjectField(t translations.TranslationHelperFunc) inventory.ServerTool
 				},
 				Required: []string{"owner_type", "owner", "project_number", "field_id"},
 			},
+			OutputSchema: APIObjectOutputSchema[github.ProjectV2Field]("Project field as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.ReadProject},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -447,12 +468,7 @@ func GetProjectField(t translations.TranslationHelperFunc) inventory.ServerTool
 				}
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get project field", resp, body), nil, nil
 			}
-			r, err := json.Marshal(projectField)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, projectField), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedProjects
@@ -511,6 +527,7 @@ func ListProjectItems(t translations.TranslationHelperFunc) inventory.ServerTool
 				},
 				Required: []string{"owner_type", "owner", "project_number"},
 			},
+			OutputSchema: listProjectItemsOutputSchema(),
 		},
 		[]scopes.Scope{scopes.ReadProject},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -586,12 +603,7 @@ func ListProjectItems(t translations.TranslationHelperFunc) inventory.ServerTool
 				"pageInfo": buildPageInfo(resp),
 			}
 
-			r, err := json.Marshal(response)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, response), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedProjects
@@ -638,6 +650,7 @@ func GetProjectItem(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner_type", "owner", "project_number", "item_id"},
 			},
+			OutputSchema: APIObjectOutputSchema[github.ProjectV2Item]("Project item as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.ReadProject},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -695,12 +708,7 @@ func GetProjectItem(t translations.TranslationHelperFunc) inventory.ServerTool {
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(projectItem)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, projectItem), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedProjects
@@ -745,6 +753,7 @@ func AddProjectItem(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner_type", "owner", "project_number", "item_type", "item_id"},
 			},
+			OutputSchema: APIObjectOutputSchema[github.ProjectV2Item]("The added project item as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Project},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -809,12 +818,7 @@ func AddProjectItem(t translations.TranslationHelperFunc) inventory.ServerTool {
 				}
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, ProjectAddFailedError, resp, body), nil, nil
 			}
-			r, err := json.Marshal(addedItem)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, addedItem), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedProjects
@@ -858,6 +862,7 @@ func UpdateProjectItem(t translations.TranslationHelperFunc) inventory.ServerToo
 				},
 				Required: []string{"owner_type", "owner", "project_number", "item_id", "updated_field"},
 			},
+			OutputSchema: APIObjectOutputSchema[github.ProjectV2Item]("The updated project item as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Project},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -924,12 +929,7 @@ func UpdateProjectItem(t translations.TranslationHelperFunc) inventory.ServerToo
 				}
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, ProjectUpdateFailedError, resp, body), nil, nil
 			}
-			r, err := json.Marshal(updatedItem)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, updatedItem), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedProjects
@@ -970,6 +970,7 @@ func DeleteProjectItem(t translations.TranslationHelperFunc) inventory.ServerToo
 				},
 				Required: []string{"owner_type", "owner", "project_number", "item_id"},
 			},
+			OutputSchema: OutputSchema[MessageResponse](),
 		},
 		[]scopes.Scope{scopes.Project},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1018,7 +1019,7 @@ func DeleteProjectItem(t translations.TranslationHelperFunc) inventory.ServerToo
 				}
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, ProjectDeleteFailedError, resp, body), nil, nil
 			}
-			return utils.NewToolResultText("project item successfully deleted"), nil, nil
+			return MessageResult("project item successfully deleted"), nil, nil
 		},
 	)
 	tool.FeatureFlagEnable = FeatureFlagHoldbackConsolidatedProjects
@@ -1090,6 +1091,7 @@ Use this tool to list projects for a user or organization, or list project field
 				},
 				Required: []string{"method", "owner"},
 			},
+			OutputSchema: projectsListOutputSchema(),
 		},
 		[]scopes.Scope{scopes.ReadProject},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1207,6 +1209,11 @@ Use this tool to get details about individual projects, project fields, and proj
 				},
 				Required: []string{"method", "owner", "project_number"},
 			},
+			OutputSchema: AnyOfOutputSchema(
+				OutputSchema[MinimalProject](),
+				APIObjectOutputSchema[github.ProjectV2Field]("Project field as returned by the GitHub REST API (method get_project_field)."),
+				APIObjectOutputSchema[github.ProjectV2Item]("Project item as returned by the GitHub REST API (method get_project_item)."),
+			),
 		},
 		[]scopes.Scope{scopes.ReadProject},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1340,6 +1347,14 @@ func ProjectsWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"method", "owner", "project_number"},
 			},
+			OutputSchema: AnyOfOutputSchema(
+				ObjectOutputSchema("The added item (method add_project_item).", map[string]*jsonschema.Schema{
+					"id":      {Type: "string"},
+					"message": {Type: "string"},
+				}),
+				APIObjectOutputSchema[github.ProjectV2Item]("The updated project item as returned by the GitHub REST API (method update_project_item)."),
+				OutputSchema[MessageResponse](),
+			),
 		},
 		[]scopes.Scope{scopes.Project},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1508,12 +1523,7 @@ func listProjects(ctx context.Context, client *github.Client, args map[string]an
 			"pageInfo": buildPageInfo(resp),
 		}
 
-		r, err := json.Marshal(response)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-		}
-
-		return utils.NewToolResultText(string(r)), nil, nil
+		return MarshalledTextResult(ctx, response), nil, nil
 	}
 
 	return nil, nil, fmt.Errorf("unexpected state in listProjects")
@@ -1564,11 +1574,7 @@ func listProjectsFromBothOwnerTypes(ctx context.Context, client *github.Client,
 		defer func() { _ = resp.Body.Close() }()
 	}
 
-	r, err := json.Marshal(response)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, response), nil, nil
 }
 
 func listProjectFields(ctx context.Context, client *github.Client, args map[string]any, owner, ownerType string) (*mcp.CallToolResult, any, error) {
@@ -1609,12 +1615,7 @@ func listProjectFields(ctx context.Context, client *github.Client, args map[stri
 		"pageInfo": buildPageInfo(resp),
 	}
 
-	r, err := json.Marshal(response)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, response), nil, nil
 }
 
 func listProjectItems(ctx context.Context, client *github.Client, args map[string]any, owner, ownerType string) (*mcp.CallToolResult, any, error) {
@@ -1674,12 +1675,7 @@ func listProjectItems(ctx context.Context, client *github.Client, args map[strin
 		"pageInfo": buildPageInfo(resp),
 	}
 
-	r, err := json.Marshal(response)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, response), nil, nil
 }
 
 func getProject(ctx context.Context, client *github.Client, owner, ownerType string, projectNumber int) (*mcp.CallToolResult, any, error) {
@@ -1710,12 +1706,7 @@ func getProject(ctx context.Context, client *github.Client, owner, ownerType str
 	}
 
 	minimalProject := convertToMinimalProject(project)
-	r, err := json.Marshal(minimalProject)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, minimalProject), nil, nil
 }
 
 func getProjectField(ctx context.Context, client *github.Client, owner, ownerType string, projectNumber int, fieldID int64) (*mcp.CallToolResult, any, error) {
@@ -1745,12 +1736,7 @@ func getProjectField(ctx context.Context, client *github.Client, owner, ownerTyp
 		}
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get project field", resp, body), nil, nil
 	}
-	r, err := json.Marshal(projectField)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, projectField), nil, nil
 }
 
 func getProjectItem(ctx context.Context, client *github.Client, owner, ownerType string, projectNumber int, itemID int64, fields []int64) (*mcp.CallToolResult, any, error) {
@@ -1788,12 +1774,7 @@ func getProjectItem(ctx context.Context, client *github.Client, owner, ownerType
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get project item", resp, body), nil, nil
 	}
 
-	r, err := json.Marshal(projectItem)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, projectItem), nil, nil
 }
 
 func updateProjectItem(ctx context.Context, client *github.Client, owner, ownerType string, projectNumber int, itemID int64, fieldValue map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1827,12 +1808,7 @@ func updateProjectItem(ctx context.Context, client *github.Client, owner, ownerT
 		}
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, ProjectUpdateFailedError, resp, body), nil, nil
 	}
-	r, err := json.Marshal(updatedItem)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, updatedItem), nil, nil
 }
 
 func deleteProjectItem(ctx context.Context, client *github.Client, owner, ownerType string, projectNumber int, itemID int64) (*mcp.CallToolResult, any, error) {
@@ -1861,7 +1837,7 @@ func deleteProjectItem(ctx context.Context, client *github.Client, owner, ownerT
 		}
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, ProjectDeleteFailedError, resp, body), nil, nil
 	}
-	return utils.NewToolResultText("project item successfully deleted"), nil, nil
+	return MessageResult("project item successfully deleted"), nil, nil
 }
 
 // addProjectItem adds an item to a project by resolving the issue/PR number to a node ID
@@ -1944,12 +1920,7 @@ func addProjectItem(ctx context.Context, gqlClient *githubv4.Client, owner, owne
 		"message": fmt.Sprintf("Successfully added %s %s/%s#%d to project %s/%d", itemType, itemOwner, itemRepo, itemNumber, owner, projectNumber),
 	}
 
-	r, err := json.Marshal(result)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, result), nil, nil
 }
 
 type pageInfo struct {
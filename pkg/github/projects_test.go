@@ -2146,6 +2146,121 @@ func Test_ProjectsWrite_AddProjectItem(t *testing.T) {
 		assert.Contains(t, response["message"], "Successfully added")
 	})
 
+	t.Run("graphql failures", func(t *testing.T) {
+		issueIDQuery := func(response githubv4mock.GQLResponse) githubv4mock.Matcher {
+			return githubv4mock.NewQueryMatcher(
+				struct {
+					Repository struct {
+						Issue struct {
+							ID githubv4.ID
+						} `graphql:"issue(number: $issueNumber)"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}{},
+				map[string]any{
+					"owner":       githubv4.String("item-owner"),
+					"repo":        githubv4.String("item-repo"),
+					"issueNumber": githubv4.Int(123),
+				},
+				response,
+			)
+		}
+		projectIDQuery := func(response githubv4mock.GQLResponse) githubv4mock.Matcher {
+			return githubv4mock.NewQueryMatcher(
+				struct {
+					Organization struct {
+						ProjectV2 struct {
+							ID githubv4.ID
+						} `graphql:"projectV2(number: $projectNumber)"`
+					} `graphql:"organization(login: $owner)"`
+				}{},
+				map[string]any{
+					"owner":         githubv4.String("octo-org"),
+					"projectNumber": githubv4mock.Any[githubv4.Int](),
+				},
+				response,
+			)
+		}
+		issueFound := githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"issue": map[string]any{"id": "I_issue123"}},
+		})
+		projectFound := githubv4mock.DataResponse(map[string]any{
+			"organization": map[string]any{"projectV2": map[string]any{"id": "PVT_project1"}},
+		})
+
+		tests := []struct {
+			name           string
+			matchers       []githubv4mock.Matcher
+			expectedErrMsg string
+		}{
+			{
+				name: "issue not found",
+				matchers: []githubv4mock.Matcher{
+					issueIDQuery(githubv4mock.TypedErrorResponse("NOT_FOUND", "Could not resolve to an Issue with the number of 123.")),
+				},
+				expectedErrMsg: "failed to resolve issue: failed to resolve issue item-owner/item-repo#123: Could not resolve to an Issue with the number of 123.",
+			},
+			{
+				name: "project not accessible",
+				matchers: []githubv4mock.Matcher{
+					issueIDQuery(issueFound),
+					projectIDQuery(githubv4mock.TypedErrorResponse("FORBIDDEN", "Resource not accessible by personal access token")),
+				},
+				expectedErrMsg: "failed to get project ID: Resource not accessible by personal access token",
+			},
+			{
+				name: "mutation fails with a server error",
+				matchers: []githubv4mock.Matcher{
+					issueIDQuery(issueFound),
+					projectIDQuery(projectFound),
+					githubv4mock.NewMutationMatcher(
+						struct {
+							AddProjectV2ItemByID struct {
+								Item struct {
+									ID githubv4.ID
+								}
+							} `graphql:"addProjectV2ItemById(input: $input)"`
+						}{},
+						githubv4.AddProjectV2ItemByIdInput{
+							ProjectID: githubv4.ID("PVT_project1"),
+							ContentID: githubv4.ID("I_issue123"),
+						},
+						nil,
+						githubv4mock.HTTPErrorResponse(http.StatusInternalServerError, "internal error"),
+					),
+				},
+				expectedErrMsg: ProjectAddFailedError + ": non-200 OK status code: 500 Internal Server Error",
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				mock := githubv4mock.NewMock(tc.matchers...)
+				defer mock.AssertExpectations(t)
+
+				deps := BaseDeps{
+					GQLClient: githubv4.NewClient(mock.Client()),
+				}
+				handler := toolDef.Handler(deps)
+				request := createMCPRequest(map[string]any{
+					"method":         "add_project_item",
+					"owner":          "octo-org",
+					"owner_type":     "org",
+					"project_number": float64(1),
+					"item_owner":     "item-owner",
+					"item_repo":      "item-repo",
+					"issue_number":   float64(123),
+					"item_type":      "issue",
+				})
+				result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				textContent := getTextResult(t, result)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+			})
+		}
+	})
+
 	t.Run("missing item_type", func(t *testing.T) {
 		mockedClient := githubv4mock.NewMockedHTTPClient()
 		client := githubv4.NewClient(mockedClient)
@@ -0,0 +1,184 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/go-github/v79/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PromptArgumentResolvers maps prompt names to the completion handlers of their arguments.
+// The same argument name can mean different things in different prompts: "repo" is a bare
+// repository name next to an "owner" argument, but a combined "owner/repo" value otherwise.
+var PromptArgumentResolvers = map[string]map[string]CompleteHandler{
+	"AssignCodingAgent": {
+		"repo": completeOwnerSlashRepo,
+	},
+	"issue_to_fix_workflow": repoPromptResolvers(map[string]CompleteHandler{
+		"labels":    completeCommaSeparated(completeLabel),
+		"assignees": completeCommaSeparated(completeAssignee),
+	}),
+	"review_pull_request": repoPromptResolvers(map[string]CompleteHandler{
+		"pull_number": completePRNumber,
+	}),
+	"diagnose_ci_failure": repoPromptResolvers(nil),
+	"draft_release_notes": repoPromptResolvers(nil),
+	"triage_issues": repoPromptResolvers(map[string]CompleteHandler{
+		"labels": completeCommaSeparated(completeLabel),
+	}),
+	"summarize_security_alerts": repoPromptResolvers(nil),
+}
+
+// repoPromptResolvers returns the resolvers for prompts that take owner and repo as separate
+// arguments, together with the resolvers in extra.
+func repoPromptResolvers(extra map[string]CompleteHandler) map[string]CompleteHandler {
+	resolvers := map[string]CompleteHandler{
+		"owner": completeOwner,
+		"repo":  completeRepo,
+	}
+	for name, resolver := range extra {
+		resolvers[name] = resolver
+	}
+	return resolvers
+}
+
+// PromptCompletionHandler returns a CompletionHandlerFunc for prompt argument completions.
+func PromptCompletionHandler(getClient GetClientFn) func(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	return func(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+		if req.Params.Ref.Type != "ref/prompt" {
+			return nil, nil // Not a prompt completion
+		}
+
+		// Free-text arguments such as titles and descriptions have nothing to complete
+		resolver, ok := PromptArgumentResolvers[req.Params.Ref.Name][req.Params.Argument.Name]
+		if !ok {
+			return &mcp.CompleteResult{Completion: mcp.CompletionResultDetails{Values: []string{}}}, nil
+		}
+
+		resolved := map[string]string{}
+		if req.Params.Context != nil && req.Params.Context.Arguments != nil {
+			resolved = req.Params.Context.Arguments
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := resolver(ctx, client, resolved, req.Params.Argument.Value)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) > 100 {
+			values = values[:100]
+		}
+
+		return &mcp.CompleteResult{
+			Completion: mcp.CompletionResultDetails{
+				Values:  values,
+				Total:   len(values),
+				HasMore: false,
+			},
+		}, nil
+	}
+}
+
+// completeOwnerSlashRepo completes a combined "owner/repo" argument, such as the repo argument
+// of AssignCodingAgent. The owner is completed first and then the repository within it.
+func completeOwnerSlashRepo(ctx context.Context, client *github.Client, resolved map[string]string, argValue string) ([]string, error) {
+	owner, repoPrefix, found := strings.Cut(argValue, "/")
+	if !found {
+		owners, err := completeOwner(ctx, client, resolved, argValue)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(owners))
+		for _, o := range owners {
+			values = append(values, o+"/")
+		}
+		return values, nil
+	}
+
+	repos, err := completeRepo(ctx, client, map[string]string{"owner": owner}, repoPrefix)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(repos))
+	for _, r := range repos {
+		values = append(values, owner+"/"+r)
+	}
+	return values, nil
+}
+
+// completeCommaSeparated adapts a single-value resolver to a comma-separated argument such as
+// "bug, enhancement". Only the last entry is completed; earlier entries are kept as typed and
+// values that are already present are not suggested again.
+func completeCommaSeparated(resolver CompleteHandler) CompleteHandler {
+	return func(ctx context.Context, client *github.Client, resolved map[string]string, argValue string) ([]string, error) {
+		prefix, current := "", argValue
+		if idx := strings.LastIndex(argValue, ","); idx >= 0 {
+			prefix, current = argValue[:idx+1], argValue[idx+1:]
+		}
+		leading := current[:len(current)-len(strings.TrimLeft(current, " "))]
+
+		chosen := map[string]struct{}{}
+		for _, v := range strings.Split(prefix, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				chosen[v] = struct{}{}
+			}
+		}
+
+		candidates, err := resolver(ctx, client, resolved, strings.TrimSpace(current))
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(candidates))
+		for _, c := range candidates {
+			if _, ok := chosen[c]; ok {
+				continue
+			}
+			values = append(values, prefix+leading+c)
+		}
+		return values, nil
+	}
+}
+
+func completeLabel(ctx context.Context, client *github.Client, resolved map[string]string, argValue string) ([]string, error) {
+	owner := resolved["owner"]
+	repo := resolved["repo"]
+	if owner == "" || repo == "" {
+		return nil, errors.New("owner or repo not specified")
+	}
+	labels, _, err := client.Issues.ListLabels(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	for _, label := range labels {
+		if argValue == "" || strings.HasPrefix(strings.ToLower(label.GetName()), strings.ToLower(argValue)) {
+			values = append(values, label.GetName())
+		}
+	}
+	return values, nil
+}
+
+func completeAssignee(ctx context.Context, client *github.Client, resolved map[string]string, argValue string) ([]string, error) {
+	owner := resolved["owner"]
+	repo := resolved["repo"]
+	if owner == "" || repo == "" {
+		return nil, errors.New("owner or repo not specified")
+	}
+	users, _, err := client.Issues.ListAssignees(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	for _, user := range users {
+		if argValue == "" || strings.HasPrefix(strings.ToLower(user.GetLogin()), strings.ToLower(argValue)) {
+			values = append(values, user.GetLogin())
+		}
+	}
+	return values, nil
+}
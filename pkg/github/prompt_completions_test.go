@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptCompletionHandler(t *testing.T) {
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetUser: mockResponse(t, http.StatusOK, &github.User{Login: github.Ptr("octocat")}),
+		GetUserOrgs: mockResponse(t, http.StatusOK, []*github.Organization{
+			{Login: github.Ptr("octo-org")},
+		}),
+		GetSearchUsers: mockResponse(t, http.StatusOK, &github.UsersSearchResult{}),
+		GetSearchRepositories: mockResponse(t, http.StatusOK, &github.RepositoriesSearchResult{
+			Repositories: []*github.Repository{{Name: github.Ptr("hello-world")}, {Name: github.Ptr("spoon-knife")}},
+		}),
+		GetReposLabelsByOwnerByRepo: mockResponse(t, http.StatusOK, []*github.Label{
+			{Name: github.Ptr("bug")}, {Name: github.Ptr("documentation")}, {Name: github.Ptr("enhancement")},
+		}),
+		GetReposAssigneesByOwnerByRepo: mockResponse(t, http.StatusOK, []*github.User{
+			{Login: github.Ptr("octocat")}, {Login: github.Ptr("hubot")},
+		}),
+		GetSearchIssues: mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+			Issues: []*github.Issue{{Number: github.Ptr(12)}, {Number: github.Ptr(34)}},
+		}),
+	})
+	getClient := func(_ context.Context) (*github.Client, error) {
+		return github.NewClient(mockedClient), nil
+	}
+	handler := CompletionsHandler(getClient)
+
+	repoContext := map[string]string{"owner": "octocat", "repo": "hello-world"}
+	tests := []struct {
+		name     string
+		prompt   string
+		argument string
+		value    string
+		resolved map[string]string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "owner",
+			argument: "owner",
+			value:    "octo",
+			expected: []string{"octocat", "octo-org"},
+		},
+		{
+			name:     "repo scoped to resolved owner",
+			argument: "repo",
+			value:    "hello",
+			resolved: map[string]string{"owner": "octocat"},
+			expected: []string{"hello-world"},
+		},
+		{
+			name:     "repo next to an owner argument is never completed as owner/repo",
+			argument: "repo",
+			value:    "octo",
+			wantErr:  true,
+		},
+		{
+			name:     "combined owner/repo completes owners first",
+			prompt:   "AssignCodingAgent",
+			argument: "repo",
+			value:    "octo",
+			expected: []string{"octocat/", "octo-org/"},
+		},
+		{
+			name:     "combined owner/repo completes repositories",
+			prompt:   "AssignCodingAgent",
+			argument: "repo",
+			value:    "octocat/sp",
+			resolved: map[string]string{"owner": "someone-else"},
+			expected: []string{"octocat/spoon-knife"},
+		},
+		{
+			name:     "labels complete the last comma-separated entry",
+			argument: "labels",
+			value:    "bug, e",
+			resolved: repoContext,
+			expected: []string{"bug, enhancement"},
+		},
+		{
+			name:     "labels skip entries already chosen",
+			argument: "labels",
+			value:    "bug,",
+			resolved: repoContext,
+			expected: []string{"bug,documentation", "bug,enhancement"},
+		},
+		{
+			name:     "assignees",
+			argument: "assignees",
+			value:    "hu",
+			resolved: repoContext,
+			expected: []string{"hubot"},
+		},
+		{
+			name:     "pull request numbers",
+			prompt:   "review_pull_request",
+			argument: "pull_number",
+			value:    "3",
+			resolved: repoContext,
+			expected: []string{"34"},
+		},
+		{
+			name:     "arguments are resolved per prompt",
+			prompt:   "triage_issues",
+			argument: "assignees",
+			value:    "hu",
+			resolved: repoContext,
+			expected: []string{},
+		},
+		{
+			name:     "labels require owner and repo",
+			argument: "labels",
+			resolved: map[string]string{"owner": "octocat"},
+			wantErr:  true,
+		},
+		{
+			name:     "free-text arguments have no completions",
+			argument: "title",
+			value:    "Fix",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			prompt := tc.prompt
+			if prompt == "" {
+				prompt = "issue_to_fix_workflow"
+			}
+			result, err := handler(t.Context(), &mcp.CompleteRequest{
+				Params: &mcp.CompleteParams{
+					Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: prompt},
+					Argument: mcp.CompleteParamsArgument{Name: tc.argument, Value: tc.value},
+					Context:  &mcp.CompleteContext{Arguments: tc.resolved},
+				},
+			})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Completion.Values)
+		})
+	}
+}
+
+func TestPromptArgumentResolversMatchPrompts(t *testing.T) {
+	prompts := map[string]map[string]bool{}
+	for _, prompt := range AllPrompts(translations.NullTranslationHelper) {
+		arguments := map[string]bool{}
+		for _, argument := range prompt.Prompt.Arguments {
+			arguments[argument.Name] = true
+		}
+		prompts[prompt.Prompt.Name] = arguments
+	}
+
+	for promptName, resolvers := range PromptArgumentResolvers {
+		arguments, ok := prompts[promptName]
+		require.True(t, ok, "resolvers for unknown prompt %s", promptName)
+		for argument := range resolvers {
+			assert.True(t, arguments[argument], "prompt %s has no argument %s", promptName, argument)
+		}
+	}
+}
@@ -12,5 +12,18 @@ func AllPrompts(t translations.TranslationHelperFunc) []inventory.ServerPrompt {
 		// Issue prompts
 		AssignCodingAgentPrompt(t),
 		IssueToFixWorkflowPrompt(t),
+		TriageIssuesPrompt(t),
+
+		// Pull request prompts
+		ReviewPullRequestPrompt(t),
+
+		// Repository prompts
+		DraftReleaseNotesPrompt(t),
+
+		// Actions prompts
+		DiagnoseCIFailurePrompt(t),
+
+		// Code security prompts
+		SummarizeSecurityAlertsPrompt(t),
 	}
 }
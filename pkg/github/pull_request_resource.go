@@ -0,0 +1,295 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/octicons"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yosida95/uritemplate/v3"
+)
+
+var (
+	pullRequestResourceURITemplate      = uritemplate.MustNew("pr://{owner}/{repo}/{number}")
+	pullRequestDiffResourceURITemplate  = uritemplate.MustNew("pr://{owner}/{repo}/{number}/diff")
+	pullRequestFilesResourceURITemplate = uritemplate.MustNew("pr://{owner}/{repo}/{number}/files")
+)
+
+// GetPullRequestResource defines the resource template for reading a pull request as Markdown.
+func GetPullRequestResource(t translations.TranslationHelperFunc) inventory.ServerResourceTemplate {
+	return inventory.NewServerResourceTemplate(
+		ToolsetMetadataPullRequests,
+		mcp.ResourceTemplate{
+			Name:        "pull_request",
+			URITemplate: pullRequestResourceURITemplate.Raw(),
+			Description: t("RESOURCE_PULL_REQUEST_DESCRIPTION", "Pull request with its comments, rendered as Markdown"),
+			MIMEType:    markdownMIMEType,
+			Icons:       octicons.Icons("git-pull-request"),
+		},
+		func(_ any) mcp.ResourceHandler {
+			return PullRequestResourceHandler(pullRequestResourceURITemplate)
+		},
+	)
+}
+
+// GetPullRequestDiffResource defines the resource template for reading a pull request diff as Markdown.
+func GetPullRequestDiffResource(t translations.TranslationHelperFunc) inventory.ServerResourceTemplate {
+	return inventory.NewServerResourceTemplate(
+		ToolsetMetadataPullRequests,
+		mcp.ResourceTemplate{
+			Name:        "pull_request_diff",
+			URITemplate: pullRequestDiffResourceURITemplate.Raw(),
+			Description: t("RESOURCE_PULL_REQUEST_DIFF_DESCRIPTION", "Unified diff of a pull request, rendered as Markdown"),
+			MIMEType:    markdownMIMEType,
+			Icons:       octicons.Icons("git-pull-request"),
+		},
+		func(_ any) mcp.ResourceHandler {
+			return PullRequestDiffResourceHandler(pullRequestDiffResourceURITemplate)
+		},
+	)
+}
+
+// GetPullRequestFilesResource defines the resource template for listing the files changed in a pull request.
+func GetPullRequestFilesResource(t translations.TranslationHelperFunc) inventory.ServerResourceTemplate {
+	return inventory.NewServerResourceTemplate(
+		ToolsetMetadataPullRequests,
+		mcp.ResourceTemplate{
+			Name:        "pull_request_files",
+			URITemplate: pullRequestFilesResourceURITemplate.Raw(),
+			Description: t("RESOURCE_PULL_REQUEST_FILES_DESCRIPTION", "Files changed in a pull request, rendered as Markdown"),
+			MIMEType:    markdownMIMEType,
+			Icons:       octicons.Icons("file"),
+		},
+		func(_ any) mcp.ResourceHandler {
+			return PullRequestFilesResourceHandler(pullRequestFilesResourceURITemplate)
+		},
+	)
+}
+
+// getSafePullRequest fetches a pull request and applies the lockdown policy to its author.
+func getSafePullRequest(ctx context.Context, deps ToolDependencies, client *github.Client, owner, repo string, pullNumber int) (*github.PullRequest, error) {
+	pr, _, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	isSafe, err := resourceContentIsSafe(ctx, deps, pr.GetUser().GetLogin(), owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	if !isSafe {
+		return nil, fmt.Errorf("access to pull request is restricted by lockdown mode")
+	}
+	return pr, nil
+}
+
+// PullRequestResourceHandler returns a handler that renders a pull request and its comments as Markdown.
+// It retrieves ToolDependencies from the context at call time via MustDepsFromContext.
+func PullRequestResourceHandler(resourceURITemplate *uritemplate.Template) mcp.ResourceHandler {
+	return func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		deps := MustDepsFromContext(ctx)
+		owner, repo, pullNumber, err := resourceRepoAndNumber(resourceURITemplate, request.Params.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := deps.GetClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		pr, err := getSafePullRequest(ctx, deps, client, owner, repo, pullNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		comments, err := listResourceIssueComments(ctx, client, owner, repo, pullNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request comments: %w", err)
+		}
+
+		safeComments := make([]*github.IssueComment, 0, len(comments))
+		for _, comment := range comments {
+			isSafe, err := resourceContentIsSafe(ctx, deps, comment.GetUser().GetLogin(), owner, repo)
+			if err != nil {
+				return nil, err
+			}
+			if isSafe {
+				safeComments = append(safeComments, comment)
+			}
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{
+					URI:      request.Params.URI,
+					MIMEType: markdownMIMEType,
+					Text:     renderPullRequestMarkdown(pr, safeComments, pr.GetComments()-len(comments)),
+				},
+			},
+		}, nil
+	}
+}
+
+// PullRequestDiffResourceHandler returns a handler that renders a pull request diff as a fenced Markdown block.
+// It retrieves ToolDependencies from the context at call time via MustDepsFromContext.
+func PullRequestDiffResourceHandler(resourceURITemplate *uritemplate.Template) mcp.ResourceHandler {
+	return func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		deps := MustDepsFromContext(ctx)
+		owner, repo, pullNumber, err := resourceRepoAndNumber(resourceURITemplate, request.Params.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := deps.GetClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		pr, err := getSafePullRequest(ctx, deps, client, owner, repo, pullNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		diff, _, err := client.PullRequests.GetRaw(ctx, owner, repo, pullNumber, github.RawOptions{Type: github.Diff})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request diff: %w", err)
+		}
+
+		var sb strings.Builder
+		writeFrontMatter(&sb, []frontMatterField{
+			{"number", pr.GetNumber()},
+			{"title", sanitize.Sanitize(pr.GetTitle())},
+			{"base", pr.GetBase().GetRef()},
+			{"head", pr.GetHead().GetRef()},
+			{"head_sha", pr.GetHead().GetSHA()},
+			{"changed_files", pr.GetChangedFiles()},
+			{"additions", pr.GetAdditions()},
+			{"deletions", pr.GetDeletions()},
+		})
+		fence := codeFence(diff)
+		fmt.Fprintf(&sb, "\n%sdiff\n%s", fence, diff)
+		if !strings.HasSuffix(diff, "\n") {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fence + "\n")
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{
+					URI:      request.Params.URI,
+					MIMEType: markdownMIMEType,
+					Text:     sb.String(),
+				},
+			},
+		}, nil
+	}
+}
+
+// PullRequestFilesResourceHandler returns a handler that renders the files changed in a pull request as a Markdown table.
+// It retrieves ToolDependencies from the context at call time via MustDepsFromContext.
+func PullRequestFilesResourceHandler(resourceURITemplate *uritemplate.Template) mcp.ResourceHandler {
+	return func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		deps := MustDepsFromContext(ctx)
+		owner, repo, pullNumber, err := resourceRepoAndNumber(resourceURITemplate, request.Params.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := deps.GetClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		pr, err := getSafePullRequest(ctx, deps, client, owner, repo, pullNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		files, err := listResourcePullRequestFiles(ctx, client, owner, repo, pullNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request files: %w", err)
+		}
+
+		var sb strings.Builder
+		writeFrontMatter(&sb, []frontMatterField{
+			{"number", pr.GetNumber()},
+			{"title", sanitize.Sanitize(pr.GetTitle())},
+			{"changed_files", pr.GetChangedFiles()},
+			{"additions", pr.GetAdditions()},
+			{"deletions", pr.GetDeletions()},
+		})
+		sb.WriteString("\n| File | Status | Additions | Deletions |\n| --- | --- | ---: | ---: |\n")
+		for _, f := range files {
+			name := f.GetFilename()
+			if prev := f.GetPreviousFilename(); prev != "" {
+				name = prev + " → " + name
+			}
+			fmt.Fprintf(&sb, "| %s | %s | +%d | -%d |\n", markdownCodeSpan(name), f.GetStatus(), f.GetAdditions(), f.GetDeletions())
+		}
+		writeOmittedNote(&sb, pr.GetChangedFiles()-len(files), "files")
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{
+					URI:      request.Params.URI,
+					MIMEType: markdownMIMEType,
+					Text:     sb.String(),
+				},
+			},
+		}, nil
+	}
+}
+
+// renderPullRequestMarkdown renders a pull request and its comments as Markdown with metadata front-matter.
+// omittedComments is the number of comments that were not fetched.
+func renderPullRequestMarkdown(pr *github.PullRequest, comments []*github.IssueComment, omittedComments int) string {
+	labels := make([]string, 0, len(pr.Labels))
+	for _, label := range pr.Labels {
+		labels = append(labels, label.GetName())
+	}
+	reviewers := make([]string, 0, len(pr.RequestedReviewers))
+	for _, reviewer := range pr.RequestedReviewers {
+		reviewers = append(reviewers, reviewer.GetLogin())
+	}
+
+	title := sanitize.Sanitize(pr.GetTitle())
+
+	var sb strings.Builder
+	writeFrontMatter(&sb, []frontMatterField{
+		{"number", pr.GetNumber()},
+		{"title", title},
+		{"state", pr.GetState()},
+		{"draft", pr.GetDraft()},
+		{"merged", pr.GetMerged()},
+		{"author", pr.GetUser().GetLogin()},
+		{"base", pr.GetBase().GetRef()},
+		{"head", pr.GetHead().GetRef()},
+		{"head_sha", pr.GetHead().GetSHA()},
+		{"labels", labels},
+		{"requested_reviewers", reviewers},
+		{"changed_files", pr.GetChangedFiles()},
+		{"additions", pr.GetAdditions()},
+		{"deletions", pr.GetDeletions()},
+		{"created_at", formatResourceTime(pr.GetCreatedAt().Time)},
+		{"updated_at", formatResourceTime(pr.GetUpdatedAt().Time)},
+		{"url", pr.GetHTMLURL()},
+	})
+
+	fmt.Fprintf(&sb, "\n# %s\n\n", title)
+	if body := sanitize.Sanitize(pr.GetBody()); body != "" {
+		sb.WriteString(body)
+		sb.WriteString("\n")
+	}
+	writeCommentsMarkdown(&sb, len(comments), omittedComments, func(i int) (string, string, string) {
+		c := comments[i]
+		return c.GetUser().GetLogin(), formatResourceTime(c.GetCreatedAt().Time), c.GetBody()
+	})
+
+	return sb.String()
+}
@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PullRequestResources(t *testing.T) {
+	mockPR := &github.PullRequest{
+		Number:       github.Ptr(7),
+		Title:        github.Ptr("Add feature"),
+		Body:         github.Ptr("This adds the feature."),
+		State:        github.Ptr("open"),
+		User:         &github.User{Login: github.Ptr("maintainer")},
+		Base:         &github.PullRequestBranch{Ref: github.Ptr("main")},
+		Head:         &github.PullRequestBranch{Ref: github.Ptr("feature"), SHA: github.Ptr("abc123")},
+		ChangedFiles: github.Ptr(5),
+		Additions:    github.Ptr(10),
+		Deletions:    github.Ptr(3),
+	}
+	mockDiff := "diff --git a/main.go b/main.go\n+fmt.Println(\"```\")\n"
+	mockFiles := []*github.CommitFile{
+		{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Additions: github.Ptr(8), Deletions: github.Ptr(3)},
+		{Filename: github.Ptr("new.go"), PreviousFilename: github.Ptr("old.go"), Status: github.Ptr("renamed"), Additions: github.Ptr(2)},
+		{Filename: github.Ptr("docs/a|b`c.md"), Status: github.Ptr("added"), Additions: github.Ptr(1)},
+	}
+	mockComments := []*github.IssueComment{
+		{Body: github.Ptr("LGTM"), User: &github.User{Login: github.Ptr("reviewer")}},
+	}
+
+	// The pull request and its raw diff share an endpoint and differ only in the Accept header
+	pullHandler := func(pr *github.PullRequest) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.Header.Get("Accept"), "diff") {
+				_, _ = w.Write([]byte(mockDiff))
+				return
+			}
+			mockResponse(t, http.StatusOK, pr)(w, r)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		resource        func(translations.TranslationHelperFunc) inventory.ServerResourceTemplate
+		uri             string
+		pr              *github.PullRequest
+		lockdownEnabled bool
+		expectError     string
+		expectContains  []string
+	}{
+		{
+			name:     "pull request",
+			resource: GetPullRequestResource,
+			uri:      "pr://owner/repo/7",
+			pr:       mockPR,
+			expectContains: []string{
+				"number: 7\ntitle: \"Add feature\"\nstate: \"open\"\ndraft: false\nmerged: false\nauthor: \"maintainer\"\nbase: \"main\"\nhead: \"feature\"\n",
+				"# Add feature\n\nThis adds the feature.\n",
+				"### @reviewer commented on",
+				"LGTM",
+			},
+		},
+		{
+			name:     "pull request diff",
+			resource: GetPullRequestDiffResource,
+			uri:      "pr://owner/repo/7/diff",
+			pr:       mockPR,
+			expectContains: []string{
+				"head_sha: \"abc123\"\n",
+				"\n````diff\n" + mockDiff + "````\n",
+			},
+		},
+		{
+			name:     "pull request files",
+			resource: GetPullRequestFilesResource,
+			uri:      "pr://owner/repo/7/files",
+			pr:       mockPR,
+			expectContains: []string{
+				"| `main.go` | modified | +8 | -3 |\n",
+				"| `old.go → new.go` | renamed | +2 | -0 |\n",
+				"| ``docs/a\\|b`c.md`` | added | +1 | -0 |\n",
+				"\n_2 more files omitted._\n",
+			},
+		},
+		{
+			name:            "lockdown rejects untrusted author",
+			resource:        GetPullRequestDiffResource,
+			uri:             "pr://owner/repo/7/diff",
+			pr:              &github.PullRequest{Number: github.Ptr(7), User: &github.User{Login: github.Ptr("testuser")}},
+			lockdownEnabled: true,
+			expectError:     "access to pull request is restricted by lockdown mode",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposPullsByOwnerByRepoByPullNumber:           pullHandler(tc.pr),
+				GetReposPullsFilesByOwnerByRepoByPullNumber:      mockResponse(t, http.StatusOK, mockFiles),
+				GetReposIssuesCommentsByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusOK, mockComments),
+			}))
+			deps := BaseDeps{
+				Client:          client,
+				RepoAccessCache: repoAccessCache,
+				Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": tc.lockdownEnabled}),
+			}
+			resource := tc.resource(translations.NullTranslationHelper)
+			assert.Equal(t, "pull_requests", string(resource.Toolset.ID))
+			handler := resource.Handler(deps)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &mcp.ReadResourceRequest{
+				Params: &mcp.ReadResourceParams{URI: tc.uri},
+			})
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Contents, 1)
+			assert.Equal(t, "text/markdown", result.Contents[0].MIMEType)
+			for _, s := range tc.expectContains {
+				assert.Contains(t, result.Contents[0].Text, s)
+			}
+		})
+	}
+}
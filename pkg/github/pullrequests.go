@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -57,7 +56,7 @@ Possible options:
 		},
 		Required: []string{"method", "owner", "repo", "pullNumber"},
 	}
-	WithPagination(schema)
+	withMaxItemsMethods(WithPagination(schema), "pull_request_read")
 
 	return NewTool(
 		ToolsetMetadataPullRequests,
@@ -69,6 +68,19 @@ Possible options:
 				ReadOnlyHint: true,
 			},
 			InputSchema: schema,
+			OutputSchema: AnyOfOutputSchema(
+				APIObjectOutputSchema[github.PullRequest]("Pull request as returned by the GitHub REST API (method get)."),
+				OutputSchema[PullRequestDiff](),
+				APIObjectOutputSchema[github.CombinedStatus]("Combined commit status as returned by the GitHub REST API (method get_status)."),
+				APIListOutputSchema[github.CommitFile]("Changed files as returned by the GitHub REST API (method get_files)."),
+				ObjectOutputSchema("Review threads, with reviewThreads, pageInfo and totalCount (method get_review_comments).", map[string]*jsonschema.Schema{
+					"reviewThreads": APIListOutputSchema[reviewThreadNode]("").Properties[structuredItemsKey],
+					"pageInfo":      graphQLPageInfoOutputSchema(),
+					"totalCount":    {Type: "integer"},
+				}),
+				APIListOutputSchema[github.PullRequestReview]("Reviews as returned by the GitHub REST API (method get_reviews)."),
+				APIListOutputSchema[github.IssueComment]("Comments as returned by the GitHub REST API (method get_comments)."),
+			),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -164,29 +176,19 @@ func GetPullRequest(ctx context.Context, client *github.Client, cache *lockdown.
 		}
 	}
 
-	if ff.LockdownMode {
-		if cache == nil {
-			return nil, fmt.Errorf("lockdown cache is not configured")
-		}
-		login := pr.GetUser().GetLogin()
-		if login != "" {
-			isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
-			if err != nil {
-				return nil, fmt.Errorf("failed to check content removal: %w", err)
-			}
-
-			if !isSafeContent {
-				return utils.NewToolResultError("access to pull request is restricted by lockdown mode"), nil
-			}
-		}
+	policy, err := newLockdownPolicy(ff, cache)
+	if err != nil {
+		return nil, err
 	}
-
-	r, err := json.Marshal(pr)
+	isSafeContent, err := policy.isSafe(ctx, authoredContent{login: pr.GetUser().GetLogin(), owner: owner, repo: repo})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return nil, fmt.Errorf("failed to check content removal: %w", err)
+	}
+	if !isSafeContent {
+		return utils.NewToolResultError("access to pull request is restricted by lockdown mode"), nil
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, pr), nil
 }
 
 func GetPullRequestDiff(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
@@ -215,8 +217,15 @@ func GetPullRequestDiff(ctx context.Context, client *github.Client, owner, repo
 
 	defer func() { _ = resp.Body.Close() }()
 
-	// Return the raw response
-	return utils.NewToolResultText(string(raw)), nil
+	// Return the raw diff as text, and wrapped in an object as structured content
+	result := utils.NewToolResultText(string(raw))
+	result.StructuredContent = PullRequestDiff{Diff: string(raw)}
+	return result, nil
+}
+
+// PullRequestDiff is the structured content of the get_diff method of pull_request_read.
+type PullRequestDiff struct {
+	Diff string `json:"diff"`
 }
 
 func GetPullRequestStatus(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
@@ -257,12 +266,7 @@ func GetPullRequestStatus(ctx context.Context, client *github.Client, owner, rep
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get combined status", resp, body), nil
 	}
 
-	r, err := json.Marshal(status)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, status), nil
 }
 
 func GetPullRequestFiles(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, pagination PaginationParams) (*mcp.CallToolResult, error) {
@@ -288,12 +292,7 @@ func GetPullRequestFiles(ctx context.Context, client *github.Client, owner, repo
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get pull request files", resp, body), nil
 	}
 
-	r, err := json.Marshal(files)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, files), nil
 }
 
 // GraphQL types for review threads query
@@ -373,27 +372,19 @@ func GetPullRequestReviewComments(ctx context.Context, gqlClient *githubv4.Clien
 	}
 
 	// Lockdown mode filtering
-	if ff.LockdownMode {
-		if cache == nil {
-			return nil, fmt.Errorf("lockdown cache is not configured")
-		}
-
+	policy, err := newLockdownPolicy(ff, cache)
+	if err != nil {
+		return nil, err
+	}
+	if policy.enabled() {
 		// Iterate through threads and filter comments
 		for i := range query.Repository.PullRequest.ReviewThreads.Nodes {
 			thread := &query.Repository.PullRequest.ReviewThreads.Nodes[i]
-			filteredComments := make([]reviewCommentNode, 0, len(thread.Comments.Nodes))
-
-			for _, comment := range thread.Comments.Nodes {
-				login := string(comment.Author.Login)
-				if login != "" {
-					isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
-					if err != nil {
-						return nil, fmt.Errorf("failed to check lockdown mode: %w", err)
-					}
-					if isSafeContent {
-						filteredComments = append(filteredComments, comment)
-					}
-				}
+			filteredComments, err := filterLockdown(ctx, policy, thread.Comments.Nodes, func(comment reviewCommentNode) authoredContent {
+				return authoredContent{login: string(comment.Author.Login), owner: owner, repo: repo}
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to check lockdown mode: %w", err)
 			}
 
 			thread.Comments.Nodes = filteredComments
@@ -413,12 +404,7 @@ func GetPullRequestReviewComments(ctx context.Context, gqlClient *githubv4.Clien
 		"totalCount": int(query.Repository.PullRequest.ReviewThreads.TotalCount),
 	}
 
-	r, err := json.Marshal(response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, response), nil
 }
 
 func GetPullRequestReviews(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner, repo string, pullNumber int, ff FeatureFlags) (*mcp.CallToolResult, error) {
@@ -440,32 +426,18 @@ func GetPullRequestReviews(ctx context.Context, client *github.Client, cache *lo
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get pull request reviews", resp, body), nil
 	}
 
-	if ff.LockdownMode {
-		if cache == nil {
-			return nil, fmt.Errorf("lockdown cache is not configured")
-		}
-		filteredReviews := make([]*github.PullRequestReview, 0, len(reviews))
-		for _, review := range reviews {
-			login := review.GetUser().GetLogin()
-			if login != "" {
-				isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
-				if err != nil {
-					return nil, fmt.Errorf("failed to check lockdown mode: %w", err)
-				}
-				if isSafeContent {
-					filteredReviews = append(filteredReviews, review)
-				}
-				reviews = filteredReviews
-			}
-		}
+	policy, err := newLockdownPolicy(ff, cache)
+	if err != nil {
+		return nil, err
 	}
-
-	r, err := json.Marshal(reviews)
+	reviews, err = filterLockdown(ctx, policy, reviews, func(review *github.PullRequestReview) authoredContent {
+		return authoredContent{login: review.GetUser().GetLogin(), owner: owner, repo: repo}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return nil, fmt.Errorf("failed to check lockdown mode: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, reviews), nil
 }
 
 // CreatePullRequest creates a tool to create a new pull request.
@@ -518,7 +490,8 @@ func CreatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 				Title:        t("TOOL_CREATE_PULL_REQUEST_USER_TITLE", "Open new pull request"),
 				ReadOnlyHint: false,
 			},
-			InputSchema: schema,
+			InputSchema:  schema,
+			OutputSchema: OutputSchema[MinimalResponse](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -599,12 +572,7 @@ func CreatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 				URL: pr.GetHTMLURL(),
 			}
 
-			r, err := json.Marshal(minimalResponse)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, minimalResponse), nil, nil
 		})
 }
 
@@ -670,7 +638,8 @@ func UpdatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 				Title:        t("TOOL_UPDATE_PULL_REQUEST_USER_TITLE", "Edit pull request"),
 				ReadOnlyHint: false,
 			},
-			InputSchema: schema,
+			InputSchema:  schema,
+			OutputSchema: OutputSchema[MinimalResponse](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -893,12 +862,7 @@ func UpdatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 				URL: finalPR.GetHTMLURL(),
 			}
 
-			r, err := json.Marshal(minimalResponse)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("Failed to marshal response", err), nil, nil
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, minimalResponse), nil, nil
 		})
 }
 
@@ -952,7 +916,8 @@ func ListPullRequests(t translations.TranslationHelperFunc) inventory.ServerTool
 				Title:        t("TOOL_LIST_PULL_REQUESTS_USER_TITLE", "List pull requests"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  WithFields(schema),
+			OutputSchema: APIListOutputSchema[github.PullRequest]("Pull requests as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1023,6 +988,17 @@ func ListPullRequests(t translations.TranslationHelperFunc) inventory.ServerTool
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list pull requests", resp, bodyBytes), nil, nil
 			}
 
+			policy, err := lockdownPolicyFor(deps)
+			if err != nil {
+				return nil, nil, err
+			}
+			prs, err = filterLockdown(ctx, policy, prs, func(pr *github.PullRequest) authoredContent {
+				return authoredContent{login: pr.GetUser().GetLogin(), owner: owner, repo: repo}
+			})
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+
 			// sanitize title/body on each PR
 			for _, pr := range prs {
 				if pr == nil {
@@ -1036,12 +1012,7 @@ func ListPullRequests(t translations.TranslationHelperFunc) inventory.ServerTool
 				}
 			}
 
-			r, err := json.Marshal(prs)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, prs), nil, nil
 		})
 }
 
@@ -1089,7 +1060,8 @@ func MergePullRequest(t translations.TranslationHelperFunc) inventory.ServerTool
 				Title:        t("TOOL_MERGE_PULL_REQUEST_USER_TITLE", "Merge pull request"),
 				ReadOnlyHint: false,
 			},
-			InputSchema: schema,
+			InputSchema:  schema,
+			OutputSchema: APIObjectOutputSchema[github.PullRequestMergeResult]("Merge result as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1145,12 +1117,7 @@ func MergePullRequest(t translations.TranslationHelperFunc) inventory.ServerTool
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to merge pull request", resp, bodyBytes), nil, nil
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, result), nil, nil
 		})
 }
 
@@ -1207,11 +1174,12 @@ func SearchPullRequests(t translations.TranslationHelperFunc) inventory.ServerTo
 				Title:        t("TOOL_SEARCH_PULL_REQUESTS_USER_TITLE", "Search pull requests"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  WithFields(schema),
+			OutputSchema: APIObjectOutputSchema[github.IssuesSearchResult]("Search results as returned by the GitHub REST API, with total_count, incomplete_results and items."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-			result, err := searchHandler(ctx, deps.GetClient, args, "pr", "failed to search pull requests")
+			result, err := searchHandler(ctx, deps, args, "pr", "failed to search pull requests")
 			return result, nil, err
 		})
 }
@@ -1250,7 +1218,8 @@ func UpdatePullRequestBranch(t translations.TranslationHelperFunc) inventory.Ser
 				Title:        t("TOOL_UPDATE_PULL_REQUEST_BRANCH_USER_TITLE", "Update pull request branch"),
 				ReadOnlyHint: false,
 			},
-			InputSchema: schema,
+			InputSchema:  schema,
+			OutputSchema: AnyOfOutputSchema(APIObjectOutputSchema[github.PullRequestBranchUpdateResponse]("Branch update result as returned by the GitHub REST API."), OutputSchema[MessageResponse]()),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1284,7 +1253,7 @@ func UpdatePullRequestBranch(t translations.TranslationHelperFunc) inventory.Ser
 				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
 				// and it's not a real error.
 				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return utils.NewToolResultText("Pull request branch update is in progress"), nil, nil
+					return MessageResult("Pull request branch update is in progress"), nil, nil
 				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to update pull request branch",
@@ -1302,12 +1271,7 @@ func UpdatePullRequestBranch(t translations.TranslationHelperFunc) inventory.Ser
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to update pull request branch", resp, bodyBytes), nil, nil
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, result), nil, nil
 		})
 }
 
@@ -1377,7 +1341,8 @@ Available methods:
 				Title:        t("TOOL_PULL_REQUEST_REVIEW_WRITE_USER_TITLE", "Write operations (create, submit, delete) on pull request reviews."),
 				ReadOnlyHint: false,
 			},
-			InputSchema: schema,
+			InputSchema:  schema,
+			OutputSchema: OutputSchema[MessageResponse](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1461,9 +1426,9 @@ func CreatePullRequestReview(ctx context.Context, client *githubv4.Client, param
 	// In future, we may want to return the review ID, but for the moment, we're not leaking
 	// API implementation details to the LLM.
 	if params.Event == "" {
-		return utils.NewToolResultText("pending pull request created"), nil
+		return MessageResult("pending pull request created"), nil
 	}
-	return utils.NewToolResultText("pull request review submitted successfully"), nil
+	return MessageResult("pull request review submitted successfully"), nil
 }
 
 func SubmitPendingPullRequestReview(ctx context.Context, client *githubv4.Client, params PullRequestReviewWriteParams) (*mcp.CallToolResult, error) {
@@ -1548,7 +1513,7 @@ func SubmitPendingPullRequestReview(ctx context.Context, client *githubv4.Client
 	// Return nothing interesting, just indicate success for the time being.
 	// In future, we may want to return the review ID, but for the moment, we're not leaking
 	// API implementation details to the LLM.
-	return utils.NewToolResultText("pending pull request review successfully submitted"), nil
+	return MessageResult("pending pull request review successfully submitted"), nil
 }
 
 func DeletePendingPullRequestReview(ctx context.Context, client *githubv4.Client, params PullRequestReviewWriteParams) (*mcp.CallToolResult, error) {
@@ -1628,7 +1593,7 @@ func DeletePendingPullRequestReview(ctx context.Context, client *githubv4.Client
 	// Return nothing interesting, just indicate success for the time being.
 	// In future, we may want to return the review ID, but for the moment, we're not leaking
 	// API implementation details to the LLM.
-	return utils.NewToolResultText("pending pull request review successfully deleted"), nil
+	return MessageResult("pending pull request review successfully deleted"), nil
 }
 
 // AddCommentToPendingReview creates a tool to add a comment to a pull request review.
@@ -1701,7 +1666,8 @@ func AddCommentToPendingReview(t translations.TranslationHelperFunc) inventory.S
 				Title:        t("TOOL_ADD_COMMENT_TO_PENDING_REVIEW_USER_TITLE", "Add review comment to the requester's latest pending pull request review"),
 				ReadOnlyHint: false,
 			},
-			InputSchema: schema,
+			InputSchema:  schema,
+			OutputSchema: OutputSchema[MessageResponse](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1817,7 +1783,7 @@ func AddCommentToPendingReview(t translations.TranslationHelperFunc) inventory.S
 			// Return nothing interesting, just indicate success for the time being.
 			// In future, we may want to return the review ID, but for the moment, we're not leaking
 			// API implementation details to the LLM.
-			return utils.NewToolResultText("pull request review comment successfully added to pending review"), nil, nil
+			return MessageResult("pull request review comment successfully added to pending review"), nil, nil
 		})
 }
 
@@ -1854,7 +1820,8 @@ func RequestCopilotReview(t translations.TranslationHelperFunc) inventory.Server
 				Title:        t("TOOL_REQUEST_COPILOT_REVIEW_USER_TITLE", "Request Copilot review"),
 				ReadOnlyHint: false,
 			},
-			InputSchema: schema,
+			InputSchema:  schema,
+			OutputSchema: OutputSchema[MessageResponse](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1906,7 +1873,7 @@ func RequestCopilotReview(t translations.TranslationHelperFunc) inventory.Server
 			}
 
 			// Return nothing on success, as there's not much value in returning the Pull Request itself
-			return utils.NewToolResultText(""), nil, nil
+			return MessageResult(""), nil, nil
 		})
 }
 
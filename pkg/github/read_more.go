@@ -0,0 +1,311 @@
+package github
+
+import (
+	"container/list"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// readMoreMetaKey is the _meta key under which truncated results return their continuation.
+	readMoreMetaKey = "read_more"
+
+	// bytesPerWindowLine bounds the size of a content window in bytes. The window is measured in
+	// lines, as for job logs, but a result can also be a single long line of JSON.
+	bytesPerWindowLine = 100
+
+	readMoreTTL = 30 * time.Minute
+	// readMoreMaxEntries and readMoreMaxBytes bound the memory used by truncated results. The
+	// least recently used results are evicted first.
+	readMoreMaxEntries = 256
+	readMoreMaxBytes   = 64 << 20
+)
+
+// readMoreCache keeps the full text of truncated results, so that read_more can return the rest.
+var readMoreCache = newReadMoreStore(readMoreMaxEntries, readMoreMaxBytes, readMoreTTL)
+
+// readMoreStore is a size-bounded LRU cache of truncated results. Results are keyed by the MCP
+// session they were returned in, so that one session cannot read another's results.
+type readMoreStore struct {
+	maxEntries int
+	maxBytes   int
+	ttl        time.Duration
+
+	mu      sync.Mutex
+	order   *list.List // of *readMoreEntry, most recently used first
+	entries map[readMoreKey]*list.Element
+	bytes   int
+}
+
+type readMoreKey struct {
+	session string
+	id      string
+}
+
+type readMoreEntry struct {
+	key     readMoreKey
+	text    string
+	expires time.Time
+}
+
+func newReadMoreStore(maxEntries, maxBytes int, ttl time.Duration) *readMoreStore {
+	return &readMoreStore{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		ttl:        ttl,
+		order:      list.New(),
+		entries:    map[readMoreKey]*list.Element{},
+	}
+}
+
+// add stores the text of a truncated result. Texts larger than the whole cache are not kept.
+func (s *readMoreStore) add(session, id, text string) {
+	if len(text) > s.maxBytes {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := readMoreKey{session: session, id: id}
+	if element, ok := s.entries[key]; ok {
+		s.remove(element)
+	}
+	s.entries[key] = s.order.PushFront(&readMoreEntry{key: key, text: text, expires: time.Now().Add(s.ttl)})
+	s.bytes += len(text)
+
+	for s.order.Len() > s.maxEntries || s.bytes > s.maxBytes {
+		s.remove(s.order.Back())
+	}
+}
+
+// get returns the text of a truncated result of session, if it has not expired or been evicted.
+func (s *readMoreStore) get(session, id string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[readMoreKey{session: session, id: id}]
+	if !ok {
+		return "", false
+	}
+	entry := element.Value.(*readMoreEntry)
+	if time.Now().After(entry.expires) {
+		s.remove(element)
+		return "", false
+	}
+	s.order.MoveToFront(element)
+	return entry.text, true
+}
+
+func (s *readMoreStore) remove(element *list.Element) {
+	entry := s.order.Remove(element).(*readMoreEntry)
+	delete(s.entries, entry.key)
+	s.bytes -= len(entry.text)
+}
+
+// sessionID returns the ID of the MCP session of a tool call. It is empty for stdio sessions and
+// for calls made outside a session, such as in tests.
+func sessionID(req *mcp.CallToolRequest) string {
+	if req == nil || req.Session == nil {
+		return ""
+	}
+	return req.Session.ID()
+}
+
+// readMoreHandle is the decoded form of the continuation argument of read_more.
+type readMoreHandle struct {
+	ID     string `json:"i"`
+	Offset int    `json:"o"`
+}
+
+func (h readMoreHandle) encode() string {
+	data, _ := json.Marshal(h)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeReadMoreHandle(s string) (readMoreHandle, error) {
+	var handle readMoreHandle
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(data, &handle)
+	}
+	if err != nil || handle.ID == "" || handle.Offset < 0 {
+		return readMoreHandle{}, fmt.Errorf("invalid continuation")
+	}
+	return handle, nil
+}
+
+// cutText returns the length of the longest prefix of text that fits in a content window of
+// window lines. The prefix ends on the coarsest boundary that keeps at least half of the
+// window: the start of a file in a diff, the start of a hunk, or the end of a line.
+func cutText(text string, window int) int {
+	limit := min(len(text), window*bytesPerWindowLine)
+	for i, lines := 0, 0; i < limit; i++ {
+		next := strings.IndexByte(text[i:limit], '\n')
+		if next < 0 {
+			break
+		}
+		i += next
+		if lines++; lines == window {
+			limit = i + 1
+			break
+		}
+	}
+	if limit == len(text) {
+		return limit
+	}
+
+	chunk := text[:limit]
+	for _, boundary := range []string{"\ndiff --git ", "\n@@ ", "\n"} {
+		if i := strings.LastIndex(chunk, boundary); i >= 0 && i+1 >= limit/2 {
+			return i + 1
+		}
+	}
+	for limit > 0 && !utf8.RuneStart(text[limit]) {
+		limit--
+	}
+	return limit
+}
+
+// readMoreNote tells the model how to fetch the rest of a text truncated at offset.
+func readMoreNote(offset, total int, handle string) *mcp.TextContent {
+	return &mcp.TextContent{
+		Text: fmt.Sprintf("The result was truncated to fit the content window after %d of %d bytes. To read the rest, call read_more with continuation set to %q.", offset, total, handle),
+	}
+}
+
+// truncateResult cuts the text of every content of result that does not fit in the content
+// window, and keeps the full text for read_more in the session of req. Only the content is
+// truncated: structuredContent keeps the full value so that it always matches the tool's output
+// schema.
+func truncateResult(deps ToolDependencies, req *mcp.CallToolRequest, result *mcp.CallToolResult) *mcp.CallToolResult {
+	window := deps.GetContentWindowSize()
+	if window <= 0 || result == nil || result.IsError {
+		return result
+	}
+
+	var notes []mcp.Content
+	for _, content := range result.Content {
+		var text *string
+		switch c := content.(type) {
+		case *mcp.TextContent:
+			text = &c.Text
+		case *mcp.EmbeddedResource:
+			if c.Resource != nil && c.Resource.Text != "" {
+				text = &c.Resource.Text
+			}
+		}
+		if text == nil {
+			continue
+		}
+
+		cut := cutText(*text, window)
+		if cut == len(*text) {
+			continue
+		}
+
+		id := make([]byte, 16)
+		_, _ = rand.Read(id)
+		handle := readMoreHandle{ID: hex.EncodeToString(id), Offset: cut}
+		readMoreCache.add(sessionID(req), handle.ID, *text)
+
+		token := handle.encode()
+		notes = append(notes, readMoreNote(cut, len(*text), token))
+		if _, ok := result.Meta[readMoreMetaKey]; !ok {
+			if result.Meta == nil {
+				result.Meta = mcp.Meta{}
+			}
+			result.Meta[readMoreMetaKey] = token
+		}
+		*text = (*text)[:cut]
+	}
+	result.Content = append(result.Content, notes...)
+	return result
+}
+
+// ReadMoreResponse is the structured content of read_more.
+type ReadMoreResponse struct {
+	Content      string `json:"content"`
+	Offset       int    `json:"offset"`
+	Total        int    `json:"total"`
+	Continuation string `json:"continuation,omitempty"`
+}
+
+// ReadMore creates a tool that returns the next chunk of a result that was truncated to fit the
+// content window.
+func ReadMore(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataContext,
+		mcp.Tool{
+			Name:        "read_more",
+			Description: t("TOOL_READ_MORE_DESCRIPTION", "Read the next part of a tool result that was truncated to fit the content window. Use the continuation returned with the truncated result."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_READ_MORE_USER_TITLE", "Read more of a truncated result"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"continuation": {
+						Type:        "string",
+						Description: "Continuation returned with a truncated result, or by a previous call to read_more",
+					},
+				},
+				Required: []string{"continuation"},
+			},
+			OutputSchema: OutputSchema[ReadMoreResponse](),
+		},
+		nil,
+		func(_ context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			continuation, err := RequiredParam[string](args, "continuation")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			handle, err := decodeReadMoreHandle(continuation)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			text, ok := readMoreCache.get(sessionID(req), handle.ID)
+			if !ok {
+				return utils.NewToolResultError("the truncated result has expired, call the original tool again"), nil, nil
+			}
+			if handle.Offset > len(text) {
+				return utils.NewToolResultError("invalid continuation"), nil, nil
+			}
+
+			rest := text[handle.Offset:]
+			cut := len(rest)
+			if window := deps.GetContentWindowSize(); window > 0 {
+				cut = cutText(rest, window)
+			}
+
+			response := ReadMoreResponse{
+				Content: rest[:cut],
+				Offset:  handle.Offset,
+				Total:   len(text),
+			}
+			result := utils.NewToolResultText(response.Content)
+			if end := handle.Offset + cut; end < len(text) {
+				response.Continuation = readMoreHandle{ID: handle.ID, Offset: end}.encode()
+				result.Meta = mcp.Meta{readMoreMetaKey: response.Continuation}
+				result.Content = append(result.Content, readMoreNote(end, len(text), response.Continuation))
+			}
+			result.StructuredContent = response
+			return result, nil, nil
+		},
+	)
+}
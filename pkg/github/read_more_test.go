@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CutText(t *testing.T) {
+	diff := "diff --git a/a.go b/a.go\n@@ -1,2 +1,2 @@\n-a\n+b\n@@ -10,2 +10,2 @@\n-c\n+d\ndiff --git a/b.go b/b.go\n@@ -1 +1 @@\n-e\n+f\n"
+
+	tests := []struct {
+		name     string
+		text     string
+		window   int
+		expected string
+	}{
+		{
+			name:     "fits",
+			text:     "one\ntwo\n",
+			window:   2,
+			expected: "one\ntwo\n",
+		},
+		{
+			name:     "cut after the last whole line",
+			text:     "one\ntwo\nthree\n",
+			window:   2,
+			expected: "one\ntwo\n",
+		},
+		{
+			name:     "cut before the next file of a diff",
+			text:     diff,
+			window:   9,
+			expected: "diff --git a/a.go b/a.go\n@@ -1,2 +1,2 @@\n-a\n+b\n@@ -10,2 +10,2 @@\n-c\n+d\n",
+		},
+		{
+			name:     "cut before the next hunk of a diff",
+			text:     diff,
+			window:   6,
+			expected: "diff --git a/a.go b/a.go\n@@ -1,2 +1,2 @@\n-a\n+b\n",
+		},
+		{
+			name:     "long line is cut on a character boundary",
+			text:     strings.Repeat("é", 100),
+			window:   1,
+			expected: strings.Repeat("é", 50),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.text[:cutText(tc.text, tc.window)])
+		})
+	}
+}
+
+func Test_ReadMore(t *testing.T) {
+	serverTool := ReadMore(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+	assert.True(t, tool.Annotations.ReadOnlyHint, "read_more tool should be read-only")
+
+	var lines []string
+	for i := range 25 {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	full := strings.Join(lines, "\n")
+
+	// A tool returning more lines than the content window
+	large := NewToolFromHandler(ToolsetMetadataRepos, mcp.Tool{
+		Name:        "get_large",
+		InputSchema: &jsonschema.Schema{Type: "object", Properties: map[string]*jsonschema.Schema{}},
+	}, nil, func(_ context.Context, _ ToolDependencies, _ *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return utils.NewToolResultText(full), nil
+	})
+
+	deps := stubDeps{contentWindowSize: 10}
+	ctx := ContextWithDeps(context.Background(), deps)
+
+	request := createMCPRequest(map[string]any{})
+	result, err := large.Handler(deps)(ctx, &request)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	read := result.Content[0].(*mcp.TextContent).Text
+	assert.Equal(t, strings.Join(lines[:10], "\n")+"\n", read)
+	continuation, ok := result.Meta[readMoreMetaKey].(string)
+	require.True(t, ok, "expected a continuation")
+	assert.Contains(t, result.Content[1].(*mcp.TextContent).Text, continuation)
+
+	// read_more returns the rest one window at a time
+	calls := 0
+	for continuation != "" {
+		calls++
+		request = createMCPRequest(map[string]any{"continuation": continuation})
+		result, err = serverTool.Handler(deps)(ctx, &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assertStructuredContent(t, tool, result)
+
+		response := result.StructuredContent.(ReadMoreResponse)
+		assert.Equal(t, len(read), response.Offset)
+		assert.Equal(t, len(full), response.Total)
+		assert.Equal(t, response.Content, result.Content[0].(*mcp.TextContent).Text)
+		read += response.Content
+		continuation = response.Continuation
+	}
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, full, read)
+	assert.Len(t, result.Content, 1)
+
+	// Invalid and unknown continuations are reported
+	for _, tc := range []struct {
+		continuation string
+		expectedErr  string
+	}{
+		{continuation: "not a continuation", expectedErr: "invalid continuation"},
+		{continuation: readMoreHandle{ID: "unknown"}.encode(), expectedErr: "the truncated result has expired"},
+	} {
+		request = createMCPRequest(map[string]any{"continuation": tc.continuation})
+		result, err = serverTool.Handler(deps)(ctx, &request)
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErr)
+	}
+}
+
+func Test_TruncateResultWithoutWindow(t *testing.T) {
+	text := strings.Repeat("line\n", 100)
+	result := truncateResult(stubDeps{}, nil, utils.NewToolResultText(text))
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, text, getTextResult(t, result).Text)
+	assert.Nil(t, result.Meta)
+}
+
+func Test_ReadMoreStore(t *testing.T) {
+	t.Run("results are kept per session", func(t *testing.T) {
+		store := newReadMoreStore(10, 1000, time.Minute)
+		store.add("session-1", "id", "text")
+
+		text, ok := store.get("session-1", "id")
+		assert.True(t, ok)
+		assert.Equal(t, "text", text)
+		_, ok = store.get("session-2", "id")
+		assert.False(t, ok)
+	})
+
+	t.Run("least recently used results are evicted past the entry limit", func(t *testing.T) {
+		store := newReadMoreStore(2, 1000, time.Minute)
+		store.add("", "a", "a")
+		store.add("", "b", "b")
+		_, _ = store.get("", "a")
+		store.add("", "c", "c")
+
+		_, ok := store.get("", "b")
+		assert.False(t, ok)
+		_, ok = store.get("", "a")
+		assert.True(t, ok)
+		_, ok = store.get("", "c")
+		assert.True(t, ok)
+	})
+
+	t.Run("results are evicted past the byte limit", func(t *testing.T) {
+		store := newReadMoreStore(10, 10, time.Minute)
+		store.add("", "a", "123456")
+		store.add("", "b", "123456")
+		store.add("", "large", "12345678901")
+
+		_, ok := store.get("", "a")
+		assert.False(t, ok)
+		_, ok = store.get("", "b")
+		assert.True(t, ok)
+		_, ok = store.get("", "large")
+		assert.False(t, ok, "results larger than the cache are not kept")
+		assert.Equal(t, 6, store.bytes)
+	})
+
+	t.Run("results expire", func(t *testing.T) {
+		store := newReadMoreStore(10, 1000, -time.Second)
+		store.add("", "a", "a")
+		_, ok := store.get("", "a")
+		assert.False(t, ok)
+		assert.Equal(t, 0, store.order.Len())
+	})
+}
@@ -30,7 +30,7 @@ func GetCommit(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_GET_COMMITS_USER_TITLE", "Get commit details"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: withoutMaxItems(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -52,7 +52,8 @@ func GetCommit(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 				},
 				Required: []string{"owner", "repo", "sha"},
-			}),
+			})),
+			OutputSchema: OutputSchema[MinimalCommit](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -104,15 +105,22 @@ func GetCommit(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get commit", resp, body), nil, nil
 			}
 
-			// Convert to minimal commit
-			minimalCommit := convertToMinimalCommit(commit, includeDiff)
-
-			r, err := json.Marshal(minimalCommit)
+			policy, err := lockdownPolicyFor(deps)
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				return nil, nil, err
 			}
+			isSafeContent, err := policy.isSafe(ctx, commitContent(commit, owner, repo))
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+			if !isSafeContent {
+				return utils.NewToolResultError("access to commit details is restricted by lockdown mode"), nil, nil
+			}
+
+			// Convert to minimal commit
+			minimalCommit := convertToMinimalCommit(commit, includeDiff)
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, minimalCommit), nil, nil
 		},
 	)
 }
@@ -128,7 +136,7 @@ func ListCommits(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_LIST_COMMITS_USER_TITLE", "List commits"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithFields(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -149,7 +157,8 @@ func ListCommits(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 				},
 				Required: []string{"owner", "repo"},
-			}),
+			})),
+			OutputSchema: OutputSchema[[]MinimalCommit](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -209,18 +218,24 @@ func ListCommits(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list commits", resp, body), nil, nil
 			}
 
+			policy, err := lockdownPolicyFor(deps)
+			if err != nil {
+				return nil, nil, err
+			}
+			commits, err = filterLockdown(ctx, policy, commits, func(commit *github.RepositoryCommit) authoredContent {
+				return commitContent(commit, owner, repo)
+			})
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+
 			// Convert to minimal commits
 			minimalCommits := make([]MinimalCommit, len(commits))
 			for i, commit := range commits {
 				minimalCommits[i] = convertToMinimalCommit(commit, false)
 			}
 
-			r, err := json.Marshal(minimalCommits)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, minimalCommits), nil, nil
 		},
 	)
 }
@@ -236,7 +251,7 @@ func ListBranches(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_LIST_BRANCHES_USER_TITLE", "List branches"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithFields(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -249,7 +264,8 @@ func ListBranches(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 				},
 				Required: []string{"owner", "repo"},
-			}),
+			})),
+			OutputSchema: OutputSchema[[]MinimalBranch](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -302,12 +318,7 @@ func ListBranches(t translations.TranslationHelperFunc) inventory.ServerTool {
 				minimalBranches = append(minimalBranches, convertToMinimalBranch(branch))
 			}
 
-			r, err := json.Marshal(minimalBranches)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, minimalBranches), nil, nil
 		},
 	)
 }
@@ -364,6 +375,7 @@ If the SHA is not provided, the tool will attempt to acquire it by fetching the
 				},
 				Required: []string{"owner", "repo", "path", "content", "message", "branch"},
 			},
+			OutputSchema: APIObjectOutputSchema[github.RepositoryContentResponse]("File content and commit as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -495,7 +507,7 @@ If the SHA is not provided, the tool will attempt to acquire it by fetching the
 
 			// Warn if file was updated without SHA validation (blind update)
 			if sha == "" && previousSHA != "" {
-				return utils.NewToolResultText(fmt.Sprintf(
+				result := utils.NewToolResultText(fmt.Sprintf(
 					"Warning: File updated without SHA validation. Previous file SHA was %s. "+
 						`Verify no unintended changes were overwritten: 
 1. Extract the SHA of the local version using git ls-tree HEAD %s.
@@ -503,10 +515,12 @@ If the SHA is not provided, the tool will attempt to acquire it by fetching the
 3. Revert changes if shas do not match.
 
 %s`,
-					previousSHA, path, string(r))), nil, nil
+					previousSHA, path, string(r)))
+				result.StructuredContent = json.RawMessage(r)
+				return result, nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, fileContent), nil, nil
 		},
 	)
 }
@@ -548,6 +562,7 @@ func CreateRepository(t translations.TranslationHelperFunc) inventory.ServerTool
 				},
 				Required: []string{"name"},
 			},
+			OutputSchema: OutputSchema[MinimalResponse](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -607,12 +622,7 @@ func CreateRepository(t translations.TranslationHelperFunc) inventory.ServerTool
 				URL: createdRepo.GetHTMLURL(),
 			}
 
-			r, err := json.Marshal(minimalResponse)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, minimalResponse), nil, nil
 		},
 	)
 }
@@ -797,11 +807,7 @@ func GetFileContents(t translations.TranslationHelperFunc) inventory.ServerTool
 				return matchFiles(ctx, client, owner, repo, ref, path, rawOpts, resp.StatusCode)
 			} else if dirContent != nil {
 				// file content or file SHA is nil which means it's a directory
-				r, err := json.Marshal(dirContent)
-				if err != nil {
-					return utils.NewToolResultError("failed to marshal response"), nil, nil
-				}
-				return utils.NewToolResultText(string(r)), nil, nil
+				return MarshalledTextResult(ctx, dirContent), nil, nil
 			}
 
 			return utils.NewToolResultError("failed to get file contents"), nil, nil
@@ -839,6 +845,7 @@ func ForkRepository(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner", "repo"},
 			},
+			OutputSchema: AnyOfOutputSchema(OutputSchema[MinimalResponse](), OutputSchema[MessageResponse]()),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -869,7 +876,7 @@ func ForkRepository(t translations.TranslationHelperFunc) inventory.ServerTool {
 				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
 				// and it's not a real error.
 				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return utils.NewToolResultText("Fork is in progress"), nil, nil
+					return MessageResult("Fork is in progress"), nil, nil
 				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to fork repository",
@@ -893,12 +900,7 @@ func ForkRepository(t translations.TranslationHelperFunc) inventory.ServerTool {
 				URL: forkedRepo.GetHTMLURL(),
 			}
 
-			r, err := json.Marshal(minimalResponse)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, minimalResponse), nil, nil
 		},
 	)
 }
@@ -946,6 +948,10 @@ func DeleteFile(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner", "repo", "path", "message", "branch"},
 			},
+			OutputSchema: ObjectOutputSchema("The commit that deleted the file, with a null content like the GitHub REST API returns.", map[string]*jsonschema.Schema{
+				"commit":  APIObjectOutputSchema[github.Commit]("Commit as returned by the GitHub REST API."),
+				"content": {Type: "null"},
+			}),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1083,12 +1089,7 @@ func DeleteFile(t translations.TranslationHelperFunc) inventory.ServerTool {
 				"content": nil,
 			}
 
-			r, err := json.Marshal(response)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, response), nil, nil
 		},
 	)
 }
@@ -1126,6 +1127,7 @@ func CreateBranch(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner", "repo", "branch"},
 			},
+			OutputSchema: APIObjectOutputSchema[github.Reference]("The created Git reference as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1196,12 +1198,7 @@ func CreateBranch(t translations.TranslationHelperFunc) inventory.ServerTool {
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(createdRef)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, createdRef), nil, nil
 		},
 	)
 }
@@ -1257,6 +1254,7 @@ func PushFiles(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner", "repo", "branch", "files", "message"},
 			},
+			OutputSchema: APIObjectOutputSchema[github.Reference]("The updated Git reference as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1430,12 +1428,7 @@ func PushFiles(t translations.TranslationHelperFunc) inventory.ServerTool {
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(updatedRef)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, updatedRef), nil, nil
 		},
 	)
 }
@@ -1451,7 +1444,7 @@ func ListTags(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_LIST_TAGS_USER_TITLE", "List tags"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithFields(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -1464,7 +1457,8 @@ func ListTags(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 				},
 				Required: []string{"owner", "repo"},
-			}),
+			})),
+			OutputSchema: APIListOutputSchema[github.RepositoryTag]("Tags as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1509,12 +1503,7 @@ func ListTags(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list tags", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(tags)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, tags), nil, nil
 		},
 	)
 }
@@ -1530,7 +1519,7 @@ func GetTag(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_GET_TAG_USER_TITLE", "Get tag details"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFields(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -1547,7 +1536,8 @@ func GetTag(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 				},
 				Required: []string{"owner", "repo", "tag"},
-			},
+			}),
+			OutputSchema: APIObjectOutputSchema[github.Tag]("Annotated tag object as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1607,12 +1597,8 @@ func GetTag(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get tag object", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(tagObj)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			// Lockdown mode returns tags unfiltered: only users with push access can create them
+			return MarshalledTextResult(ctx, tagObj), nil, nil
 		},
 	)
 }
@@ -1628,7 +1614,7 @@ func ListReleases(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_LIST_RELEASES_USER_TITLE", "List releases"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithFields(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -1641,7 +1627,8 @@ func ListReleases(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 				},
 				Required: []string{"owner", "repo"},
-			}),
+			})),
+			OutputSchema: APIListOutputSchema[github.RepositoryRelease]("Releases as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1682,12 +1669,18 @@ func ListReleases(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list releases", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(releases)
+			policy, err := lockdownPolicyFor(deps)
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				return nil, nil, err
+			}
+			releases, err = filterLockdown(ctx, policy, releases, func(release *github.RepositoryRelease) authoredContent {
+				return authoredContent{login: release.GetAuthor().GetLogin(), owner: owner, repo: repo}
+			})
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, releases), nil, nil
 		},
 	)
 }
@@ -1703,7 +1696,7 @@ func GetLatestRelease(t translations.TranslationHelperFunc) inventory.ServerTool
 				Title:        t("TOOL_GET_LATEST_RELEASE_USER_TITLE", "Get latest release"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFields(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -1716,7 +1709,8 @@ func GetLatestRelease(t translations.TranslationHelperFunc) inventory.ServerTool
 					},
 				},
 				Required: []string{"owner", "repo"},
-			},
+			}),
+			OutputSchema: APIObjectOutputSchema[github.RepositoryRelease]("Release as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1748,12 +1742,19 @@ func GetLatestRelease(t translations.TranslationHelperFunc) inventory.ServerTool
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get latest release", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(release)
+			policy, err := lockdownPolicyFor(deps)
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				return nil, nil, err
+			}
+			isSafeContent, err := policy.isSafe(ctx, authoredContent{login: release.GetAuthor().GetLogin(), owner: owner, repo: repo})
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+			if !isSafeContent {
+				return utils.NewToolResultError("access to release details is restricted by lockdown mode"), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, release), nil, nil
 		},
 	)
 }
@@ -1768,7 +1769,7 @@ func GetReleaseByTag(t translations.TranslationHelperFunc) inventory.ServerTool
 				Title:        t("TOOL_GET_RELEASE_BY_TAG_USER_TITLE", "Get a release by tag name"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFields(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -1785,7 +1786,8 @@ func GetReleaseByTag(t translations.TranslationHelperFunc) inventory.ServerTool
 					},
 				},
 				Required: []string{"owner", "repo", "tag"},
-			},
+			}),
+			OutputSchema: APIObjectOutputSchema[github.RepositoryRelease]("Release as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1825,12 +1827,19 @@ func GetReleaseByTag(t translations.TranslationHelperFunc) inventory.ServerTool
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get release by tag", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(release)
+			policy, err := lockdownPolicyFor(deps)
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				return nil, nil, err
+			}
+			isSafeContent, err := policy.isSafe(ctx, authoredContent{login: release.GetAuthor().GetLogin(), owner: owner, repo: repo})
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+			if !isSafeContent {
+				return utils.NewToolResultError("access to release details is restricted by lockdown mode"), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, release), nil, nil
 		},
 	)
 }
@@ -1846,7 +1855,7 @@ func ListStarredRepositories(t translations.TranslationHelperFunc) inventory.Ser
 				Title:        t("TOOL_LIST_STARRED_REPOSITORIES_USER_TITLE", "List starred repositories"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithFields(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"username": {
@@ -1864,7 +1873,8 @@ func ListStarredRepositories(t translations.TranslationHelperFunc) inventory.Ser
 						Enum:        []any{"asc", "desc"},
 					},
 				},
-			}),
+			})),
+			OutputSchema: OutputSchema[[]MinimalRepository](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1957,12 +1967,7 @@ func ListStarredRepositories(t translations.TranslationHelperFunc) inventory.Ser
 				minimalRepos = append(minimalRepos, minimalRepo)
 			}
 
-			r, err := json.Marshal(minimalRepos)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal starred repositories: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, minimalRepos), nil, nil
 		},
 	)
 }
@@ -1993,6 +1998,7 @@ func StarRepository(t translations.TranslationHelperFunc) inventory.ServerTool {
 				},
 				Required: []string{"owner", "repo"},
 			},
+			OutputSchema: OutputSchema[MessageResponse](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -2028,7 +2034,7 @@ func StarRepository(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to star repository", resp, body), nil, nil
 			}
 
-			return utils.NewToolResultText(fmt.Sprintf("Successfully starred repository %s/%s", owner, repo)), nil, nil
+			return MessageResult(fmt.Sprintf("Successfully starred repository %s/%s", owner, repo)), nil, nil
 		},
 	)
 }
@@ -2058,6 +2064,7 @@ func UnstarRepository(t translations.TranslationHelperFunc) inventory.ServerTool
 				},
 				Required: []string{"owner", "repo"},
 			},
+			OutputSchema: OutputSchema[MessageResponse](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -2093,7 +2100,7 @@ func UnstarRepository(t translations.TranslationHelperFunc) inventory.ServerTool
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to unstar repository", resp, body), nil, nil
 			}
 
-			return utils.NewToolResultText(fmt.Sprintf("Successfully unstarred repository %s/%s", owner, repo)), nil, nil
+			return MessageResult(fmt.Sprintf("Successfully unstarred repository %s/%s", owner, repo)), nil, nil
 		},
 	)
 }
@@ -1011,6 +1011,7 @@ func Test_ListCommits(t *testing.T) {
 
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
+			assertStructuredContent(t, tool, result)
 
 			// Unmarshal and verify the result
 			var returnedCommits []MinimalCommit
@@ -2430,6 +2431,7 @@ func Test_ListBranches(t *testing.T) {
 
 			textContent := getTextResult(t, result)
 			require.NotEmpty(t, textContent.Text)
+			assertStructuredContent(t, tool, result)
 
 			// Verify response
 			var branches []*github.Branch
@@ -3841,6 +3843,7 @@ func Test_ListStarredRepositories(t *testing.T) {
 
 				// Parse the result and get the text content
 				textContent := getTextResult(t, result)
+				assertStructuredContent(t, tool, result)
 
 				// Unmarshal and verify the result
 				var returnedRepos []MinimalRepository
@@ -3942,6 +3945,7 @@ func Test_StarRepository(t *testing.T) {
 
 				// Parse the result and get the text content
 				textContent := getTextResult(t, result)
+				assertStructuredContent(t, tool, result)
 				assert.Contains(t, textContent.Text, "Successfully starred repository")
 			}
 		})
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/go-github/v79/github"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
 )
 
 // CompleteHandler defines function signature for completion handlers
@@ -24,8 +25,35 @@ var RepositoryResourceArgumentResolvers = map[string]CompleteHandler{
 	"path":     completePath,
 }
 
+// IssueResourceArgumentResolvers is a map of argument names to their completion handlers for issue resources
+var IssueResourceArgumentResolvers = map[string]CompleteHandler{
+	"owner":  completeOwner,
+	"repo":   completeRepo,
+	"number": completeIssueNumber,
+}
+
+// PullRequestResourceArgumentResolvers is a map of argument names to their completion handlers for pull request resources
+var PullRequestResourceArgumentResolvers = map[string]CompleteHandler{
+	"owner":  completeOwner,
+	"repo":   completeRepo,
+	"number": completePRNumber,
+}
+
+// DiscussionResourceArgumentResolvers is a map of argument names to their completion handlers for discussion resources
+var DiscussionResourceArgumentResolvers = map[string]CompleteHandler{
+	"owner":  completeOwner,
+	"repo":   completeRepo,
+	"number": completeDiscussionNumber,
+}
+
 // RepositoryResourceCompletionHandler returns a CompletionHandlerFunc for repository resource completions.
 func RepositoryResourceCompletionHandler(getClient GetClientFn) func(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	return ResourceCompletionHandler(getClient, RepositoryResourceArgumentResolvers)
+}
+
+// ResourceCompletionHandler returns a CompletionHandlerFunc that completes resource template
+// arguments using the given argument resolvers.
+func ResourceCompletionHandler(getClient GetClientFn, resolvers map[string]CompleteHandler) func(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
 	return func(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
 		if req.Params.Ref.Type != "ref/resource" {
 			return nil, nil // Not a resource completion
@@ -45,9 +73,6 @@ func RepositoryResourceCompletionHandler(getClient GetClientFn) func(ctx context
 			return nil, err
 		}
 
-		// Argument resolver functions
-		resolvers := RepositoryResourceArgumentResolvers
-
 		resolver, ok := resolvers[argName]
 		if !ok {
 			return nil, errors.New("no resolver for argument: " + argName)
@@ -231,6 +256,74 @@ func completePRNumber(ctx context.Context, client *github.Client, resolved map[s
 	return values, nil
 }
 
+func completeIssueNumber(ctx context.Context, client *github.Client, resolved map[string]string, argValue string) ([]string, error) {
+	var values []string
+	owner := resolved["owner"]
+	repo := resolved["repo"]
+	if owner == "" || repo == "" {
+		return values, errors.New("owner or repo not specified")
+	}
+
+	issues, _, err := client.Search.Issues(ctx, fmt.Sprintf("repo:%s/%s is:open is:issue", owner, repo), &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100}})
+	if err != nil {
+		return values, err
+	}
+	for _, issue := range issues.Issues {
+		num := fmt.Sprintf("%d", issue.GetNumber())
+		if argValue == "" || strings.HasPrefix(num, argValue) {
+			values = append(values, num)
+		}
+	}
+	if len(values) > 100 {
+		values = values[:100]
+	}
+	return values, nil
+}
+
+// completeDiscussionNumber completes discussion numbers. Discussions are only available through
+// the GraphQL API, so the GraphQL client is taken from the ToolDependencies in the context.
+func completeDiscussionNumber(ctx context.Context, _ *github.Client, resolved map[string]string, argValue string) ([]string, error) {
+	var values []string
+	owner := resolved["owner"]
+	repo := resolved["repo"]
+	if owner == "" || repo == "" {
+		return values, errors.New("owner or repo not specified")
+	}
+
+	deps, ok := DepsFromContext(ctx)
+	if !ok {
+		return values, ErrDepsNotInContext
+	}
+	gqlClient, err := deps.GetGQLClient(ctx)
+	if err != nil {
+		return values, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+	}
+
+	var q struct {
+		Repository struct {
+			Discussions struct {
+				Nodes []struct {
+					Number githubv4.Int
+				}
+			} `graphql:"discussions(first: 100, orderBy: {field: UPDATED_AT, direction: DESC})"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+	}
+	if err := gqlClient.Query(ctx, &q, vars); err != nil {
+		return values, err
+	}
+	for _, d := range q.Repository.Discussions.Nodes {
+		num := fmt.Sprintf("%d", d.Number)
+		if argValue == "" || strings.HasPrefix(num, argValue) {
+			values = append(values, num)
+		}
+	}
+	return values, nil
+}
+
 func completePath(ctx context.Context, client *github.Client, resolved map[string]string, argValue string) ([]string, error) {
 	owner := resolved["owner"]
 	repo := resolved["repo"]
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/google/go-github/v79/github"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -370,3 +374,93 @@ func TestRepositoryResourceCompletionHandler_NilContext(t *testing.T) {
 	// Restore original resolver
 	RepositoryResourceArgumentResolvers["repo"] = originalResolver
 }
+
+func TestCompleteNumber_MissingDependencies(t *testing.T) {
+	ctx := t.Context()
+
+	resolved := map[string]string{"repo": "testrepo"}
+	result, err := completeIssueNumber(ctx, nil, resolved, "1")
+	require.Error(t, err)
+	assert.Nil(t, result)
+
+	resolved = map[string]string{"owner": "testowner"}
+	result, err = completeDiscussionNumber(ctx, nil, resolved, "1")
+	require.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestCompleteDiscussionNumber(t *testing.T) {
+	var q struct {
+		Repository struct {
+			Discussions struct {
+				Nodes []struct {
+					Number githubv4.Int
+				}
+			} `graphql:"discussions(first: 100, orderBy: {field: UPDATED_AT, direction: DESC})"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	matcher := githubv4mock.NewQueryMatcher(q, map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+	}, githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{"discussions": map[string]any{"nodes": []map[string]any{
+			{"number": 12}, {"number": 3}, {"number": 15},
+		}}},
+	}))
+	deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))}
+	ctx := ContextWithDeps(t.Context(), deps)
+
+	result, err := completeDiscussionNumber(ctx, nil, map[string]string{"owner": "owner", "repo": "repo"}, "1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"12", "15"}, result)
+
+	// Without deps in the context the GraphQL client is unavailable
+	_, err = completeDiscussionNumber(t.Context(), nil, map[string]string{"owner": "owner", "repo": "repo"}, "")
+	require.ErrorIs(t, err, ErrDepsNotInContext)
+}
+
+func TestCompletionsHandler_ResourceRouting(t *testing.T) {
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetSearchIssues: func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query().Get("q")
+			number := 1
+			if strings.Contains(q, "is:pr") {
+				number = 2
+			}
+			mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+				Issues: []*github.Issue{{Number: github.Ptr(number)}},
+			})(w, r)
+		},
+	})
+	getClient := func(_ context.Context) (*github.Client, error) {
+		return github.NewClient(mockedClient), nil
+	}
+	handler := CompletionsHandler(getClient)
+
+	tests := []struct {
+		uri      string
+		expected []string
+		wantErr  bool
+	}{
+		{uri: "issue://{owner}/{repo}/{number}", expected: []string{"1"}},
+		{uri: "pr://{owner}/{repo}/{number}/diff", expected: []string{"2"}},
+		{uri: "unknown://{owner}", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.uri, func(t *testing.T) {
+			result, err := handler(t.Context(), &mcp.CompleteRequest{
+				Params: &mcp.CompleteParams{
+					Ref:      &mcp.CompleteReference{Type: "ref/resource", URI: tc.uri},
+					Argument: mcp.CompleteParamsArgument{Name: "number"},
+					Context:  &mcp.CompleteContext{Arguments: map[string]string{"owner": "owner", "repo": "repo"}},
+				},
+			})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Completion.Values)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yosida95/uritemplate/v3"
+)
+
+// RepositoryScope is the single repository a server is restricted to with --repo.
+type RepositoryScope struct {
+	Owner string
+	Repo  string
+}
+
+// ParseRepositoryScope parses a repository in the owner/name form.
+func ParseRepositoryScope(s string) (RepositoryScope, error) {
+	owner, repo, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return RepositoryScope{}, fmt.Errorf("invalid repository %q, expected owner/name", s)
+	}
+	return RepositoryScope{Owner: owner, Repo: repo}, nil
+}
+
+func (s RepositoryScope) String() string {
+	return s.Owner + "/" + s.Repo
+}
+
+// Instructions tells the model which repository the server is restricted to.
+func (s RepositoryScope) Instructions() string {
+	return fmt.Sprintf("This server is restricted to the repository %s. The owner and repo of tool calls are set automatically, and other repositories cannot be accessed.", s)
+}
+
+var (
+	// repositoryAgnosticTools have no owner and repo parameters but do not access repositories,
+	// so they stay available in single-repository mode.
+	repositoryAgnosticTools = []string{"get_me", "get_teams", "get_team_members", "read_more"}
+
+	// ownerScopedTools only have an owner parameter, which is set to the owner of the repository.
+	ownerScopedTools = []string{"list_issue_types"}
+
+	// queryScopedTools search with a query that is restricted to the repository with a repo:
+	// qualifier, although they have no owner and repo parameters.
+	queryScopedTools = []string{"search_code"}
+
+	// repoQualifiedSearchTools always get a repo: qualifier for the repository prepended to their
+	// query. The search tools only add one themselves when the query has no repo: filter, which a
+	// negated -repo: qualifier also counts as.
+	repoQualifiedSearchTools = []string{"search_code", "search_issues", "search_pull_requests"}
+)
+
+// searchQualifierPattern matches the search qualifiers that select repositories or owners.
+// Negated qualifiers only exclude results, so they are not matched.
+var searchQualifierPattern = regexp.MustCompile(`(?i)(?:^|[\s(])(repo|org|user|owner):("[^"]*"|[^\s)]+)`)
+
+// ScopeToolToRepository returns a transform for single-repository mode. Tools with owner and repo
+// parameters lose them from their input schema and get them set at call time, and calls that
+// target another repository are rejected. Tools that could access other repositories, such as
+// search_repositories or the gists and projects tools, are dropped.
+func ScopeToolToRepository(scope RepositoryScope) inventory.ToolTransform {
+	return func(st inventory.ServerTool) (inventory.ServerTool, bool) {
+		schema, ok := st.Tool.InputSchema.(*jsonschema.Schema)
+		if !ok || schema == nil {
+			return st, slices.Contains(repositoryAgnosticTools, st.Tool.Name)
+		}
+
+		var params map[string]string
+		_, hasOwner := schema.Properties["owner"]
+		_, hasRepo := schema.Properties["repo"]
+		switch {
+		case hasOwner && hasRepo:
+			params = map[string]string{"owner": scope.Owner, "repo": scope.Repo}
+		case slices.Contains(ownerScopedTools, st.Tool.Name):
+			params = map[string]string{"owner": scope.Owner}
+		case slices.Contains(queryScopedTools, st.Tool.Name):
+			// Scoped through the query only
+		case slices.Contains(repositoryAgnosticTools, st.Tool.Name):
+			return st, true
+		default:
+			return st, false
+		}
+		_, hasQuery := schema.Properties["query"]
+		addRepoQualifier := slices.Contains(repoQualifiedSearchTools, st.Tool.Name)
+
+		schema = schema.CloneSchemas()
+		for name := range params {
+			delete(schema.Properties, name)
+		}
+		schema.Required = slices.DeleteFunc(slices.Clone(schema.Required), func(name string) bool {
+			_, ok := params[name]
+			return ok
+		})
+		st.Tool.InputSchema = schema
+
+		handlerFunc := st.HandlerFunc
+		st.HandlerFunc = func(deps any) mcp.ToolHandler {
+			handler := handlerFunc(deps)
+			return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				scoped, err := scope.scopeRequest(req, params, hasQuery, addRepoQualifier)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil
+				}
+				return handler(ctx, scoped)
+			}
+		}
+		return st, true
+	}
+}
+
+// scopeRequest returns a copy of req with the given parameters set, after checking that the
+// arguments and the search query, if any, do not target another repository.
+func (s RepositoryScope) scopeRequest(req *mcp.CallToolRequest, params map[string]string, hasQuery, addRepoQualifier bool) (*mcp.CallToolRequest, error) {
+	args := map[string]any{}
+	if req.Params != nil && len(req.Params.Arguments) > 0 {
+		if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+
+	for name, value := range params {
+		if arg, ok := args[name]; ok {
+			if str, isString := arg.(string); !isString || !strings.EqualFold(str, value) {
+				return nil, fmt.Errorf("this server is restricted to the repository %s, %s %v is not allowed", s, name, arg)
+			}
+		}
+		args[name] = value
+	}
+
+	if query, ok := args["query"].(string); ok && hasQuery {
+		if err := s.checkQuery(query); err != nil {
+			return nil, err
+		}
+		if addRepoQualifier {
+			args["query"] = fmt.Sprintf("repo:%s %s", s, query)
+		}
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal arguments: %w", err)
+	}
+	scoped := *req
+	scopedParams := mcp.CallToolParamsRaw{}
+	if req.Params != nil {
+		scopedParams = *req.Params
+	}
+	scopedParams.Arguments = data
+	scoped.Params = &scopedParams
+	return &scoped, nil
+}
+
+// checkQuery rejects search queries with repo qualifiers for anything else than the repository,
+// and with org, user or owner qualifiers, which would widen the search to other repositories
+// of the owner.
+func (s RepositoryScope) checkQuery(query string) error {
+	for _, match := range searchQualifierPattern.FindAllStringSubmatch(query, -1) {
+		qualifier, value := strings.ToLower(match[1]), strings.Trim(match[2], `"`)
+		if qualifier != "repo" || !strings.EqualFold(value, s.String()) {
+			return fmt.Errorf("this server is restricted to the repository %s, the qualifier %s:%s is not allowed", s, qualifier, value)
+		}
+	}
+	return nil
+}
+
+// ScopeResourceToRepository returns a resource template transform for single-repository mode.
+// The owner and repo variables of the URI template are replaced with the repository, so that
+// clients only see and read URIs of the repository, and reads of other repositories are
+// rejected. Resource templates without owner and repo variables are dropped.
+func ScopeResourceToRepository(scope RepositoryScope) inventory.ResourceTemplateTransform {
+	return func(sr inventory.ServerResourceTemplate) (inventory.ServerResourceTemplate, bool) {
+		tmpl, err := uritemplate.New(sr.Template.URITemplate)
+		if err != nil || !slices.Contains(tmpl.Varnames(), "owner") || !slices.Contains(tmpl.Varnames(), "repo") {
+			return sr, false
+		}
+		sr.Template.URITemplate = strings.NewReplacer("{owner}", scope.Owner, "{repo}", scope.Repo).Replace(sr.Template.URITemplate)
+
+		handlerFunc := sr.HandlerFunc
+		sr.HandlerFunc = func(deps any) mcp.ResourceHandler {
+			handler := handlerFunc(deps)
+			return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+				values := tmpl.Match(req.Params.URI)
+				if values == nil ||
+					!strings.EqualFold(values.Get("owner").String(), scope.Owner) ||
+					!strings.EqualFold(values.Get("repo").String(), scope.Repo) {
+					return nil, fmt.Errorf("this server is restricted to the repository %s, %s is not allowed", scope, req.Params.URI)
+				}
+				return handler(ctx, req)
+			}
+		}
+		return sr, true
+	}
+}
+
+// ScopeCompletionsToRepository restricts the resource template completions of handler to the
+// repository. The owner and repo arguments only complete to the repository, and the other
+// arguments, such as branches or issue numbers, are completed within it.
+func ScopeCompletionsToRepository(scope RepositoryScope, handler func(context.Context, *mcp.CompleteRequest) (*mcp.CompleteResult, error)) func(context.Context, *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	return func(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+		if req.Params == nil || req.Params.Ref == nil || req.Params.Ref.Type != "ref/resource" {
+			return handler(ctx, req)
+		}
+
+		switch req.Params.Argument.Name {
+		case "owner":
+			return scopedCompletion(scope.Owner, req.Params.Argument.Value), nil
+		case "repo":
+			return scopedCompletion(scope.Repo, req.Params.Argument.Value), nil
+		}
+
+		arguments := map[string]string{}
+		if req.Params.Context != nil {
+			maps.Copy(arguments, req.Params.Context.Arguments)
+		}
+		arguments["owner"], arguments["repo"] = scope.Owner, scope.Repo
+
+		params := *req.Params
+		params.Context = &mcp.CompleteContext{Arguments: arguments}
+		scoped := *req
+		scoped.Params = &params
+		return handler(ctx, &scoped)
+	}
+}
+
+// scopedCompletion completes value to the only allowed value, if it is a prefix of it.
+func scopedCompletion(allowed, value string) *mcp.CompleteResult {
+	values := []string{}
+	if strings.HasPrefix(strings.ToLower(allowed), strings.ToLower(value)) {
+		values = append(values, allowed)
+	}
+	return &mcp.CompleteResult{
+		Completion: mcp.CompletionResultDetails{
+			Values: values,
+			Total:  len(values),
+		},
+	}
+}
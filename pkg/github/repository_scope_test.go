@@ -0,0 +1,226 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseRepositoryScope(t *testing.T) {
+	scope, err := ParseRepositoryScope(" octo-org/hello-world ")
+	require.NoError(t, err)
+	assert.Equal(t, RepositoryScope{Owner: "octo-org", Repo: "hello-world"}, scope)
+	assert.Equal(t, "octo-org/hello-world", scope.String())
+
+	for _, invalid := range []string{"", "octo-org", "octo-org/", "/hello-world", "octo-org/hello/world"} {
+		_, err := ParseRepositoryScope(invalid)
+		assert.ErrorContains(t, err, "expected owner/name", invalid)
+	}
+}
+
+func Test_ScopeToolToRepositoryInventory(t *testing.T) {
+	scope := RepositoryScope{Owner: "octo-org", Repo: "hello-world"}
+	inv, err := NewInventory(translations.NullTranslationHelper).
+		WithToolsets([]string{"all"}).
+		WithToolTransform(ScopeToolToRepository(scope)).
+		Build()
+	require.NoError(t, err)
+
+	tools := map[string]inventory.ServerTool{}
+	for _, st := range inv.AvailableTools(context.Background()) {
+		tools[st.Tool.Name] = st
+	}
+
+	// Repository tools lose their owner and repo parameters
+	getFileContents, ok := tools["get_file_contents"]
+	require.True(t, ok)
+	schema := getFileContents.Tool.InputSchema.(*jsonschema.Schema)
+	assert.NotContains(t, schema.Properties, "owner")
+	assert.NotContains(t, schema.Properties, "repo")
+	assert.NotContains(t, schema.Required, "owner")
+	assert.Contains(t, schema.Properties, "path")
+
+	// The original definitions are not modified
+	original := GetFileContents(translations.NullTranslationHelper).Tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, original.Properties, "owner")
+
+	// Tools that do not access repositories are kept, the ones that could access others are dropped
+	for _, name := range []string{"get_me", "read_more", "search_code", "search_issues", "list_issue_types"} {
+		assert.Contains(t, tools, name)
+	}
+	for _, name := range []string{"search_repositories", "create_repository", "list_gists", "list_projects", "get_notification_details"} {
+		assert.NotContains(t, tools, name)
+	}
+	assert.False(t, inv.HasToolset("gists"), "toolsets without tools should not be offered")
+}
+
+func Test_ScopeToolToRepositoryCalls(t *testing.T) {
+	scope := RepositoryScope{Owner: "octo-org", Repo: "hello-world"}
+
+	// A stand-in tool that returns its arguments
+	echo := func(name string, properties ...string) inventory.ServerTool {
+		schema := &jsonschema.Schema{Type: "object", Properties: map[string]*jsonschema.Schema{}, Required: properties}
+		for _, p := range properties {
+			schema.Properties[p] = &jsonschema.Schema{Type: "string"}
+		}
+		return NewToolFromHandler(ToolsetMetadataRepos, mcp.Tool{Name: name, InputSchema: schema}, nil,
+			func(_ context.Context, _ ToolDependencies, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return utils.NewToolResultText(string(req.Params.Arguments)), nil
+			})
+	}
+
+	tests := []struct {
+		name        string
+		tool        inventory.ServerTool
+		args        map[string]any
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			name:     "owner and repo are set",
+			tool:     echo("get_thing", "owner", "repo", "path"),
+			args:     map[string]any{"path": "README.md"},
+			expected: map[string]any{"owner": "octo-org", "repo": "hello-world", "path": "README.md"},
+		},
+		{
+			name:     "the same repository is accepted",
+			tool:     echo("get_thing", "owner", "repo"),
+			args:     map[string]any{"owner": "Octo-Org", "repo": "hello-world"},
+			expected: map[string]any{"owner": "octo-org", "repo": "hello-world"},
+		},
+		{
+			name:        "another repository is rejected",
+			tool:        echo("get_thing", "owner", "repo"),
+			args:        map[string]any{"owner": "octo-org", "repo": "other"},
+			expectedErr: "this server is restricted to the repository octo-org/hello-world, repo other is not allowed",
+		},
+		{
+			name:     "search queries are restricted to the repository",
+			tool:     echo("search_code", "query"),
+			args:     map[string]any{"query": "func main"},
+			expected: map[string]any{"query": "repo:octo-org/hello-world func main"},
+		},
+		{
+			name:     "qualifiers for the repository are accepted",
+			tool:     echo("search_code", "query"),
+			args:     map[string]any{"query": "func main repo:Octo-Org/hello-world"},
+			expected: map[string]any{"query": "repo:octo-org/hello-world func main repo:Octo-Org/hello-world"},
+		},
+		{
+			name:     "negated repo qualifiers do not replace the repository qualifier",
+			tool:     echo("search_issues", "owner", "repo", "query"),
+			args:     map[string]any{"query": "is:open -repo:octo-org/other"},
+			expected: map[string]any{"owner": "octo-org", "repo": "hello-world", "query": "repo:octo-org/hello-world is:open -repo:octo-org/other"},
+		},
+		{
+			name:        "owner qualifiers are rejected even for the owner of the repository",
+			tool:        echo("search_issues", "owner", "repo", "query"),
+			args:        map[string]any{"query": "is:open org:octo-org -repo:octo-org/other"},
+			expectedErr: "the qualifier org:octo-org is not allowed",
+		},
+		{
+			name:        "qualifiers for other repositories are rejected",
+			tool:        echo("search_code", "query"),
+			args:        map[string]any{"query": "func main (repo:octo-org/other)"},
+			expectedErr: "the qualifier repo:octo-org/other is not allowed",
+		},
+		{
+			name:        "qualifiers for other owners are rejected",
+			tool:        echo("search_issues", "owner", "repo", "query"),
+			args:        map[string]any{"query": `user:"someone"`},
+			expectedErr: "the qualifier user:someone is not allowed",
+		},
+	}
+
+	deps := stubDeps{}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			st, ok := ScopeToolToRepository(scope)(tc.tool)
+			require.True(t, ok)
+
+			request := createMCPRequest(tc.args)
+			result, err := st.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectedErr != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErr)
+				return
+			}
+			var args map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &args))
+			assert.Equal(t, tc.expected, args)
+		})
+	}
+}
+
+func Test_ScopeResourceToRepository(t *testing.T) {
+	scope := RepositoryScope{Owner: "octo-org", Repo: "hello-world"}
+
+	// A stand-in handler that returns the URI it read
+	echo := inventory.NewServerResourceTemplate(ToolsetMetadataIssues,
+		mcp.ResourceTemplate{Name: "issue_content", URITemplate: "issue://{owner}/{repo}/{number}"},
+		func(_ any) mcp.ResourceHandler {
+			return func(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+				return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{URI: req.Params.URI}}}, nil
+			}
+		})
+
+	sr, ok := ScopeResourceToRepository(scope)(echo)
+	require.True(t, ok)
+	assert.Equal(t, "issue://octo-org/hello-world/{number}", sr.Template.URITemplate)
+	assert.Equal(t, "issue://{owner}/{repo}/{number}", echo.Template.URITemplate, "the original template should not be modified")
+
+	handler := sr.Handler(nil)
+	result, err := handler(context.Background(), &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: "issue://Octo-Org/hello-world/1"}})
+	require.NoError(t, err)
+	assert.Equal(t, "issue://Octo-Org/hello-world/1", result.Contents[0].URI)
+
+	_, err = handler(context.Background(), &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: "issue://octo-org/other/1"}})
+	assert.EqualError(t, err, "this server is restricted to the repository octo-org/hello-world, issue://octo-org/other/1 is not allowed")
+
+	// Every resource template of the server has owner and repo variables and is kept
+	for _, resource := range AllResources(translations.NullTranslationHelper) {
+		scoped, ok := ScopeResourceToRepository(scope)(resource)
+		assert.True(t, ok, resource.Template.Name)
+		assert.NotContains(t, scoped.Template.URITemplate, "{owner}", resource.Template.Name)
+		assert.NotContains(t, scoped.Template.URITemplate, "{repo}", resource.Template.Name)
+	}
+}
+
+func Test_ScopeCompletionsToRepository(t *testing.T) {
+	scope := RepositoryScope{Owner: "octo-org", Repo: "hello-world"}
+
+	// A stand-in handler that returns the owner and repo it completes within
+	var called bool
+	handler := ScopeCompletionsToRepository(scope, func(_ context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+		called = true
+		args := req.Params.Context.Arguments
+		return &mcp.CompleteResult{Completion: mcp.CompletionResultDetails{Values: []string{args["owner"] + "/" + args["repo"]}}}, nil
+	})
+
+	complete := func(argument, value string, resolved map[string]string) []string {
+		called = false
+		result, err := handler(context.Background(), &mcp.CompleteRequest{Params: &mcp.CompleteParams{
+			Ref:      &mcp.CompleteReference{Type: "ref/resource", URI: "repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}"},
+			Argument: mcp.CompleteParamsArgument{Name: argument, Value: value},
+			Context:  &mcp.CompleteContext{Arguments: resolved},
+		}})
+		require.NoError(t, err)
+		return result.Completion.Values
+	}
+
+	assert.Equal(t, []string{"octo-org"}, complete("owner", "Octo", nil))
+	assert.Empty(t, complete("owner", "someone", nil))
+	assert.False(t, called, "owner completions should not reach the handler")
+	assert.Equal(t, []string{"hello-world"}, complete("repo", "", map[string]string{"owner": "someone"}))
+	assert.Empty(t, complete("repo", "other", nil))
+	assert.Equal(t, []string{"octo-org/hello-world"}, complete("branch", "", map[string]string{"owner": "someone", "repo": "other"}))
+	assert.True(t, called)
+}
@@ -1,8 +1,20 @@
 package github
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/sanitize"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/yosida95/uritemplate/v3"
 )
 
 // AllResources returns all resource templates with their embedded toolset metadata.
@@ -15,5 +27,202 @@ func AllResources(t translations.TranslationHelperFunc) []inventory.ServerResour
 		GetRepositoryResourceCommitContent(t),
 		GetRepositoryResourceTagContent(t),
 		GetRepositoryResourcePrContent(t),
+
+		// Issue resources
+		GetIssueResource(t),
+
+		// Pull request resources
+		GetPullRequestResource(t),
+		GetPullRequestDiffResource(t),
+		GetPullRequestFilesResource(t),
+
+		// Discussion resources
+		GetDiscussionResource(t),
+	}
+}
+
+// markdownMIMEType is the MIME type used for resources rendered as Markdown.
+const markdownMIMEType = "text/markdown"
+
+// frontMatterField is a single key/value pair in a Markdown front-matter block.
+type frontMatterField struct {
+	Key   string
+	Value any
+}
+
+// writeFrontMatter writes a YAML front-matter block to sb. Values are encoded as JSON,
+// which is valid YAML, so that strings containing colons or quotes stay unambiguous.
+// Fields with nil values or empty strings/slices are omitted.
+func writeFrontMatter(sb *strings.Builder, fields []frontMatterField) {
+	sb.WriteString("---\n")
+	for _, f := range fields {
+		switch v := f.Value.(type) {
+		case nil:
+			continue
+		case string:
+			if v == "" {
+				continue
+			}
+		case []string:
+			if len(v) == 0 {
+				continue
+			}
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(f.Value); err != nil {
+			continue
+		}
+		// Encode terminates each value with a newline
+		fmt.Fprintf(sb, "%s: %s", f.Key, buf.String())
+	}
+	sb.WriteString("---\n")
+}
+
+// codeFence returns a backtick fence that is longer than any backtick run in content,
+// so the content can be safely embedded in a fenced code block.
+func codeFence(content string) string {
+	return strings.Repeat("`", max(3, longestBacktickRun(content)+1))
+}
+
+// markdownCodeSpan renders s as an inline code span that is safe inside a Markdown table
+// cell. The delimiter is longer than any backtick run in s, and pipes are escaped so they
+// do not end the cell.
+func markdownCodeSpan(s string) string {
+	delimiter := strings.Repeat("`", longestBacktickRun(s)+1)
+	s = strings.ReplaceAll(s, "|", "\\|")
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return delimiter + s + delimiter
+}
+
+// longestBacktickRun returns the length of the longest run of consecutive backticks in s.
+func longestBacktickRun(s string) int {
+	longest, current := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			current++
+			if current > longest {
+				longest = current
+			}
+			continue
+		}
+		current = 0
+	}
+	return longest
+}
+
+// resourceRepoAndNumber extracts the owner, repo and number variables shared by the
+// issue, pull request and discussion resource templates.
+func resourceRepoAndNumber(tmpl *uritemplate.Template, uri string) (string, string, int, error) {
+	uriValues := tmpl.Match(uri)
+	if uriValues == nil {
+		return "", "", 0, fmt.Errorf("failed to match URI: %s", uri)
+	}
+
+	owner := uriValues.Get("owner").String()
+	repo := uriValues.Get("repo").String()
+	if owner == "" {
+		return "", "", 0, errors.New("owner is required")
+	}
+	if repo == "" {
+		return "", "", 0, errors.New("repo is required")
+	}
+
+	number, err := strconv.Atoi(uriValues.Get("number").String())
+	if err != nil || number <= 0 {
+		return "", "", 0, fmt.Errorf("invalid number in URI: %s", uri)
+	}
+
+	return owner, repo, number, nil
+}
+
+// resourceContentIsSafe reports whether content authored by login may be returned
+// from a resource under the lockdown policy of deps.
+func resourceContentIsSafe(ctx context.Context, deps ToolDependencies, login, owner, repo string) (bool, error) {
+	policy, err := lockdownPolicyFor(deps)
+	if err != nil {
+		return false, err
+	}
+	isSafe, err := policy.isSafe(ctx, authoredContent{login: login, owner: owner, repo: repo})
+	if err != nil {
+		return false, fmt.Errorf("failed to check lockdown mode: %w", err)
+	}
+	return isSafe, nil
+}
+
+// formatResourceTime formats a timestamp for resource front-matter, returning an empty
+// string for the zero time so the field is omitted.
+func formatResourceTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// writeCommentsMarkdown appends a "Comments" section to sb. The comment accessor returns
+// the author login, creation timestamp and raw body for the comment at index i. omitted is
+// the number of comments that were not fetched, which is noted after the last comment.
+func writeCommentsMarkdown(sb *strings.Builder, n, omitted int, comment func(i int) (author, createdAt, body string)) {
+	if n == 0 && omitted <= 0 {
+		return
+	}
+	sb.WriteString("\n## Comments\n")
+	for i := range n {
+		author, createdAt, body := comment(i)
+		fmt.Fprintf(sb, "\n### @%s commented on %s\n\n%s\n", author, createdAt, sanitize.Sanitize(body))
+	}
+	writeOmittedNote(sb, omitted, "comments")
+}
+
+// writeOmittedNote notes that n items of a list were cut from a resource. It writes
+// nothing when n is not positive.
+func writeOmittedNote(sb *strings.Builder, n int, items string) {
+	if n > 0 {
+		fmt.Fprintf(sb, "\n_%d more %s omitted._\n", n, items)
+	}
+}
+
+// maxResourcePages bounds the number of pages of 100 items a resource fetches for a list,
+// so that very long threads do not exhaust the rate limit.
+const maxResourcePages = 10
+
+// listResourceIssueComments fetches the comments of an issue or pull request, following
+// pagination for up to maxResourcePages pages.
+func listResourceIssueComments(ctx context.Context, client *github.Client, owner, repo string, number int) ([]*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var all []*github.IssueComment
+	for range maxResourcePages {
+		comments, resp, err := client.Issues.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, comments...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return all, nil
+}
+
+// listResourcePullRequestFiles fetches the files changed in a pull request, following
+// pagination for up to maxResourcePages pages.
+func listResourcePullRequestFiles(ctx context.Context, client *github.Client, owner, repo string, number int) ([]*github.CommitFile, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	var all []*github.CommitFile
+	for range maxResourcePages {
+		files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, files...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
 	}
+	return all, nil
 }
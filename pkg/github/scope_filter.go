@@ -2,6 +2,8 @@ package github
 
 import (
 	"context"
+	"slices"
+	"sort"
 
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/scopes"
@@ -62,3 +64,74 @@ func CreateToolScopeFilter(tokenScopes []string) inventory.ToolFilter {
 		return scopes.HasRequiredScopes(tokenScopes, tool.AcceptedScopes), nil
 	}
 }
+
+// ScopesToUnlock returns the accepted scopes of a tool hidden by CreateToolScopeFilter that
+// the token lacks, including parent scopes. Adding any one of them makes the tool visible.
+// Returns nil if the tool is visible.
+func ScopesToUnlock(tokenScopes []string, tool *inventory.ServerTool) []string {
+	if visible, _ := CreateToolScopeFilter(tokenScopes)(context.Background(), tool); visible {
+		return nil
+	}
+	granted := make(map[string]bool)
+	for _, scope := range scopes.GrantedScopes(tokenScopes) {
+		granted[scope] = true
+	}
+	var missing []string
+	for _, scope := range tool.AcceptedScopes {
+		if !granted[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// MinimalScopesToUnlock returns a small set of extra scopes that makes all the given tools visible
+// to a token with tokenScopes. It greedily picks the scope unlocking the most remaining tools.
+// On ties it prefers the least privileged scope, the one granting the fewest child scopes in
+// scopes.ScopeHierarchy, so read:org is picked over write:org and admin:org, and then the scope
+// that comes first alphabetically. The result is sorted.
+func MinimalScopesToUnlock(tokenScopes []string, tools []inventory.ServerTool) []string {
+	var remaining [][]string
+	for i := range tools {
+		if unlock := ScopesToUnlock(tokenScopes, &tools[i]); len(unlock) > 0 {
+			remaining = append(remaining, unlock)
+		}
+	}
+
+	var result []string
+	for len(remaining) > 0 {
+		counts := make(map[string]int)
+		for _, unlock := range remaining {
+			for _, scope := range unlock {
+				counts[scope]++
+			}
+		}
+		best := ""
+		for scope, count := range counts {
+			if count > counts[best] || (count == counts[best] && lessPrivileged(scope, best)) {
+				best = scope
+			}
+		}
+		result = append(result, best)
+
+		var next [][]string
+		for _, unlock := range remaining {
+			if !slices.Contains(unlock, best) {
+				next = append(next, unlock)
+			}
+		}
+		remaining = next
+	}
+	sort.Strings(result)
+	return result
+}
+
+// lessPrivileged reports whether scope a grants fewer child scopes than scope b, or as many and
+// comes first alphabetically.
+func lessPrivileged(a, b string) bool {
+	grantedA, grantedB := len(scopes.GrantedScopes([]string{a})), len(scopes.GrantedScopes([]string{b}))
+	if grantedA != grantedB {
+		return grantedA < grantedB
+	}
+	return a < b
+}
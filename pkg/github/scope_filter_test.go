@@ -189,3 +189,74 @@ func TestCreateToolScopeFilter_Integration(t *testing.T) {
 	assert.Contains(t, toolNames, "repo_tool")
 	assert.NotContains(t, toolNames, "gist_tool")
 }
+
+func TestScopesToUnlock(t *testing.T) {
+	gistTool := &inventory.ServerTool{
+		Tool:           mcp.Tool{Name: "gist_tool"},
+		RequiredScopes: []string{"gist"},
+		AcceptedScopes: []string{"gist"},
+	}
+	orgTool := &inventory.ServerTool{
+		Tool:           mcp.Tool{Name: "org_tool"},
+		RequiredScopes: []string{"read:org"},
+		AcceptedScopes: []string{"read:org", "write:org", "admin:org"},
+	}
+
+	assert.Nil(t, ScopesToUnlock([]string{"gist"}, gistTool), "visible tools need no scopes")
+	assert.Equal(t, []string{"gist"}, ScopesToUnlock([]string{"repo"}, gistTool))
+	assert.Equal(t, []string{"read:org", "write:org", "admin:org"}, ScopesToUnlock([]string{}, orgTool))
+	assert.Nil(t, ScopesToUnlock([]string{"admin:org"}, orgTool), "parent scopes unlock child-scoped tools")
+}
+
+func TestMinimalScopesToUnlock(t *testing.T) {
+	tools := []inventory.ServerTool{
+		{
+			Tool:           mcp.Tool{Name: "public_tool"},
+			AcceptedScopes: nil,
+		},
+		{
+			Tool:           mcp.Tool{Name: "repo_tool"},
+			RequiredScopes: []string{"repo"},
+			AcceptedScopes: []string{"repo"},
+		},
+		{
+			Tool:           mcp.Tool{Name: "other_repo_tool"},
+			RequiredScopes: []string{"repo"},
+			AcceptedScopes: []string{"repo"},
+		},
+		{
+			Tool:           mcp.Tool{Name: "gist_tool"},
+			RequiredScopes: []string{"gist"},
+			AcceptedScopes: []string{"gist"},
+		},
+		{
+			Tool:           mcp.Tool{Name: "repo_or_gist_tool"},
+			RequiredScopes: []string{"gist", "repo"},
+			AcceptedScopes: []string{"gist", "repo"},
+		},
+		{
+			Tool:           mcp.Tool{Name: "read_project_tool"},
+			RequiredScopes: []string{"read:project"},
+			AcceptedScopes: []string{"read:project", "project"},
+		},
+		{
+			Tool:           mcp.Tool{Name: "project_tool"},
+			RequiredScopes: []string{"project"},
+			AcceptedScopes: []string{"project"},
+		},
+		{
+			Tool:           mcp.Tool{Name: "read_org_tool"},
+			RequiredScopes: []string{"read:org"},
+			AcceptedScopes: []string{"admin:org", "read:org", "write:org"},
+		},
+	}
+
+	assert.Equal(t, []string{"gist", "project", "read:org", "repo"}, MinimalScopesToUnlock([]string{}, tools))
+	assert.Equal(t, []string{"gist", "project", "read:org"}, MinimalScopesToUnlock([]string{"repo"}, tools))
+	assert.Nil(t, MinimalScopesToUnlock([]string{"repo", "gist", "project", "read:org"}, tools))
+	assert.Equal(t, []string{"repo"}, MinimalScopesToUnlock([]string{"gist"}, tools[:3]))
+
+	// Ties are broken toward the least privileged scope
+	assert.Equal(t, []string{"read:project"}, MinimalScopesToUnlock([]string{}, tools[5:6]))
+	assert.Equal(t, []string{"read:org"}, MinimalScopesToUnlock([]string{}, tools[7:8]))
+}
@@ -55,7 +55,8 @@ func SearchRepositories(t translations.TranslationHelperFunc) inventory.ServerTo
 				Title:        t("TOOL_SEARCH_REPOSITORIES_USER_TITLE", "Search repositories"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  schema,
+			OutputSchema: AnyOfOutputSchema(OutputSchema[MinimalSearchRepositoriesResult](), APIObjectOutputSchema[github.RepositoriesSearchResult]("Search results as returned by the GitHub REST API, when minimal_output is false.")),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -111,7 +112,6 @@ func SearchRepositories(t translations.TranslationHelperFunc) inventory.ServerTo
 			}
 
 			// Return either minimal or full response based on parameter
-			var r []byte
 			if minimalOutput {
 				minimalRepos := make([]MinimalRepository, 0, len(result.Repositories))
 				for _, repo := range result.Repositories {
@@ -150,18 +150,10 @@ func SearchRepositories(t translations.TranslationHelperFunc) inventory.ServerTo
 					Items:             minimalRepos,
 				}
 
-				r, err = json.Marshal(minimalResult)
-				if err != nil {
-					return utils.NewToolResultErrorFromErr("failed to marshal minimal response", err), nil, nil
-				}
-			} else {
-				r, err = json.Marshal(result)
-				if err != nil {
-					return utils.NewToolResultErrorFromErr("failed to marshal full response", err), nil, nil
-				}
+				return MarshalledTextResult(ctx, minimalResult), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, result), nil, nil
 		},
 	)
 }
@@ -198,7 +190,8 @@ func SearchCode(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_SEARCH_CODE_USER_TITLE", "Search code"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  schema,
+			OutputSchema: APIObjectOutputSchema[github.CodeSearchResult]("Search results as returned by the GitHub REST API, with total_count, incomplete_results and items."),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -251,12 +244,9 @@ func SearchCode(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to search code", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			// Lockdown mode returns code unfiltered: code search only indexes default branches,
+			// which only users with push access can change
+			return MarshalledTextResult(ctx, result), nil, nil
 		},
 	)
 }
@@ -340,11 +330,7 @@ func userOrOrgHandler(ctx context.Context, accountType string, deps ToolDependen
 		minimalResp.IncompleteResults = *result.IncompleteResults
 	}
 
-	r, err := json.Marshal(minimalResp)
-	if err != nil {
-		return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
-	}
-	return utils.NewToolResultText(string(r)), nil, nil
+	return MarshalledTextResult(ctx, minimalResp), nil, nil
 }
 
 // SearchUsers creates a tool to search for GitHub users.
@@ -380,7 +366,8 @@ func SearchUsers(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_SEARCH_USERS_USER_TITLE", "Search users"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  schema,
+			OutputSchema: OutputSchema[MinimalSearchUsersResult](),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -422,7 +409,8 @@ func SearchOrgs(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Title:        t("TOOL_SEARCH_ORGS_USER_TITLE", "Search organizations"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  schema,
+			OutputSchema: OutputSchema[MinimalSearchUsersResult](),
 		},
 		[]scopes.Scope{scopes.ReadOrg},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -548,6 +548,7 @@ func Test_SearchUsers(t *testing.T) {
 			require.NotNil(t, result)
 
 			textContent := getTextResult(t, result)
+			assertStructuredContent(t, tool, result)
 
 			// Unmarshal and verify the result
 			var returnedResult MinimalSearchUsersResult
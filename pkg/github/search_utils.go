@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,8 +14,9 @@ import (
 )
 
 func hasFilter(query, filterType string) bool {
-	// Match filter at start of string, after whitespace, or after non-word characters like '('
-	pattern := fmt.Sprintf(`(^|\s|\W)%s:\S+`, regexp.QuoteMeta(filterType))
+	// Match filter at start of string, after whitespace, or after non-word characters like '('.
+	// Negated filters like -repo:x only exclude results, so they do not count.
+	pattern := fmt.Sprintf(`(^|[^\w-])%s:\S+`, regexp.QuoteMeta(filterType))
 	matched, _ := regexp.MatchString(pattern, query)
 	return matched
 }
@@ -39,7 +39,7 @@ func hasTypeFilter(query string) bool {
 
 func searchHandler(
 	ctx context.Context,
-	getClient GetClientFn,
+	deps ToolDependencies,
 	args map[string]any,
 	searchType string,
 	errorPrefix string,
@@ -90,7 +90,7 @@ func searchHandler(
 		},
 	}
 
-	client, err := getClient(ctx)
+	client, err := deps.GetClient(ctx)
 	if err != nil {
 		return utils.NewToolResultErrorFromErr(errorPrefix+": failed to get GitHub client", err), nil
 	}
@@ -108,10 +108,17 @@ func searchHandler(
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, errorPrefix, resp, body), nil
 	}
 
-	r, err := json.Marshal(result)
+	policy, err := lockdownPolicyFor(deps)
 	if err != nil {
-		return utils.NewToolResultErrorFromErr(errorPrefix+": failed to marshal response", err), nil
+		return nil, err
+	}
+	result.Issues, err = filterLockdown(ctx, policy, result.Issues, func(issue *github.Issue) authoredContent {
+		owner, repo := repositoryFromAPIURL(issue.GetRepositoryURL())
+		return authoredContent{login: issue.GetUser().GetLogin(), owner: owner, repo: repo}
+	})
+	if err != nil {
+		return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, result), nil
 }
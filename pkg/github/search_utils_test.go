@@ -169,6 +169,11 @@ func Test_hasRepoFilter(t *testing.T) {
 			query:    "repo:github/github-mcp-server is:issue (label:critical OR label:urgent)",
 			expected: true,
 		},
+		{
+			name:     "query with only a negated repo: filter",
+			query:    "is:issue -repo:github/github-mcp-server",
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
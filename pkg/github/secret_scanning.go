@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,7 +26,7 @@ func GetSecretScanningAlert(t translations.TranslationHelperFunc) inventory.Serv
 				Title:        t("TOOL_GET_SECRET_SCANNING_ALERT_USER_TITLE", "Get secret scanning alert"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFields(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -44,7 +43,8 @@ func GetSecretScanningAlert(t translations.TranslationHelperFunc) inventory.Serv
 					},
 				},
 				Required: []string{"owner", "repo", "alertNumber"},
-			},
+			}),
+			OutputSchema: APIObjectOutputSchema[github.SecretScanningAlert]("Secret scanning alert as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.SecurityEvents},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -84,12 +84,7 @@ func GetSecretScanningAlert(t translations.TranslationHelperFunc) inventory.Serv
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get alert", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(alert)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal alert: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, alert), nil, nil
 		},
 	)
 }
@@ -104,7 +99,7 @@ func ListSecretScanningAlerts(t translations.TranslationHelperFunc) inventory.Se
 				Title:        t("TOOL_LIST_SECRET_SCANNING_ALERTS_USER_TITLE", "List secret scanning alerts"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFields(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -131,7 +126,8 @@ func ListSecretScanningAlerts(t translations.TranslationHelperFunc) inventory.Se
 					},
 				},
 				Required: []string{"owner", "repo"},
-			},
+			}),
+			OutputSchema: APIListOutputSchema[github.SecretScanningAlert]("Secret scanning alerts as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.SecurityEvents},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -178,12 +174,7 @@ func ListSecretScanningAlerts(t translations.TranslationHelperFunc) inventory.Se
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list alerts", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(alerts)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal alerts: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, alerts), nil, nil
 		},
 	)
 }
@@ -83,6 +83,7 @@ func ListGlobalSecurityAdvisories(t translations.TranslationHelperFunc) inventor
 					},
 				},
 			},
+			OutputSchema: APIListOutputSchema[github.GlobalSecurityAdvisory]("Global security advisories as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.SecurityEvents},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -198,12 +199,7 @@ func ListGlobalSecurityAdvisories(t translations.TranslationHelperFunc) inventor
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list advisories", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(advisories)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal advisories: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, advisories), nil, nil
 		},
 	)
 }
@@ -247,6 +243,7 @@ func ListRepositorySecurityAdvisories(t translations.TranslationHelperFunc) inve
 				},
 				Required: []string{"owner", "repo"},
 			},
+			OutputSchema: APIListOutputSchema[github.SecurityAdvisory]("Repository security advisories as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.SecurityEvents},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -302,12 +299,7 @@ func ListRepositorySecurityAdvisories(t translations.TranslationHelperFunc) inve
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list repository advisories", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(advisories)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal advisories: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, advisories), nil, nil
 		},
 	)
 }
@@ -332,6 +324,7 @@ func GetGlobalSecurityAdvisory(t translations.TranslationHelperFunc) inventory.S
 				},
 				Required: []string{"ghsaId"},
 			},
+			OutputSchema: APIObjectOutputSchema[github.GlobalSecurityAdvisory]("Global security advisory as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.SecurityEvents},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -359,12 +352,7 @@ func GetGlobalSecurityAdvisory(t translations.TranslationHelperFunc) inventory.S
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get advisory", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(advisory)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal advisory: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, advisory), nil, nil
 		},
 	)
 }
@@ -404,6 +392,7 @@ func ListOrgRepositorySecurityAdvisories(t translations.TranslationHelperFunc) i
 				},
 				Required: []string{"org"},
 			},
+			OutputSchema: APIListOutputSchema[github.SecurityAdvisory]("Repository security advisories as returned by the GitHub REST API."),
 		},
 		[]scopes.Scope{scopes.SecurityEvents},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -454,12 +443,7 @@ func ListOrgRepositorySecurityAdvisories(t translations.TranslationHelperFunc) i
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list organization repository advisories", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(advisories)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal advisories: %w", err)
-			}
-
-			return utils.NewToolResultText(string(r)), nil, nil
+			return MarshalledTextResult(ctx, advisories), nil, nil
 		},
 	)
 }
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/github/github-mcp-server/pkg/octicons"
+	"github.com/github/github-mcp-server/pkg/outputformat"
 	"github.com/github/github-mcp-server/pkg/utils"
 	"github.com/google/go-github/v79/github"
 	"github.com/google/jsonschema-go/jsonschema"
@@ -37,12 +38,19 @@ func CompletionsHandler(getClient GetClientFn) func(ctx context.Context, req *mc
 	return func(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
 		switch req.Params.Ref.Type {
 		case "ref/resource":
-			if strings.HasPrefix(req.Params.Ref.URI, "repo://") {
+			switch {
+			case strings.HasPrefix(req.Params.Ref.URI, "repo://"):
 				return RepositoryResourceCompletionHandler(getClient)(ctx, req)
+			case strings.HasPrefix(req.Params.Ref.URI, "issue://"):
+				return ResourceCompletionHandler(getClient, IssueResourceArgumentResolvers)(ctx, req)
+			case strings.HasPrefix(req.Params.Ref.URI, "pr://"):
+				return ResourceCompletionHandler(getClient, PullRequestResourceArgumentResolvers)(ctx, req)
+			case strings.HasPrefix(req.Params.Ref.URI, "discussion://"):
+				return ResourceCompletionHandler(getClient, DiscussionResourceArgumentResolvers)(ctx, req)
 			}
 			return nil, fmt.Errorf("unsupported resource URI: %s", req.Params.Ref.URI)
 		case "ref/prompt":
-			return nil, nil
+			return PromptCompletionHandler(getClient)(ctx, req)
 		default:
 			return nil, fmt.Errorf("unsupported ref type: %s", req.Params.Ref.Type)
 		}
@@ -281,7 +289,8 @@ func OptionalBigIntArrayParam(args map[string]any, p string) ([]int64, error) {
 	}
 }
 
-// WithPagination adds REST API pagination parameters to a tool.
+// WithPagination adds REST API pagination parameters to a tool, including max_items to fetch
+// several pages in one call (see autoPaginate).
 // https://docs.github.com/en/rest/using-the-rest-api/using-pagination-in-the-rest-api
 func WithPagination(schema *jsonschema.Schema) *jsonschema.Schema {
 	schema.Properties["page"] = &jsonschema.Schema{
@@ -297,7 +306,7 @@ func WithPagination(schema *jsonschema.Schema) *jsonschema.Schema {
 		Maximum:     jsonschema.Ptr(100.0),
 	}
 
-	return schema
+	return withMaxItems(schema)
 }
 
 // WithUnifiedPagination adds REST API pagination parameters to a tool.
@@ -321,7 +330,7 @@ func WithUnifiedPagination(schema *jsonschema.Schema) *jsonschema.Schema {
 		Description: "Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs.",
 	}
 
-	return schema
+	return withMaxItems(schema)
 }
 
 // WithCursorPagination adds only cursor-based pagination parameters to a tool (no page parameter).
@@ -338,7 +347,7 @@ func WithCursorPagination(schema *jsonschema.Schema) *jsonschema.Schema {
 		Description: "Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs.",
 	}
 
-	return schema
+	return withMaxItems(schema)
 }
 
 type PaginationParams struct {
@@ -432,11 +441,46 @@ func (p PaginationParams) ToGraphQLParams() (*GraphQLPaginationParams, error) {
 	return cursor.ToGraphQLParams()
 }
 
-func MarshalledTextResult(v any) *mcp.CallToolResult {
+// MarshalledTextResult returns v serialized as text in the output format of the current tool call
+// (see ContextWithOutputFormat), together with the same value as JSON structuredContent so clients
+// can consume it without parsing the text. Values that are not JSON objects, such as lists, are
+// wrapped in an object under the "items" key, matching OutputSchema. When the call selects fields,
+// both the text and structuredContent only contain those fields.
+func MarshalledTextResult(ctx context.Context, v any) *mcp.CallToolResult {
 	data, err := json.Marshal(v)
 	if err != nil {
 		return utils.NewToolResultErrorFromErr("failed to marshal text result to json", err)
 	}
 
-	return utils.NewToolResultText(string(data))
+	if selection := fieldsFromContext(ctx); selection != nil {
+		data, err = selection.projectJSON(data)
+		if err != nil {
+			return utils.NewToolResultErrorFromErr("failed to select fields of text result", err)
+		}
+	}
+
+	text, err := outputformat.Render(OutputFormatFromContext(ctx), data)
+	if err != nil {
+		return utils.NewToolResultErrorFromErr("failed to render text result", err)
+	}
+
+	result := utils.NewToolResultText(text)
+	if len(data) > 0 && data[0] == '{' {
+		result.StructuredContent = json.RawMessage(data)
+	} else {
+		result.StructuredContent = map[string]json.RawMessage{structuredItemsKey: data}
+	}
+	return result
+}
+
+// MessageResponse is the structured content of tools that only report the outcome of an action.
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+// MessageResult returns a plain text message, together with a MessageResponse as structuredContent.
+func MessageResult(message string) *mcp.CallToolResult {
+	result := utils.NewToolResultText(message)
+	result.StructuredContent = MessageResponse{Message: message}
+	return result
 }
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/outputformat"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v79/github"
@@ -28,6 +29,7 @@ type stubDeps struct {
 	t                 translations.TranslationHelperFunc
 	flags             FeatureFlags
 	contentWindowSize int
+	outputFormat      outputformat.Format
 }
 
 func (s stubDeps) GetClient(ctx context.Context) (*github.Client, error) {
@@ -55,6 +57,7 @@ func (s stubDeps) GetRepoAccessCache() *lockdown.RepoAccessCache     { return s.
 func (s stubDeps) GetT() translations.TranslationHelperFunc          { return s.t }
 func (s stubDeps) GetFlags() FeatureFlags                            { return s.flags }
 func (s stubDeps) GetContentWindowSize() int                         { return s.contentWindowSize }
+func (s stubDeps) GetOutputFormat() outputformat.Format              { return s.outputFormat }
 func (s stubDeps) IsFeatureEnabled(_ context.Context, _ string) bool { return false }
 
 // Helper functions to create stub client functions for error testing
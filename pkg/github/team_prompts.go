@@ -0,0 +1,285 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// repoPromptArguments are the owner and repo arguments shared by every repository-scoped prompt.
+// Their names match PromptArgumentResolvers so clients can complete them.
+func repoPromptArguments() []*mcp.PromptArgument {
+	return []*mcp.PromptArgument{
+		{
+			Name:        "owner",
+			Description: "Repository owner",
+			Required:    true,
+		},
+		{
+			Name:        "repo",
+			Description: "Repository name",
+			Required:    true,
+		},
+	}
+}
+
+// ReviewPullRequestPrompt guides a thorough review of a pull request using its diff, changed files and existing review comments
+func ReviewPullRequestPrompt(t translations.TranslationHelperFunc) inventory.ServerPrompt {
+	return inventory.NewServerPrompt(
+		ToolsetMetadataPullRequests,
+		mcp.Prompt{
+			Name:        "review_pull_request",
+			Description: t("PROMPT_REVIEW_PULL_REQUEST_DESCRIPTION", "Review a pull request using its diff, changed files and existing review comments"),
+			Arguments: append(repoPromptArguments(),
+				&mcp.PromptArgument{
+					Name:        "pull_number",
+					Description: "Pull request number",
+					Required:    true,
+				},
+			),
+		},
+		func(_ context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner := request.Params.Arguments["owner"]
+			repo := request.Params.Arguments["repo"]
+			pullNumber := request.Params.Arguments["pull_number"]
+
+			messages := []*mcp.PromptMessage{
+				{
+					Role: "user",
+					Content: &mcp.TextContent{
+						Text: "You are an experienced code reviewer. Focus on correctness, security, readability and test coverage. Do not repeat feedback that reviewers have already given, and clearly separate blocking issues from suggestions.",
+					},
+				},
+				{
+					Role:    "user",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("Please review pull request #%s in %s/%s.", pullNumber, owner, repo)},
+				},
+				{
+					Role:    "assistant",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("I'll review pull request #%s in %s/%s. Let me start by gathering the pull request details, its diff and the existing review discussion.", pullNumber, owner, repo)},
+				},
+				{
+					Role: "user",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("Please:\n"+
+						"1. Use `pull_request_read` with method `get` to understand the purpose of the change\n"+
+						"2. Use `pull_request_read` with method `get_files` to see which files changed and how much\n"+
+						"3. Use `pull_request_read` with method `get_diff` to read the actual changes\n"+
+						"4. Use `pull_request_read` with method `get_review_comments` to see what has already been discussed\n"+
+						"5. Summarize the change and list your findings for #%s, grouped into blocking issues and suggestions, referencing file names and lines", pullNumber)},
+				},
+			}
+			return &mcp.GetPromptResult{
+				Messages: messages,
+			}, nil
+		},
+	)
+}
+
+// DiagnoseCIFailurePrompt guides the investigation of a failed workflow run using the logs of its failed jobs
+func DiagnoseCIFailurePrompt(t translations.TranslationHelperFunc) inventory.ServerPrompt {
+	return inventory.NewServerPrompt(
+		ToolsetMetadataActions,
+		mcp.Prompt{
+			Name:        "diagnose_ci_failure",
+			Description: t("PROMPT_DIAGNOSE_CI_FAILURE_DESCRIPTION", "Diagnose why a GitHub Actions workflow run failed using the logs of its failed jobs"),
+			Arguments: append(repoPromptArguments(),
+				&mcp.PromptArgument{
+					Name:        "run_id",
+					Description: "Workflow run ID",
+					Required:    true,
+				},
+			),
+		},
+		func(_ context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner := request.Params.Arguments["owner"]
+			repo := request.Params.Arguments["repo"]
+			runID := request.Params.Arguments["run_id"]
+
+			messages := []*mcp.PromptMessage{
+				{
+					Role: "user",
+					Content: &mcp.TextContent{
+						Text: "You are a CI troubleshooting assistant. Find the root cause of failures from the logs rather than guessing, distinguish genuine test or build failures from flaky or infrastructure problems, and propose a concrete fix.",
+					},
+				},
+				{
+					Role:    "user",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("Workflow run %s in %s/%s failed. Please figure out why.", runID, owner, repo)},
+				},
+				{
+					Role:    "assistant",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("I'll diagnose workflow run %s in %s/%s. Let me start by fetching the logs of the jobs that failed.", runID, owner, repo)},
+				},
+				{
+					Role: "user",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("Please:\n"+
+						"1. Use `get_job_logs` with `run_id` %s, `failed_only` set to true and `return_content` set to true to read the logs of every failed job\n"+
+						"2. If the logs are long, use `tail_lines` to focus on the end of each job where the error is usually reported\n"+
+						"3. Identify the failing step and the first meaningful error message\n"+
+						"4. Explain the root cause, whether the failure looks flaky, and the change needed to fix it", runID)},
+				},
+			}
+			return &mcp.GetPromptResult{
+				Messages: messages,
+			}, nil
+		},
+	)
+}
+
+// DraftReleaseNotesPrompt guides drafting release notes from the commits and pull requests between two tags
+func DraftReleaseNotesPrompt(t translations.TranslationHelperFunc) inventory.ServerPrompt {
+	return inventory.NewServerPrompt(
+		ToolsetMetadataRepos,
+		mcp.Prompt{
+			Name:        "draft_release_notes",
+			Description: t("PROMPT_DRAFT_RELEASE_NOTES_DESCRIPTION", "Draft release notes from the changes between two tags"),
+			Arguments: append(repoPromptArguments(),
+				&mcp.PromptArgument{
+					Name:        "from_tag",
+					Description: "Tag of the previous release",
+					Required:    true,
+				},
+				&mcp.PromptArgument{
+					Name:        "to_tag",
+					Description: "Tag of the new release",
+					Required:    true,
+				},
+			),
+		},
+		func(_ context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner := request.Params.Arguments["owner"]
+			repo := request.Params.Arguments["repo"]
+			fromTag := request.Params.Arguments["from_tag"]
+			toTag := request.Params.Arguments["to_tag"]
+
+			messages := []*mcp.PromptMessage{
+				{
+					Role: "user",
+					Content: &mcp.TextContent{
+						Text: "You are a release manager writing release notes for users of the project. Group changes into features, fixes and other changes, call out breaking changes first, credit contributors, and leave out purely internal changes such as CI tweaks unless they affect users.",
+					},
+				},
+				{
+					Role:    "user",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("Please draft release notes for %s/%s covering everything from %s to %s.", owner, repo, fromTag, toTag)},
+				},
+				{
+					Role:    "assistant",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("I'll draft release notes for %s/%s between %s and %s. Let me start by resolving both tags.", owner, repo, fromTag, toTag)},
+				},
+				{
+					Role: "user",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("Please:\n"+
+						"1. Use `get_tag` for %s and %s to find the commit and date of each release\n"+
+						"2. Use `list_commits` with `sha` set to %s and stop once you reach the commit tagged %s\n"+
+						"3. Use `search_pull_requests` with a query like `repo:%s/%s is:merged merged:<from date>..<to date>` to find the pull requests behind those commits\n"+
+						"4. Write the release notes in Markdown with sections for breaking changes, features, fixes and other changes, linking each entry to its pull request", fromTag, toTag, toTag, fromTag, owner, repo)},
+				},
+			}
+			return &mcp.GetPromptResult{
+				Messages: messages,
+			}, nil
+		},
+	)
+}
+
+// TriageIssuesPrompt guides labelling recently opened issues using the labels defined in the repository
+func TriageIssuesPrompt(t translations.TranslationHelperFunc) inventory.ServerPrompt {
+	return inventory.NewServerPrompt(
+		ToolsetMetadataIssues,
+		mcp.Prompt{
+			Name:        "triage_issues",
+			Description: t("PROMPT_TRIAGE_ISSUES_DESCRIPTION", "Triage new issues by applying the repository's labels"),
+			Arguments: append(repoPromptArguments(),
+				&mcp.PromptArgument{
+					Name:        "labels",
+					Description: "Comma-separated list of labels to choose from (optional, defaults to all repository labels)",
+					Required:    false,
+				},
+			),
+		},
+		func(_ context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner := request.Params.Arguments["owner"]
+			repo := request.Params.Arguments["repo"]
+
+			labelStep := "1. Use `list_label` to see which labels the repository defines and what they mean"
+			if labels := request.Params.Arguments["labels"]; labels != "" {
+				labelStep = fmt.Sprintf("1. Only use these labels: %s", labels)
+			}
+
+			messages := []*mcp.PromptMessage{
+				{
+					Role: "user",
+					Content: &mcp.TextContent{
+						Text: "You are an issue triage assistant. Read each issue carefully, apply only labels that clearly fit, and never close, assign or edit the content of issues. When an issue is unclear, say so instead of guessing.",
+					},
+				},
+				{
+					Role:    "user",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("Please triage the new issues in %s/%s.", owner, repo)},
+				},
+				{
+					Role:    "assistant",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("I'll triage the new issues in %s/%s. Let me start by reviewing the available labels and the open issues.", owner, repo)},
+				},
+				{
+					Role: "user",
+					Content: &mcp.TextContent{Text: labelStep + "\n" +
+						"2. Use `list_issues` with `state` OPEN, ordered by `CREATED_AT` descending, to find recent issues that have no labels yet\n" +
+						"3. For each of them, decide which labels apply based on the title and body\n" +
+						"4. Show me the proposed labels per issue, and after I confirm, apply them with `issue_write` using method `update`"},
+				},
+			}
+			return &mcp.GetPromptResult{
+				Messages: messages,
+			}, nil
+		},
+	)
+}
+
+// SummarizeSecurityAlertsPrompt guides summarizing the open security alerts of a repository
+func SummarizeSecurityAlertsPrompt(t translations.TranslationHelperFunc) inventory.ServerPrompt {
+	return inventory.NewServerPrompt(
+		ToolsetMetadataCodeSecurity,
+		mcp.Prompt{
+			Name:        "summarize_security_alerts",
+			Description: t("PROMPT_SUMMARIZE_SECURITY_ALERTS_DESCRIPTION", "Summarize the open security alerts of a repository and suggest what to fix first"),
+			Arguments:   repoPromptArguments(),
+		},
+		func(_ context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner := request.Params.Arguments["owner"]
+			repo := request.Params.Arguments["repo"]
+
+			messages := []*mcp.PromptMessage{
+				{
+					Role: "user",
+					Content: &mcp.TextContent{
+						Text: "You are a security analyst. Prioritize alerts by severity and exploitability, group related alerts together, and keep the summary short enough for a maintainer to act on.",
+					},
+				},
+				{
+					Role:    "user",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("Please summarize the open security alerts in %s/%s.", owner, repo)},
+				},
+				{
+					Role:    "assistant",
+					Content: &mcp.TextContent{Text: fmt.Sprintf("I'll summarize the open security alerts in %s/%s. Let me start by collecting alerts from every available source.", owner, repo)},
+				},
+				{
+					Role: "user",
+					Content: &mcp.TextContent{Text: "Please:\n" +
+						"1. Use `list_code_scanning_alerts` with `state` open to collect code scanning alerts\n" +
+						"2. If available, use `list_dependabot_alerts` and `list_secret_scanning_alerts` with `state` open as well\n" +
+						"3. Summarize the number of alerts per source and severity\n" +
+						"4. List the most important alerts to fix first, with a short explanation of the risk and the suggested remediation"},
+				},
+			}
+			return &mcp.GetPromptResult{
+				Messages: messages,
+			}, nil
+		},
+	)
+}
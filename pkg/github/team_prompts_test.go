@@ -0,0 +1,103 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TeamPrompts(t *testing.T) {
+	tests := []struct {
+		name           string
+		prompt         func(translations.TranslationHelperFunc) inventory.ServerPrompt
+		toolset        string
+		args           map[string]string
+		expectContains []string
+		expectMissing  []string
+	}{
+		{
+			name:           "review_pull_request",
+			prompt:         ReviewPullRequestPrompt,
+			toolset:        "pull_requests",
+			args:           map[string]string{"owner": "octo", "repo": "app", "pull_number": "7"},
+			expectContains: []string{"#7 in octo/app", "`get_diff`", "`get_files`", "`get_review_comments`"},
+		},
+		{
+			name:           "diagnose_ci_failure",
+			prompt:         DiagnoseCIFailurePrompt,
+			toolset:        "actions",
+			args:           map[string]string{"owner": "octo", "repo": "app", "run_id": "123"},
+			expectContains: []string{"run 123 in octo/app", "`get_job_logs` with `run_id` 123, `failed_only` set to true"},
+		},
+		{
+			name:           "draft_release_notes",
+			prompt:         DraftReleaseNotesPrompt,
+			toolset:        "repos",
+			args:           map[string]string{"owner": "octo", "repo": "app", "from_tag": "v1.0.0", "to_tag": "v1.1.0"},
+			expectContains: []string{"from v1.0.0 to v1.1.0", "`get_tag`", "`list_commits` with `sha` set to v1.1.0", "repo:octo/app is:merged"},
+		},
+		{
+			name:           "triage_issues with repository labels",
+			prompt:         TriageIssuesPrompt,
+			toolset:        "issues",
+			args:           map[string]string{"owner": "octo", "repo": "app"},
+			expectContains: []string{"`list_label`", "`list_issues`", "`issue_write`"},
+		},
+		{
+			name:           "triage_issues with given labels",
+			prompt:         TriageIssuesPrompt,
+			toolset:        "issues",
+			args:           map[string]string{"owner": "octo", "repo": "app", "labels": "bug, question"},
+			expectContains: []string{"Only use these labels: bug, question"},
+			expectMissing:  []string{"`list_label`"},
+		},
+		{
+			name:           "summarize_security_alerts",
+			prompt:         SummarizeSecurityAlertsPrompt,
+			toolset:        "code_security",
+			args:           map[string]string{"owner": "octo", "repo": "app"},
+			expectContains: []string{"in octo/app", "`list_code_scanning_alerts`", "`list_dependabot_alerts`", "`list_secret_scanning_alerts`"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			prompt := tc.prompt(translations.NullTranslationHelper)
+			assert.Equal(t, tc.toolset, string(prompt.Toolset.ID))
+			assert.NotEmpty(t, prompt.Prompt.Description)
+
+			// Every required argument must be supplied by the test case
+			for _, arg := range prompt.Prompt.Arguments {
+				if arg.Required {
+					assert.Contains(t, tc.args, arg.Name)
+				}
+			}
+
+			result, err := prompt.Handler(context.Background(), &mcp.GetPromptRequest{
+				Params: &mcp.GetPromptParams{Name: prompt.Prompt.Name, Arguments: tc.args},
+			})
+			require.NoError(t, err)
+			require.NotEmpty(t, result.Messages)
+
+			var sb strings.Builder
+			for _, msg := range result.Messages {
+				text, ok := msg.Content.(*mcp.TextContent)
+				require.True(t, ok)
+				sb.WriteString(text.Text)
+				sb.WriteString("\n")
+			}
+			for _, s := range tc.expectContains {
+				assert.Contains(t, sb.String(), s)
+			}
+			for _, s := range tc.expectMissing {
+				assert.NotContains(t, sb.String(), s)
+			}
+		})
+	}
+}
@@ -158,8 +158,10 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 	return []inventory.ServerTool{
 		// Context tools
 		GetMe(t),
+		GetCurrentRepository(t),
 		GetTeams(t),
 		GetTeamMembers(t),
+		ReadMore(t),
 
 		// Repository tools
 		SearchRepositories(t),
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -81,6 +82,40 @@ func TestAllPromptsHaveRequiredMetadata(t *testing.T) {
 	}
 }
 
+// TestAllToolsHaveOutputSchema validates that every tool declares an object output schema that resolves
+// and declares the fields of its results, so clients can rely on structuredContent and arguments such as
+// fields can be validated. Tools that return embedded resources are exempt.
+func TestAllToolsHaveOutputSchema(t *testing.T) {
+	exempt := map[string]bool{
+		"get_file_contents": true,
+	}
+	// Tools whose results have no fields to declare.
+	withoutFields := map[string]bool{
+		"get_team_members": true, // a list of logins
+	}
+
+	for _, tool := range AllTools(stubTranslation) {
+		t.Run(tool.Tool.Name, func(t *testing.T) {
+			if exempt[tool.Tool.Name] {
+				assert.Nil(t, tool.Tool.OutputSchema,
+					"Tool %q returns resources and should not declare an OutputSchema", tool.Tool.Name)
+				return
+			}
+
+			require.NotNil(t, tool.Tool.OutputSchema,
+				"Tool %q must declare an OutputSchema", tool.Tool.Name)
+			schema, ok := tool.Tool.OutputSchema.(*jsonschema.Schema)
+			require.True(t, ok, "Tool %q OutputSchema should be a *jsonschema.Schema", tool.Tool.Name)
+			assert.Equal(t, "object", schema.Type,
+				"Tool %q OutputSchema must have type object", tool.Tool.Name)
+			_, err := schema.Resolve(nil)
+			assert.NoError(t, err, "Tool %q OutputSchema must resolve", tool.Tool.Name)
+			assert.Equal(t, !withoutFields[tool.Tool.Name], declaresFields(schema),
+				"Tool %q OutputSchema must declare the properties of its results, in every anyOf alternative", tool.Tool.Name)
+		})
+	}
+}
+
 // TestToolReadOnlyHintConsistency validates that read-only tools are correctly annotated
 func TestToolReadOnlyHintConsistency(t *testing.T) {
 	tools := AllTools(stubTranslation)
@@ -1,12 +1,20 @@
 package github
 
-import "github.com/github/github-mcp-server/pkg/inventory"
+import (
+	"context"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+)
 
 // Toolset instruction functions - these generate context-aware instructions for each toolset.
 // They are called during inventory build to generate server instructions.
 
-func generateContextToolsetInstructions(_ *inventory.Inventory) string {
-	return "Always call 'get_me' first to understand current user permissions and context."
+func generateContextToolsetInstructions(inv *inventory.Inventory) string {
+	instructions := "Always call 'get_me' first to understand current user permissions and context."
+	if inv.IsToolEnabled(context.Background(), "get_current_repository") {
+		instructions += " When the user refers to the current repository or does not name one, call 'get_current_repository' to get the default owner and repo instead of guessing."
+	}
+	return instructions
 }
 
 func generateIssuesToolsetInstructions(_ *inventory.Inventory) string {
@@ -0,0 +1,225 @@
+// Package gitrepo finds the GitHub repository of a local git checkout from its remotes.
+package gitrepo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Repository is a GitHub repository found in the remotes of a local checkout.
+type Repository struct {
+	Owner string
+	Name  string
+	// Remote is the name of the git remote pointing to the repository, such as origin.
+	Remote string
+	// URL is the URL of the remote.
+	URL string
+	// Dir is the root of the working tree.
+	Dir string
+}
+
+// FullName returns the repository in the owner/name form.
+func (r Repository) FullName() string {
+	return r.Owner + "/" + r.Name
+}
+
+// Find looks for a git checkout containing dir and returns the repository of its first remote on
+// the given host, preferring the remote called origin. It returns nil if dir is not in a git
+// checkout or if no remote points to the host.
+func Find(dir, hostname string) (*Repository, error) {
+	root, gitDir, err := findGitDir(dir)
+	if err != nil || root == "" {
+		return nil, err
+	}
+
+	remotes, err := readRemotes(configPath(gitDir))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == "origin") != (names[j] == "origin") {
+			return names[i] == "origin"
+		}
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		host, owner, repo, ok := ParseRemoteURL(remotes[name])
+		if ok && MatchesHost(host, hostname) {
+			return &Repository{Owner: owner, Name: repo, Remote: name, URL: remotes[name], Dir: root}, nil
+		}
+	}
+	return nil, nil
+}
+
+// ParseRemoteURL returns the host, owner and repository name of a git remote URL. It accepts
+// URLs such as https://github.com/owner/repo.git, ssh://git@github.com/owner/repo and the scp-like
+// git@github.com:owner/repo.git form.
+func ParseRemoteURL(remoteURL string) (host, owner, repo string, ok bool) {
+	remoteURL = strings.TrimSpace(remoteURL)
+
+	var path string
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return "", "", "", false
+		}
+		switch u.Scheme {
+		case "https", "http", "ssh", "git", "git+ssh", "ssh+git":
+		default:
+			return "", "", "", false
+		}
+		host, path = u.Hostname(), u.Path
+	} else {
+		userHost, p, found := strings.Cut(remoteURL, ":")
+		if !found || strings.Contains(userHost, "/") {
+			return "", "", "", false
+		}
+		if _, h, hasUser := strings.Cut(userHost, "@"); hasUser {
+			userHost = h
+		}
+		host, path = userHost, p
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	owner, repo, found := strings.Cut(path, "/")
+	if host == "" || !found || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", "", false
+	}
+	return host, owner, repo, true
+}
+
+// MatchesHost reports whether a remote host is the given GitHub hostname. Remotes on github.com may
+// also use the ssh.github.com and www.github.com hosts.
+func MatchesHost(remoteHost, hostname string) bool {
+	remoteHost, hostname = strings.ToLower(remoteHost), strings.ToLower(hostname)
+	if remoteHost == hostname {
+		return true
+	}
+	return hostname == "github.com" && (remoteHost == "ssh.github.com" || remoteHost == "www.github.com")
+}
+
+// findGitDir walks up from dir to the root of a working tree and returns it with its git
+// directory. The .git entry of worktrees and submodules is a file pointing to the git directory.
+func findGitDir(dir string) (root, gitDir string, err error) {
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	for {
+		dotGit := filepath.Join(dir, ".git")
+		info, err := os.Stat(dotGit)
+		switch {
+		case err == nil && info.IsDir():
+			return dir, dotGit, nil
+		case err == nil:
+			gitDir, err := readGitFile(dotGit)
+			if err != nil {
+				return "", "", err
+			}
+			return dir, gitDir, nil
+		case !errors.Is(err, os.ErrNotExist):
+			return "", "", fmt.Errorf("failed to read %s: %w", dotGit, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", nil
+		}
+		dir = parent
+	}
+}
+
+// readGitFile returns the git directory that a .git file points to.
+func readGitFile(path string) (string, error) {
+	data, err := os.ReadFile(path) //#nosec G304 -- path is a .git file in a directory of the client or the server
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return "", fmt.Errorf("%s does not point to a git directory", path)
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+	return gitDir, nil
+}
+
+// configPath returns the config file of a git directory. Linked worktrees share the config of the
+// main git directory, which their commondir file points to.
+func configPath(gitDir string) string {
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil { //#nosec G304 -- path is in a git directory
+		commonDir := strings.TrimSpace(string(data))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+		return filepath.Join(commonDir, "config")
+	}
+	return filepath.Join(gitDir, "config")
+}
+
+// readRemotes returns the URL of each remote in a git config file. Only the first URL of a remote
+// is used, as git does for fetching.
+func readRemotes(path string) (map[string]string, error) {
+	f, err := os.Open(path) //#nosec G304 -- path is a config file in a git directory
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git config: %w", err)
+	}
+	defer f.Close()
+
+	remotes := make(map[string]string)
+	var remote string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			remote = parseRemoteSection(line)
+			continue
+		}
+		if remote == "" {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found || !strings.EqualFold(strings.TrimSpace(key), "url") {
+			continue
+		}
+		if _, seen := remotes[remote]; !seen {
+			remotes[remote] = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git config: %w", err)
+	}
+	return remotes, nil
+}
+
+// parseRemoteSection returns the remote name of a [remote "name"] section header, or an empty
+// string for other sections.
+func parseRemoteSection(line string) string {
+	section, _, _ := strings.Cut(strings.TrimPrefix(line, "["), "]")
+	kind, name, found := strings.Cut(strings.TrimSpace(section), " ")
+	if !found || !strings.EqualFold(kind, "remote") {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(name), `"`)
+}
@@ -11,6 +11,14 @@ import (
 // Returns true if the tool should be included, false to exclude it.
 type ToolFilter func(ctx context.Context, tool *ServerTool) (bool, error)
 
+// ToolTransform is a function that rewrites a tool when the inventory is built, for example to
+// change its input schema and wrap its handler. Returns false to drop the tool from the inventory.
+type ToolTransform func(tool ServerTool) (ServerTool, bool)
+
+// ResourceTemplateTransform is a function that rewrites a resource template when the inventory
+// is built. Returns false to drop the resource template from the inventory.
+type ResourceTemplateTransform func(resource ServerResourceTemplate) (ServerResourceTemplate, bool)
+
 // Builder builds a Registry with the specified configuration.
 // Use NewBuilder to create a builder, chain configuration methods,
 // then call Build() to create the final inventory.
@@ -35,11 +43,14 @@ type Builder struct {
 
 	// Configuration options (processed at Build time)
 	readOnly             bool
-	toolsetIDs           []string // raw input, processed at Build()
-	toolsetIDsIsNil      bool     // tracks if nil was passed (nil = defaults)
-	additionalTools      []string // raw input, processed at Build()
+	toolsetIDs           []string        // raw input, processed at Build()
+	toolsetIDsIsNil      bool            // tracks if nil was passed (nil = defaults)
+	additionalTools      []string        // raw input, processed at Build()
+	customToolsets       []CustomToolset // raw input, processed at Build()
 	featureChecker       FeatureFlagChecker
-	filters              []ToolFilter // filters to apply to all tools
+	filters              []ToolFilter    // filters to apply to all tools
+	transforms           []ToolTransform // transforms to apply to all tools at Build()
+	resourceTransforms   []ResourceTemplateTransform
 	generateInstructions bool
 }
 
@@ -135,6 +146,65 @@ func (b *Builder) WithFilter(filter ToolFilter) *Builder {
 	return b
 }
 
+// WithToolTransform adds a transform that is applied to every tool at Build time.
+// Unlike filters, which are evaluated on every request, dropped tools are removed from the
+// inventory entirely, including from ToolsForToolset and the toolset IDs.
+// Multiple transforms can be added and are applied in order.
+// Returns self for chaining.
+func (b *Builder) WithToolTransform(transform ToolTransform) *Builder {
+	b.transforms = append(b.transforms, transform)
+	return b
+}
+
+// WithResourceTemplateTransform adds a transform that is applied to every resource template at
+// Build time. Multiple transforms can be added and are applied in order.
+// Returns self for chaining.
+func (b *Builder) WithResourceTemplateTransform(transform ResourceTemplateTransform) *Builder {
+	b.resourceTransforms = append(b.resourceTransforms, transform)
+	return b
+}
+
+// transformResourceTemplates applies the resource transforms to the resource templates, without
+// modifying b.resourceTemplates.
+func (b *Builder) transformResourceTemplates() []ServerResourceTemplate {
+	if len(b.resourceTransforms) == 0 {
+		return b.resourceTemplates
+	}
+	resources := make([]ServerResourceTemplate, 0, len(b.resourceTemplates))
+	for _, resource := range b.resourceTemplates {
+		keep := true
+		for _, transform := range b.resourceTransforms {
+			if resource, keep = transform(resource); !keep {
+				break
+			}
+		}
+		if keep {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// transformTools applies the transforms to the tools, without modifying b.tools.
+func (b *Builder) transformTools() []ServerTool {
+	if len(b.transforms) == 0 {
+		return b.tools
+	}
+	tools := make([]ServerTool, 0, len(b.tools))
+	for _, tool := range b.tools {
+		keep := true
+		for _, transform := range b.transforms {
+			if tool, keep = transform(tool); !keep {
+				break
+			}
+		}
+		if keep {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
 // cleanTools trims whitespace and removes duplicates from tool names.
 // Empty strings after trimming are excluded.
 func cleanTools(tools []string) []string {
@@ -163,8 +233,8 @@ func cleanTools(tools []string) []string {
 // This ensures invalid tool configurations fail fast at build time.
 func (b *Builder) Build() (*Inventory, error) {
 	r := &Inventory{
-		tools:             b.tools,
-		resourceTemplates: b.resourceTemplates,
+		tools:             b.transformTools(),
+		resourceTemplates: b.transformResourceTemplates(),
 		prompts:           b.prompts,
 		deprecatedAliases: b.deprecatedAliases,
 		readOnly:          b.readOnly,
@@ -172,15 +242,22 @@ func (b *Builder) Build() (*Inventory, error) {
 		filters:           b.filters,
 	}
 
-	// Process toolsets and pre-compute metadata in a single pass
-	r.enabledToolsets, r.unrecognizedToolsets, r.toolsetIDs, r.toolsetIDSet, r.defaultToolsetIDs, r.toolsetDescriptions = b.processToolsets()
-
 	// Build set of valid tool names for validation
 	validToolNames := make(map[string]bool, len(b.tools))
 	for i := range b.tools {
 		validToolNames[b.tools[i].Tool.Name] = true
 	}
 
+	// Process custom toolsets first, so that they are recognized as toolsets below
+	var err error
+	r.customToolsets, r.customToolsetsByTool, err = b.processCustomToolsets(validToolNames)
+	if err != nil {
+		return nil, err
+	}
+
+	// Process toolsets and pre-compute metadata in a single pass
+	r.enabledToolsets, r.unrecognizedToolsets, r.toolsetIDs, r.toolsetIDSet, r.defaultToolsetIDs, r.toolsetDescriptions = b.processToolsets(r.tools, r.customToolsets)
+
 	// Process additional tools (clean, resolve aliases, and track unrecognized)
 	if len(b.additionalTools) > 0 {
 		cleanedTools := cleanTools(b.additionalTools)
@@ -222,14 +299,14 @@ func (b *Builder) Build() (*Inventory, error) {
 // - toolsetIDSet map for O(1) HasToolset lookup
 // - defaultToolsetIDs sorted list of default toolset IDs
 // - toolsetDescriptions map of toolset ID to description
-func (b *Builder) processToolsets() (map[ToolsetID]bool, []string, []ToolsetID, map[ToolsetID]bool, []ToolsetID, map[ToolsetID]string) {
+func (b *Builder) processToolsets(tools []ServerTool, customToolsets []CustomToolset) (map[ToolsetID]bool, []string, []ToolsetID, map[ToolsetID]bool, []ToolsetID, map[ToolsetID]string) {
 	// Single pass: collect all toolset metadata together
 	validIDs := make(map[ToolsetID]bool)
 	defaultIDs := make(map[ToolsetID]bool)
 	descriptions := make(map[ToolsetID]string)
 
-	for i := range b.tools {
-		t := &b.tools[i]
+	for i := range tools {
+		t := &tools[i]
 		validIDs[t.Toolset.ID] = true
 		if t.Toolset.Default {
 			defaultIDs[t.Toolset.ID] = true
@@ -258,6 +335,14 @@ func (b *Builder) processToolsets() (map[ToolsetID]bool, []string, []ToolsetID,
 			descriptions[p.Toolset.ID] = p.Toolset.Description
 		}
 	}
+	for i := range customToolsets {
+		ts := &customToolsets[i]
+		validIDs[ts.ID] = true
+		if ts.Default {
+			defaultIDs[ts.ID] = true
+		}
+		descriptions[ts.ID] = ts.Description
+	}
 
 	// Build sorted slices from the collected maps
 	allToolsetIDs := make([]ToolsetID, 0, len(validIDs))
@@ -0,0 +1,118 @@
+package inventory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CustomToolset is a toolset defined by configuration rather than in code. It is composed of
+// existing tools, which keep belonging to their own toolset as well. Once built into an
+// Inventory, a custom toolset can be used anywhere a toolset ID is accepted.
+type CustomToolset struct {
+	ToolsetMetadata
+	// Tools are the names of the tools in the toolset. Deprecated aliases are resolved to
+	// their canonical names during Build().
+	Tools []string
+}
+
+// WithCustomToolsets adds toolsets composed of existing tools.
+// Build() returns an error if a custom toolset has no tools, refers to unknown tools, or uses
+// the ID of a built-in toolset or a special keyword.
+// Returns self for chaining.
+func (b *Builder) WithCustomToolsets(toolsets []CustomToolset) *Builder {
+	b.customToolsets = append(b.customToolsets, toolsets...)
+	return b
+}
+
+// processCustomToolsets validates the custom toolsets and resolves their tool names. It returns
+// the toolsets sorted by ID and, for each tool, the custom toolsets that contain it.
+func (b *Builder) processCustomToolsets(validToolNames map[string]bool) ([]CustomToolset, map[string][]ToolsetID, error) {
+	if len(b.customToolsets) == 0 {
+		return nil, nil, nil
+	}
+
+	reserved := map[ToolsetID]bool{"all": true, "default": true}
+	for i := range b.tools {
+		reserved[b.tools[i].Toolset.ID] = true
+	}
+	for i := range b.resourceTemplates {
+		reserved[b.resourceTemplates[i].Toolset.ID] = true
+	}
+	for i := range b.prompts {
+		reserved[b.prompts[i].Toolset.ID] = true
+	}
+
+	toolsets := make([]CustomToolset, 0, len(b.customToolsets))
+	defined := make(map[ToolsetID]bool, len(b.customToolsets))
+	byTool := make(map[string][]ToolsetID)
+	for _, ts := range b.customToolsets {
+		ts.ID = ToolsetID(strings.TrimSpace(string(ts.ID)))
+		switch {
+		case ts.ID == "":
+			return nil, nil, fmt.Errorf("custom toolset has no ID")
+		case reserved[ts.ID]:
+			return nil, nil, fmt.Errorf("custom toolset %q conflicts with a built-in toolset or keyword", ts.ID)
+		case defined[ts.ID]:
+			return nil, nil, fmt.Errorf("custom toolset %q is defined more than once", ts.ID)
+		}
+		defined[ts.ID] = true
+
+		var tools, unrecognized []string
+		seen := make(map[string]bool)
+		for _, name := range cleanTools(ts.Tools) {
+			if canonical, isAlias := b.deprecatedAliases[name]; isAlias {
+				name = canonical
+			}
+			if !validToolNames[name] {
+				unrecognized = append(unrecognized, name)
+				continue
+			}
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			tools = append(tools, name)
+			byTool[name] = append(byTool[name], ts.ID)
+		}
+		if len(unrecognized) > 0 {
+			return nil, nil, fmt.Errorf("custom toolset %q has unrecognized tools: %s", ts.ID, strings.Join(unrecognized, ", "))
+		}
+		if len(tools) == 0 {
+			return nil, nil, fmt.Errorf("custom toolset %q has no tools", ts.ID)
+		}
+		ts.Tools = tools
+		if ts.Description == "" {
+			ts.Description = "Custom toolset with " + strings.Join(tools, ", ")
+		}
+		toolsets = append(toolsets, ts)
+	}
+
+	sort.Slice(toolsets, func(i, j int) bool { return toolsets[i].ID < toolsets[j].ID })
+	return toolsets, byTool, nil
+}
+
+// CustomToolsets returns the toolsets defined by configuration, sorted by ID.
+func (r *Inventory) CustomToolsets() []CustomToolset {
+	return r.customToolsets
+}
+
+// customToolset returns the custom toolset with the given ID, or nil if there is none.
+func (r *Inventory) customToolset(toolsetID ToolsetID) *CustomToolset {
+	for i := range r.customToolsets {
+		if r.customToolsets[i].ID == toolsetID {
+			return &r.customToolsets[i]
+		}
+	}
+	return nil
+}
+
+// isInEnabledCustomToolset checks if a tool belongs to a custom toolset that is enabled.
+func (r *Inventory) isInEnabledCustomToolset(toolName string) bool {
+	for _, id := range r.customToolsetsByTool[toolName] {
+		if r.isToolsetEnabled(id) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ExportFormat is a machine-readable format the tool catalog can be exported in.
+type ExportFormat string
+
+const (
+	// ExportFormatMCP is the result of an MCP tools/list request.
+	ExportFormatMCP ExportFormat = "mcp"
+	// ExportFormatOpenAI is the function-calling tool list of the OpenAI APIs.
+	ExportFormatOpenAI ExportFormat = "openai"
+	// ExportFormatAnthropic is the tool-use tool list of the Anthropic Messages API.
+	ExportFormatAnthropic ExportFormat = "anthropic"
+	// ExportFormatJSONSchema is a JSON Schema document defining the input of each tool.
+	ExportFormatJSONSchema ExportFormat = "jsonschema"
+)
+
+// ExportFormats lists the supported export formats.
+var ExportFormats = []ExportFormat{ExportFormatMCP, ExportFormatOpenAI, ExportFormatAnthropic, ExportFormatJSONSchema}
+
+// ExportMetaKey is the key under which exported tools carry their ToolMetadata: in _meta for
+// the MCP format, as an x- keyword for the JSON Schema format.
+const ExportMetaKey = "github"
+
+// ToolMetadata is the metadata the server keeps about a tool beyond its MCP definition,
+// included in every export format so that gateways can register and filter tools.
+type ToolMetadata struct {
+	Toolset        string               `json:"toolset"`
+	ReadOnly       bool                 `json:"read_only"`
+	RequiredScopes []string             `json:"required_scopes"`
+	AcceptedScopes []string             `json:"accepted_scopes"`
+	Annotations    *mcp.ToolAnnotations `json:"annotations,omitempty"`
+}
+
+// OpenAITool is a tool in the OpenAI function-calling format.
+type OpenAITool struct {
+	Type     string         `json:"type"`
+	Function OpenAIFunction `json:"function"`
+	// Metadata is not part of the OpenAI format and must be removed before registering the tool.
+	Metadata ToolMetadata `json:"metadata"`
+}
+
+// OpenAIFunction is the function definition of an OpenAITool.
+type OpenAIFunction struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Parameters  any    `json:"parameters"`
+}
+
+// AnthropicTool is a tool in the Anthropic tool-use format.
+type AnthropicTool struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	InputSchema any    `json:"input_schema"`
+	// Metadata is not part of the Anthropic format and must be removed before registering the tool.
+	Metadata ToolMetadata `json:"metadata"`
+}
+
+// jsonSchemaDialect is the JSON Schema dialect of the tool input schemas.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// NewToolMetadata returns the metadata of a tool.
+func NewToolMetadata(tool *ServerTool) ToolMetadata {
+	requiredScopes := tool.RequiredScopes
+	if requiredScopes == nil {
+		requiredScopes = []string{}
+	}
+	acceptedScopes := tool.AcceptedScopes
+	if acceptedScopes == nil {
+		acceptedScopes = []string{}
+	}
+	return ToolMetadata{
+		Toolset:        string(tool.Toolset.ID),
+		ReadOnly:       tool.IsReadOnly(),
+		RequiredScopes: requiredScopes,
+		AcceptedScopes: acceptedScopes,
+		Annotations:    tool.Tool.Annotations,
+	}
+}
+
+// ExportTools converts tools to the given format. The result is meant to be encoded as JSON.
+func ExportTools(tools []ServerTool, format ExportFormat) (any, error) {
+	switch format {
+	case ExportFormatMCP:
+		return exportMCP(tools), nil
+	case ExportFormatOpenAI:
+		return exportOpenAI(tools)
+	case ExportFormatAnthropic:
+		return exportAnthropic(tools)
+	case ExportFormatJSONSchema:
+		return exportJSONSchema(tools)
+	default:
+		return nil, fmt.Errorf("unknown export format %q, expected one of %v", format, ExportFormats)
+	}
+}
+
+func exportMCP(tools []ServerTool) *mcp.ListToolsResult {
+	result := &mcp.ListToolsResult{Tools: make([]*mcp.Tool, 0, len(tools))}
+	for i := range tools {
+		tool := tools[i].Tool
+		meta := mcp.Meta{}
+		for k, v := range tool.Meta {
+			meta[k] = v
+		}
+		meta[ExportMetaKey] = NewToolMetadata(&tools[i])
+		tool.Meta = meta
+		result.Tools = append(result.Tools, &tool)
+	}
+	return result
+}
+
+func exportOpenAI(tools []ServerTool) ([]OpenAITool, error) {
+	result := make([]OpenAITool, 0, len(tools))
+	for i := range tools {
+		schema, err := schemaMap(tools[i].Tool.InputSchema)
+		if err != nil {
+			return nil, fmt.Errorf("tool %s: %w", tools[i].Tool.Name, err)
+		}
+		result = append(result, OpenAITool{
+			Type: "function",
+			Function: OpenAIFunction{
+				Name:        tools[i].Tool.Name,
+				Description: tools[i].Tool.Description,
+				Parameters:  schema,
+			},
+			Metadata: NewToolMetadata(&tools[i]),
+		})
+	}
+	return result, nil
+}
+
+func exportAnthropic(tools []ServerTool) ([]AnthropicTool, error) {
+	result := make([]AnthropicTool, 0, len(tools))
+	for i := range tools {
+		schema, err := schemaMap(tools[i].Tool.InputSchema)
+		if err != nil {
+			return nil, fmt.Errorf("tool %s: %w", tools[i].Tool.Name, err)
+		}
+		result = append(result, AnthropicTool{
+			Name:        tools[i].Tool.Name,
+			Description: tools[i].Tool.Description,
+			InputSchema: schema,
+			Metadata:    NewToolMetadata(&tools[i]),
+		})
+	}
+	return result, nil
+}
+
+// exportJSONSchema returns a JSON Schema document with the input schema of each tool in $defs,
+// keyed by tool name. Each definition carries the tool's description as its description, and
+// the tool's metadata under the x-github keyword.
+func exportJSONSchema(tools []ServerTool) (map[string]any, error) {
+	defs := make(map[string]any, len(tools))
+	for i := range tools {
+		schema, err := schemaMap(tools[i].Tool.InputSchema)
+		if err != nil {
+			return nil, fmt.Errorf("tool %s: %w", tools[i].Tool.Name, err)
+		}
+		schema["title"] = tools[i].Tool.Name
+		schema["description"] = tools[i].Tool.Description
+		schema["x-"+ExportMetaKey] = NewToolMetadata(&tools[i])
+		defs[tools[i].Tool.Name] = schema
+	}
+	return map[string]any{
+		"$schema": jsonSchemaDialect,
+		"title":   "GitHub MCP Server tools",
+		"$defs":   defs,
+	}, nil
+}
+
+// schemaMap converts a tool schema, which may be a *jsonschema.Schema, a json.RawMessage or a
+// map, to a generic map so it can be extended and encoded with sorted keys.
+func schemaMap(schema any) (map[string]any, error) {
+	if schema == nil {
+		return map[string]any{"type": "object", "properties": map[string]any{}}, nil
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input schema: %w", err)
+	}
+	var result map[string]any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal input schema: %w", err)
+	}
+	return result, nil
+}
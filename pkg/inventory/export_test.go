@@ -0,0 +1,124 @@
+package inventory
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportTestTools() []ServerTool {
+	gist := mockTool("create_gist", "gists", false)
+	gist.Tool.Description = "Create a gist"
+	gist.Tool.Annotations.Title = "Create gist"
+	gist.Tool.InputSchema = &jsonschema.Schema{
+		Type:       "object",
+		Properties: map[string]*jsonschema.Schema{"content": {Type: "string"}},
+		Required:   []string{"content"},
+	}
+	gist.Tool.Meta = mcp.Meta{"existing": true}
+	gist.RequiredScopes = []string{"gist"}
+	gist.AcceptedScopes = []string{"gist"}
+
+	me := mockTool("get_me", "context", true)
+	me.Tool.Description = "Get the user"
+
+	return []ServerTool{gist, me}
+}
+
+// roundTrip encodes v as JSON and decodes it into a generic value, as a consumer would see it.
+func roundTrip(t *testing.T, v any) any {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	var result any
+	require.NoError(t, json.Unmarshal(data, &result))
+	return result
+}
+
+func TestExportTools_MCP(t *testing.T) {
+	tools := exportTestTools()
+	exported, err := ExportTools(tools, ExportFormatMCP)
+	require.NoError(t, err)
+
+	result := roundTrip(t, exported).(map[string]any)
+	list := result["tools"].([]any)
+	require.Len(t, list, 2)
+
+	gist := list[0].(map[string]any)
+	assert.Equal(t, "create_gist", gist["name"])
+	assert.Equal(t, map[string]any{"title": "Create gist"}, gist["annotations"])
+	assert.Equal(t, map[string]any{
+		"existing": true,
+		"github": map[string]any{
+			"toolset":         "gists",
+			"read_only":       false,
+			"required_scopes": []any{"gist"},
+			"accepted_scopes": []any{"gist"},
+			"annotations":     map[string]any{"title": "Create gist"},
+		},
+	}, gist["_meta"])
+
+	me := list[1].(map[string]any)
+	assert.Equal(t, []any{}, me["_meta"].(map[string]any)["github"].(map[string]any)["required_scopes"])
+
+	assert.Equal(t, mcp.Meta{"existing": true}, tools[0].Tool.Meta, "exporting doesn't modify the tools")
+}
+
+func TestExportTools_OpenAI(t *testing.T) {
+	exported, err := ExportTools(exportTestTools(), ExportFormatOpenAI)
+	require.NoError(t, err)
+
+	result := roundTrip(t, exported).([]any)
+	require.Len(t, result, 2)
+	gist := result[0].(map[string]any)
+	assert.Equal(t, "function", gist["type"])
+	assert.Equal(t, map[string]any{
+		"name":        "create_gist",
+		"description": "Create a gist",
+		"parameters": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"content": map[string]any{"type": "string"}},
+			"required":   []any{"content"},
+		},
+	}, gist["function"])
+	assert.Equal(t, "gists", gist["metadata"].(map[string]any)["toolset"])
+}
+
+func TestExportTools_Anthropic(t *testing.T) {
+	exported, err := ExportTools(exportTestTools(), ExportFormatAnthropic)
+	require.NoError(t, err)
+
+	result := roundTrip(t, exported).([]any)
+	require.Len(t, result, 2)
+	me := result[1].(map[string]any)
+	assert.Equal(t, "get_me", me["name"])
+	assert.Equal(t, "Get the user", me["description"])
+	assert.Equal(t, map[string]any{"type": "object", "properties": map[string]any{}}, me["input_schema"])
+	assert.Equal(t, true, me["metadata"].(map[string]any)["read_only"])
+}
+
+func TestExportTools_JSONSchema(t *testing.T) {
+	exported, err := ExportTools(exportTestTools(), ExportFormatJSONSchema)
+	require.NoError(t, err)
+
+	result := roundTrip(t, exported).(map[string]any)
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", result["$schema"])
+	defs := result["$defs"].(map[string]any)
+	require.Len(t, defs, 2)
+
+	gist := defs["create_gist"].(map[string]any)
+	assert.Equal(t, "create_gist", gist["title"])
+	assert.Equal(t, "Create a gist", gist["description"])
+	assert.Equal(t, []any{"content"}, gist["required"])
+	assert.Equal(t, []any{"gist"}, gist["x-github"].(map[string]any)["required_scopes"])
+}
+
+func TestExportTools_UnknownFormat(t *testing.T) {
+	_, err := ExportTools(exportTestTools(), "yaml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown export format "yaml"`)
+}
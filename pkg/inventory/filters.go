@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 )
 
@@ -56,7 +57,9 @@ func (r *Inventory) isFeatureFlagAllowed(ctx context.Context, enableFlag, disabl
 //  2. FeatureFlagEnable/FeatureFlagDisable
 //  3. Read-only filter
 //  4. Builder filters (via WithFilter)
-//  5. Toolset/additional tools
+//  5. Tools disabled at runtime
+//  6. Additional tools
+//  7. Toolset, or a custom toolset containing the tool
 func (r *Inventory) isToolEnabled(ctx context.Context, tool *ServerTool) bool {
 	// 1. Check tool's own Enabled function first
 	if tool.Enabled != nil {
@@ -88,15 +91,16 @@ func (r *Inventory) isToolEnabled(ctx context.Context, tool *ServerTool) bool {
 			return false
 		}
 	}
-	// 5. Check if tool is in additionalTools (bypasses toolset filter)
+	// 5. Check if tool was disabled at runtime
+	if r.disabledTools[tool.Tool.Name] {
+		return false
+	}
+	// 6. Check if tool is in additionalTools (bypasses toolset filter)
 	if r.additionalTools != nil && r.additionalTools[tool.Tool.Name] {
 		return true
 	}
-	// 5. Check toolset filter
-	if !r.isToolsetEnabled(tool.Toolset.ID) {
-		return false
-	}
-	return true
+	// 7. Check toolset filter, including custom toolsets
+	return r.isToolsetEnabled(tool.Toolset.ID) || r.isInEnabledCustomToolset(tool.Tool.Name)
 }
 
 // AvailableTools returns the tools that pass all current filters,
@@ -214,15 +218,20 @@ func (r *Inventory) filterPromptsByName(name string) []ServerPrompt {
 	return []ServerPrompt{}
 }
 
-// ToolsForToolset returns all tools belonging to a specific toolset.
+// ToolsForToolset returns all tools belonging to a specific toolset, or listed in a custom toolset.
 // This method bypasses the toolset enabled filter (for dynamic toolset registration),
 // but still respects the read-only filter.
 func (r *Inventory) ToolsForToolset(toolsetID ToolsetID) []ServerTool {
+	inToolset := func(tool *ServerTool) bool { return tool.Toolset.ID == toolsetID }
+	if custom := r.customToolset(toolsetID); custom != nil {
+		inToolset = func(tool *ServerTool) bool { return slices.Contains(custom.Tools, tool.Tool.Name) }
+	}
+
 	var result []ServerTool
 	for i := range r.tools {
 		tool := &r.tools[i]
 		// Only check read-only filter, not toolset enabled filter
-		if tool.Toolset.ID == toolsetID {
+		if inToolset(tool) {
 			if r.readOnly && !tool.IsReadOnly() {
 				continue
 			}
@@ -245,7 +254,11 @@ func (r *Inventory) IsToolsetEnabled(toolsetID ToolsetID) bool {
 
 // EnableToolset marks a toolset as enabled in this group.
 // This is used by dynamic toolset management to track which toolsets have been enabled.
+// Tools of the toolset that were disabled individually are enabled again.
 func (r *Inventory) EnableToolset(toolsetID ToolsetID) {
+	for _, tool := range r.ToolsForToolset(toolsetID) {
+		delete(r.disabledTools, tool.Tool.Name)
+	}
 	if r.enabledToolsets == nil {
 		// nil means all enabled, so nothing to do
 		return
@@ -253,6 +266,51 @@ func (r *Inventory) EnableToolset(toolsetID ToolsetID) {
 	r.enabledToolsets[toolsetID] = true
 }
 
+// DisableToolset marks a toolset as disabled in this group.
+// This is used by dynamic toolset management to drop toolsets that are no longer needed.
+// Tools of the toolset that were enabled individually stay enabled.
+func (r *Inventory) DisableToolset(toolsetID ToolsetID) {
+	if r.enabledToolsets == nil {
+		// nil means all enabled, so switch to an explicit set without this toolset
+		r.enabledToolsets = make(map[ToolsetID]bool, len(r.toolsetIDs))
+		for _, id := range r.toolsetIDs {
+			r.enabledToolsets[id] = true
+		}
+	}
+	delete(r.enabledToolsets, toolsetID)
+}
+
+// EnableTool marks a single tool as enabled, whether or not its toolset is enabled.
+// This is used by dynamic toolset management to add individual tools.
+func (r *Inventory) EnableTool(toolName string) {
+	delete(r.disabledTools, toolName)
+	if r.additionalTools == nil {
+		r.additionalTools = make(map[string]bool)
+	}
+	r.additionalTools[toolName] = true
+}
+
+// DisableTool marks a single tool as disabled, even if its toolset is enabled.
+// This is used by dynamic toolset management to drop individual tools.
+func (r *Inventory) DisableTool(toolName string) {
+	delete(r.additionalTools, toolName)
+	if r.disabledTools == nil {
+		r.disabledTools = make(map[string]bool)
+	}
+	r.disabledTools[toolName] = true
+}
+
+// IsToolEnabled checks if a tool with the given name passes all current filters.
+// The context is used for feature flag evaluation.
+func (r *Inventory) IsToolEnabled(ctx context.Context, toolName string) bool {
+	for i := range r.tools {
+		if r.tools[i].Tool.Name == toolName && r.isToolEnabled(ctx, &r.tools[i]) {
+			return true
+		}
+	}
+	return false
+}
+
 // EnabledToolsetIDs returns the list of enabled toolset IDs based on current filters.
 // Returns all toolset IDs if no filter is set.
 func (r *Inventory) EnabledToolsetIDs() []ToolsetID {
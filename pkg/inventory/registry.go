@@ -3,6 +3,7 @@ package inventory
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"slices"
 	"sort"
@@ -23,7 +24,7 @@ import (
 //   - Filtered access to tools/resources/prompts via Available* methods
 //   - Deterministic ordering for documentation generation
 //   - Lazy dependency injection during registration via RegisterAll()
-//   - Runtime toolset enabling for dynamic toolsets mode
+//   - Runtime toolset and tool enabling/disabling for dynamic toolsets mode
 type Inventory struct {
 	// tools holds all tools in this group (ordered for iteration)
 	tools []ServerTool
@@ -49,6 +50,14 @@ type Inventory struct {
 	// additionalTools are specific tools that bypass toolset filtering (but still respect read-only)
 	// These are additive - a tool is included if it matches toolset filters OR is in this set
 	additionalTools map[string]bool
+	// customToolsets are the toolsets defined by configuration, sorted by ID
+	customToolsets []CustomToolset
+	// customToolsetsByTool maps tool names to the custom toolsets that contain them. A tool is
+	// included if its own toolset or one of these toolsets is enabled.
+	customToolsetsByTool map[string][]ToolsetID
+	// disabledTools are specific tools that were disabled at runtime. They are excluded even if
+	// their toolset is enabled or they are in additionalTools.
+	disabledTools map[string]bool
 	// featureChecker when non-nil, checks if a feature flag is enabled.
 	// Takes context and flag name, returns (enabled, error). If error, log and treat as false.
 	// If checker is nil, all flag checks return false.
@@ -103,14 +112,19 @@ func (r *Inventory) ForMCPRequest(method string, itemName string) *Inventory {
 	// Create a shallow copy with shared filter settings
 	// Note: lazy-init maps (toolsByName, etc.) are NOT copied - the new Registry
 	// will initialize its own maps on first use if needed
+	// The enabled and disabled toolsets and tools are copied, since dynamic toolset management
+	// changes them at runtime through EnableToolset, EnableTool and friends.
 	result := &Inventory{
 		tools:                r.tools,
 		resourceTemplates:    r.resourceTemplates,
 		prompts:              r.prompts,
 		deprecatedAliases:    r.deprecatedAliases,
 		readOnly:             r.readOnly,
-		enabledToolsets:      r.enabledToolsets, // shared, not modified
-		additionalTools:      r.additionalTools, // shared, not modified
+		enabledToolsets:      maps.Clone(r.enabledToolsets),
+		additionalTools:      maps.Clone(r.additionalTools),
+		disabledTools:        maps.Clone(r.disabledTools),
+		customToolsets:       r.customToolsets,
+		customToolsetsByTool: r.customToolsetsByTool,
 		featureChecker:       r.featureChecker,
 		filters:              r.filters, // shared, not modified
 		unrecognizedToolsets: r.unrecognizedToolsets,
@@ -268,7 +282,7 @@ func (r *Inventory) AllTools() []ServerTool {
 
 // AvailableToolsets returns the unique toolsets that have tools, in sorted order.
 // This is the ordered intersection of toolsets with reality - only toolsets that
-// actually contain tools are returned, sorted by toolset ID.
+// actually contain tools are returned, sorted by toolset ID. Custom toolsets are included.
 // Optional exclude parameter filters out specific toolset IDs from the result.
 func (r *Inventory) AvailableToolsets(exclude ...ToolsetID) []ToolsetMetadata {
 	tools := r.AllTools()
@@ -292,6 +306,15 @@ func (r *Inventory) AvailableToolsets(exclude ...ToolsetID) []ToolsetMetadata {
 			}
 		}
 	}
+	if len(r.customToolsets) == 0 {
+		return result
+	}
+	for _, ts := range r.customToolsets {
+		if !excludeSet[ts.ID] {
+			result = append(result, ts.ToolsetMetadata)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
 	return result
 }
 
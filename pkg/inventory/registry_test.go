@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -812,6 +814,35 @@ func TestForMCPRequest_ToolsCall_NotFound(t *testing.T) {
 	}
 }
 
+func TestForMCPRequest_CopiesEnabledTools(t *testing.T) {
+	tools := []ServerTool{
+		mockTool("get_me", "context", true),
+		mockTool("create_issue", "issues", false),
+		mockTool("list_repos", "repos", true),
+	}
+
+	reg := mustBuild(t, NewBuilder().SetTools(tools).WithToolsets([]string{"context"}).WithTools([]string{"list_repos"}))
+	filtered := reg.ForMCPRequest(MCPMethodToolsList, "")
+
+	// Dynamic toolset management changes the inventory while requests use their copies
+	reg.EnableTool("create_issue")
+	reg.DisableTool("list_repos")
+	reg.DisableToolset("context")
+
+	var names []string
+	for _, tool := range filtered.AvailableTools(context.Background()) {
+		names = append(names, tool.Tool.Name)
+	}
+	if !slices.Equal(names, []string{"get_me", "list_repos"}) {
+		t.Errorf("Expected the request's tools to be unaffected, got %v", names)
+	}
+
+	filtered.EnableTool("get_me")
+	if reg.IsToolEnabled(context.Background(), "get_me") {
+		t.Error("Expected changes to the request's inventory not to affect the original")
+	}
+}
+
 func TestForMCPRequest_ToolsCall_DeprecatedAlias(t *testing.T) {
 	tools := []ServerTool{
 		mockTool("get_me", "context", true),
@@ -1832,3 +1863,206 @@ func TestWithTools_DeprecatedAliasAndFeatureFlag(t *testing.T) {
 		t.Errorf("Flag ON: Expected new_tool (via alias), got %s", availableOn[0].Tool.Name)
 	}
 }
+
+func TestRuntimeToolsetAndToolToggling(t *testing.T) {
+	ctx := context.Background()
+	tools := []ServerTool{
+		mockTool("tool1", "toolset1", true),
+		mockTool("tool2", "toolset1", true),
+		mockTool("tool3", "toolset2", true),
+	}
+	reg := mustBuild(t, NewBuilder().SetTools(tools).WithToolsets([]string{"all"}))
+
+	availableNames := func() []string {
+		var names []string
+		for _, tool := range reg.AvailableTools(ctx) {
+			names = append(names, tool.Tool.Name)
+		}
+		return names
+	}
+
+	// Disabling a toolset when all toolsets are enabled keeps the others
+	reg.DisableToolset("toolset1")
+	require.False(t, reg.IsToolsetEnabled("toolset1"))
+	require.True(t, reg.IsToolsetEnabled("toolset2"))
+	require.Equal(t, []string{"tool3"}, availableNames())
+
+	// A single tool can be enabled without its toolset
+	reg.EnableTool("tool2")
+	require.True(t, reg.IsToolEnabled(ctx, "tool2"))
+	require.False(t, reg.IsToolEnabled(ctx, "tool1"))
+	require.Equal(t, []string{"tool2", "tool3"}, availableNames())
+
+	// A single tool can be disabled even though its toolset is enabled
+	reg.DisableTool("tool3")
+	require.False(t, reg.IsToolEnabled(ctx, "tool3"))
+	require.Equal(t, []string{"tool2"}, availableNames())
+
+	// Enabling the toolset again enables all of its tools
+	reg.EnableToolset("toolset1")
+	reg.EnableToolset("toolset2")
+	require.Equal(t, []string{"tool1", "tool2", "tool3"}, availableNames())
+
+	// Disabling a tool enabled on its own removes it again
+	reg.DisableToolset("toolset1")
+	reg.DisableTool("tool2")
+	reg.EnableTool("tool1")
+	reg.DisableTool("tool1")
+	require.Equal(t, []string{"tool3"}, availableNames())
+}
+
+func TestCustomToolsets(t *testing.T) {
+	ctx := context.Background()
+	tools := []ServerTool{
+		mockTool("tool1", "toolset1", true),
+		mockTool("tool2", "toolset1", false),
+		mockTool("tool3", "toolset2", true),
+	}
+	custom := []CustomToolset{{
+		ToolsetMetadata: ToolsetMetadata{
+			ID:               "mine",
+			Icon:             "star",
+			InstructionsFunc: func(_ *Inventory) string { return "Use mine." },
+		},
+		Tools: []string{"tool3", "old_tool", "tool1"},
+	}}
+
+	reg := mustBuild(t, NewBuilder().
+		SetTools(tools).
+		WithDeprecatedAliases(map[string]string{"old_tool": "tool2"}).
+		WithToolsets([]string{"mine"}).
+		WithCustomToolsets(custom).
+		WithServerInstructions())
+
+	// The custom toolset is a toolset like any other
+	require.Empty(t, reg.UnrecognizedToolsets())
+	require.True(t, reg.HasToolset("mine"))
+	require.Equal(t, []ToolsetID{"mine", "toolset1", "toolset2"}, reg.ToolsetIDs())
+	require.Equal(t, "Custom toolset with tool3, tool2, tool1", reg.ToolsetDescriptions()["mine"])
+	require.Equal(t, []ToolsetID{"mine"}, reg.EnabledToolsetIDs())
+	require.Contains(t, reg.Instructions(), "Use mine.")
+
+	var ids []ToolsetID
+	for _, ts := range reg.AvailableToolsets() {
+		ids = append(ids, ts.ID)
+	}
+	require.Equal(t, []ToolsetID{"mine", "toolset1", "toolset2"}, ids)
+
+	// Its tools are enabled, with aliases resolved, and keep their own toolset
+	available := reg.AvailableTools(ctx)
+	require.Len(t, available, 3)
+	require.Equal(t, ToolsetID("toolset1"), available[0].Toolset.ID)
+	require.Len(t, reg.ToolsForToolset("mine"), 3)
+
+	// It can be disabled and enabled at runtime
+	reg.DisableToolset("mine")
+	require.Empty(t, reg.AvailableTools(ctx))
+	reg.EnableToolset("toolset2")
+	require.Len(t, reg.AvailableTools(ctx), 1)
+	reg.EnableToolset("mine")
+	require.Len(t, reg.AvailableTools(ctx), 3)
+
+	// Read-only mode still applies to its tools
+	reg = mustBuild(t, NewBuilder().SetTools(tools).WithReadOnly(true).WithToolsets([]string{"mine"}).WithCustomToolsets([]CustomToolset{{
+		ToolsetMetadata: ToolsetMetadata{ID: "mine"},
+		Tools:           []string{"tool1", "tool2"},
+	}}))
+	require.Len(t, reg.AvailableTools(ctx), 1)
+	require.Len(t, reg.ToolsForToolset("mine"), 1)
+}
+
+func TestCustomToolsetsErrors(t *testing.T) {
+	tools := []ServerTool{
+		mockTool("tool1", "toolset1", true),
+	}
+
+	tests := []struct {
+		name          string
+		toolsets      []CustomToolset
+		errorContains string
+	}{
+		{
+			name:          "unknown tool",
+			toolsets:      []CustomToolset{{ToolsetMetadata: ToolsetMetadata{ID: "mine"}, Tools: []string{"tool1", "nope"}}},
+			errorContains: `custom toolset "mine" has unrecognized tools: nope`,
+		},
+		{
+			name:          "no tools",
+			toolsets:      []CustomToolset{{ToolsetMetadata: ToolsetMetadata{ID: "mine"}}},
+			errorContains: `custom toolset "mine" has no tools`,
+		},
+		{
+			name:          "built-in toolset ID",
+			toolsets:      []CustomToolset{{ToolsetMetadata: ToolsetMetadata{ID: "toolset1"}, Tools: []string{"tool1"}}},
+			errorContains: `custom toolset "toolset1" conflicts with a built-in toolset or keyword`,
+		},
+		{
+			name:          "keyword",
+			toolsets:      []CustomToolset{{ToolsetMetadata: ToolsetMetadata{ID: "all"}, Tools: []string{"tool1"}}},
+			errorContains: `custom toolset "all" conflicts with a built-in toolset or keyword`,
+		},
+		{
+			name: "defined twice",
+			toolsets: []CustomToolset{
+				{ToolsetMetadata: ToolsetMetadata{ID: "mine"}, Tools: []string{"tool1"}},
+				{ToolsetMetadata: ToolsetMetadata{ID: "mine"}, Tools: []string{"tool1"}},
+			},
+			errorContains: `custom toolset "mine" is defined more than once`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBuilder().SetTools(tools).WithCustomToolsets(tt.toolsets).Build()
+			require.ErrorContains(t, err, tt.errorContains)
+		})
+	}
+}
+
+func TestWithToolTransform(t *testing.T) {
+	tools := []ServerTool{
+		mockTool("tool1", "toolset1", true),
+		mockTool("tool2", "toolset1", true),
+		mockTool("tool3", "toolset2", true),
+	}
+
+	reg := mustBuild(t, NewBuilder().
+		SetTools(tools).
+		WithToolsets([]string{"all"}).
+		WithToolTransform(func(tool ServerTool) (ServerTool, bool) {
+			tool.Tool.Description = "transformed"
+			return tool, tool.Tool.Name != "tool3"
+		}))
+
+	available := reg.AvailableTools(context.Background())
+	require.Len(t, available, 2)
+	for _, tool := range available {
+		require.Equal(t, "transformed", tool.Tool.Description)
+	}
+	require.Equal(t, "", tools[0].Tool.Description, "original tools should not be modified")
+
+	// Toolsets whose tools were all dropped are gone
+	require.Equal(t, []ToolsetID{"toolset1"}, reg.ToolsetIDs())
+	require.False(t, reg.HasToolset("toolset2"))
+	require.Empty(t, reg.ToolsForToolset("toolset2"))
+}
+
+func TestWithResourceTemplateTransform(t *testing.T) {
+	resources := []ServerResourceTemplate{
+		mockResource("res1", "toolset1", "res1://{owner}"),
+		mockResource("res2", "toolset1", "res2://{owner}"),
+	}
+
+	reg := mustBuild(t, NewBuilder().
+		SetResources(resources).
+		WithToolsets([]string{"all"}).
+		WithResourceTemplateTransform(func(resource ServerResourceTemplate) (ServerResourceTemplate, bool) {
+			resource.Template.URITemplate = strings.ReplaceAll(resource.Template.URITemplate, "{owner}", "octocat")
+			return resource, resource.Template.Name != "res2"
+		}))
+
+	available := reg.AvailableResourceTemplates(context.Background())
+	require.Len(t, available, 1)
+	require.Equal(t, "res1://octocat", available[0].Template.URITemplate)
+	require.Equal(t, "res1://{owner}", resources[0].Template.URITemplate, "original resource templates should not be modified")
+}
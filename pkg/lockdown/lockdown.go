@@ -21,6 +21,7 @@ type RepoAccessCache struct {
 	ttl              time.Duration
 	logger           *slog.Logger
 	trustedBotLogins map[string]struct{}
+	viewerLogin      string
 }
 
 type repoAccessCacheEntry struct {
@@ -134,6 +135,49 @@ func (c *RepoAccessCache) IsSafeContent(ctx context.Context, username, owner, re
 	return repoInfo.HasPushAccess, nil
 }
 
+// IsSafeUserContent determines if content that does not belong to a repository, such as a gist,
+// can safely be returned. Safe access applies when the content was created by a trusted bot or by
+// the viewer.
+func (c *RepoAccessCache) IsSafeUserContent(ctx context.Context, username string) (bool, error) {
+	if c == nil {
+		return false, fmt.Errorf("nil repo access cache")
+	}
+	if username == "" {
+		return false, nil
+	}
+	if c.isTrustedBot(username) {
+		return true, nil
+	}
+	viewerLogin, err := c.getViewerLogin(ctx)
+	if err != nil {
+		return false, err
+	}
+	return viewerLogin == strings.ToLower(username), nil
+}
+
+// getViewerLogin returns the normalized login of the viewer, querying it on first use.
+func (c *RepoAccessCache) getViewerLogin(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.viewerLogin != "" {
+		return c.viewerLogin, nil
+	}
+	if c.client == nil {
+		return "", fmt.Errorf("nil GraphQL client")
+	}
+
+	var query struct {
+		Viewer struct {
+			Login githubv4.String
+		}
+	}
+	if err := c.client.Query(ctx, &query, nil); err != nil {
+		return "", fmt.Errorf("failed to query viewer: %w", err)
+	}
+	c.viewerLogin = strings.ToLower(string(query.Viewer.Login))
+	return c.viewerLogin, nil
+}
+
 func (c *RepoAccessCache) getRepoAccessInfo(ctx context.Context, username, owner, repo string) (RepoAccessInfo, error) {
 	if c == nil {
 		return RepoAccessInfo{}, fmt.Errorf("nil repo access cache")
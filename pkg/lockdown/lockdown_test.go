@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/muesli/cache2go"
 	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/require"
 )
@@ -53,18 +54,10 @@ func (c *countingTransport) CallCount() int {
 	return c.calls
 }
 
-func newMockRepoAccessCache(t *testing.T, ttl time.Duration) (*RepoAccessCache, *countingTransport) {
-	t.Helper()
-
-	var query repoAccessQuery
-
-	variables := map[string]any{
-		"owner":    githubv4.String(testOwner),
-		"name":     githubv4.String(testRepo),
-		"username": githubv4.String(testUser),
-	}
-
-	response := githubv4mock.DataResponse(map[string]any{
+// repoAccessResponse is the response to the repository access query of a public repository, on
+// which testUser is the viewer and the collaborator has a permission.
+func repoAccessResponse(collaborator, permission string) githubv4mock.GQLResponse {
+	return githubv4mock.DataResponse(map[string]any{
 		"viewer": map[string]any{
 			"login": testUser,
 		},
@@ -73,15 +66,29 @@ func newMockRepoAccessCache(t *testing.T, ttl time.Duration) (*RepoAccessCache,
 			"collaborators": map[string]any{
 				"edges": []any{
 					map[string]any{
-						"permission": "WRITE",
+						"permission": permission,
 						"node": map[string]any{
-							"login": testUser,
+							"login": collaborator,
 						},
 					},
 				},
 			},
 		},
 	})
+}
+
+func newMockRepoAccessCache(t *testing.T, ttl time.Duration) (*RepoAccessCache, *countingTransport) {
+	t.Helper()
+
+	var query repoAccessQuery
+
+	variables := map[string]any{
+		"owner":    githubv4.String(testOwner),
+		"name":     githubv4.String(testRepo),
+		"username": githubv4.String(testUser),
+	}
+
+	response := repoAccessResponse(testUser, "WRITE")
 
 	httpClient := githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(query, variables, response))
 	counting := &countingTransport{next: httpClient.Transport}
@@ -110,3 +117,89 @@ func TestRepoAccessCacheEvictsAfterTTL(t *testing.T) {
 	require.True(t, info.HasPushAccess)
 	require.EqualValues(t, 2, transport.CallCount())
 }
+
+func TestRepoAccessCacheDoesNotCacheErrors(t *testing.T) {
+	ctx := t.Context()
+
+	// Any username matches, so that the same matcher serves the queries about every user in turn
+	variables := map[string]any{
+		"owner":    githubv4.String(testOwner),
+		"name":     githubv4.String(testRepo),
+		"username": githubv4mock.Any[githubv4.String](),
+	}
+	mock := githubv4mock.NewMock(
+		githubv4mock.NewQueryMatcher(repoAccessQuery{}, variables,
+			githubv4mock.TypedErrorResponse("FORBIDDEN", "Resource not accessible by integration"),
+		).Then(
+			githubv4mock.HTTPErrorResponse(http.StatusBadGateway, "upstream unavailable"),
+			repoAccessResponse(testUser, "WRITE"),
+			githubv4mock.TypedErrorResponse("RATE_LIMITED", "API rate limit exceeded"),
+			repoAccessResponse("stranger", "READ"),
+		),
+	)
+	defer mock.AssertExpectations(t)
+
+	cache := &RepoAccessCache{
+		client: githubv4.NewClient(mock.Client()),
+		cache:  cache2go.Cache(t.Name()),
+		ttl:    time.Minute,
+	}
+
+	_, err := cache.IsSafeContent(ctx, testUser, testOwner, testRepo)
+	require.ErrorContains(t, err, "failed to query repository access info: Resource not accessible by integration")
+
+	_, err = cache.IsSafeContent(ctx, testUser, testOwner, testRepo)
+	require.ErrorContains(t, err, "502 Bad Gateway")
+
+	safe, err := cache.IsSafeContent(ctx, testUser, testOwner, testRepo)
+	require.NoError(t, err)
+	require.True(t, safe)
+
+	// A failure to look up another user leaves the cached entry of the repository intact
+	_, err = cache.IsSafeContent(ctx, "stranger", testOwner, testRepo)
+	require.ErrorContains(t, err, "API rate limit exceeded")
+
+	safe, err = cache.IsSafeContent(ctx, "stranger", testOwner, testRepo)
+	require.NoError(t, err)
+	require.False(t, safe)
+}
+
+func TestIsSafeUserContent(t *testing.T) {
+	ctx := t.Context()
+
+	var query struct {
+		Viewer struct {
+			Login githubv4.String
+		}
+	}
+	mock := githubv4mock.NewMock(
+		githubv4mock.NewQueryMatcher(query, nil, githubv4mock.DataResponse(map[string]any{
+			"viewer": map[string]any{"login": "OctoCat"},
+		})),
+	)
+	defer mock.AssertExpectations(t)
+
+	cache := &RepoAccessCache{
+		client:           githubv4.NewClient(mock.Client()),
+		cache:            cache2go.Cache(t.Name()),
+		ttl:              time.Minute,
+		trustedBotLogins: map[string]struct{}{"copilot": {}},
+	}
+
+	safe, err := cache.IsSafeUserContent(ctx, "Copilot")
+	require.NoError(t, err)
+	require.True(t, safe, "content from trusted bots is safe")
+
+	safe, err = cache.IsSafeUserContent(ctx, testUser)
+	require.NoError(t, err)
+	require.True(t, safe, "content from the viewer is safe")
+
+	// The viewer is queried once
+	safe, err = cache.IsSafeUserContent(ctx, "stranger")
+	require.NoError(t, err)
+	require.False(t, safe, "content from other users is not safe")
+
+	safe, err = cache.IsSafeUserContent(ctx, "")
+	require.NoError(t, err)
+	require.False(t, safe, "content without an author is not safe")
+}
@@ -0,0 +1,367 @@
+// Package outputformat renders JSON tool results in formats that need fewer tokens than JSON.
+package outputformat
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is the text format of tool results.
+type Format string
+
+const (
+	// JSON returns results as JSON, exactly as they are marshalled.
+	JSON Format = "json"
+	// Compact renders lists of objects as CSV rows under a single header, and objects as one
+	// "key: value" line per field. Null fields are omitted.
+	Compact Format = "compact"
+	// Markdown renders lists of objects as Markdown tables and objects as bullet lists.
+	Markdown Format = "markdown"
+)
+
+// Formats lists the supported formats, in the order they are documented.
+var Formats = []Format{JSON, Compact, Markdown}
+
+// Parse returns the Format named by s. An empty string selects JSON.
+func Parse(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case "":
+		return JSON, nil
+	case JSON, Compact, Markdown:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q, expected one of: json, compact, markdown", s)
+	}
+}
+
+// Render renders the JSON document data in the given format.
+func Render(format Format, data []byte) (string, error) {
+	if format == JSON || format == "" {
+		return string(data), nil
+	}
+
+	v, err := decode(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode result: %w", err)
+	}
+
+	var buf strings.Builder
+	switch format {
+	case Compact:
+		err = writeCompact(&buf, v)
+	case Markdown:
+		err = writeMarkdown(&buf, v, "")
+	default:
+		return "", fmt.Errorf("unknown output format %q", format)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+// object is a decoded JSON object that remembers the order of its keys, so that rendered
+// columns and fields follow the order of the Go struct the result was marshalled from.
+type object struct {
+	keys   []string
+	values map[string]any
+}
+
+// decode decodes data into objects, []any and scalars (string, json.Number, bool and nil).
+func decode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	v, err := decodeValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+func decodeValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		obj := &object{values: map[string]any{}}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := keyTok.(string)
+			val, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			if _, seen := obj.values[key]; !seen {
+				obj.keys = append(obj.keys, key)
+			}
+			obj.values[key] = val
+		}
+		_, err := dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			val, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, val)
+		}
+		_, err := dec.Token()
+		return arr, err
+	default:
+		return tok, nil
+	}
+}
+
+// objectList returns the elements of v as objects if v is a non-empty list of objects.
+func objectList(v any) ([]*object, bool) {
+	arr, ok := v.([]any)
+	if !ok || len(arr) == 0 {
+		return nil, false
+	}
+	objs := make([]*object, 0, len(arr))
+	for _, el := range arr {
+		obj, ok := el.(*object)
+		if !ok {
+			return nil, false
+		}
+		objs = append(objs, obj)
+	}
+	return objs, true
+}
+
+// columns returns the keys used by any of objs, in first-seen order, leaving out keys that are
+// null or empty in every object.
+func columns(objs []*object) []string {
+	seen := map[string]bool{}
+	var cols []string
+	for _, obj := range objs {
+		for _, key := range obj.keys {
+			if seen[key] || isEmpty(obj.values[key]) {
+				continue
+			}
+			seen[key] = true
+			cols = append(cols, key)
+		}
+	}
+	return cols
+}
+
+func isEmpty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case *object:
+		return len(v.keys) == 0
+	default:
+		return false
+	}
+}
+
+// text returns a single-line representation of v: strings, numbers and booleans as-is and
+// anything else as minified JSON.
+func text(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	default:
+		var buf strings.Builder
+		writeJSON(&buf, v)
+		return buf.String()
+	}
+}
+
+// writeJSON writes v as minified JSON, preserving key order.
+func writeJSON(w *strings.Builder, v any) {
+	switch v := v.(type) {
+	case *object:
+		w.WriteByte('{')
+		for i, key := range v.keys {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			writeJSON(w, key)
+			w.WriteByte(':')
+			writeJSON(w, v.values[key])
+		}
+		w.WriteByte('}')
+	case []any:
+		w.WriteByte('[')
+		for i, el := range v {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			writeJSON(w, el)
+		}
+		w.WriteByte(']')
+	default:
+		data, _ := json.Marshal(v)
+		w.Write(data)
+	}
+}
+
+func writeCompact(w *strings.Builder, v any) error {
+	if objs, ok := objectList(v); ok {
+		return writeCSV(w, objs)
+	}
+
+	obj, ok := v.(*object)
+	if !ok {
+		w.WriteString(compactScalar(v))
+		return nil
+	}
+
+	for _, key := range obj.keys {
+		val := obj.values[key]
+		if val == nil {
+			continue
+		}
+		if objs, ok := objectList(val); ok {
+			fmt.Fprintf(w, "%s:\n", key)
+			if err := writeCSV(w, objs); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(w, "%s: %s\n", key, compactScalar(val))
+	}
+	return nil
+}
+
+// compactScalar keeps "key: value" lines on a single line by quoting multi-line strings.
+func compactScalar(v any) string {
+	if s, ok := v.(string); ok && strings.ContainsAny(s, "\r\n") {
+		data, _ := json.Marshal(s)
+		return string(data)
+	}
+	return text(v)
+}
+
+func writeCSV(w *strings.Builder, objs []*object) error {
+	cols := columns(objs)
+	cw := csv.NewWriter(w)
+	if err := cw.Write(cols); err != nil {
+		return err
+	}
+	row := make([]string, len(cols))
+	for _, obj := range objs {
+		for i, col := range cols {
+			row[i] = text(obj.values[col])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeMarkdown(w *strings.Builder, v any, indent string) error {
+	if objs, ok := objectList(v); ok {
+		writeMarkdownTable(w, objs)
+		return nil
+	}
+
+	switch v := v.(type) {
+	case *object:
+		for _, key := range v.keys {
+			val := v.values[key]
+			if val == nil {
+				continue
+			}
+			if objs, ok := objectList(val); ok {
+				fmt.Fprintf(w, "\n%s**%s**:\n\n", indent, key)
+				writeMarkdownTable(w, objs)
+				w.WriteString("\n")
+				continue
+			}
+			switch val := val.(type) {
+			case *object:
+				fmt.Fprintf(w, "%s- **%s**:\n", indent, key)
+				if err := writeMarkdown(w, val, indent+"  "); err != nil {
+					return err
+				}
+			case string:
+				if strings.Contains(val, "\n") {
+					fmt.Fprintf(w, "%s- **%s**:\n\n%s\n\n", indent, key, val)
+					continue
+				}
+				fmt.Fprintf(w, "%s- **%s**: %s\n", indent, key, val)
+			case []any:
+				items := make([]string, len(val))
+				for i, el := range val {
+					items[i] = text(el)
+				}
+				fmt.Fprintf(w, "%s- **%s**: %s\n", indent, key, strings.Join(items, ", "))
+			default:
+				fmt.Fprintf(w, "%s- **%s**: %s\n", indent, key, text(val))
+			}
+		}
+	case []any:
+		if len(v) == 0 {
+			w.WriteString("[]")
+		}
+		for _, el := range v {
+			fmt.Fprintf(w, "%s- %s\n", indent, text(el))
+		}
+	default:
+		w.WriteString(text(v))
+	}
+	return nil
+}
+
+func writeMarkdownTable(w *strings.Builder, objs []*object) {
+	cols := columns(objs)
+	writeMarkdownRow(w, cols)
+	sep := make([]string, len(cols))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	writeMarkdownRow(w, sep)
+	row := make([]string, len(cols))
+	for _, obj := range objs {
+		for i, col := range cols {
+			row[i] = markdownCell(text(obj.values[col]))
+		}
+		writeMarkdownRow(w, row)
+	}
+}
+
+func writeMarkdownRow(w *strings.Builder, cells []string) {
+	w.WriteString("| ")
+	w.WriteString(strings.Join(cells, " | "))
+	w.WriteString(" |\n")
+}
+
+var markdownCellReplacer = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+func markdownCell(s string) string {
+	return markdownCellReplacer.Replace(s)
+}
@@ -0,0 +1,139 @@
+package outputformat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    Format
+		expectedErr string
+	}{
+		{input: "", expected: JSON},
+		{input: "json", expected: JSON},
+		{input: "compact", expected: Compact},
+		{input: " Markdown ", expected: Markdown},
+		{input: "yaml", expectedErr: `unknown output format "yaml"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			format, err := Parse(tc.input)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, format)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	list := `[{"number":1,"title":"First, with comma","state":"open","labels":["bug","ui"],"milestone":null},` +
+		`{"number":2,"title":"Second | piped","state":"closed","labels":[],"milestone":null,"body":"line1\nline2"}]`
+	object := `{"total_count":2,"incomplete_results":false,"items":[{"name":"a","stars":1},{"name":"b","stars":2}],` +
+		`"owner":{"login":"octocat","id":1},"body":"line1\nline2","topics":["go","mcp"],"license":null}`
+
+	tests := []struct {
+		name     string
+		format   Format
+		input    string
+		expected string
+	}{
+		{
+			name:     "json is returned unchanged",
+			format:   JSON,
+			input:    `{"b":1, "a":2}`,
+			expected: `{"b":1, "a":2}`,
+		},
+		{
+			name:   "compact list of objects",
+			format: Compact,
+			input:  list,
+			expected: "number,title,state,labels,body\n" +
+				"1,\"First, with comma\",open,\"[\"\"bug\"\",\"\"ui\"\"]\",\n" +
+				"2,Second | piped,closed,[],\"line1\nline2\"",
+		},
+		{
+			name:   "compact object",
+			format: Compact,
+			input:  object,
+			expected: "total_count: 2\n" +
+				"incomplete_results: false\n" +
+				"items:\n" +
+				"name,stars\n" +
+				"a,1\n" +
+				"b,2\n" +
+				"owner: {\"login\":\"octocat\",\"id\":1}\n" +
+				"body: \"line1\\nline2\"\n" +
+				"topics: [\"go\",\"mcp\"]",
+		},
+		{
+			name:     "compact list of scalars",
+			format:   Compact,
+			input:    `["a","b"]`,
+			expected: `["a","b"]`,
+		},
+		{
+			name:   "markdown list of objects",
+			format: Markdown,
+			input:  list,
+			expected: "| number | title | state | labels | body |\n" +
+				"| --- | --- | --- | --- | --- |\n" +
+				"| 1 | First, with comma | open | [\"bug\",\"ui\"] |  |\n" +
+				"| 2 | Second \\| piped | closed | [] | line1<br>line2 |",
+		},
+		{
+			name:   "markdown object",
+			format: Markdown,
+			input:  object,
+			expected: "- **total_count**: 2\n" +
+				"- **incomplete_results**: false\n" +
+				"\n**items**:\n\n" +
+				"| name | stars |\n" +
+				"| --- | --- |\n" +
+				"| a | 1 |\n" +
+				"| b | 2 |\n" +
+				"\n" +
+				"- **owner**:\n" +
+				"  - **login**: octocat\n" +
+				"  - **id**: 1\n" +
+				"- **body**:\n\nline1\nline2\n\n" +
+				"- **topics**: go, mcp",
+		},
+		{
+			name:     "markdown list of scalars",
+			format:   Markdown,
+			input:    `["a","b"]`,
+			expected: "- a\n- b",
+		},
+		{
+			name:     "markdown empty list",
+			format:   Markdown,
+			input:    `[]`,
+			expected: "[]",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.format, []byte(tc.input))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestRenderInvalidJSON(t *testing.T) {
+	_, err := Render(Compact, []byte(`{"a":`))
+	require.Error(t, err)
+
+	_, err = Render(Markdown, []byte(`{} {}`))
+	require.Error(t, err)
+}
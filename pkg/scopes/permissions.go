@@ -0,0 +1,355 @@
+package scopes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Permission represents a permission of fine-grained personal access tokens and GitHub App
+// installation tokens. Unlike OAuth scopes, these tokens do not report their permissions, so they
+// are discovered by probing endpoints that require them.
+// See https://docs.github.com/en/rest/authentication/permissions-required-for-fine-grained-personal-access-tokens
+type Permission string
+
+const (
+	// PermissionMetadata grants read access to repository metadata, which every token has
+	PermissionMetadata Permission = "metadata"
+
+	// PermissionContents grants access to repository contents, commits, branches and releases
+	PermissionContents Permission = "contents"
+
+	// PermissionIssues grants access to issues, labels and milestones
+	PermissionIssues Permission = "issues"
+
+	// PermissionPullRequests grants access to pull requests and their reviews
+	PermissionPullRequests Permission = "pull_requests"
+
+	// PermissionActions grants access to workflows, workflow runs and artifacts
+	PermissionActions Permission = "actions"
+
+	// PermissionCodeScanningAlerts grants access to code scanning alerts
+	PermissionCodeScanningAlerts Permission = "security_events"
+
+	// PermissionDependabotAlerts grants access to Dependabot alerts
+	PermissionDependabotAlerts Permission = "vulnerability_alerts"
+
+	// PermissionSecretScanningAlerts grants access to secret scanning alerts
+	PermissionSecretScanningAlerts Permission = "secret_scanning_alerts"
+
+	// PermissionNotifications grants access to notifications. Fine-grained tokens cannot be
+	// granted it, so probing it tells whether the token type supports notifications.
+	PermissionNotifications Permission = "notifications"
+
+	// PermissionMembers grants access to organization members and teams
+	PermissionMembers Permission = "members"
+
+	// PermissionOrganizationProjects grants access to organization projects
+	PermissionOrganizationProjects Permission = "organization_projects"
+
+	// PermissionStarring grants access to starring repositories
+	PermissionStarring Permission = "starring"
+)
+
+// AcceptedPermissionsHeader is the HTTP response header listing the fine-grained permissions
+// accepted by an endpoint.
+const AcceptedPermissionsHeader = "X-Accepted-GitHub-Permissions"
+
+// IsFineGrainedToken reports whether a token uses fine-grained permissions instead of OAuth
+// scopes: fine-grained personal access tokens (github_pat_) and GitHub App installation tokens
+// (ghs_).
+func IsFineGrainedToken(token string) bool {
+	return strings.HasPrefix(token, "github_pat_") || strings.HasPrefix(token, "ghs_")
+}
+
+// TokenPermissions records the outcome of probing the permissions of a token. A permission that
+// maps to true is granted, one that maps to false is denied, and one that is missing could not be
+// probed and is unknown.
+type TokenPermissions map[Permission]bool
+
+// IsDenied reports whether the permission is known to be denied.
+func (p TokenPermissions) IsDenied(permission Permission) bool {
+	granted, probed := p[permission]
+	return probed && !granted
+}
+
+// Denied returns the permissions known to be denied, sorted for deterministic output.
+func (p TokenPermissions) Denied() []string {
+	var denied []string
+	for permission, granted := range p {
+		if !granted {
+			denied = append(denied, string(permission))
+		}
+	}
+	sort.Strings(denied)
+	return denied
+}
+
+// ParseAcceptedPermissionsHeader parses the X-Accepted-GitHub-Permissions header into the
+// permissions that the endpoint accepts on their own. The header lists alternatives separated by
+// semicolons, each being one or more comma-separated permission=level pairs that are all required,
+// for example "contents=read; issues=read,pull_requests=read". Alternatives that require several
+// permissions are skipped, since a token denied access may lack any one of them.
+func ParseAcceptedPermissionsHeader(header string) []Permission {
+	var permissions []Permission
+	for _, alternative := range strings.Split(header, ";") {
+		pairs := strings.Split(alternative, ",")
+		if len(pairs) != 1 {
+			continue
+		}
+		name, _, _ := strings.Cut(strings.TrimSpace(pairs[0]), "=")
+		if name != "" {
+			permissions = append(permissions, Permission(name))
+		}
+	}
+	return permissions
+}
+
+// permissionProbe is a cheap read endpoint that a token can only call with a permission.
+type permissionProbe struct {
+	permission Permission
+	// path is the endpoint to call, with %s standing for the owner/name of the probe repository
+	// for repository probes, and for its owner for organization probes.
+	path string
+}
+
+// withTarget returns the probes with their paths formatted for target.
+func withTarget(probes []permissionProbe, target string) []permissionProbe {
+	formatted := make([]permissionProbe, 0, len(probes))
+	for _, probe := range probes {
+		formatted = append(formatted, permissionProbe{probe.permission, fmt.Sprintf(probe.path, target)})
+	}
+	return formatted
+}
+
+// repositoryProbes need a private repository, since fine-grained tokens can read public
+// repositories without any permission.
+var repositoryProbes = []permissionProbe{
+	{PermissionContents, "repos/%s/commits"},
+	{PermissionIssues, "repos/%s/issues"},
+	{PermissionPullRequests, "repos/%s/pulls"},
+	{PermissionActions, "repos/%s/actions/runs"},
+	{PermissionCodeScanningAlerts, "repos/%s/code-scanning/alerts"},
+	{PermissionDependabotAlerts, "repos/%s/dependabot/alerts"},
+	{PermissionSecretScanningAlerts, "repos/%s/secret-scanning/alerts"},
+}
+
+// organizationProbes run on the owner of the probe repository. When it is a user, they fail
+// with a 404 and the permissions stay unknown.
+var organizationProbes = []permissionProbe{
+	{PermissionMembers, "orgs/%s/members"},
+	{PermissionOrganizationProjects, "orgs/%s/projectsV2"},
+}
+
+var accountProbes = []permissionProbe{
+	{PermissionNotifications, "notifications"},
+	{PermissionStarring, "user/starred"},
+}
+
+// PermissionProberOptions configures the permission prober.
+type PermissionProberOptions struct {
+	// HTTPClient is the HTTP client to use for requests.
+	// If nil, a default client with DefaultFetchTimeout is used.
+	HTTPClient *http.Client
+
+	// APIHost is the GitHub API host (e.g., "https://api.github.com").
+	// Defaults to "https://api.github.com" if empty.
+	APIHost string
+
+	// Repository is the private repository, in the owner/name form, to probe repository
+	// permissions on. If empty, the first private repository the token can access is used.
+	Repository string
+}
+
+// PermissionProber discovers the permissions of fine-grained tokens by calling cheap read
+// endpoints that require them. A permission is denied when the endpoint answers with a 403
+// "Resource not accessible" error, and granted when it succeeds. Other failures, such as a
+// feature not being enabled for the repository, leave the permission unknown.
+//
+// Read access is all that can be probed without side effects, so a token with read but not
+// write access to a permission is reported as granted.
+type PermissionProber struct {
+	client     *http.Client
+	apiHost    string
+	repository string
+}
+
+// NewPermissionProber creates a new permission prober with the given options.
+func NewPermissionProber(opts PermissionProberOptions) *PermissionProber {
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: DefaultFetchTimeout}
+	}
+
+	apiHost := opts.APIHost
+	if apiHost == "" {
+		apiHost = "https://api.github.com"
+	}
+
+	return &PermissionProber{
+		client:     client,
+		apiHost:    apiHost,
+		repository: opts.Repository,
+	}
+}
+
+// probeResult is the outcome of a single request.
+type probeResult struct {
+	status              int
+	message             string
+	acceptedPermissions []Permission
+}
+
+// notAccessible reports whether the request was denied for lack of a permission.
+func (r probeResult) notAccessible() bool {
+	return r.status == http.StatusForbidden && strings.HasPrefix(r.message, "Resource not accessible")
+}
+
+// ProbePermissions returns the permissions of a fine-grained token. Repository and organization
+// permissions are only probed if the token can access a private repository.
+func (p *PermissionProber) ProbePermissions(ctx context.Context, token string) (TokenPermissions, error) {
+	repository := p.repository
+	if repository == "" {
+		var err error
+		repository, err = p.findPrivateRepository(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	probes := accountProbes
+	if repository != "" {
+		owner, _, _ := strings.Cut(repository, "/")
+		probes = append(withTarget(repositoryProbes, repository), withTarget(organizationProbes, owner)...)
+		probes = append(probes, accountProbes...)
+	}
+
+	permissions := make(TokenPermissions)
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	for _, probe := range probes {
+		wg.Add(1)
+		go func(probe permissionProbe) {
+			defer wg.Done()
+			result, err := p.get(ctx, token, probe.path, url.Values{"per_page": {"1"}}, nil)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				if firstErr == nil {
+					firstErr = err
+				}
+			case result.status < 300 || result.status == http.StatusConflict:
+				// Empty repositories answer 409 to commit listings
+				permissions[probe.permission] = true
+			case result.notAccessible():
+				permissions[probe.permission] = false
+				for _, accepted := range result.acceptedPermissions {
+					if _, probed := permissions[accepted]; !probed {
+						permissions[accepted] = false
+					}
+				}
+			}
+		}(probe)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return permissions, nil
+}
+
+// findPrivateRepository returns a private repository the token can access, or an empty string if
+// there is none.
+func (p *PermissionProber) findPrivateRepository(ctx context.Context, token string) (string, error) {
+	type repository struct {
+		FullName string `json:"full_name"`
+		Private  bool   `json:"private"`
+	}
+
+	if strings.HasPrefix(token, "ghs_") {
+		var installation struct {
+			Repositories []repository `json:"repositories"`
+		}
+		if _, err := p.get(ctx, token, "installation/repositories", url.Values{"per_page": {"100"}}, &installation); err != nil {
+			return "", err
+		}
+		for _, repo := range installation.Repositories {
+			if repo.Private {
+				return repo.FullName, nil
+			}
+		}
+		return "", nil
+	}
+
+	var repos []repository
+	if _, err := p.get(ctx, token, "user/repos", url.Values{"visibility": {"private"}, "per_page": {"1"}}, &repos); err != nil {
+		return "", err
+	}
+	if len(repos) == 0 {
+		return "", nil
+	}
+	return repos[0].FullName, nil
+}
+
+// get calls an endpoint and, if v is not nil, decodes a successful response into it.
+func (p *PermissionProber) get(ctx context.Context, token, path string, query url.Values, v any) (probeResult, error) {
+	endpoint, err := url.JoinPath(p.apiHost, path)
+	if err != nil {
+		return probeResult{}, fmt.Errorf("failed to construct API URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return probeResult{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return probeResult{}, fmt.Errorf("failed to probe permissions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return probeResult{}, fmt.Errorf("invalid or expired token")
+	}
+
+	result := probeResult{
+		status:              resp.StatusCode,
+		acceptedPermissions: ParseAcceptedPermissionsHeader(resp.Header.Get(AcceptedPermissionsHeader)),
+	}
+	if resp.StatusCode >= 300 {
+		var body struct {
+			Message string `json:"message"`
+		}
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		_ = json.Unmarshal(data, &body)
+		result.message = body.Message
+		if v != nil {
+			return result, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+		return result, nil
+	}
+
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return result, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return result, nil
+}
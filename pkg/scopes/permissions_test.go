@@ -0,0 +1,177 @@
+package scopes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsFineGrainedToken(t *testing.T) {
+	assert.True(t, IsFineGrainedToken("github_pat_11AAAAAA"))
+	assert.True(t, IsFineGrainedToken("ghs_abc"))
+	assert.False(t, IsFineGrainedToken("ghp_abc"))
+	assert.False(t, IsFineGrainedToken("gho_abc"))
+	assert.False(t, IsFineGrainedToken(""))
+}
+
+func TestParseAcceptedPermissionsHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected []Permission
+	}{
+		{name: "empty header", header: "", expected: nil},
+		{name: "single permission", header: "issues=read", expected: []Permission{"issues"}},
+		{name: "alternatives", header: "issues=read; pull_requests=read", expected: []Permission{"issues", "pull_requests"}},
+		{name: "combined permissions are skipped", header: "contents=read,actions=read; metadata=read", expected: []Permission{"metadata"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseAcceptedPermissionsHeader(tt.header))
+		})
+	}
+}
+
+func TestTokenPermissions(t *testing.T) {
+	permissions := TokenPermissions{PermissionContents: true, PermissionIssues: false, PermissionNotifications: false}
+	assert.False(t, permissions.IsDenied(PermissionContents))
+	assert.True(t, permissions.IsDenied(PermissionIssues))
+	assert.False(t, permissions.IsDenied(PermissionActions), "unknown permissions are not denied")
+	assert.Equal(t, []string{"issues", "notifications"}, permissions.Denied())
+}
+
+// notAccessible answers like GitHub does when a fine-grained token lacks a permission.
+func notAccessible(w http.ResponseWriter, accepted string) {
+	w.Header().Set(AcceptedPermissionsHeader, accepted)
+	w.WriteHeader(http.StatusForbidden)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": "Resource not accessible by personal access token"})
+}
+
+func TestPermissionProber_ProbePermissions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /user/repos", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "private", r.URL.Query().Get("visibility"))
+		_ = json.NewEncoder(w).Encode([]map[string]any{{"full_name": "octo/private", "private": true}})
+	})
+	mux.HandleFunc("GET /installation/repositories", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"repositories": []map[string]any{
+			{"full_name": "octo/public", "private": false},
+			{"full_name": "octo/private", "private": true},
+		}})
+	})
+	mux.HandleFunc("GET /repos/octo/private/commits", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusConflict) // empty repository
+	})
+	mux.HandleFunc("GET /repos/octo/private/issues", func(w http.ResponseWriter, _ *http.Request) {
+		notAccessible(w, "issues=read")
+	})
+	mux.HandleFunc("GET /repos/octo/private/pulls", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("[]"))
+	})
+	mux.HandleFunc("GET /repos/octo/private/actions/runs", func(w http.ResponseWriter, _ *http.Request) {
+		notAccessible(w, "actions=read")
+	})
+	mux.HandleFunc("GET /repos/octo/private/code-scanning/alerts", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "Advanced Security must be enabled for this repository to use code scanning."})
+	})
+	mux.HandleFunc("GET /orgs/octo/members", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("[]"))
+	})
+	mux.HandleFunc("GET /orgs/octo/projectsV2", func(w http.ResponseWriter, _ *http.Request) {
+		notAccessible(w, "organization_projects=read")
+	})
+	mux.HandleFunc("GET /notifications", func(w http.ResponseWriter, _ *http.Request) {
+		notAccessible(w, "")
+	})
+	mux.HandleFunc("GET /user/starred", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("[]"))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	expected := TokenPermissions{
+		PermissionContents:      true,
+		PermissionIssues:        false,
+		PermissionPullRequests:  true,
+		PermissionActions:       false,
+		PermissionNotifications: false,
+		PermissionStarring:      true,
+		PermissionMembers:       true,
+
+		PermissionOrganizationProjects: false,
+	}
+
+	t.Run("fine-grained PAT", func(t *testing.T) {
+		prober := NewPermissionProber(PermissionProberOptions{APIHost: server.URL})
+		permissions, err := prober.ProbePermissions(context.Background(), "github_pat_test")
+		require.NoError(t, err)
+		assert.Equal(t, expected, permissions)
+	})
+
+	t.Run("repository owned by a user", func(t *testing.T) {
+		prober := NewPermissionProber(PermissionProberOptions{APIHost: server.URL, Repository: "someone/other"})
+		permissions, err := prober.ProbePermissions(context.Background(), "github_pat_test")
+		require.NoError(t, err)
+		assert.Equal(t, TokenPermissions{PermissionNotifications: false, PermissionStarring: true}, permissions,
+			"organization permissions of a user are unknown")
+	})
+
+	t.Run("installation token", func(t *testing.T) {
+		prober := NewPermissionProber(PermissionProberOptions{APIHost: server.URL})
+		permissions, err := prober.ProbePermissions(context.Background(), "ghs_test")
+		require.NoError(t, err)
+		assert.Equal(t, expected, permissions)
+	})
+
+	t.Run("configured repository", func(t *testing.T) {
+		prober := NewPermissionProber(PermissionProberOptions{APIHost: server.URL, Repository: "octo/other"})
+		permissions, err := prober.ProbePermissions(context.Background(), "github_pat_test")
+		require.NoError(t, err)
+		assert.Equal(t, TokenPermissions{
+			PermissionNotifications:        false,
+			PermissionStarring:             true,
+			PermissionMembers:              true,
+			PermissionOrganizationProjects: false,
+		}, permissions, "permissions of a repository the endpoints fail for are unknown")
+	})
+}
+
+func TestPermissionProber_NoPrivateRepository(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user/repos":
+			_, _ = w.Write([]byte("[]"))
+		case "/notifications", "/user/starred":
+			_, _ = w.Write([]byte("[]"))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	prober := NewPermissionProber(PermissionProberOptions{APIHost: server.URL})
+	permissions, err := prober.ProbePermissions(context.Background(), "github_pat_test")
+	require.NoError(t, err)
+	assert.Equal(t, TokenPermissions{PermissionNotifications: true, PermissionStarring: true}, permissions)
+}
+
+func TestPermissionProber_InvalidToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	prober := NewPermissionProber(PermissionProberOptions{APIHost: server.URL})
+	_, err := prober.ProbePermissions(context.Background(), "github_pat_expired")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid or expired token")
+}
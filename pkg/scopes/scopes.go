@@ -167,6 +167,18 @@ func expandScopeSet(scopes []string) map[string]bool {
 	return expanded
 }
 
+// GrantedScopes returns all scopes granted by the given token scopes, including child scopes
+// from the hierarchy. The returned slice is sorted for deterministic output.
+func GrantedScopes(tokenScopes []string) []string {
+	expanded := expandScopeSet(tokenScopes)
+	result := make([]string, 0, len(expanded))
+	for scope := range expanded {
+		result = append(result, scope)
+	}
+	sort.Strings(result)
+	return result
+}
+
 // HasRequiredScopes checks if tokenScopes satisfy the acceptedScopes requirement.
 // A tool's acceptedScopes includes both the required scopes AND parent scopes
 // that implicitly grant the required permissions (via ExpandScopes).
@@ -224,6 +224,12 @@ func TestExpandScopeSet(t *testing.T) {
 	}
 }
 
+func TestGrantedScopes(t *testing.T) {
+	assert.Equal(t, []string{}, GrantedScopes(nil))
+	assert.Equal(t, []string{"gist", "public_repo", "repo", "security_events"}, GrantedScopes([]string{"repo", "gist"}))
+	assert.Equal(t, []string{"admin:org", "read:org", "write:org"}, GrantedScopes([]string{"admin:org"}))
+}
+
 func TestHasRequiredScopes(t *testing.T) {
 	tests := []struct {
 		name           string
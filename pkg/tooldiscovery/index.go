@@ -0,0 +1,348 @@
+package tooldiscovery
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/lithammer/fuzzysearch/fuzzy"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Document is a tool to index, with the toolset it belongs to.
+type Document struct {
+	Tool               mcp.Tool
+	Toolset            string
+	ToolsetDescription string
+}
+
+// field identifies the part of a document a term was found in.
+type field uint8
+
+const (
+	fieldName field = 1 << iota
+	fieldDescription
+	fieldParameter
+	fieldToolset
+)
+
+var fieldNames = []struct {
+	field field
+	name  string
+}{
+	{fieldName, "name"},
+	{fieldDescription, "description"},
+	{fieldParameter, "parameter"},
+	{fieldToolset, "toolset"},
+}
+
+// fieldWeights scale the term frequencies of each field, so that a term in the tool name counts
+// for more than the same term in a long description.
+var fieldWeights = map[field]float64{
+	fieldName:        3,
+	fieldDescription: 1,
+	fieldParameter:   1,
+	fieldToolset:     0.5,
+}
+
+const (
+	// BM25 parameters: k1 saturates repeated terms and b normalizes for document length.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// nameCoverageBoost is the bonus for tools whose name terms are all in the query.
+	nameCoverageBoost = 0.5
+
+	// exactNameWordBoost is the bonus for each query word found verbatim in the tool name, which
+	// keeps the plural: "alerts" prefers list_dependabot_alerts over get_dependabot_alert.
+	exactNameWordBoost = 0.1
+
+	// Weights of query terms that were not typed by the user.
+	synonymTermWeight = 0.8
+	typoTermWeight    = 0.5
+)
+
+type posting struct {
+	doc    int
+	tf     float64
+	fields field
+}
+
+type queryTerm struct {
+	term   string
+	weight float64
+}
+
+// Index is an inverted index of tools ranked with BM25 over their names, descriptions, parameter
+// names and toolset descriptions. Build it once with NewIndex and reuse it for every query.
+type Index struct {
+	docs      []Document
+	lengths   []float64
+	nameTerms []int
+	nameWords []map[string]bool
+	avgLength float64
+	postings  map[string][]posting
+}
+
+// NewIndex indexes the given documents.
+func NewIndex(docs []Document) *Index {
+	idx := &Index{
+		docs:      docs,
+		lengths:   make([]float64, len(docs)),
+		nameTerms: make([]int, len(docs)),
+		nameWords: make([]map[string]bool, len(docs)),
+		postings:  make(map[string][]posting),
+	}
+
+	var totalLength float64
+	for i, doc := range docs {
+		frequencies := make(map[string]*posting)
+		add := func(f field, text string) {
+			for _, term := range tokenize(text) {
+				p, ok := frequencies[term]
+				if !ok {
+					p = &posting{doc: i}
+					frequencies[term] = p
+				}
+				p.tf += fieldWeights[f]
+				p.fields |= f
+				idx.lengths[i] += fieldWeights[f]
+			}
+		}
+		add(fieldName, doc.Tool.Name)
+		idx.nameWords[i] = make(map[string]bool)
+		for _, word := range splitWords(doc.Tool.Name) {
+			idx.nameWords[i][word] = true
+		}
+		add(fieldDescription, doc.Tool.Description)
+		if doc.Tool.Annotations != nil {
+			add(fieldDescription, doc.Tool.Annotations.Title)
+		}
+		for _, name := range inputParameterTerms(doc.Tool.InputSchema) {
+			add(fieldParameter, name)
+		}
+		add(fieldToolset, doc.Toolset)
+		add(fieldToolset, doc.ToolsetDescription)
+
+		for term, p := range frequencies {
+			idx.postings[term] = append(idx.postings[term], *p)
+			if p.fields&fieldName != 0 {
+				idx.nameTerms[i]++
+			}
+		}
+		totalLength += idx.lengths[i]
+	}
+	if len(docs) > 0 {
+		idx.avgLength = totalLength / float64(len(docs))
+	}
+	return idx
+}
+
+// Search returns the most relevant tools for a free-text query, best first. Abbreviations and
+// synonyms such as "PR" or "CI" are expanded, and words that match no indexed term are matched
+// to the closest term to tolerate typos.
+//
+// Empty or whitespace-only queries return (nil, nil).
+func (idx *Index) Search(query string, options ...SearchOptions) ([]SearchResult, error) {
+	maxResults := getMaxResults(options)
+
+	terms := idx.queryTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	scores := make(map[int]float64)
+	matched := make(map[int]field)
+	matchedNameTerms := make(map[int]int)
+	n := float64(len(idx.docs))
+	for _, qt := range terms {
+		postings := idx.postings[qt.term]
+		if len(postings) == 0 {
+			continue
+		}
+		idf := math.Log(1 + (n-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+		for _, p := range postings {
+			norm := 1 - bm25B + bm25B*idx.lengths[p.doc]/idx.avgLength
+			scores[p.doc] += qt.weight * idf * p.tf * (bm25K1 + 1) / (p.tf + bm25K1*norm)
+			matched[p.doc] |= p.fields
+			if p.fields&fieldName != 0 {
+				matchedNameTerms[p.doc]++
+			}
+		}
+	}
+
+	words := splitWords(query)
+	results := make([]SearchResult, 0, len(scores))
+	for i, score := range scores {
+		// Prefer tools whose name is covered by the query, e.g. list_discussions over
+		// list_discussion_categories for "list discussions".
+		if idx.nameTerms[i] > 0 {
+			score *= 1 + nameCoverageBoost*float64(matchedNameTerms[i])/float64(idx.nameTerms[i])
+		}
+		exact := 0
+		for _, word := range words {
+			if idx.nameWords[i][word] {
+				exact++
+			}
+		}
+		score *= 1 + exactNameWordBoost*float64(exact)
+
+		var matchedIn []string
+		for _, f := range fieldNames {
+			if matched[i]&f.field != 0 {
+				matchedIn = append(matchedIn, f.name)
+			}
+		}
+		results = append(results, SearchResult{
+			Tool:      idx.docs[i].Tool,
+			Toolset:   idx.docs[i].Toolset,
+			Score:     score,
+			MatchedIn: matchedIn,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Tool.Name < results[j].Tool.Name
+	})
+
+	if len(results) > maxResults {
+		results = results[:maxResults]
+	}
+	return results, nil
+}
+
+// queryTerms tokenizes the query and expands it with synonyms and typo corrections. Each term
+// keeps the highest weight it was reached with.
+func (idx *Index) queryTerms(query string) []queryTerm {
+	weights := make(map[string]float64)
+	var order []string
+	add := func(term string, weight float64) {
+		if existing, ok := weights[term]; !ok {
+			order = append(order, term)
+		} else if existing >= weight {
+			return
+		}
+		weights[term] = weight
+	}
+
+	for _, word := range splitWords(query) {
+		term := normalizeTerm(word)
+		if term == "" {
+			continue
+		}
+
+		expanded := false
+		for _, table := range []struct {
+			expansions map[string][]string
+			weight     float64
+		}{
+			{abbreviations, 1},
+			{synonyms, synonymTermWeight},
+		} {
+			expansions, ok := table.expansions[word]
+			if !ok {
+				expansions, ok = table.expansions[term]
+			}
+			for _, expansion := range expansions {
+				for _, t := range tokenize(expansion) {
+					add(t, table.weight)
+				}
+			}
+			expanded = expanded || ok
+		}
+
+		if _, ok := idx.postings[term]; ok {
+			add(term, 1)
+		} else if !expanded {
+			if correction := idx.closestTerm(term); correction != "" {
+				add(correction, typoTermWeight)
+			}
+		}
+	}
+
+	terms := make([]queryTerm, len(order))
+	for i, term := range order {
+		terms[i] = queryTerm{term: term, weight: weights[term]}
+	}
+	return terms
+}
+
+// closestTerm returns the indexed term within one edit of term, or two edits for long terms.
+func (idx *Index) closestTerm(term string) string {
+	if len(term) < 4 {
+		return ""
+	}
+	maxDistance := 1
+	if len(term) >= 8 {
+		maxDistance = 2
+	}
+
+	best, bestDistance := "", maxDistance+1
+	for candidate := range idx.postings {
+		if abs(len(candidate)-len(term)) > maxDistance {
+			continue
+		}
+		distance := fuzzy.LevenshteinDistance(term, candidate)
+		if distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// stopWords are too common in queries and descriptions to help ranking.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "how": true, "i": true, "in": true, "is": true,
+	"it": true, "its": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "use": true, "want": true, "what": true, "when": true,
+	"which": true, "with": true,
+}
+
+// tokenize splits text into normalized terms, dropping stop words.
+func tokenize(text string) []string {
+	words := splitWords(text)
+	terms := words[:0]
+	for _, word := range words {
+		if term := normalizeTerm(word); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// splitWords lowercases text and splits it into words on anything but letters and digits, so
+// that tool names such as list_pull_requests are split into their words.
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// normalizeTerm drops stop words and reduces plurals to their singular form, so that "issues"
+// matches "issue".
+func normalizeTerm(word string) string {
+	if stopWords[word] {
+		return ""
+	}
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		return word[:len(word)-3] + "y"
+	case len(word) > 4 && (strings.HasSuffix(word, "ses") || strings.HasSuffix(word, "xes")):
+		return word[:len(word)-2]
+	case len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us"):
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
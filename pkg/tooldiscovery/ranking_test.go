@@ -0,0 +1,89 @@
+package tooldiscovery_test
+
+import (
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/tooldiscovery"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rankingFixture pairs queries an agent might send with the tool it should find. Queries use
+// abbreviations, synonyms and plurals on purpose.
+var rankingFixture = []struct {
+	query    string
+	expected string
+}{
+	{"list my PRs", "list_pull_requests"},
+	{"merge a pull request", "merge_pull_request"},
+	{"review a PR", "pull_request_review_write"},
+	{"PR diff", "pull_request_read"},
+	{"request copilot review", "request_copilot_review"},
+	{"update pull request branch", "update_pull_request_branch"},
+	{"rerun failed jobs", "rerun_failed_jobs"},
+	{"CI job logs", "get_job_logs"},
+	{"list workflows", "list_workflows"},
+	{"workflow artifacts", "list_workflow_run_artifacts"},
+	{"create an issue", "issue_write"},
+	{"close an issue", "issue_write"},
+	{"add a comment to an issue", "add_issue_comment"},
+	{"search issues", "search_issues"},
+	{"sub issues", "sub_issue_write"},
+	{"assign copilot", "assign_copilot_to_issue"},
+	{"get the readme", "get_file_contents"},
+	{"read a file", "get_file_contents"},
+	{"push multiple files", "push_files"},
+	{"create a branch", "create_branch"},
+	{"list branches", "list_branches"},
+	{"list commits", "list_commits"},
+	{"get commit details", "get_commit"},
+	{"fork a repo", "fork_repository"},
+	{"create a repository", "create_repository"},
+	{"find repositories", "search_repositories"},
+	{"search code", "search_code"},
+	{"star a repo", "star_repository"},
+	{"dependabot alerts", "list_dependabot_alerts"},
+	{"vulnerable dependencies", "list_dependabot_alerts"},
+	{"codeql alerts", "list_code_scanning_alerts"},
+	{"leaked secrets", "list_secret_scanning_alerts"},
+	{"security advisories", "list_repository_security_advisories"},
+	{"list notifications", "list_notifications"},
+	{"who am i", "get_me"},
+	{"list releases", "list_releases"},
+	{"latest release", "get_latest_release"},
+	{"list tags", "list_tags"},
+	{"create a gist", "create_gist"},
+	{"list discussions", "list_discussions"},
+	{"add labels", "label_write"},
+	{"org teams", "get_teams"},
+	{"search users", "search_users"},
+	{"merge a pull reqest", "merge_pull_request"},
+}
+
+func TestIndexRanking(t *testing.T) {
+	reg, err := github.NewInventory(translations.NullTranslationHelper).Build()
+	require.NoError(t, err)
+	index := github.NewToolSearchIndex(reg)
+
+	first := 0
+	for _, tc := range rankingFixture {
+		results, err := index.Search(tc.query, tooldiscovery.SearchOptions{MaxResults: 3})
+		require.NoError(t, err)
+
+		names := make([]string, len(results))
+		for i, r := range results {
+			names[i] = r.Tool.Name
+		}
+		assert.Contains(t, names, tc.expected, "query %q", tc.query)
+		if len(names) > 0 && names[0] == tc.expected {
+			first++
+		} else {
+			t.Logf("query %q ranked %v", tc.query, names)
+		}
+	}
+
+	// Most queries should find the expected tool first, not just in the top 3.
+	assert.GreaterOrEqual(t, float64(first)/float64(len(rankingFixture)), 0.8, "%d of %d queries ranked the expected tool first", first, len(rankingFixture))
+}
@@ -55,3 +55,28 @@ func TestSearchTools_FindsByParameterName_MapSchema(t *testing.T) {
 	require.NotEmpty(t, results)
 	require.Equal(t, "unrelated_tool", results[0].Tool.Name)
 }
+
+func TestSearchTools_ExpandsAbbreviations(t *testing.T) {
+	tools := []mcp.Tool{
+		{Name: "list_pull_requests", Description: "List pull requests"},
+		{Name: "list_issues", Description: "List issues"},
+	}
+
+	results, err := SearchTools(tools, "my PRs", SearchOptions{MaxResults: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "list_pull_requests", results[0].Tool.Name)
+	require.Equal(t, []string{"name", "description"}, results[0].MatchedIn)
+}
+
+func TestSearchTools_ToleratesTypos(t *testing.T) {
+	tools := []mcp.Tool{
+		{Name: "list_pull_requests", Description: "List pull requests"},
+		{Name: "list_issues", Description: "List issues"},
+	}
+
+	results, err := SearchTools(tools, "reqests", SearchOptions{MaxResults: 10})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Equal(t, "list_pull_requests", results[0].Tool.Name)
+}
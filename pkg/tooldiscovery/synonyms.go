@@ -0,0 +1,82 @@
+package tooldiscovery
+
+// abbreviations expand the short forms people use for GitHub concepts. Their expansions count as
+// much as words typed in the query.
+var abbreviations = map[string][]string{
+	"pr":     {"pull request"},
+	"prs":    {"pull requests"},
+	"mr":     {"pull request"},
+	"ci":     {"actions workflow"},
+	"cd":     {"actions workflow"},
+	"gha":    {"actions workflow"},
+	"repo":   {"repository"},
+	"repos":  {"repositories"},
+	"org":    {"organization"},
+	"orgs":   {"organizations"},
+	"ghas":   {"code security scanning"},
+	"sast":   {"code scanning"},
+	"codeql": {"code scanning"},
+	"cve":    {"security advisory vulnerability"},
+	"ghsa":   {"security advisory"},
+	"sha":    {"commit"},
+	"ref":    {"branch tag"},
+	"deps":   {"dependabot dependency"},
+	"desc":   {"description"},
+	"msg":    {"message"},
+	"info":   {"details"},
+}
+
+// synonyms map words that do not appear in tool descriptions to the ones that do. They count for
+// a bit less than the words typed in the query, which are kept.
+var synonyms = map[string][]string{
+	"bug":           {"issue"},
+	"ticket":        {"issue"},
+	"task":          {"issue"},
+	"pipeline":      {"workflow actions"},
+	"build":         {"workflow run"},
+	"job":           {"workflow job"},
+	"check":         {"status workflow"},
+	"failing":       {"failed"},
+	"failure":       {"failed"},
+	"broken":        {"failed"},
+	"rerun":         {"re run"},
+	"retry":         {"re run"},
+	"vulnerability": {"dependabot security advisory alert"},
+	"vulnerable":    {"dependabot security alert"},
+	"leak":          {"secret scanning"},
+	"leaked":        {"secret scanning"},
+	"credential":    {"secret"},
+	"token":         {"secret"},
+	"dependency":    {"dependabot"},
+	"approve":       {"review"},
+	"feedback":      {"review comment"},
+	"whoami":        {"me authenticated user"},
+	"who":           {"me authenticated user"},
+	"profile":       {"me user"},
+	"myself":        {"me"},
+	"account":       {"user"},
+	"inbox":         {"notification"},
+	"unread":        {"notification"},
+	"board":         {"project"},
+	"kanban":        {"project"},
+	"forum":         {"discussion"},
+	"snippet":       {"gist"},
+	"version":       {"release tag"},
+	"changelog":     {"release"},
+	"readme":        {"file content"},
+	"show":          {"get"},
+	"view":          {"get"},
+	"read":          {"get"},
+	"fetch":         {"get"},
+	"find":          {"search"},
+	"lookup":        {"search"},
+	"add":           {"create"},
+	"new":           {"create"},
+	"edit":          {"update"},
+	"modify":        {"update"},
+	"change":        {"update"},
+	"remove":        {"delete"},
+	"close":         {"update state"},
+	"star":          {"starred"},
+	"stars":         {"starred"},
+}
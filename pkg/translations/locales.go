@@ -0,0 +1,177 @@
+package translations
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// embeddedLocales holds the locale bundles shipped with the binary.
+//
+//go:embed locales
+var embeddedLocales embed.FS
+
+// Bundle maps translation keys to the translated text of a locale.
+type Bundle map[string]string
+
+// Bundles are the locale bundles available to the server, keyed by normalized locale.
+type Bundles struct {
+	bundles map[string]Bundle
+}
+
+// LoadBundles loads the bundles embedded in the binary and, if dir is not empty, the bundles
+// in dir. Bundles are JSON files named after their locale, like de.json or pt-BR.json, in the
+// format written by --export-translations. Keys in dir override embedded keys of the same locale.
+func LoadBundles(dir string) (*Bundles, error) {
+	b := &Bundles{bundles: map[string]Bundle{}}
+	if err := b.load(embeddedLocales, "locales"); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := b.load(os.DirFS(dir), "."); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func (b *Bundles) load(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("failed to read locale bundles: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read locale bundle %s: %w", entry.Name(), err)
+		}
+		var values map[string]string
+		if err := json.Unmarshal(data, &values); err != nil {
+			return fmt.Errorf("failed to parse locale bundle %s: %w", entry.Name(), err)
+		}
+
+		locale := NormalizeLocale(strings.TrimSuffix(entry.Name(), ".json"))
+		if b.bundles[locale] == nil {
+			b.bundles[locale] = Bundle{}
+		}
+		for key, value := range values {
+			b.bundles[locale][strings.ToUpper(key)] = value
+		}
+	}
+	return nil
+}
+
+// Locales returns the locales there are bundles for, sorted.
+func (b *Bundles) Locales() []string {
+	if b == nil {
+		return nil
+	}
+	locales := make([]string, 0, len(b.bundles))
+	for locale := range b.bundles {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Bundle returns the bundle of exactly the given locale, or nil if there is none.
+func (b *Bundles) Bundle(locale string) Bundle {
+	if b == nil {
+		return nil
+	}
+	return b.bundles[NormalizeLocale(locale)]
+}
+
+// Lookup returns the translations for a locale: the bundle of its language, overridden by the
+// bundle of the locale itself, so that pt-BR falls back to pt. Returns nil if there is neither.
+func (b *Bundles) Lookup(locale string) Bundle {
+	locale = NormalizeLocale(locale)
+	if b == nil || locale == "" {
+		return nil
+	}
+	language, _, _ := strings.Cut(locale, "-")
+
+	var result Bundle
+	for _, name := range []string{language, locale} {
+		bundle, ok := b.bundles[name]
+		if !ok {
+			continue
+		}
+		if result == nil {
+			result = Bundle{}
+		}
+		for key, value := range bundle {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// NormalizeLocale converts a locale from a BCP 47 tag like pt-BR, or a POSIX locale like
+// pt_BR.UTF-8, to the lowercase form bundles are keyed by, like pt-br. The POSIX C locale
+// normalizes to the empty string.
+func NormalizeLocale(locale string) string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if locale == "c" || locale == "posix" {
+		return ""
+	}
+	return locale
+}
+
+// RecordingTranslationHelper returns a translation helper that returns the default values and
+// records them in keys, by upper-cased key, to find the keys in use.
+func RecordingTranslationHelper(keys map[string]string) TranslationHelperFunc {
+	return func(key string, defaultValue string) string {
+		keys[strings.ToUpper(key)] = defaultValue
+		return defaultValue
+	}
+}
+
+// BundleReport lists the keys of a locale bundle that need work.
+type BundleReport struct {
+	Locale string `json:"locale"`
+	// Translated is the number of keys in use that the bundle translates.
+	Translated int `json:"translated"`
+	// Missing are the keys in use that the bundle doesn't translate.
+	Missing []string `json:"missing"`
+	// Stale are the keys of the bundle that are no longer in use.
+	Stale []string `json:"stale"`
+}
+
+// CheckBundles compares each bundle against the keys in use, as recorded by
+// RecordingTranslationHelper, and reports the missing and stale keys of each locale. Keys a
+// regional bundle like pt-BR falls back to its language bundle for are not missing.
+func CheckBundles(bundles *Bundles, keys map[string]string) []BundleReport {
+	var reports []BundleReport
+	for _, locale := range bundles.Locales() {
+		bundle := bundles.Bundle(locale)
+		translations := bundles.Lookup(locale)
+		report := BundleReport{Locale: locale, Missing: []string{}, Stale: []string{}}
+		for key := range keys {
+			if _, ok := translations[key]; ok {
+				report.Translated++
+			} else {
+				report.Missing = append(report.Missing, key)
+			}
+		}
+		for key := range bundle {
+			if _, ok := keys[key]; !ok {
+				report.Stale = append(report.Stale, key)
+			}
+		}
+		sort.Strings(report.Missing)
+		sort.Strings(report.Stale)
+		reports = append(reports, report)
+	}
+	return reports
+}
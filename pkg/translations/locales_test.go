@@ -0,0 +1,108 @@
+package translations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeBundles(t *testing.T, bundles map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range bundles {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	return dir
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	assert.Equal(t, "de", NormalizeLocale("de"))
+	assert.Equal(t, "pt-br", NormalizeLocale("pt-BR"))
+	assert.Equal(t, "pt-br", NormalizeLocale("pt_BR.UTF-8"))
+	assert.Equal(t, "sr-rs", NormalizeLocale("sr_RS@latin"))
+	assert.Equal(t, "", NormalizeLocale("C"))
+	assert.Equal(t, "", NormalizeLocale(""))
+}
+
+func TestLoadBundles(t *testing.T) {
+	dir := writeBundles(t, map[string]string{
+		"pt.json":    `{"tool_a_description": "A (pt)", "TOOL_B_DESCRIPTION": "B (pt)"}`,
+		"pt-BR.json": `{"TOOL_B_DESCRIPTION": "B (pt-BR)"}`,
+		"notes.txt":  `not a bundle`,
+	})
+
+	bundles, err := LoadBundles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"de", "pt", "pt-br"}, bundles.Locales(), "bundles in dir are added to the embedded ones")
+	assert.Equal(t, Bundle{"TOOL_B_DESCRIPTION": "B (pt-BR)"}, bundles.Bundle("pt_BR"))
+
+	assert.Equal(t, Bundle{"TOOL_A_DESCRIPTION": "A (pt)", "TOOL_B_DESCRIPTION": "B (pt-BR)"}, bundles.Lookup("pt-BR"), "regional bundles fall back to the language")
+	assert.Equal(t, Bundle{"TOOL_A_DESCRIPTION": "A (pt)", "TOOL_B_DESCRIPTION": "B (pt)"}, bundles.Lookup("pt-PT"))
+	assert.Nil(t, bundles.Lookup("fr"))
+	assert.Nil(t, bundles.Lookup(""))
+
+	var none *Bundles
+	assert.Nil(t, none.Lookup("pt"))
+	assert.Empty(t, none.Locales())
+}
+
+func TestLoadBundles_Invalid(t *testing.T) {
+	dir := writeBundles(t, map[string]string{"de.json": `{"TOOL_A_DESCRIPTION": 1}`})
+	_, err := LoadBundles(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse locale bundle de.json")
+
+	_, err = LoadBundles(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
+
+func TestLocaleTranslationHelper(t *testing.T) {
+	dir := writeBundles(t, map[string]string{
+		"de.json": `{"TOOL_A_DESCRIPTION": "A (de)", "TOOL_B_DESCRIPTION": "B (de)"}`,
+	})
+	bundles, err := LoadBundles(dir)
+	require.NoError(t, err)
+	t.Setenv("GITHUB_MCP_TOOL_B_DESCRIPTION", "B (env)")
+
+	translate, _ := LocaleTranslationHelper(bundles, "de-DE")
+	assert.Equal(t, "A (de)", translate("tool_a_description", "A"))
+	assert.Equal(t, "B (env)", translate("TOOL_B_DESCRIPTION", "B"), "environment variables override bundles")
+	assert.Equal(t, "C", translate("TOOL_C_DESCRIPTION", "C"), "missing keys fall back to English")
+
+	english, _ := LocaleTranslationHelper(bundles, "")
+	assert.Equal(t, "A", english("TOOL_A_DESCRIPTION", "A"))
+}
+
+func TestCheckBundles(t *testing.T) {
+	dir := writeBundles(t, map[string]string{
+		"pt.json":    `{"TOOL_A_DESCRIPTION": "A (pt)", "TOOL_REMOVED_DESCRIPTION": "removed"}`,
+		"pt-BR.json": `{"TOOL_B_DESCRIPTION": "B (pt-BR)"}`,
+	})
+	// Only the bundles in dir, without the embedded ones
+	bundles := &Bundles{bundles: map[string]Bundle{}}
+	require.NoError(t, bundles.load(os.DirFS(dir), "."))
+
+	keys := map[string]string{}
+	record := RecordingTranslationHelper(keys)
+	assert.Equal(t, "A", record("tool_a_description", "A"))
+	record("TOOL_B_DESCRIPTION", "B")
+	record("TOOL_C_DESCRIPTION", "C")
+
+	assert.Equal(t, []BundleReport{
+		{
+			Locale:     "pt",
+			Translated: 1,
+			Missing:    []string{"TOOL_B_DESCRIPTION", "TOOL_C_DESCRIPTION"},
+			Stale:      []string{"TOOL_REMOVED_DESCRIPTION"},
+		},
+		{
+			Locale:     "pt-br",
+			Translated: 2,
+			Missing:    []string{"TOOL_C_DESCRIPTION"},
+			Stale:      []string{},
+		},
+	}, CheckBundles(bundles, keys))
+}
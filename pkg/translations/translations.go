@@ -17,7 +17,15 @@ func NullTranslationHelper(_ string, defaultValue string) string {
 }
 
 func TranslationHelper() (TranslationHelperFunc, func()) {
+	return LocaleTranslationHelper(nil, "")
+}
+
+// LocaleTranslationHelper is like TranslationHelper, but falls back to the bundle of the given
+// locale before the default (English) value. Environment variables and the
+// github-mcp-server-config.json file still take precedence over the bundle.
+func LocaleTranslationHelper(bundles *Bundles, locale string) (TranslationHelperFunc, func()) {
 	var translationKeyMap = map[string]string{}
+	bundle := bundles.Lookup(locale)
 	v := viper.New()
 
 	// Load from JSON file
@@ -45,6 +53,9 @@ func TranslationHelper() (TranslationHelperFunc, func()) {
 				return value
 			}
 
+			if value, exists := bundle[key]; exists {
+				defaultValue = value
+			}
 			v.SetDefault(key, defaultValue)
 			translationKeyMap[key] = v.GetString(key)
 			return translationKeyMap[key]